@@ -1,13 +1,19 @@
 package main
 
 import (
+	// the alpine image has no zoneinfo for app.timezone
+	_ "time/tzdata"
+
+	"github.com/sMARCHz/secretaria-bot/internal/config"
 	"github.com/sMARCHz/secretaria-bot/internal/infrastructure"
 	"github.com/sMARCHz/secretaria-bot/internal/logger"
 )
 
 func main() {
-	sync := logger.InitLogger()
+	// Log to stdout until the configuration, which holds the log settings, is loaded
+	logger.InitLogger(logger.DefaultConfig())
+	sync := logger.InitLogger(config.Get().Log)
 	defer sync()
 
-	infrastructure.StartHTTPServer()
+	infrastructure.Start()
 }
@@ -12,9 +12,15 @@ import (
 	"github.com/sMARCHz/secretaria-bot/internal/ports/client"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+const (
+	userIDMetadataKey           = "x-user-id"
+	accountNamespaceMetadataKey = "x-account-namespace"
+)
+
 type financeServiceClient struct {
 	client pb.FinanceServiceClient
 }
@@ -30,11 +36,11 @@ func NewFinanceServiceClient() client.FinanceServiceClient {
 	}
 }
 
-func (f *financeServiceClient) Withdraw(req *domain.TransactionRequest) (*domain.TransactionResponse, *apperrors.AppError) {
-	res, err := f.client.Withdraw(context.Background(), req.ToProto())
+func (f *financeServiceClient) Withdraw(ctx context.Context, req *domain.TransactionRequest) (*domain.TransactionResponse, *apperrors.AppError) {
+	res, err := f.client.Withdraw(withCallerMetadata(ctx), req.ToProto())
 	if err != nil {
 		err = errors.Wrap(err, "cannot withdraw money")
-		logger.Error(err)
+		logger.Ctx(ctx).Error(err)
 		return nil, apperrors.BadGatewayError(err.Error())
 	}
 	return &domain.TransactionResponse{
@@ -43,11 +49,11 @@ func (f *financeServiceClient) Withdraw(req *domain.TransactionRequest) (*domain
 	}, nil
 }
 
-func (f *financeServiceClient) Deposit(req *domain.TransactionRequest) (*domain.TransactionResponse, *apperrors.AppError) {
-	res, err := f.client.Deposit(context.Background(), req.ToProto())
+func (f *financeServiceClient) Deposit(ctx context.Context, req *domain.TransactionRequest) (*domain.TransactionResponse, *apperrors.AppError) {
+	res, err := f.client.Deposit(withCallerMetadata(ctx), req.ToProto())
 	if err != nil {
 		err = errors.Wrap(err, "cannot deposit money")
-		logger.Error(err)
+		logger.Ctx(ctx).Error(err)
 		return nil, apperrors.BadGatewayError(err.Error())
 	}
 	return &domain.TransactionResponse{
@@ -56,11 +62,11 @@ func (f *financeServiceClient) Deposit(req *domain.TransactionRequest) (*domain.
 	}, nil
 }
 
-func (f *financeServiceClient) Transfer(req *domain.TransferRequest) (*domain.TransferResponse, *apperrors.AppError) {
-	res, err := f.client.Transfer(context.Background(), req.ToProto())
+func (f *financeServiceClient) Transfer(ctx context.Context, req *domain.TransferRequest) (*domain.TransferResponse, *apperrors.AppError) {
+	res, err := f.client.Transfer(withCallerMetadata(ctx), req.ToProto())
 	if err != nil {
 		err = errors.Wrap(err, "cannot transfer money")
-		logger.Error(err)
+		logger.Ctx(ctx).Error(err)
 		return nil, apperrors.BadGatewayError(err.Error())
 	}
 	return &domain.TransferResponse{
@@ -69,11 +75,11 @@ func (f *financeServiceClient) Transfer(req *domain.TransferRequest) (*domain.Tr
 	}, nil
 }
 
-func (f *financeServiceClient) GetBalance() (*domain.GetBalanceResponse, *apperrors.AppError) {
-	res, err := f.client.GetBalance(context.Background(), &emptypb.Empty{})
+func (f *financeServiceClient) GetBalance(ctx context.Context) (*domain.GetBalanceResponse, *apperrors.AppError) {
+	res, err := f.client.GetBalance(withCallerMetadata(ctx), &emptypb.Empty{})
 	if err != nil {
 		err = errors.Wrap(err, "cannot get balance")
-		logger.Error(err)
+		logger.Ctx(ctx).Error(err)
 		return nil, apperrors.BadGatewayError(err.Error())
 	}
 	accounts := make([]domain.AccountBalance, len(res.Accounts))
@@ -85,36 +91,86 @@ func (f *financeServiceClient) GetBalance() (*domain.GetBalanceResponse, *apperr
 	}, nil
 }
 
-func (f *financeServiceClient) GetOverviewStatement(req *domain.GetOverviewStatementRequest) (*domain.GetOverviewStatementResponse, *apperrors.AppError) {
-	res, err := f.client.GetOverviewStatement(context.Background(), req.ToProto())
+func (f *financeServiceClient) GetOverviewStatement(ctx context.Context, req *domain.GetOverviewStatementRequest) (*domain.GetOverviewStatementResponse, *apperrors.AppError) {
+	res, err := f.client.GetOverviewStatement(withCallerMetadata(ctx), req.ToProto())
 	if err != nil {
 		err = errors.Wrap(err, "cannot get overview statement")
-		logger.Error(err)
+		logger.Ctx(ctx).Error(err)
 		return nil, apperrors.BadGatewayError(err.Error())
 	}
 	return f.toGetOverviewStatementResponse(res), nil
 }
 
-func (f *financeServiceClient) GetOverviewMonthlyStatement() (*domain.GetOverviewStatementResponse, *apperrors.AppError) {
-	res, err := f.client.GetOverviewMonthlyStatement(context.Background(), &emptypb.Empty{})
+func (f *financeServiceClient) GetOverviewMonthlyStatement(ctx context.Context) (*domain.GetOverviewStatementResponse, *apperrors.AppError) {
+	res, err := f.client.GetOverviewMonthlyStatement(withCallerMetadata(ctx), &emptypb.Empty{})
 	if err != nil {
 		err = errors.Wrap(err, "cannot get monthly overview statement")
-		logger.Error(err)
+		logger.Ctx(ctx).Error(err)
 		return nil, apperrors.BadGatewayError(err.Error())
 	}
 	return f.toGetOverviewStatementResponse(res), nil
 }
 
-func (f *financeServiceClient) GetOverviewAnnualStatement() (*domain.GetOverviewStatementResponse, *apperrors.AppError) {
-	res, err := f.client.GetOverviewAnnualStatement(context.Background(), &emptypb.Empty{})
+func (f *financeServiceClient) GetOverviewAnnualStatement(ctx context.Context) (*domain.GetOverviewStatementResponse, *apperrors.AppError) {
+	res, err := f.client.GetOverviewAnnualStatement(withCallerMetadata(ctx), &emptypb.Empty{})
 	if err != nil {
 		err = errors.Wrap(err, "cannot get annual overview statement")
-		logger.Error(err)
+		logger.Ctx(ctx).Error(err)
 		return nil, apperrors.BadGatewayError(err.Error())
 	}
 	return f.toGetOverviewStatementResponse(res), nil
 }
 
+// GetDetailedStatement returns the transactions and transfers of the range.
+func (f *financeServiceClient) GetDetailedStatement(ctx context.Context, req *domain.GetOverviewStatementRequest) (*domain.GetDetailedStatementResponse, *apperrors.AppError) {
+	res, err := f.client.GetDetailedStatement(withCallerMetadata(ctx), req.ToProto())
+	if err != nil {
+		err = errors.Wrap(err, "cannot get detailed statement")
+		logger.Ctx(ctx).Error(err)
+		return nil, apperrors.BadGatewayError(err.Error())
+	}
+	return f.toGetDetailedStatementResponse(res), nil
+}
+
+// ListTransactions returns a page of the withdrawals, deposits and
+// transfers, newest first.
+func (f *financeServiceClient) ListTransactions(ctx context.Context, req *domain.ListTransactionsRequest) (*domain.ListTransactionsResponse, *apperrors.AppError) {
+	res, err := f.client.ListTransactions(withCallerMetadata(ctx), req.ToProto())
+	if err != nil {
+		err = errors.Wrap(err, "cannot list transactions")
+		logger.Ctx(ctx).Error(err)
+		return nil, apperrors.BadGatewayError(err.Error())
+	}
+	return f.toListTransactionsResponse(res), nil
+}
+
+// UpdateTransaction patches a transaction, returning it as it was before
+// and after.
+func (f *financeServiceClient) UpdateTransaction(ctx context.Context, req *domain.UpdateTransactionRequest) (*domain.UpdateTransactionResponse, *apperrors.AppError) {
+	res, err := f.client.UpdateTransaction(withCallerMetadata(ctx), req.ToProto())
+	if err != nil {
+		err = errors.Wrap(err, "cannot update transaction")
+		logger.Ctx(ctx).Error(err)
+		return nil, apperrors.BadGatewayError(err.Error())
+	}
+	before, _ := toTransaction(res.GetBefore())
+	after, _ := toTransaction(res.GetAfter())
+	return &domain.UpdateTransactionResponse{Before: before, After: after}, nil
+}
+
+// withCallerMetadata tells the finance service on whose behalf the call is made
+// so that it can scope the accounts to the caller's namespace.
+func withCallerMetadata(ctx context.Context) context.Context {
+	user, ok := domain.UserFromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx,
+		userIDMetadataKey, user.ID,
+		accountNamespaceMetadataKey, user.AccountNamespace,
+	)
+}
+
 func (*financeServiceClient) toGetOverviewStatementResponse(o *pb.OverviewStatementResponse) *domain.GetOverviewStatementResponse {
 	if o == nil {
 		return &domain.GetOverviewStatementResponse{}
@@ -149,3 +205,92 @@ func (*financeServiceClient) toGetOverviewStatementResponse(o *pb.OverviewStatem
 		Profit:  o.Profit,
 	}
 }
+
+func (*financeServiceClient) toGetDetailedStatementResponse(o *pb.DetailedStatementResponse) *domain.GetDetailedStatementResponse {
+	if o == nil {
+		return &domain.GetDetailedStatementResponse{}
+	}
+
+	toSection := func(s *pb.DetailedStatementSection) *domain.GetDetailedStatementSection {
+		if s == nil {
+			return nil
+		}
+		entries := make([]domain.Entry, len(s.Entries))
+		for i, v := range s.Entries {
+			entries[i] = domain.Entry{
+				Timestamp:   v.Timestamp.AsTime(),
+				Account:     v.AccountName,
+				Category:    v.Category,
+				Amount:      v.Amount,
+				Description: v.Description,
+			}
+		}
+		return &domain.GetDetailedStatementSection{Entries: entries, Total: s.GetTotal()}
+	}
+
+	transfers := make([]domain.TransferEntry, len(o.Transfers))
+	for i, v := range o.Transfers {
+		transfers[i] = domain.TransferEntry{
+			Timestamp:   v.Timestamp.AsTime(),
+			FromAccount: v.FromAccountName,
+			ToAccount:   v.ToAccountName,
+			Amount:      v.Amount,
+			Description: v.Description,
+		}
+	}
+	return &domain.GetDetailedStatementResponse{
+		Revenue:   toSection(o.Revenue),
+		Expense:   toSection(o.Expense),
+		Transfers: transfers,
+		Profit:    o.Profit,
+	}
+}
+
+func (*financeServiceClient) toListTransactionsResponse(o *pb.ListTransactionsResponse) *domain.ListTransactionsResponse {
+	if o == nil {
+		return &domain.ListTransactionsResponse{}
+	}
+
+	transactions := make([]domain.Transaction, 0, len(o.Transactions))
+	for _, v := range o.Transactions {
+		// Skip the kinds unknown to this version of the bot
+		if t, ok := toTransaction(v); ok {
+			transactions = append(transactions, t)
+		}
+	}
+	return &domain.ListTransactionsResponse{
+		Transactions:  transactions,
+		NextPageToken: o.NextPageToken,
+	}
+}
+
+// toTransaction reports false when the transaction's kind is unknown.
+func toTransaction(o *pb.Transaction) (domain.Transaction, bool) {
+	t := domain.Transaction{ID: o.GetId()}
+	switch kind := o.GetKind().(type) {
+	case *pb.Transaction_Withdrawal:
+		t.Type = domain.TransactionWithdrawal
+		setEntry(&t, kind.Withdrawal)
+	case *pb.Transaction_Deposit:
+		t.Type = domain.TransactionDeposit
+		setEntry(&t, kind.Deposit)
+	case *pb.Transaction_Transfer:
+		t.Type = domain.TransactionTransfer
+		t.Timestamp = kind.Transfer.GetTimestamp().AsTime()
+		t.Account = kind.Transfer.GetFromAccountName()
+		t.ToAccount = kind.Transfer.GetToAccountName()
+		t.Amount = kind.Transfer.GetAmount()
+		t.Description = kind.Transfer.GetDescription()
+	default:
+		return t, false
+	}
+	return t, true
+}
+
+func setEntry(t *domain.Transaction, e *pb.Entry) {
+	t.Timestamp = e.GetTimestamp().AsTime()
+	t.Account = e.GetAccountName()
+	t.Category = e.GetCategory()
+	t.Amount = e.GetAmount()
+	t.Description = e.GetDescription()
+}
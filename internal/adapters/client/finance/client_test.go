@@ -1,15 +1,19 @@
 package finance
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/sMARCHz/secretaria-bot/internal/adapters/client/finance/pb"
 	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/test/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestNewFinanceServiceClient(t *testing.T) {
@@ -27,7 +31,7 @@ func TestWithdraw(t *testing.T) {
 		client: gRPCClient,
 	}
 
-	res, err := client.Withdraw(&domain.TransactionRequest{})
+	res, err := client.Withdraw(context.Background(), &domain.TransactionRequest{})
 
 	expected := &domain.TransactionResponse{
 		Account: gRPCRes.AccountName,
@@ -44,7 +48,7 @@ func TestWithdraw_Error(t *testing.T) {
 		client: gRPCClient,
 	}
 
-	res, err := client.Withdraw(&domain.TransactionRequest{})
+	res, err := client.Withdraw(context.Background(), &domain.TransactionRequest{})
 
 	assert.Nil(t, res)
 	assert.EqualError(t, err, "cannot withdraw money: fails to withdraw")
@@ -62,7 +66,7 @@ func TestDeposit(t *testing.T) {
 		client: gRPCClient,
 	}
 
-	res, err := client.Deposit(&domain.TransactionRequest{})
+	res, err := client.Deposit(context.Background(), &domain.TransactionRequest{})
 
 	expected := &domain.TransactionResponse{
 		Account: gRPCRes.AccountName,
@@ -79,7 +83,7 @@ func TestDeposit_Error(t *testing.T) {
 		client: gRPCClient,
 	}
 
-	res, err := client.Deposit(&domain.TransactionRequest{})
+	res, err := client.Deposit(context.Background(), &domain.TransactionRequest{})
 
 	assert.Nil(t, res)
 	assert.EqualError(t, err, "cannot deposit money: fails to deposit")
@@ -97,7 +101,7 @@ func TestTransfer(t *testing.T) {
 		client: gRPCClient,
 	}
 
-	res, err := client.Transfer(&domain.TransferRequest{})
+	res, err := client.Transfer(context.Background(), &domain.TransferRequest{})
 
 	expected := &domain.TransferResponse{
 		FromAccount: gRPCRes.FromAccountName,
@@ -114,7 +118,7 @@ func TestTransfer_Error(t *testing.T) {
 		client: gRPCClient,
 	}
 
-	res, err := client.Transfer(&domain.TransferRequest{})
+	res, err := client.Transfer(context.Background(), &domain.TransferRequest{})
 
 	assert.Nil(t, res)
 	assert.EqualError(t, err, "cannot transfer money: fails to transfer")
@@ -136,7 +140,7 @@ func TestGetBalance(t *testing.T) {
 		client: gRPCClient,
 	}
 
-	res, err := client.GetBalance()
+	res, err := client.GetBalance(context.Background())
 
 	expected := &domain.GetBalanceResponse{
 		Accounts: []domain.AccountBalance{
@@ -157,7 +161,7 @@ func TestGetBalance_Error(t *testing.T) {
 		client: gRPCClient,
 	}
 
-	res, err := client.GetBalance()
+	res, err := client.GetBalance(context.Background())
 
 	assert.Nil(t, res)
 	assert.EqualError(t, err, "cannot get balance: fails to get balance")
@@ -180,7 +184,7 @@ func TestGetOverviewStatement(t *testing.T) {
 		client: gRPCClient,
 	}
 
-	res, err := client.GetOverviewStatement(&domain.GetOverviewStatementRequest{})
+	res, err := client.GetOverviewStatement(context.Background(), &domain.GetOverviewStatementRequest{})
 
 	expected := &domain.GetOverviewStatementResponse{
 		Revenue: res.Revenue,
@@ -198,7 +202,7 @@ func TestGetOverviewStatement_Error(t *testing.T) {
 		client: gRPCClient,
 	}
 
-	res, err := client.GetOverviewStatement(&domain.GetOverviewStatementRequest{})
+	res, err := client.GetOverviewStatement(context.Background(), &domain.GetOverviewStatementRequest{})
 
 	assert.Nil(t, res)
 	assert.EqualError(t, err, "cannot get overview statement: fails to get overview statement")
@@ -221,7 +225,7 @@ func TestGetOverviewMonthlyStatement(t *testing.T) {
 		client: gRPCClient,
 	}
 
-	res, err := client.GetOverviewMonthlyStatement()
+	res, err := client.GetOverviewMonthlyStatement(context.Background())
 
 	expected := &domain.GetOverviewStatementResponse{
 		Revenue: res.Revenue,
@@ -239,7 +243,7 @@ func TestGetOverviewMonthlyStatement_Error(t *testing.T) {
 		client: gRPCClient,
 	}
 
-	res, err := client.GetOverviewMonthlyStatement()
+	res, err := client.GetOverviewMonthlyStatement(context.Background())
 
 	assert.Nil(t, res)
 	assert.EqualError(t, err, "cannot get monthly overview statement: fails to get monthly statement")
@@ -262,7 +266,7 @@ func TestGetOverviewAnnualStatement(t *testing.T) {
 		client: gRPCClient,
 	}
 
-	res, err := client.GetOverviewAnnualStatement()
+	res, err := client.GetOverviewAnnualStatement(context.Background())
 
 	expected := &domain.GetOverviewStatementResponse{
 		Revenue: res.Revenue,
@@ -280,13 +284,147 @@ func TestGetOverviewAnnualStatement_Error(t *testing.T) {
 		client: gRPCClient,
 	}
 
-	res, err := client.GetOverviewAnnualStatement()
+	res, err := client.GetOverviewAnnualStatement(context.Background())
 
 	assert.Nil(t, res)
 	assert.EqualError(t, err, "cannot get annual overview statement: fails to get annual statement")
 	assert.Equal(t, http.StatusBadGateway, err.StatusCode)
 }
 
+func TestGetDetailedStatement(t *testing.T) {
+	timestamp := time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC)
+	gRPCRes := &pb.DetailedStatementResponse{
+		Revenue: &pb.DetailedStatementSection{
+			Total: 5000,
+			Entries: []*pb.Entry{
+				{Timestamp: timestamppb.New(timestamp), AccountName: "debit1", Category: "salary", Amount: 5000, Description: "january"},
+			},
+		},
+		Transfers: []*pb.TransferEntry{
+			{Timestamp: timestamppb.New(timestamp), FromAccountName: "debit1", ToAccountName: "credit1", Amount: 200},
+		},
+		Profit: 5000,
+	}
+	gRPCClient := mocks.NewMockGRPCFinanceServiceClient(t)
+	gRPCClient.On("GetDetailedStatement", mock.Anything, mock.Anything).Return(gRPCRes, nil)
+	client := &financeServiceClient{
+		client: gRPCClient,
+	}
+
+	res, err := client.GetDetailedStatement(context.Background(), &domain.GetOverviewStatementRequest{})
+
+	expected := &domain.GetDetailedStatementResponse{
+		Revenue: &domain.GetDetailedStatementSection{
+			Total: 5000,
+			Entries: []domain.Entry{
+				{Timestamp: timestamp, Account: "debit1", Category: "salary", Amount: 5000, Description: "january"},
+			},
+		},
+		Transfers: []domain.TransferEntry{
+			{Timestamp: timestamp, FromAccount: "debit1", ToAccount: "credit1", Amount: 200},
+		},
+		Profit: 5000,
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, expected, res)
+}
+
+func TestGetDetailedStatement_Error(t *testing.T) {
+	gRPCClient := mocks.NewMockGRPCFinanceServiceClient(t)
+	gRPCClient.On("GetDetailedStatement", mock.Anything, mock.Anything).Return(nil, errors.New("fails to get detailed statement"))
+	client := &financeServiceClient{
+		client: gRPCClient,
+	}
+
+	res, err := client.GetDetailedStatement(context.Background(), &domain.GetOverviewStatementRequest{})
+
+	assert.Nil(t, res)
+	assert.EqualError(t, err, "cannot get detailed statement: fails to get detailed statement")
+	assert.Equal(t, http.StatusBadGateway, err.StatusCode)
+}
+
+func TestListTransactions(t *testing.T) {
+	timestamp := time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC)
+	gRPCRes := &pb.ListTransactionsResponse{
+		Transactions: []*pb.Transaction{
+			{Id: "tx-3", Kind: &pb.Transaction_Withdrawal{Withdrawal: &pb.Entry{Timestamp: timestamppb.New(timestamp), AccountName: "debit1", Category: "fd", Amount: 120, Description: "lunch"}}},
+			{Id: "tx-2", Kind: &pb.Transaction_Deposit{Deposit: &pb.Entry{Timestamp: timestamppb.New(timestamp), AccountName: "debit1", Category: "salary", Amount: 5000}}},
+			{Id: "tx-1", Kind: &pb.Transaction_Transfer{Transfer: &pb.TransferEntry{Timestamp: timestamppb.New(timestamp), FromAccountName: "debit1", ToAccountName: "credit1", Amount: 200}}},
+			{Id: "tx-0"},
+		},
+		NextPageToken: "page-2",
+	}
+	gRPCClient := mocks.NewMockGRPCFinanceServiceClient(t)
+	gRPCClient.On("ListTransactions", mock.Anything, &pb.ListTransactionsRequest{PageSize: 4, PageToken: "page-1"}).Return(gRPCRes, nil)
+	client := &financeServiceClient{
+		client: gRPCClient,
+	}
+
+	res, err := client.ListTransactions(context.Background(), &domain.ListTransactionsRequest{PageSize: 4, PageToken: "page-1"})
+
+	expected := &domain.ListTransactionsResponse{
+		Transactions: []domain.Transaction{
+			{ID: "tx-3", Type: domain.TransactionWithdrawal, Timestamp: timestamp, Account: "debit1", Category: "fd", Amount: 120, Description: "lunch"},
+			{ID: "tx-2", Type: domain.TransactionDeposit, Timestamp: timestamp, Account: "debit1", Category: "salary", Amount: 5000},
+			{ID: "tx-1", Type: domain.TransactionTransfer, Timestamp: timestamp, Account: "debit1", ToAccount: "credit1", Amount: 200},
+		},
+		NextPageToken: "page-2",
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, expected, res)
+}
+
+func TestListTransactions_Error(t *testing.T) {
+	gRPCClient := mocks.NewMockGRPCFinanceServiceClient(t)
+	gRPCClient.On("ListTransactions", mock.Anything, mock.Anything).Return(nil, errors.New("fails to list transactions"))
+	client := &financeServiceClient{
+		client: gRPCClient,
+	}
+
+	res, err := client.ListTransactions(context.Background(), &domain.ListTransactionsRequest{})
+
+	assert.Nil(t, res)
+	assert.EqualError(t, err, "cannot list transactions: fails to list transactions")
+	assert.Equal(t, http.StatusBadGateway, err.StatusCode)
+}
+
+func TestUpdateTransaction(t *testing.T) {
+	timestamp := time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC)
+	category := "sh"
+	gRPCRes := &pb.UpdateTransactionResponse{
+		Before: &pb.Transaction{Id: "tx-3", Kind: &pb.Transaction_Withdrawal{Withdrawal: &pb.Entry{Timestamp: timestamppb.New(timestamp), AccountName: "debit1", Category: "fd", Amount: 120}}},
+		After:  &pb.Transaction{Id: "tx-3", Kind: &pb.Transaction_Withdrawal{Withdrawal: &pb.Entry{Timestamp: timestamppb.New(timestamp), AccountName: "debit1", Category: "sh", Amount: 120}}},
+	}
+	gRPCClient := mocks.NewMockGRPCFinanceServiceClient(t)
+	gRPCClient.On("UpdateTransaction", mock.Anything, &pb.UpdateTransactionRequest{Id: "tx-3", Category: &category}).Return(gRPCRes, nil)
+	client := &financeServiceClient{
+		client: gRPCClient,
+	}
+
+	res, err := client.UpdateTransaction(context.Background(), &domain.UpdateTransactionRequest{ID: "tx-3", Category: &category})
+
+	expected := &domain.UpdateTransactionResponse{
+		Before: domain.Transaction{ID: "tx-3", Type: domain.TransactionWithdrawal, Timestamp: timestamp, Account: "debit1", Category: "fd", Amount: 120},
+		After:  domain.Transaction{ID: "tx-3", Type: domain.TransactionWithdrawal, Timestamp: timestamp, Account: "debit1", Category: "sh", Amount: 120},
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, expected, res)
+}
+
+func TestUpdateTransaction_Error(t *testing.T) {
+	gRPCClient := mocks.NewMockGRPCFinanceServiceClient(t)
+	gRPCClient.On("UpdateTransaction", mock.Anything, mock.Anything).Return(nil, errors.New("fails to update transaction"))
+	client := &financeServiceClient{
+		client: gRPCClient,
+	}
+
+	res, err := client.UpdateTransaction(context.Background(), &domain.UpdateTransactionRequest{})
+
+	assert.Nil(t, res)
+	assert.EqualError(t, err, "cannot update transaction: fails to update transaction")
+	assert.Equal(t, http.StatusBadGateway, err.StatusCode)
+}
+
 func TestToGetOverviewStatementResponse(t *testing.T) {
 	testcases := []struct {
 		it       string
@@ -381,3 +519,31 @@ func TestToGetOverviewStatementResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestWithCallerMetadata(t *testing.T) {
+	testcases := []struct {
+		it       string
+		ctx      context.Context
+		expected metadata.MD
+	}{
+		{
+			it:       "adds the caller identity to the outgoing metadata",
+			ctx:      domain.ContextWithUser(context.Background(), domain.User{ID: "partner", AccountNamespace: "shared"}),
+			expected: metadata.Pairs("x-user-id", "partner", "x-account-namespace", "shared"),
+		},
+		{
+			it:       "leaves the context untouched when there is no caller",
+			ctx:      context.Background(),
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			ctx := withCallerMetadata(tc.ctx)
+
+			md, _ := metadata.FromOutgoingContext(ctx)
+			assert.Equal(t, tc.expected, md)
+		})
+	}
+}
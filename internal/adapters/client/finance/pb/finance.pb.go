@@ -1,8 +1,8 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.28.0
+// 	protoc-gen-go v1.36.10
 // 	protoc        v3.21.2
-// source: proto/finance.proto
+// source: finance.proto
 
 package pb
 
@@ -13,6 +13,7 @@ import (
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
+	unsafe "unsafe"
 )
 
 const (
@@ -24,23 +25,22 @@ const (
 
 // Transaction
 type TransactionRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	AccountName string                 `protobuf:"bytes,1,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	Amount      float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Category    string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// When the transaction happened, now when unset.
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	AccountName string  `protobuf:"bytes,1,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
-	Amount      float64 `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
-	Category    string  `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
-	Description string  `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TransactionRequest) Reset() {
 	*x = TransactionRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_finance_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_finance_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *TransactionRequest) String() string {
@@ -50,8 +50,8 @@ func (x *TransactionRequest) String() string {
 func (*TransactionRequest) ProtoMessage() {}
 
 func (x *TransactionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_finance_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_finance_proto_msgTypes[0]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -63,7 +63,7 @@ func (x *TransactionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransactionRequest.ProtoReflect.Descriptor instead.
 func (*TransactionRequest) Descriptor() ([]byte, []int) {
-	return file_proto_finance_proto_rawDescGZIP(), []int{0}
+	return file_finance_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *TransactionRequest) GetAccountName() string {
@@ -94,24 +94,28 @@ func (x *TransactionRequest) GetDescription() string {
 	return ""
 }
 
+func (x *TransactionRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
 type TransactionResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        int32                  `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	AccountName   string                 `protobuf:"bytes,3,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	Balance       float64                `protobuf:"fixed64,4,opt,name=balance,proto3" json:"balance,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Status      int32   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
-	Error       string  `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	AccountName string  `protobuf:"bytes,3,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
-	Balance     float64 `protobuf:"fixed64,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TransactionResponse) Reset() {
 	*x = TransactionResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_finance_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_finance_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *TransactionResponse) String() string {
@@ -121,8 +125,8 @@ func (x *TransactionResponse) String() string {
 func (*TransactionResponse) ProtoMessage() {}
 
 func (x *TransactionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_finance_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_finance_proto_msgTypes[1]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -134,7 +138,7 @@ func (x *TransactionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransactionResponse.ProtoReflect.Descriptor instead.
 func (*TransactionResponse) Descriptor() ([]byte, []int) {
-	return file_proto_finance_proto_rawDescGZIP(), []int{1}
+	return file_finance_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *TransactionResponse) GetStatus() int32 {
@@ -167,23 +171,20 @@ func (x *TransactionResponse) GetBalance() float64 {
 
 // Transfer
 type TransferRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	FromAccountName string  `protobuf:"bytes,1,opt,name=from_account_name,json=fromAccountName,proto3" json:"from_account_name,omitempty"`
-	ToAccountName   string  `protobuf:"bytes,2,opt,name=to_account_name,json=toAccountName,proto3" json:"to_account_name,omitempty"`
-	Amount          float64 `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	Description     string  `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	FromAccountName string                 `protobuf:"bytes,1,opt,name=from_account_name,json=fromAccountName,proto3" json:"from_account_name,omitempty"`
+	ToAccountName   string                 `protobuf:"bytes,2,opt,name=to_account_name,json=toAccountName,proto3" json:"to_account_name,omitempty"`
+	Amount          float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Description     string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *TransferRequest) Reset() {
 	*x = TransferRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_finance_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_finance_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *TransferRequest) String() string {
@@ -193,8 +194,8 @@ func (x *TransferRequest) String() string {
 func (*TransferRequest) ProtoMessage() {}
 
 func (x *TransferRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_finance_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_finance_proto_msgTypes[2]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -206,7 +207,7 @@ func (x *TransferRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransferRequest.ProtoReflect.Descriptor instead.
 func (*TransferRequest) Descriptor() ([]byte, []int) {
-	return file_proto_finance_proto_rawDescGZIP(), []int{2}
+	return file_finance_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *TransferRequest) GetFromAccountName() string {
@@ -238,23 +239,20 @@ func (x *TransferRequest) GetDescription() string {
 }
 
 type TransferResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Status          int32   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
-	Error           string  `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	FromAccountName string  `protobuf:"bytes,3,opt,name=from_account_name,json=fromAccountName,proto3" json:"from_account_name,omitempty"`
-	Balance         float64 `protobuf:"fixed64,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Status          int32                  `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Error           string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	FromAccountName string                 `protobuf:"bytes,3,opt,name=from_account_name,json=fromAccountName,proto3" json:"from_account_name,omitempty"`
+	Balance         float64                `protobuf:"fixed64,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *TransferResponse) Reset() {
 	*x = TransferResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_finance_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_finance_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *TransferResponse) String() string {
@@ -264,8 +262,8 @@ func (x *TransferResponse) String() string {
 func (*TransferResponse) ProtoMessage() {}
 
 func (x *TransferResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_finance_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_finance_proto_msgTypes[3]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -277,7 +275,7 @@ func (x *TransferResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransferResponse.ProtoReflect.Descriptor instead.
 func (*TransferResponse) Descriptor() ([]byte, []int) {
-	return file_proto_finance_proto_rawDescGZIP(), []int{3}
+	return file_finance_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *TransferResponse) GetStatus() int32 {
@@ -310,22 +308,19 @@ func (x *TransferResponse) GetBalance() float64 {
 
 // Balance
 type GetBalanceResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        int32                  `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Accounts      []*AccountBalance      `protobuf:"bytes,3,rep,name=accounts,proto3" json:"accounts,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Status   int32             `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
-	Error    string            `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	Accounts []*AccountBalance `protobuf:"bytes,3,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetBalanceResponse) Reset() {
 	*x = GetBalanceResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_finance_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_finance_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *GetBalanceResponse) String() string {
@@ -335,8 +330,8 @@ func (x *GetBalanceResponse) String() string {
 func (*GetBalanceResponse) ProtoMessage() {}
 
 func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_finance_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_finance_proto_msgTypes[4]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -348,7 +343,7 @@ func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBalanceResponse.ProtoReflect.Descriptor instead.
 func (*GetBalanceResponse) Descriptor() ([]byte, []int) {
-	return file_proto_finance_proto_rawDescGZIP(), []int{4}
+	return file_finance_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetBalanceResponse) GetStatus() int32 {
@@ -373,21 +368,18 @@ func (x *GetBalanceResponse) GetAccounts() []*AccountBalance {
 }
 
 type AccountBalance struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountName   string                 `protobuf:"bytes,1,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	Balance       float64                `protobuf:"fixed64,2,opt,name=balance,proto3" json:"balance,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	AccountName string  `protobuf:"bytes,1,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
-	Balance     float64 `protobuf:"fixed64,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AccountBalance) Reset() {
 	*x = AccountBalance{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_finance_proto_msgTypes[5]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_finance_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *AccountBalance) String() string {
@@ -397,8 +389,8 @@ func (x *AccountBalance) String() string {
 func (*AccountBalance) ProtoMessage() {}
 
 func (x *AccountBalance) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_finance_proto_msgTypes[5]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_finance_proto_msgTypes[5]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -410,7 +402,7 @@ func (x *AccountBalance) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AccountBalance.ProtoReflect.Descriptor instead.
 func (*AccountBalance) Descriptor() ([]byte, []int) {
-	return file_proto_finance_proto_rawDescGZIP(), []int{5}
+	return file_finance_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *AccountBalance) GetAccountName() string {
@@ -429,21 +421,18 @@ func (x *AccountBalance) GetBalance() float64 {
 
 // Overview Statement
 type OverviewStatementRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	From *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
-	To   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OverviewStatementRequest) Reset() {
 	*x = OverviewStatementRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_finance_proto_msgTypes[6]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_finance_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *OverviewStatementRequest) String() string {
@@ -453,8 +442,8 @@ func (x *OverviewStatementRequest) String() string {
 func (*OverviewStatementRequest) ProtoMessage() {}
 
 func (x *OverviewStatementRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_finance_proto_msgTypes[6]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_finance_proto_msgTypes[6]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -466,7 +455,7 @@ func (x *OverviewStatementRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OverviewStatementRequest.ProtoReflect.Descriptor instead.
 func (*OverviewStatementRequest) Descriptor() ([]byte, []int) {
-	return file_proto_finance_proto_rawDescGZIP(), []int{6}
+	return file_finance_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *OverviewStatementRequest) GetFrom() *timestamppb.Timestamp {
@@ -484,24 +473,21 @@ func (x *OverviewStatementRequest) GetTo() *timestamppb.Timestamp {
 }
 
 type OverviewStatementResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Status        int32                     `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Error         string                    `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Revenue       *OverviewStatementSection `protobuf:"bytes,3,opt,name=revenue,proto3" json:"revenue,omitempty"`
+	Expense       *OverviewStatementSection `protobuf:"bytes,4,opt,name=expense,proto3" json:"expense,omitempty"`
+	Profit        float64                   `protobuf:"fixed64,5,opt,name=profit,proto3" json:"profit,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Status  int32                     `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
-	Error   string                    `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	Revenue *OverviewStatementSection `protobuf:"bytes,3,opt,name=revenue,proto3" json:"revenue,omitempty"`
-	Expense *OverviewStatementSection `protobuf:"bytes,4,opt,name=expense,proto3" json:"expense,omitempty"`
-	Profit  float64                   `protobuf:"fixed64,5,opt,name=profit,proto3" json:"profit,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OverviewStatementResponse) Reset() {
 	*x = OverviewStatementResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_finance_proto_msgTypes[7]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_finance_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *OverviewStatementResponse) String() string {
@@ -511,8 +497,8 @@ func (x *OverviewStatementResponse) String() string {
 func (*OverviewStatementResponse) ProtoMessage() {}
 
 func (x *OverviewStatementResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_finance_proto_msgTypes[7]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_finance_proto_msgTypes[7]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -524,7 +510,7 @@ func (x *OverviewStatementResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OverviewStatementResponse.ProtoReflect.Descriptor instead.
 func (*OverviewStatementResponse) Descriptor() ([]byte, []int) {
-	return file_proto_finance_proto_rawDescGZIP(), []int{7}
+	return file_finance_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *OverviewStatementResponse) GetStatus() int32 {
@@ -563,21 +549,18 @@ func (x *OverviewStatementResponse) GetProfit() float64 {
 }
 
 type OverviewStatementSection struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         float64                `protobuf:"fixed64,1,opt,name=total,proto3" json:"total,omitempty"`
+	Entries       []*CategorizedEntry    `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Total   float64             `protobuf:"fixed64,1,opt,name=total,proto3" json:"total,omitempty"`
-	Entries []*CategorizedEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OverviewStatementSection) Reset() {
 	*x = OverviewStatementSection{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_finance_proto_msgTypes[8]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_finance_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *OverviewStatementSection) String() string {
@@ -587,8 +570,8 @@ func (x *OverviewStatementSection) String() string {
 func (*OverviewStatementSection) ProtoMessage() {}
 
 func (x *OverviewStatementSection) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_finance_proto_msgTypes[8]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_finance_proto_msgTypes[8]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -600,7 +583,7 @@ func (x *OverviewStatementSection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OverviewStatementSection.ProtoReflect.Descriptor instead.
 func (*OverviewStatementSection) Descriptor() ([]byte, []int) {
-	return file_proto_finance_proto_rawDescGZIP(), []int{8}
+	return file_finance_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *OverviewStatementSection) GetTotal() float64 {
@@ -618,21 +601,18 @@ func (x *OverviewStatementSection) GetEntries() []*CategorizedEntry {
 }
 
 type CategorizedEntry struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Category string  `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
-	Amount   float64 `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CategorizedEntry) Reset() {
 	*x = CategorizedEntry{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_finance_proto_msgTypes[9]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_finance_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *CategorizedEntry) String() string {
@@ -642,8 +622,8 @@ func (x *CategorizedEntry) String() string {
 func (*CategorizedEntry) ProtoMessage() {}
 
 func (x *CategorizedEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_finance_proto_msgTypes[9]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_finance_proto_msgTypes[9]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -655,7 +635,7 @@ func (x *CategorizedEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CategorizedEntry.ProtoReflect.Descriptor instead.
 func (*CategorizedEntry) Descriptor() ([]byte, []int) {
-	return file_proto_finance_proto_rawDescGZIP(), []int{9}
+	return file_finance_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *CategorizedEntry) GetCategory() string {
@@ -674,24 +654,22 @@ func (x *CategorizedEntry) GetAmount() float64 {
 
 // Detailed Statement
 type DetailedStatementResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Status        int32                     `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Error         string                    `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Revenue       *DetailedStatementSection `protobuf:"bytes,3,opt,name=revenue,proto3" json:"revenue,omitempty"`
+	Expense       *DetailedStatementSection `protobuf:"bytes,4,opt,name=expense,proto3" json:"expense,omitempty"`
+	Profit        float64                   `protobuf:"fixed64,5,opt,name=profit,proto3" json:"profit,omitempty"`
+	Transfers     []*TransferEntry          `protobuf:"bytes,6,rep,name=transfers,proto3" json:"transfers,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Status  int32                     `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
-	Error   string                    `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	Revenue *DetailedStatementSection `protobuf:"bytes,3,opt,name=revenue,proto3" json:"revenue,omitempty"`
-	Expense *DetailedStatementSection `protobuf:"bytes,4,opt,name=expense,proto3" json:"expense,omitempty"`
-	Profit  float64                   `protobuf:"fixed64,5,opt,name=profit,proto3" json:"profit,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DetailedStatementResponse) Reset() {
 	*x = DetailedStatementResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_finance_proto_msgTypes[10]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_finance_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *DetailedStatementResponse) String() string {
@@ -701,8 +679,8 @@ func (x *DetailedStatementResponse) String() string {
 func (*DetailedStatementResponse) ProtoMessage() {}
 
 func (x *DetailedStatementResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_finance_proto_msgTypes[10]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_finance_proto_msgTypes[10]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -714,7 +692,7 @@ func (x *DetailedStatementResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DetailedStatementResponse.ProtoReflect.Descriptor instead.
 func (*DetailedStatementResponse) Descriptor() ([]byte, []int) {
-	return file_proto_finance_proto_rawDescGZIP(), []int{10}
+	return file_finance_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DetailedStatementResponse) GetStatus() int32 {
@@ -752,22 +730,26 @@ func (x *DetailedStatementResponse) GetProfit() float64 {
 	return 0
 }
 
+func (x *DetailedStatementResponse) GetTransfers() []*TransferEntry {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
 type DetailedStatementSection struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         float64                `protobuf:"fixed64,1,opt,name=total,proto3" json:"total,omitempty"`
+	Entries       []*Entry               `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Total   float64  `protobuf:"fixed64,1,opt,name=total,proto3" json:"total,omitempty"`
-	Entries []*Entry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DetailedStatementSection) Reset() {
 	*x = DetailedStatementSection{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_finance_proto_msgTypes[11]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_finance_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *DetailedStatementSection) String() string {
@@ -777,8 +759,8 @@ func (x *DetailedStatementSection) String() string {
 func (*DetailedStatementSection) ProtoMessage() {}
 
 func (x *DetailedStatementSection) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_finance_proto_msgTypes[11]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_finance_proto_msgTypes[11]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -790,7 +772,7 @@ func (x *DetailedStatementSection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DetailedStatementSection.ProtoReflect.Descriptor instead.
 func (*DetailedStatementSection) Descriptor() ([]byte, []int) {
-	return file_proto_finance_proto_rawDescGZIP(), []int{11}
+	return file_finance_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *DetailedStatementSection) GetTotal() float64 {
@@ -808,24 +790,21 @@ func (x *DetailedStatementSection) GetEntries() []*Entry {
 }
 
 type Entry struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	AccountName   string                 `protobuf:"bytes,2,opt,name=accountName,proto3" json:"accountName,omitempty"`
+	Category      string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Timestamp   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	AccountName string                 `protobuf:"bytes,2,opt,name=accountName,proto3" json:"accountName,omitempty"`
-	Category    string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
-	Amount      float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
-	Description string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Entry) Reset() {
 	*x = Entry{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_finance_proto_msgTypes[12]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_finance_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Entry) String() string {
@@ -835,8 +814,8 @@ func (x *Entry) String() string {
 func (*Entry) ProtoMessage() {}
 
 func (x *Entry) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_finance_proto_msgTypes[12]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_finance_proto_msgTypes[12]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -848,7 +827,7 @@ func (x *Entry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Entry.ProtoReflect.Descriptor instead.
 func (*Entry) Descriptor() ([]byte, []int) {
-	return file_proto_finance_proto_rawDescGZIP(), []int{12}
+	return file_finance_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Entry) GetTimestamp() *timestamppb.Timestamp {
@@ -886,171 +865,579 @@ func (x *Entry) GetDescription() string {
 	return ""
 }
 
-var File_proto_finance_proto protoreflect.FileDescriptor
-
-var file_proto_finance_proto_rawDesc = []byte{
-	0x0a, 0x13, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x22, 0x8d, 0x01, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a,
-	0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x61,
-	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
-	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
-	0x79, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x22, 0x80, 0x01, 0x0a, 0x13, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07,
-	0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x62,
-	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x9f, 0x01, 0x0a, 0x0f, 0x54, 0x72, 0x61, 0x6e, 0x73,
-	0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x66, 0x72,
-	0x6f, 0x6d, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x66, 0x72, 0x6f, 0x6d, 0x41, 0x63, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x74, 0x6f, 0x5f, 0x61, 0x63, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0d, 0x74, 0x6f, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16,
-	0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06,
-	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x86, 0x01, 0x0a, 0x10, 0x54, 0x72, 0x61,
-	0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x2a, 0x0a, 0x11, 0x66,
-	0x72, 0x6f, 0x6d, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x66, 0x72, 0x6f, 0x6d, 0x41, 0x63, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e,
-	0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63,
-	0x65, 0x22, 0x6f, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x2b, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x08, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x73, 0x22, 0x4d, 0x0a, 0x0e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x61, 0x6c,
-	0x61, 0x6e, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e,
-	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63,
-	0x65, 0x22, 0x76, 0x0a, 0x18, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e, 0x0a,
-	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x2a, 0x0a,
-	0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x02, 0x74, 0x6f, 0x22, 0xcb, 0x01, 0x0a, 0x19, 0x4f, 0x76,
-	0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x33, 0x0a, 0x07, 0x72, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65,
-	0x77, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x07, 0x72, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x65, 0x78,
-	0x70, 0x65, 0x6e, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x4f, 0x76,
-	0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x12,
-	0x16, 0x0a, 0x06, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52,
-	0x06, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x22, 0x5d, 0x0a, 0x18, 0x4f, 0x76, 0x65, 0x72, 0x76,
-	0x69, 0x65, 0x77, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x01, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x2b, 0x0a, 0x07, 0x65, 0x6e, 0x74,
-	0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x43, 0x61, 0x74,
-	0x65, 0x67, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65,
-	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x46, 0x0a, 0x10, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f,
-	0x72, 0x69, 0x7a, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61,
-	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61,
-	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xcb,
-	0x01, 0x0a, 0x19, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65,
-	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x33, 0x0a, 0x07, 0x72, 0x65,
-	0x76, 0x65, 0x6e, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x44, 0x65,
-	0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x72, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x12,
-	0x33, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x19, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65,
-	0x6d, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x65, 0x78, 0x70,
-	0x65, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x22, 0x52, 0x0a, 0x18,
-	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e,
-	0x74, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61,
-	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x20,
-	0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x06, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73,
-	0x22, 0xb9, 0x01, 0x0a, 0x05, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e,
-	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f,
-	0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f,
-	0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x01, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
-	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x32, 0xeb, 0x03, 0x0a,
-	0x0e, 0x46, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x37, 0x0a, 0x08, 0x57, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x12, 0x13, 0x2e, 0x54, 0x72,
-	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x14, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x07, 0x44, 0x65, 0x70, 0x6f,
-	0x73, 0x69, 0x74, 0x12, 0x13, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
-	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x31, 0x0a, 0x08, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x10, 0x2e, 0x54,
-	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11,
-	0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63,
-	0x65, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x47, 0x65, 0x74, 0x42,
-	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x4f, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x53,
-	0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x76,
-	0x69, 0x65, 0x77, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x53, 0x74,
-	0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x53, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77,
-	0x4d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74,
-	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1a, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x76,
-	0x69, 0x65, 0x77, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x4f, 0x76, 0x65,
-	0x72, 0x76, 0x69, 0x65, 0x77, 0x41, 0x6e, 0x6e, 0x75, 0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65,
-	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1a, 0x2e, 0x4f,
-	0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f,
-	0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+type TransferEntry struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	FromAccountName string                 `protobuf:"bytes,2,opt,name=fromAccountName,proto3" json:"fromAccountName,omitempty"`
+	ToAccountName   string                 `protobuf:"bytes,3,opt,name=toAccountName,proto3" json:"toAccountName,omitempty"`
+	Amount          float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Description     string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
+func (x *TransferEntry) Reset() {
+	*x = TransferEntry{}
+	mi := &file_finance_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferEntry) ProtoMessage() {}
+
+func (x *TransferEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_finance_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferEntry.ProtoReflect.Descriptor instead.
+func (*TransferEntry) Descriptor() ([]byte, []int) {
+	return file_finance_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TransferEntry) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *TransferEntry) GetFromAccountName() string {
+	if x != nil {
+		return x.FromAccountName
+	}
+	return ""
+}
+
+func (x *TransferEntry) GetToAccountName() string {
+	if x != nil {
+		return x.ToAccountName
+	}
+	return ""
+}
+
+func (x *TransferEntry) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *TransferEntry) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// List Transactions
+type ListTransactionsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	PageSize int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of the previous page, empty for
+	// the first page.
+	PageToken     string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsRequest) Reset() {
+	*x = ListTransactionsRequest{}
+	mi := &file_finance_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsRequest) ProtoMessage() {}
+
+func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_finance_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_finance_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListTransactionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// ListTransactionsResponse lists the transactions, newest first.
+type ListTransactionsResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Status       int32                  `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Error        string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Transactions []*Transaction         `protobuf:"bytes,3,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	// next_page_token is empty on the last page.
+	NextPageToken string `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsResponse) Reset() {
+	*x = ListTransactionsResponse{}
+	mi := &file_finance_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsResponse) ProtoMessage() {}
+
+func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_finance_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_finance_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListTransactionsResponse) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ListTransactionsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ListTransactionsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type Transaction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Types that are valid to be assigned to Kind:
+	//
+	//	*Transaction_Withdrawal
+	//	*Transaction_Deposit
+	//	*Transaction_Transfer
+	Kind          isTransaction_Kind `protobuf_oneof:"kind"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_finance_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_finance_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_finance_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Transaction) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Transaction) GetKind() isTransaction_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *Transaction) GetWithdrawal() *Entry {
+	if x != nil {
+		if x, ok := x.Kind.(*Transaction_Withdrawal); ok {
+			return x.Withdrawal
+		}
+	}
+	return nil
+}
+
+func (x *Transaction) GetDeposit() *Entry {
+	if x != nil {
+		if x, ok := x.Kind.(*Transaction_Deposit); ok {
+			return x.Deposit
+		}
+	}
+	return nil
+}
+
+func (x *Transaction) GetTransfer() *TransferEntry {
+	if x != nil {
+		if x, ok := x.Kind.(*Transaction_Transfer); ok {
+			return x.Transfer
+		}
+	}
+	return nil
+}
+
+type isTransaction_Kind interface {
+	isTransaction_Kind()
+}
+
+type Transaction_Withdrawal struct {
+	Withdrawal *Entry `protobuf:"bytes,2,opt,name=withdrawal,proto3,oneof"`
+}
+
+type Transaction_Deposit struct {
+	Deposit *Entry `protobuf:"bytes,3,opt,name=deposit,proto3,oneof"`
+}
+
+type Transaction_Transfer struct {
+	Transfer *TransferEntry `protobuf:"bytes,4,opt,name=transfer,proto3,oneof"`
+}
+
+func (*Transaction_Withdrawal) isTransaction_Kind() {}
+
+func (*Transaction_Deposit) isTransaction_Kind() {}
+
+func (*Transaction_Transfer) isTransaction_Kind() {}
+
+// Update Transaction
+// UpdateTransactionRequest patches the fields of the transaction which are
+// set. Transfers have no category.
+type UpdateTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Category      *string                `protobuf:"bytes,2,opt,name=category,proto3,oneof" json:"category,omitempty"`
+	Amount        *float64               `protobuf:"fixed64,3,opt,name=amount,proto3,oneof" json:"amount,omitempty"`
+	Description   *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTransactionRequest) Reset() {
+	*x = UpdateTransactionRequest{}
+	mi := &file_finance_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTransactionRequest) ProtoMessage() {}
+
+func (x *UpdateTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_finance_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTransactionRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_finance_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateTransactionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateTransactionRequest) GetCategory() string {
+	if x != nil && x.Category != nil {
+		return *x.Category
+	}
+	return ""
+}
+
+func (x *UpdateTransactionRequest) GetAmount() float64 {
+	if x != nil && x.Amount != nil {
+		return *x.Amount
+	}
+	return 0
+}
+
+func (x *UpdateTransactionRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+type UpdateTransactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        int32                  `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Before        *Transaction           `protobuf:"bytes,3,opt,name=before,proto3" json:"before,omitempty"`
+	After         *Transaction           `protobuf:"bytes,4,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTransactionResponse) Reset() {
+	*x = UpdateTransactionResponse{}
+	mi := &file_finance_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTransactionResponse) ProtoMessage() {}
+
+func (x *UpdateTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_finance_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTransactionResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_finance_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdateTransactionResponse) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *UpdateTransactionResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *UpdateTransactionResponse) GetBefore() *Transaction {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *UpdateTransactionResponse) GetAfter() *Transaction {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+var File_finance_proto protoreflect.FileDescriptor
+
+const file_finance_proto_rawDesc = "" +
+	"\n" +
+	"\rfinance.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc7\x01\n" +
+	"\x12TransactionRequest\x12!\n" +
+	"\faccount_name\x18\x01 \x01(\tR\vaccountName\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x128\n" +
+	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\x80\x01\n" +
+	"\x13TransactionResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\x05R\x06status\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12!\n" +
+	"\faccount_name\x18\x03 \x01(\tR\vaccountName\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\x01R\abalance\"\x9f\x01\n" +
+	"\x0fTransferRequest\x12*\n" +
+	"\x11from_account_name\x18\x01 \x01(\tR\x0ffromAccountName\x12&\n" +
+	"\x0fto_account_name\x18\x02 \x01(\tR\rtoAccountName\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"\x86\x01\n" +
+	"\x10TransferResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\x05R\x06status\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12*\n" +
+	"\x11from_account_name\x18\x03 \x01(\tR\x0ffromAccountName\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\x01R\abalance\"o\n" +
+	"\x12GetBalanceResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\x05R\x06status\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12+\n" +
+	"\baccounts\x18\x03 \x03(\v2\x0f.AccountBalanceR\baccounts\"M\n" +
+	"\x0eAccountBalance\x12!\n" +
+	"\faccount_name\x18\x01 \x01(\tR\vaccountName\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\x01R\abalance\"v\n" +
+	"\x18OverviewStatementRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"\xcb\x01\n" +
+	"\x19OverviewStatementResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\x05R\x06status\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x123\n" +
+	"\arevenue\x18\x03 \x01(\v2\x19.OverviewStatementSectionR\arevenue\x123\n" +
+	"\aexpense\x18\x04 \x01(\v2\x19.OverviewStatementSectionR\aexpense\x12\x16\n" +
+	"\x06profit\x18\x05 \x01(\x01R\x06profit\"]\n" +
+	"\x18OverviewStatementSection\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x01R\x05total\x12+\n" +
+	"\aentries\x18\x02 \x03(\v2\x11.CategorizedEntryR\aentries\"F\n" +
+	"\x10CategorizedEntry\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\"\xf9\x01\n" +
+	"\x19DetailedStatementResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\x05R\x06status\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x123\n" +
+	"\arevenue\x18\x03 \x01(\v2\x19.DetailedStatementSectionR\arevenue\x123\n" +
+	"\aexpense\x18\x04 \x01(\v2\x19.DetailedStatementSectionR\aexpense\x12\x16\n" +
+	"\x06profit\x18\x05 \x01(\x01R\x06profit\x12,\n" +
+	"\ttransfers\x18\x06 \x03(\v2\x0e.TransferEntryR\ttransfers\"R\n" +
+	"\x18DetailedStatementSection\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x01R\x05total\x12 \n" +
+	"\aentries\x18\x02 \x03(\v2\x06.EntryR\aentries\"\xb9\x01\n" +
+	"\x05Entry\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12 \n" +
+	"\vaccountName\x18\x02 \x01(\tR\vaccountName\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\"\xd3\x01\n" +
+	"\rTransferEntry\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12(\n" +
+	"\x0ffromAccountName\x18\x02 \x01(\tR\x0ffromAccountName\x12$\n" +
+	"\rtoAccountName\x18\x03 \x01(\tR\rtoAccountName\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\"U\n" +
+	"\x17ListTransactionsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"\xa2\x01\n" +
+	"\x18ListTransactionsResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\x05R\x06status\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x120\n" +
+	"\ftransactions\x18\x03 \x03(\v2\f.TransactionR\ftransactions\x12&\n" +
+	"\x0fnext_page_token\x18\x04 \x01(\tR\rnextPageToken\"\xa1\x01\n" +
+	"\vTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12(\n" +
+	"\n" +
+	"withdrawal\x18\x02 \x01(\v2\x06.EntryH\x00R\n" +
+	"withdrawal\x12\"\n" +
+	"\adeposit\x18\x03 \x01(\v2\x06.EntryH\x00R\adeposit\x12,\n" +
+	"\btransfer\x18\x04 \x01(\v2\x0e.TransferEntryH\x00R\btransferB\x06\n" +
+	"\x04kind\"\xb7\x01\n" +
+	"\x18UpdateTransactionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\bcategory\x18\x02 \x01(\tH\x00R\bcategory\x88\x01\x01\x12\x1b\n" +
+	"\x06amount\x18\x03 \x01(\x01H\x01R\x06amount\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x02R\vdescription\x88\x01\x01B\v\n" +
+	"\t_categoryB\t\n" +
+	"\a_amountB\x0e\n" +
+	"\f_description\"\x93\x01\n" +
+	"\x19UpdateTransactionResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\x05R\x06status\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12$\n" +
+	"\x06before\x18\x03 \x01(\v2\f.TransactionR\x06before\x12\"\n" +
+	"\x05after\x18\x04 \x01(\v2\f.TransactionR\x05after2\xd5\x05\n" +
+	"\x0eFinanceService\x127\n" +
+	"\bWithdraw\x12\x13.TransactionRequest\x1a\x14.TransactionResponse\"\x00\x126\n" +
+	"\aDeposit\x12\x13.TransactionRequest\x1a\x14.TransactionResponse\"\x00\x121\n" +
+	"\bTransfer\x12\x10.TransferRequest\x1a\x11.TransferResponse\"\x00\x12;\n" +
+	"\n" +
+	"GetBalance\x12\x16.google.protobuf.Empty\x1a\x13.GetBalanceResponse\"\x00\x12O\n" +
+	"\x14GetOverviewStatement\x12\x19.OverviewStatementRequest\x1a\x1a.OverviewStatementResponse\"\x00\x12S\n" +
+	"\x1bGetOverviewMonthlyStatement\x12\x16.google.protobuf.Empty\x1a\x1a.OverviewStatementResponse\"\x00\x12R\n" +
+	"\x1aGetOverviewAnnualStatement\x12\x16.google.protobuf.Empty\x1a\x1a.OverviewStatementResponse\"\x00\x12O\n" +
+	"\x14GetDetailedStatement\x12\x19.OverviewStatementRequest\x1a\x1a.DetailedStatementResponse\"\x00\x12I\n" +
+	"\x10ListTransactions\x12\x18.ListTransactionsRequest\x1a\x19.ListTransactionsResponse\"\x00\x12L\n" +
+	"\x11UpdateTransaction\x12\x19.UpdateTransactionRequest\x1a\x1a.UpdateTransactionResponse\"\x00B\x06Z\x04./pbb\x06proto3"
+
 var (
-	file_proto_finance_proto_rawDescOnce sync.Once
-	file_proto_finance_proto_rawDescData = file_proto_finance_proto_rawDesc
+	file_finance_proto_rawDescOnce sync.Once
+	file_finance_proto_rawDescData []byte
 )
 
-func file_proto_finance_proto_rawDescGZIP() []byte {
-	file_proto_finance_proto_rawDescOnce.Do(func() {
-		file_proto_finance_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_finance_proto_rawDescData)
+func file_finance_proto_rawDescGZIP() []byte {
+	file_finance_proto_rawDescOnce.Do(func() {
+		file_finance_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_finance_proto_rawDesc), len(file_finance_proto_rawDesc)))
 	})
-	return file_proto_finance_proto_rawDescData
+	return file_finance_proto_rawDescData
 }
 
-var file_proto_finance_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
-var file_proto_finance_proto_goTypes = []interface{}{
+var file_finance_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_finance_proto_goTypes = []any{
 	(*TransactionRequest)(nil),        // 0: TransactionRequest
 	(*TransactionResponse)(nil),       // 1: TransactionResponse
 	(*TransferRequest)(nil),           // 2: TransferRequest
@@ -1064,220 +1451,88 @@ var file_proto_finance_proto_goTypes = []interface{}{
 	(*DetailedStatementResponse)(nil), // 10: DetailedStatementResponse
 	(*DetailedStatementSection)(nil),  // 11: DetailedStatementSection
 	(*Entry)(nil),                     // 12: Entry
-	(*timestamppb.Timestamp)(nil),     // 13: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),             // 14: google.protobuf.Empty
-}
-var file_proto_finance_proto_depIdxs = []int32{
-	5,  // 0: GetBalanceResponse.accounts:type_name -> AccountBalance
-	13, // 1: OverviewStatementRequest.from:type_name -> google.protobuf.Timestamp
-	13, // 2: OverviewStatementRequest.to:type_name -> google.protobuf.Timestamp
-	8,  // 3: OverviewStatementResponse.revenue:type_name -> OverviewStatementSection
-	8,  // 4: OverviewStatementResponse.expense:type_name -> OverviewStatementSection
-	9,  // 5: OverviewStatementSection.entries:type_name -> CategorizedEntry
-	11, // 6: DetailedStatementResponse.revenue:type_name -> DetailedStatementSection
-	11, // 7: DetailedStatementResponse.expense:type_name -> DetailedStatementSection
-	12, // 8: DetailedStatementSection.entries:type_name -> Entry
-	13, // 9: Entry.timestamp:type_name -> google.protobuf.Timestamp
-	0,  // 10: FinanceService.Withdraw:input_type -> TransactionRequest
-	0,  // 11: FinanceService.Deposit:input_type -> TransactionRequest
-	2,  // 12: FinanceService.Transfer:input_type -> TransferRequest
-	14, // 13: FinanceService.GetBalance:input_type -> google.protobuf.Empty
-	6,  // 14: FinanceService.GetOverviewStatement:input_type -> OverviewStatementRequest
-	14, // 15: FinanceService.GetOverviewMonthlyStatement:input_type -> google.protobuf.Empty
-	14, // 16: FinanceService.GetOverviewAnnualStatement:input_type -> google.protobuf.Empty
-	1,  // 17: FinanceService.Withdraw:output_type -> TransactionResponse
-	1,  // 18: FinanceService.Deposit:output_type -> TransactionResponse
-	3,  // 19: FinanceService.Transfer:output_type -> TransferResponse
-	4,  // 20: FinanceService.GetBalance:output_type -> GetBalanceResponse
-	7,  // 21: FinanceService.GetOverviewStatement:output_type -> OverviewStatementResponse
-	7,  // 22: FinanceService.GetOverviewMonthlyStatement:output_type -> OverviewStatementResponse
-	7,  // 23: FinanceService.GetOverviewAnnualStatement:output_type -> OverviewStatementResponse
-	17, // [17:24] is the sub-list for method output_type
-	10, // [10:17] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
-}
-
-func init() { file_proto_finance_proto_init() }
-func file_proto_finance_proto_init() {
-	if File_proto_finance_proto != nil {
+	(*TransferEntry)(nil),             // 13: TransferEntry
+	(*ListTransactionsRequest)(nil),   // 14: ListTransactionsRequest
+	(*ListTransactionsResponse)(nil),  // 15: ListTransactionsResponse
+	(*Transaction)(nil),               // 16: Transaction
+	(*UpdateTransactionRequest)(nil),  // 17: UpdateTransactionRequest
+	(*UpdateTransactionResponse)(nil), // 18: UpdateTransactionResponse
+	(*timestamppb.Timestamp)(nil),     // 19: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),             // 20: google.protobuf.Empty
+}
+var file_finance_proto_depIdxs = []int32{
+	19, // 0: TransactionRequest.timestamp:type_name -> google.protobuf.Timestamp
+	5,  // 1: GetBalanceResponse.accounts:type_name -> AccountBalance
+	19, // 2: OverviewStatementRequest.from:type_name -> google.protobuf.Timestamp
+	19, // 3: OverviewStatementRequest.to:type_name -> google.protobuf.Timestamp
+	8,  // 4: OverviewStatementResponse.revenue:type_name -> OverviewStatementSection
+	8,  // 5: OverviewStatementResponse.expense:type_name -> OverviewStatementSection
+	9,  // 6: OverviewStatementSection.entries:type_name -> CategorizedEntry
+	11, // 7: DetailedStatementResponse.revenue:type_name -> DetailedStatementSection
+	11, // 8: DetailedStatementResponse.expense:type_name -> DetailedStatementSection
+	13, // 9: DetailedStatementResponse.transfers:type_name -> TransferEntry
+	12, // 10: DetailedStatementSection.entries:type_name -> Entry
+	19, // 11: Entry.timestamp:type_name -> google.protobuf.Timestamp
+	19, // 12: TransferEntry.timestamp:type_name -> google.protobuf.Timestamp
+	16, // 13: ListTransactionsResponse.transactions:type_name -> Transaction
+	12, // 14: Transaction.withdrawal:type_name -> Entry
+	12, // 15: Transaction.deposit:type_name -> Entry
+	13, // 16: Transaction.transfer:type_name -> TransferEntry
+	16, // 17: UpdateTransactionResponse.before:type_name -> Transaction
+	16, // 18: UpdateTransactionResponse.after:type_name -> Transaction
+	0,  // 19: FinanceService.Withdraw:input_type -> TransactionRequest
+	0,  // 20: FinanceService.Deposit:input_type -> TransactionRequest
+	2,  // 21: FinanceService.Transfer:input_type -> TransferRequest
+	20, // 22: FinanceService.GetBalance:input_type -> google.protobuf.Empty
+	6,  // 23: FinanceService.GetOverviewStatement:input_type -> OverviewStatementRequest
+	20, // 24: FinanceService.GetOverviewMonthlyStatement:input_type -> google.protobuf.Empty
+	20, // 25: FinanceService.GetOverviewAnnualStatement:input_type -> google.protobuf.Empty
+	6,  // 26: FinanceService.GetDetailedStatement:input_type -> OverviewStatementRequest
+	14, // 27: FinanceService.ListTransactions:input_type -> ListTransactionsRequest
+	17, // 28: FinanceService.UpdateTransaction:input_type -> UpdateTransactionRequest
+	1,  // 29: FinanceService.Withdraw:output_type -> TransactionResponse
+	1,  // 30: FinanceService.Deposit:output_type -> TransactionResponse
+	3,  // 31: FinanceService.Transfer:output_type -> TransferResponse
+	4,  // 32: FinanceService.GetBalance:output_type -> GetBalanceResponse
+	7,  // 33: FinanceService.GetOverviewStatement:output_type -> OverviewStatementResponse
+	7,  // 34: FinanceService.GetOverviewMonthlyStatement:output_type -> OverviewStatementResponse
+	7,  // 35: FinanceService.GetOverviewAnnualStatement:output_type -> OverviewStatementResponse
+	10, // 36: FinanceService.GetDetailedStatement:output_type -> DetailedStatementResponse
+	15, // 37: FinanceService.ListTransactions:output_type -> ListTransactionsResponse
+	18, // 38: FinanceService.UpdateTransaction:output_type -> UpdateTransactionResponse
+	29, // [29:39] is the sub-list for method output_type
+	19, // [19:29] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_finance_proto_init() }
+func file_finance_proto_init() {
+	if File_finance_proto != nil {
 		return
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_proto_finance_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TransactionRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_finance_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TransactionResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_finance_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TransferRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_finance_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TransferResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_finance_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBalanceResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_finance_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AccountBalance); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_finance_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OverviewStatementRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_finance_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OverviewStatementResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_finance_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OverviewStatementSection); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_finance_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CategorizedEntry); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_finance_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DetailedStatementResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_finance_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DetailedStatementSection); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_finance_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Entry); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
+	file_finance_proto_msgTypes[16].OneofWrappers = []any{
+		(*Transaction_Withdrawal)(nil),
+		(*Transaction_Deposit)(nil),
+		(*Transaction_Transfer)(nil),
 	}
+	file_finance_proto_msgTypes[17].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_proto_finance_proto_rawDesc,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_finance_proto_rawDesc), len(file_finance_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
-		GoTypes:           file_proto_finance_proto_goTypes,
-		DependencyIndexes: file_proto_finance_proto_depIdxs,
-		MessageInfos:      file_proto_finance_proto_msgTypes,
+		GoTypes:           file_finance_proto_goTypes,
+		DependencyIndexes: file_finance_proto_depIdxs,
+		MessageInfos:      file_finance_proto_msgTypes,
 	}.Build()
-	File_proto_finance_proto = out.File
-	file_proto_finance_proto_rawDesc = nil
-	file_proto_finance_proto_goTypes = nil
-	file_proto_finance_proto_depIdxs = nil
+	File_finance_proto = out.File
+	file_finance_proto_goTypes = nil
+	file_finance_proto_depIdxs = nil
 }
@@ -2,7 +2,7 @@
 // versions:
 // - protoc-gen-go-grpc v1.2.0
 // - protoc             v3.21.2
-// source: proto/finance.proto
+// source: finance.proto
 
 package pb
 
@@ -30,6 +30,9 @@ type FinanceServiceClient interface {
 	GetOverviewStatement(ctx context.Context, in *OverviewStatementRequest, opts ...grpc.CallOption) (*OverviewStatementResponse, error)
 	GetOverviewMonthlyStatement(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*OverviewStatementResponse, error)
 	GetOverviewAnnualStatement(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*OverviewStatementResponse, error)
+	GetDetailedStatement(ctx context.Context, in *OverviewStatementRequest, opts ...grpc.CallOption) (*DetailedStatementResponse, error)
+	ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
+	UpdateTransaction(ctx context.Context, in *UpdateTransactionRequest, opts ...grpc.CallOption) (*UpdateTransactionResponse, error)
 }
 
 type financeServiceClient struct {
@@ -103,6 +106,33 @@ func (c *financeServiceClient) GetOverviewAnnualStatement(ctx context.Context, i
 	return out, nil
 }
 
+func (c *financeServiceClient) GetDetailedStatement(ctx context.Context, in *OverviewStatementRequest, opts ...grpc.CallOption) (*DetailedStatementResponse, error) {
+	out := new(DetailedStatementResponse)
+	err := c.cc.Invoke(ctx, "/FinanceService/GetDetailedStatement", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error) {
+	out := new(ListTransactionsResponse)
+	err := c.cc.Invoke(ctx, "/FinanceService/ListTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) UpdateTransaction(ctx context.Context, in *UpdateTransactionRequest, opts ...grpc.CallOption) (*UpdateTransactionResponse, error) {
+	out := new(UpdateTransactionResponse)
+	err := c.cc.Invoke(ctx, "/FinanceService/UpdateTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FinanceServiceServer is the server API for FinanceService service.
 // All implementations must embed UnimplementedFinanceServiceServer
 // for forward compatibility
@@ -114,6 +144,9 @@ type FinanceServiceServer interface {
 	GetOverviewStatement(context.Context, *OverviewStatementRequest) (*OverviewStatementResponse, error)
 	GetOverviewMonthlyStatement(context.Context, *emptypb.Empty) (*OverviewStatementResponse, error)
 	GetOverviewAnnualStatement(context.Context, *emptypb.Empty) (*OverviewStatementResponse, error)
+	GetDetailedStatement(context.Context, *OverviewStatementRequest) (*DetailedStatementResponse, error)
+	ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error)
+	UpdateTransaction(context.Context, *UpdateTransactionRequest) (*UpdateTransactionResponse, error)
 	mustEmbedUnimplementedFinanceServiceServer()
 }
 
@@ -142,6 +175,15 @@ func (UnimplementedFinanceServiceServer) GetOverviewMonthlyStatement(context.Con
 func (UnimplementedFinanceServiceServer) GetOverviewAnnualStatement(context.Context, *emptypb.Empty) (*OverviewStatementResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOverviewAnnualStatement not implemented")
 }
+func (UnimplementedFinanceServiceServer) GetDetailedStatement(context.Context, *OverviewStatementRequest) (*DetailedStatementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDetailedStatement not implemented")
+}
+func (UnimplementedFinanceServiceServer) ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
+}
+func (UnimplementedFinanceServiceServer) UpdateTransaction(context.Context, *UpdateTransactionRequest) (*UpdateTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTransaction not implemented")
+}
 func (UnimplementedFinanceServiceServer) mustEmbedUnimplementedFinanceServiceServer() {}
 
 // UnsafeFinanceServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -281,6 +323,60 @@ func _FinanceService_GetOverviewAnnualStatement_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FinanceService_GetDetailedStatement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OverviewStatementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).GetDetailedStatement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/FinanceService/GetDetailedStatement",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).GetDetailedStatement(ctx, req.(*OverviewStatementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).ListTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/FinanceService/ListTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).ListTransactions(ctx, req.(*ListTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_UpdateTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).UpdateTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/FinanceService/UpdateTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).UpdateTransaction(ctx, req.(*UpdateTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FinanceService_ServiceDesc is the grpc.ServiceDesc for FinanceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -316,7 +412,19 @@ var FinanceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetOverviewAnnualStatement",
 			Handler:    _FinanceService_GetOverviewAnnualStatement_Handler,
 		},
+		{
+			MethodName: "GetDetailedStatement",
+			Handler:    _FinanceService_GetDetailedStatement_Handler,
+		},
+		{
+			MethodName: "ListTransactions",
+			Handler:    _FinanceService_ListTransactions_Handler,
+		},
+		{
+			MethodName: "UpdateTransaction",
+			Handler:    _FinanceService_UpdateTransaction_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "proto/finance.proto",
+	Metadata: "finance.proto",
 }
@@ -0,0 +1,34 @@
+package grpc
+
+import (
+	"net/http"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatusError converts an AppError into the gRPC status error of the
+// closest code, keeping its message.
+func toStatusError(err *errors.AppError) error {
+	return status.Error(statusCode(err.StatusCode), err.Message)
+}
+
+func statusCode(httpStatusCode int) codes.Code {
+	switch httpStatusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
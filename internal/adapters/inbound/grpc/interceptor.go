@@ -0,0 +1,66 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	apiKeyMetadataKey    = "x-api-key"
+	requestIDMetadataKey = "x-request-id"
+)
+
+// UserFinder returns the user an API key acts on behalf of.
+type UserFinder func(apiKey string) (domain.User, bool)
+
+// loggingInterceptor tags every call with a request ID, taken from the
+// x-request-id metadata when the caller provides one, echoes it in the
+// response header and logs the outcome of the call.
+func loggingInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	requestID := metadataValue(ctx, requestIDMetadataKey)
+	if !logger.IsValidRequestID(requestID) {
+		requestID = logger.NewRequestID()
+	}
+	ctx = logger.WithRequestID(ctx, requestID)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadataKey, requestID))
+
+	start := time.Now()
+	res, err := handler(ctx, req)
+	logger.Ctx(ctx).Infow("handled grpc call",
+		"method", info.FullMethod,
+		"code", status.Code(err).String(),
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
+	return res, err
+}
+
+// authInterceptor only lets through calls bearing a known API key in the
+// x-api-key metadata, and attaches the key's user to the context.
+func authInterceptor(findUser UserFinder) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		user, ok := findUser(metadataValue(ctx, apiKeyMetadataKey))
+		if !ok {
+			logger.Ctx(ctx).Warnw("rejected grpc call", "method", info.FullMethod)
+			return nil, status.Error(codes.Unauthenticated, "missing or invalid API key")
+		}
+		return handler(domain.ContextWithUser(ctx, user), req)
+	}
+}
+
+func metadataValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
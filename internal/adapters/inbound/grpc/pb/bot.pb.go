@@ -0,0 +1,802 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v3.21.2
+// source: bot.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// HandleText
+type HandleTextRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HandleTextRequest) Reset() {
+	*x = HandleTextRequest{}
+	mi := &file_bot_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HandleTextRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HandleTextRequest) ProtoMessage() {}
+
+func (x *HandleTextRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bot_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HandleTextRequest.ProtoReflect.Descriptor instead.
+func (*HandleTextRequest) Descriptor() ([]byte, []int) {
+	return file_bot_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *HandleTextRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type HandleTextResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReplyMessage  string                 `protobuf:"bytes,1,opt,name=reply_message,json=replyMessage,proto3" json:"reply_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HandleTextResponse) Reset() {
+	*x = HandleTextResponse{}
+	mi := &file_bot_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HandleTextResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HandleTextResponse) ProtoMessage() {}
+
+func (x *HandleTextResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bot_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HandleTextResponse.ProtoReflect.Descriptor instead.
+func (*HandleTextResponse) Descriptor() ([]byte, []int) {
+	return file_bot_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HandleTextResponse) GetReplyMessage() string {
+	if x != nil {
+		return x.ReplyMessage
+	}
+	return ""
+}
+
+// Transaction
+type TransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountName   string                 `protobuf:"bytes,1,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Category      string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionRequest) Reset() {
+	*x = TransactionRequest{}
+	mi := &file_bot_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionRequest) ProtoMessage() {}
+
+func (x *TransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bot_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionRequest.ProtoReflect.Descriptor instead.
+func (*TransactionRequest) Descriptor() ([]byte, []int) {
+	return file_bot_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TransactionRequest) GetAccountName() string {
+	if x != nil {
+		return x.AccountName
+	}
+	return ""
+}
+
+func (x *TransactionRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *TransactionRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *TransactionRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type TransactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountName   string                 `protobuf:"bytes,1,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	Balance       float64                `protobuf:"fixed64,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionResponse) Reset() {
+	*x = TransactionResponse{}
+	mi := &file_bot_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionResponse) ProtoMessage() {}
+
+func (x *TransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bot_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionResponse.ProtoReflect.Descriptor instead.
+func (*TransactionResponse) Descriptor() ([]byte, []int) {
+	return file_bot_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TransactionResponse) GetAccountName() string {
+	if x != nil {
+		return x.AccountName
+	}
+	return ""
+}
+
+func (x *TransactionResponse) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+// Transfer
+type TransferRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	FromAccountName string                 `protobuf:"bytes,1,opt,name=from_account_name,json=fromAccountName,proto3" json:"from_account_name,omitempty"`
+	ToAccountName   string                 `protobuf:"bytes,2,opt,name=to_account_name,json=toAccountName,proto3" json:"to_account_name,omitempty"`
+	Amount          float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Description     string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TransferRequest) Reset() {
+	*x = TransferRequest{}
+	mi := &file_bot_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferRequest) ProtoMessage() {}
+
+func (x *TransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bot_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferRequest.ProtoReflect.Descriptor instead.
+func (*TransferRequest) Descriptor() ([]byte, []int) {
+	return file_bot_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TransferRequest) GetFromAccountName() string {
+	if x != nil {
+		return x.FromAccountName
+	}
+	return ""
+}
+
+func (x *TransferRequest) GetToAccountName() string {
+	if x != nil {
+		return x.ToAccountName
+	}
+	return ""
+}
+
+func (x *TransferRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *TransferRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type TransferResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	FromAccountName string                 `protobuf:"bytes,1,opt,name=from_account_name,json=fromAccountName,proto3" json:"from_account_name,omitempty"`
+	Balance         float64                `protobuf:"fixed64,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TransferResponse) Reset() {
+	*x = TransferResponse{}
+	mi := &file_bot_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferResponse) ProtoMessage() {}
+
+func (x *TransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bot_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferResponse.ProtoReflect.Descriptor instead.
+func (*TransferResponse) Descriptor() ([]byte, []int) {
+	return file_bot_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TransferResponse) GetFromAccountName() string {
+	if x != nil {
+		return x.FromAccountName
+	}
+	return ""
+}
+
+func (x *TransferResponse) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+// GetBalance
+type GetBalanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accounts      []*AccountBalance      `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceResponse) Reset() {
+	*x = GetBalanceResponse{}
+	mi := &file_bot_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceResponse) ProtoMessage() {}
+
+func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bot_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceResponse.ProtoReflect.Descriptor instead.
+func (*GetBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_bot_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetBalanceResponse) GetAccounts() []*AccountBalance {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+type AccountBalance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountName   string                 `protobuf:"bytes,1,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	Balance       float64                `protobuf:"fixed64,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountBalance) Reset() {
+	*x = AccountBalance{}
+	mi := &file_bot_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountBalance) ProtoMessage() {}
+
+func (x *AccountBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_bot_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountBalance.ProtoReflect.Descriptor instead.
+func (*AccountBalance) Descriptor() ([]byte, []int) {
+	return file_bot_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AccountBalance) GetAccountName() string {
+	if x != nil {
+		return x.AccountName
+	}
+	return ""
+}
+
+func (x *AccountBalance) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+// GetOverviewStatement
+type OverviewStatementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OverviewStatementRequest) Reset() {
+	*x = OverviewStatementRequest{}
+	mi := &file_bot_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OverviewStatementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverviewStatementRequest) ProtoMessage() {}
+
+func (x *OverviewStatementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bot_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverviewStatementRequest.ProtoReflect.Descriptor instead.
+func (*OverviewStatementRequest) Descriptor() ([]byte, []int) {
+	return file_bot_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *OverviewStatementRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *OverviewStatementRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type OverviewStatementResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Revenue       *OverviewStatementSection `protobuf:"bytes,1,opt,name=revenue,proto3" json:"revenue,omitempty"`
+	Expense       *OverviewStatementSection `protobuf:"bytes,2,opt,name=expense,proto3" json:"expense,omitempty"`
+	Profit        float64                   `protobuf:"fixed64,3,opt,name=profit,proto3" json:"profit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OverviewStatementResponse) Reset() {
+	*x = OverviewStatementResponse{}
+	mi := &file_bot_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OverviewStatementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverviewStatementResponse) ProtoMessage() {}
+
+func (x *OverviewStatementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bot_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverviewStatementResponse.ProtoReflect.Descriptor instead.
+func (*OverviewStatementResponse) Descriptor() ([]byte, []int) {
+	return file_bot_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OverviewStatementResponse) GetRevenue() *OverviewStatementSection {
+	if x != nil {
+		return x.Revenue
+	}
+	return nil
+}
+
+func (x *OverviewStatementResponse) GetExpense() *OverviewStatementSection {
+	if x != nil {
+		return x.Expense
+	}
+	return nil
+}
+
+func (x *OverviewStatementResponse) GetProfit() float64 {
+	if x != nil {
+		return x.Profit
+	}
+	return 0
+}
+
+type OverviewStatementSection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         float64                `protobuf:"fixed64,1,opt,name=total,proto3" json:"total,omitempty"`
+	Entries       []*CategorizedEntry    `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OverviewStatementSection) Reset() {
+	*x = OverviewStatementSection{}
+	mi := &file_bot_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OverviewStatementSection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverviewStatementSection) ProtoMessage() {}
+
+func (x *OverviewStatementSection) ProtoReflect() protoreflect.Message {
+	mi := &file_bot_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverviewStatementSection.ProtoReflect.Descriptor instead.
+func (*OverviewStatementSection) Descriptor() ([]byte, []int) {
+	return file_bot_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *OverviewStatementSection) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *OverviewStatementSection) GetEntries() []*CategorizedEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type CategorizedEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategorizedEntry) Reset() {
+	*x = CategorizedEntry{}
+	mi := &file_bot_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategorizedEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategorizedEntry) ProtoMessage() {}
+
+func (x *CategorizedEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_bot_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategorizedEntry.ProtoReflect.Descriptor instead.
+func (*CategorizedEntry) Descriptor() ([]byte, []int) {
+	return file_bot_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CategorizedEntry) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CategorizedEntry) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+var File_bot_proto protoreflect.FileDescriptor
+
+const file_bot_proto_rawDesc = "" +
+	"\n" +
+	"\tbot.proto\x12\x03bot\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"-\n" +
+	"\x11HandleTextRequest\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"9\n" +
+	"\x12HandleTextResponse\x12#\n" +
+	"\rreply_message\x18\x01 \x01(\tR\freplyMessage\"\x8d\x01\n" +
+	"\x12TransactionRequest\x12!\n" +
+	"\faccount_name\x18\x01 \x01(\tR\vaccountName\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"R\n" +
+	"\x13TransactionResponse\x12!\n" +
+	"\faccount_name\x18\x01 \x01(\tR\vaccountName\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\x01R\abalance\"\x9f\x01\n" +
+	"\x0fTransferRequest\x12*\n" +
+	"\x11from_account_name\x18\x01 \x01(\tR\x0ffromAccountName\x12&\n" +
+	"\x0fto_account_name\x18\x02 \x01(\tR\rtoAccountName\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"X\n" +
+	"\x10TransferResponse\x12*\n" +
+	"\x11from_account_name\x18\x01 \x01(\tR\x0ffromAccountName\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\x01R\abalance\"E\n" +
+	"\x12GetBalanceResponse\x12/\n" +
+	"\baccounts\x18\x01 \x03(\v2\x13.bot.AccountBalanceR\baccounts\"M\n" +
+	"\x0eAccountBalance\x12!\n" +
+	"\faccount_name\x18\x01 \x01(\tR\vaccountName\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\x01R\abalance\"v\n" +
+	"\x18OverviewStatementRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"\xa5\x01\n" +
+	"\x19OverviewStatementResponse\x127\n" +
+	"\arevenue\x18\x01 \x01(\v2\x1d.bot.OverviewStatementSectionR\arevenue\x127\n" +
+	"\aexpense\x18\x02 \x01(\v2\x1d.bot.OverviewStatementSectionR\aexpense\x12\x16\n" +
+	"\x06profit\x18\x03 \x01(\x01R\x06profit\"a\n" +
+	"\x18OverviewStatementSection\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x01R\x05total\x12/\n" +
+	"\aentries\x18\x02 \x03(\v2\x15.bot.CategorizedEntryR\aentries\"F\n" +
+	"\x10CategorizedEntry\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount2\xa3\x03\n" +
+	"\n" +
+	"BotService\x12?\n" +
+	"\n" +
+	"HandleText\x12\x16.bot.HandleTextRequest\x1a\x17.bot.HandleTextResponse\"\x00\x12?\n" +
+	"\bWithdraw\x12\x17.bot.TransactionRequest\x1a\x18.bot.TransactionResponse\"\x00\x12>\n" +
+	"\aDeposit\x12\x17.bot.TransactionRequest\x1a\x18.bot.TransactionResponse\"\x00\x129\n" +
+	"\bTransfer\x12\x14.bot.TransferRequest\x1a\x15.bot.TransferResponse\"\x00\x12?\n" +
+	"\n" +
+	"GetBalance\x12\x16.google.protobuf.Empty\x1a\x17.bot.GetBalanceResponse\"\x00\x12W\n" +
+	"\x14GetOverviewStatement\x12\x1d.bot.OverviewStatementRequest\x1a\x1e.bot.OverviewStatementResponse\"\x00B\x06Z\x04./pbb\x06proto3"
+
+var (
+	file_bot_proto_rawDescOnce sync.Once
+	file_bot_proto_rawDescData []byte
+)
+
+func file_bot_proto_rawDescGZIP() []byte {
+	file_bot_proto_rawDescOnce.Do(func() {
+		file_bot_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_bot_proto_rawDesc), len(file_bot_proto_rawDesc)))
+	})
+	return file_bot_proto_rawDescData
+}
+
+var file_bot_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_bot_proto_goTypes = []any{
+	(*HandleTextRequest)(nil),         // 0: bot.HandleTextRequest
+	(*HandleTextResponse)(nil),        // 1: bot.HandleTextResponse
+	(*TransactionRequest)(nil),        // 2: bot.TransactionRequest
+	(*TransactionResponse)(nil),       // 3: bot.TransactionResponse
+	(*TransferRequest)(nil),           // 4: bot.TransferRequest
+	(*TransferResponse)(nil),          // 5: bot.TransferResponse
+	(*GetBalanceResponse)(nil),        // 6: bot.GetBalanceResponse
+	(*AccountBalance)(nil),            // 7: bot.AccountBalance
+	(*OverviewStatementRequest)(nil),  // 8: bot.OverviewStatementRequest
+	(*OverviewStatementResponse)(nil), // 9: bot.OverviewStatementResponse
+	(*OverviewStatementSection)(nil),  // 10: bot.OverviewStatementSection
+	(*CategorizedEntry)(nil),          // 11: bot.CategorizedEntry
+	(*timestamppb.Timestamp)(nil),     // 12: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),             // 13: google.protobuf.Empty
+}
+var file_bot_proto_depIdxs = []int32{
+	7,  // 0: bot.GetBalanceResponse.accounts:type_name -> bot.AccountBalance
+	12, // 1: bot.OverviewStatementRequest.from:type_name -> google.protobuf.Timestamp
+	12, // 2: bot.OverviewStatementRequest.to:type_name -> google.protobuf.Timestamp
+	10, // 3: bot.OverviewStatementResponse.revenue:type_name -> bot.OverviewStatementSection
+	10, // 4: bot.OverviewStatementResponse.expense:type_name -> bot.OverviewStatementSection
+	11, // 5: bot.OverviewStatementSection.entries:type_name -> bot.CategorizedEntry
+	0,  // 6: bot.BotService.HandleText:input_type -> bot.HandleTextRequest
+	2,  // 7: bot.BotService.Withdraw:input_type -> bot.TransactionRequest
+	2,  // 8: bot.BotService.Deposit:input_type -> bot.TransactionRequest
+	4,  // 9: bot.BotService.Transfer:input_type -> bot.TransferRequest
+	13, // 10: bot.BotService.GetBalance:input_type -> google.protobuf.Empty
+	8,  // 11: bot.BotService.GetOverviewStatement:input_type -> bot.OverviewStatementRequest
+	1,  // 12: bot.BotService.HandleText:output_type -> bot.HandleTextResponse
+	3,  // 13: bot.BotService.Withdraw:output_type -> bot.TransactionResponse
+	3,  // 14: bot.BotService.Deposit:output_type -> bot.TransactionResponse
+	5,  // 15: bot.BotService.Transfer:output_type -> bot.TransferResponse
+	6,  // 16: bot.BotService.GetBalance:output_type -> bot.GetBalanceResponse
+	9,  // 17: bot.BotService.GetOverviewStatement:output_type -> bot.OverviewStatementResponse
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_bot_proto_init() }
+func file_bot_proto_init() {
+	if File_bot_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bot_proto_rawDesc), len(file_bot_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bot_proto_goTypes,
+		DependencyIndexes: file_bot_proto_depIdxs,
+		MessageInfos:      file_bot_proto_msgTypes,
+	}.Build()
+	File_bot_proto = out.File
+	file_bot_proto_goTypes = nil
+	file_bot_proto_depIdxs = nil
+}
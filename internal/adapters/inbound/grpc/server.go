@@ -0,0 +1,128 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/inbound/grpc/pb"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/inbound"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// BotServer implements the gRPC BotService over the same services as the
+// HTTP adapters. The caller is put in the context by authInterceptor.
+type BotServer struct {
+	pb.UnimplementedBotServiceServer
+	bot     inbound.BotService
+	finance inbound.FinanceService
+}
+
+// NewBotServer constructs the gRPC BotService implementation.
+func NewBotServer(bot inbound.BotService, finance inbound.FinanceService) *BotServer {
+	return &BotServer{bot: bot, finance: finance}
+}
+
+// NewServer creates a gRPC server serving the BotService, which logs
+// every call and requires an API key.
+func NewServer(bot inbound.BotService, finance inbound.FinanceService, findUser UserFinder) *grpc.Server {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		loggingInterceptor,
+		authInterceptor(findUser),
+	))
+	pb.RegisterBotServiceServer(server, NewBotServer(bot, finance))
+	return server
+}
+
+func (s *BotServer) HandleText(ctx context.Context, req *pb.HandleTextRequest) (*pb.HandleTextResponse, error) {
+	res, err := s.bot.HandleTextMessage(ctx, caller(ctx), req.Message)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.HandleTextResponse{ReplyMessage: res.ReplyMessage}, nil
+}
+
+func (s *BotServer) Withdraw(ctx context.Context, req *pb.TransactionRequest) (*pb.TransactionResponse, error) {
+	res, err := s.finance.Withdraw(ctx, caller(ctx), transactionRequestFromProto(req))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.TransactionResponse{AccountName: res.Account, Balance: res.Balance}, nil
+}
+
+func (s *BotServer) Deposit(ctx context.Context, req *pb.TransactionRequest) (*pb.TransactionResponse, error) {
+	res, err := s.finance.Deposit(ctx, caller(ctx), transactionRequestFromProto(req))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.TransactionResponse{AccountName: res.Account, Balance: res.Balance}, nil
+}
+
+func (s *BotServer) Transfer(ctx context.Context, req *pb.TransferRequest) (*pb.TransferResponse, error) {
+	res, err := s.finance.Transfer(ctx, caller(ctx), &domain.TransferRequest{
+		FromAccount: req.FromAccountName,
+		ToAccount:   req.ToAccountName,
+		Amount:      req.Amount,
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.TransferResponse{FromAccountName: res.FromAccount, Balance: res.Balance}, nil
+}
+
+func (s *BotServer) GetBalance(ctx context.Context, _ *emptypb.Empty) (*pb.GetBalanceResponse, error) {
+	res, err := s.finance.GetBalance(ctx, caller(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	accounts := make([]*pb.AccountBalance, len(res.Accounts))
+	for i, v := range res.Accounts {
+		accounts[i] = &pb.AccountBalance{AccountName: v.Account, Balance: v.Balance}
+	}
+	return &pb.GetBalanceResponse{Accounts: accounts}, nil
+}
+
+func (s *BotServer) GetOverviewStatement(ctx context.Context, req *pb.OverviewStatementRequest) (*pb.OverviewStatementResponse, error) {
+	statementReq := &domain.GetOverviewStatementRequest{}
+	if req.From != nil {
+		statementReq.From = req.From.AsTime()
+	}
+	if req.To != nil {
+		statementReq.To = req.To.AsTime()
+	}
+	res, err := s.finance.GetOverviewStatement(ctx, caller(ctx), statementReq)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.OverviewStatementResponse{
+		Revenue: statementSectionToProto(res.Revenue),
+		Expense: statementSectionToProto(res.Expense),
+		Profit:  res.Profit,
+	}, nil
+}
+
+func caller(ctx context.Context) domain.User {
+	user, _ := domain.UserFromContext(ctx)
+	return user
+}
+
+func transactionRequestFromProto(req *pb.TransactionRequest) *domain.TransactionRequest {
+	return &domain.TransactionRequest{
+		Account:     req.AccountName,
+		Amount:      req.Amount,
+		Category:    req.Category,
+		Description: req.Description,
+	}
+}
+
+func statementSectionToProto(section *domain.GetOverviewStatementSection) *pb.OverviewStatementSection {
+	if section == nil {
+		return &pb.OverviewStatementSection{}
+	}
+	entries := make([]*pb.CategorizedEntry, len(section.Entries))
+	for i, v := range section.Entries {
+		entries[i] = &pb.CategorizedEntry{Category: v.Category, Amount: v.Amount}
+	}
+	return &pb.OverviewStatementSection{Total: section.Total, Entries: entries}
+}
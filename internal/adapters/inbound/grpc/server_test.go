@@ -0,0 +1,253 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/inbound/grpc/pb"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const testAPIKey = "api-key"
+
+var grpcCaller = domain.User{ID: "owner"}
+
+func findTestUser(apiKey string) (domain.User, bool) {
+	if apiKey == testAPIKey {
+		return grpcCaller, true
+	}
+	return domain.User{}, false
+}
+
+// newTestClient serves the BotService in memory and returns a client to it.
+func newTestClient(t *testing.T, bot *mocks.MockBotService, finance *mocks.MockFinanceService) pb.BotServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := NewServer(bot, finance, findTestUser)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewBotServiceClient(conn)
+}
+
+func withAPIKey(apiKey string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), apiKeyMetadataKey, apiKey)
+}
+
+func TestNewBotServer(t *testing.T) {
+	bot := mocks.NewMockBotService(t)
+	finance := mocks.NewMockFinanceService(t)
+
+	res := NewBotServer(bot, finance)
+
+	assert.Equal(t, &BotServer{bot: bot, finance: finance}, res)
+}
+
+func TestBotServer(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	testcases := []struct {
+		it       string
+		mock     func(bot *mocks.MockBotService, finance *mocks.MockFinanceService)
+		call     func(ctx context.Context, client pb.BotServiceClient) (proto.Message, error)
+		expected proto.Message
+	}{
+		{
+			it: "talks to the bot on behalf of the caller",
+			mock: func(bot *mocks.MockBotService, _ *mocks.MockFinanceService) {
+				bot.EXPECT().HandleTextMessage(mock.Anything, grpcCaller, "balance").
+					Return(&domain.TextMessageResponse{ReplyMessage: "Your balance"}, nil)
+			},
+			call: func(ctx context.Context, client pb.BotServiceClient) (proto.Message, error) {
+				return client.HandleText(ctx, &pb.HandleTextRequest{Message: "balance"})
+			},
+			expected: &pb.HandleTextResponse{ReplyMessage: "Your balance"},
+		},
+		{
+			it: "withdraws on behalf of the caller",
+			mock: func(_ *mocks.MockBotService, finance *mocks.MockFinanceService) {
+				finance.EXPECT().Withdraw(mock.Anything, grpcCaller, &domain.TransactionRequest{
+					Account:  "debit1",
+					Amount:   500,
+					Category: "sh",
+				}).Return(&domain.TransactionResponse{Account: "debit1", Balance: 1000}, nil)
+			},
+			call: func(ctx context.Context, client pb.BotServiceClient) (proto.Message, error) {
+				return client.Withdraw(ctx, &pb.TransactionRequest{AccountName: "debit1", Amount: 500, Category: "sh"})
+			},
+			expected: &pb.TransactionResponse{AccountName: "debit1", Balance: 1000},
+		},
+		{
+			it: "deposits on behalf of the caller",
+			mock: func(_ *mocks.MockBotService, finance *mocks.MockFinanceService) {
+				finance.EXPECT().Deposit(mock.Anything, grpcCaller, &domain.TransactionRequest{
+					Account:  "debit1",
+					Amount:   30000,
+					Category: "salary",
+				}).Return(&domain.TransactionResponse{Account: "debit1", Balance: 31000}, nil)
+			},
+			call: func(ctx context.Context, client pb.BotServiceClient) (proto.Message, error) {
+				return client.Deposit(ctx, &pb.TransactionRequest{AccountName: "debit1", Amount: 30000, Category: "salary"})
+			},
+			expected: &pb.TransactionResponse{AccountName: "debit1", Balance: 31000},
+		},
+		{
+			it: "transfers on behalf of the caller",
+			mock: func(_ *mocks.MockBotService, finance *mocks.MockFinanceService) {
+				finance.EXPECT().Transfer(mock.Anything, grpcCaller, &domain.TransferRequest{
+					FromAccount: "debit1",
+					ToAccount:   "credit1",
+					Amount:      200,
+				}).Return(&domain.TransferResponse{FromAccount: "debit1", Balance: 800}, nil)
+			},
+			call: func(ctx context.Context, client pb.BotServiceClient) (proto.Message, error) {
+				return client.Transfer(ctx, &pb.TransferRequest{FromAccountName: "debit1", ToAccountName: "credit1", Amount: 200})
+			},
+			expected: &pb.TransferResponse{FromAccountName: "debit1", Balance: 800},
+		},
+		{
+			it: "returns the balances",
+			mock: func(_ *mocks.MockBotService, finance *mocks.MockFinanceService) {
+				finance.EXPECT().GetBalance(mock.Anything, grpcCaller).Return(&domain.GetBalanceResponse{
+					Accounts: []domain.AccountBalance{{Account: "debit1", Balance: 1000}},
+				}, nil)
+			},
+			call: func(ctx context.Context, client pb.BotServiceClient) (proto.Message, error) {
+				return client.GetBalance(ctx, &emptypb.Empty{})
+			},
+			expected: &pb.GetBalanceResponse{Accounts: []*pb.AccountBalance{{AccountName: "debit1", Balance: 1000}}},
+		},
+		{
+			it: "returns the statement of the range",
+			mock: func(_ *mocks.MockBotService, finance *mocks.MockFinanceService) {
+				finance.EXPECT().GetOverviewStatement(mock.Anything, grpcCaller, &domain.GetOverviewStatementRequest{From: from, To: to}).
+					Return(&domain.GetOverviewStatementResponse{
+						Revenue: &domain.GetOverviewStatementSection{Total: 100, Entries: []domain.CategorizedEntry{{Category: "salary", Amount: 100}}},
+						Profit:  100,
+					}, nil)
+			},
+			call: func(ctx context.Context, client pb.BotServiceClient) (proto.Message, error) {
+				return client.GetOverviewStatement(ctx, &pb.OverviewStatementRequest{From: timestamppb.New(from), To: timestamppb.New(to)})
+			},
+			expected: &pb.OverviewStatementResponse{
+				Revenue: &pb.OverviewStatementSection{Total: 100, Entries: []*pb.CategorizedEntry{{Category: "salary", Amount: 100}}},
+				Expense: &pb.OverviewStatementSection{},
+				Profit:  100,
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			bot := mocks.NewMockBotService(t)
+			finance := mocks.NewMockFinanceService(t)
+			tc.mock(bot, finance)
+			client := newTestClient(t, bot, finance)
+
+			res, err := tc.call(withAPIKey(testAPIKey), client)
+
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(tc.expected, res), "expected %v, got %v", tc.expected, res)
+		})
+	}
+}
+
+func TestBotServer_Error(t *testing.T) {
+	testcases := []struct {
+		it              string
+		apiKey          string
+		mock            func(finance *mocks.MockFinanceService)
+		expectedCode    codes.Code
+		expectedMessage string
+	}{
+		{
+			it:              "rejects a call without API key",
+			expectedCode:    codes.Unauthenticated,
+			expectedMessage: "missing or invalid API key",
+		},
+		{
+			it:              "rejects a call with an unknown API key",
+			apiKey:          "guess",
+			expectedCode:    codes.Unauthenticated,
+			expectedMessage: "missing or invalid API key",
+		},
+		{
+			it:     "returns the status matching the error of the service layer",
+			apiKey: testAPIKey,
+			mock: func(finance *mocks.MockFinanceService) {
+				finance.EXPECT().GetBalance(mock.Anything, grpcCaller).Return(nil, errors.ForbiddenError("You are not permitted to run 'balance'"))
+			},
+			expectedCode:    codes.PermissionDenied,
+			expectedMessage: "You are not permitted to run 'balance'",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			finance := mocks.NewMockFinanceService(t)
+			if tc.mock != nil {
+				tc.mock(finance)
+			}
+			client := newTestClient(t, mocks.NewMockBotService(t), finance)
+
+			ctx := context.Background()
+			if tc.apiKey != "" {
+				ctx = withAPIKey(tc.apiKey)
+			}
+			res, err := client.GetBalance(ctx, &emptypb.Empty{})
+
+			assert.Nil(t, res)
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Equal(t, tc.expectedMessage, status.Convert(err).Message())
+		})
+	}
+}
+
+func TestLoggingInterceptor_RequestID(t *testing.T) {
+	bot := mocks.NewMockBotService(t)
+	bot.EXPECT().HandleTextMessage(mock.Anything, grpcCaller, "hello").
+		Return(&domain.TextMessageResponse{ReplyMessage: "world"}, nil).Times(2)
+	client := newTestClient(t, bot, mocks.NewMockFinanceService(t))
+
+	var header metadata.MD
+	ctx := metadata.AppendToOutgoingContext(withAPIKey(testAPIKey), requestIDMetadataKey, "abc-123")
+	_, err := client.HandleText(ctx, &pb.HandleTextRequest{Message: "hello"}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"abc-123"}, header.Get(requestIDMetadataKey))
+
+	ctx = metadata.AppendToOutgoingContext(withAPIKey(testAPIKey), requestIDMetadataKey, "abc 123")
+	_, err = client.HandleText(ctx, &pb.HandleTextRequest{Message: "hello"}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Len(t, header.Get(requestIDMetadataKey), 1)
+	assert.NotEqual(t, "abc 123", header.Get(requestIDMetadataKey)[0])
+}
+
+func TestStatusCode(t *testing.T) {
+	assert.Equal(t, codes.InvalidArgument, statusCode(http.StatusBadRequest))
+	assert.Equal(t, codes.Unavailable, statusCode(http.StatusBadGateway))
+	assert.Equal(t, codes.Internal, statusCode(http.StatusInternalServerError))
+}
@@ -0,0 +1,50 @@
+package http
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/inbound"
+)
+
+// adminAuthMiddleware only lets through requests bearing the admin token
+// in the Authorization header ("Bearer <token>").
+func adminAuthMiddleware(adminToken string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token, found := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+			logger.Ctx(ctx.Request.Context()).Warnw("rejected admin request", "path", ctx.Request.URL.Path)
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// registerAdminRoutes mounts the admin endpoints. They are left out
+// entirely when no admin token is configured.
+func registerAdminRoutes(router *gin.Engine, adminToken string, audit inbound.AuditService) {
+	if adminToken == "" {
+		return
+	}
+	admin := router.Group("/admin", adminAuthMiddleware(adminToken))
+	admin.GET("/log/level", gin.WrapH(logger.LevelHandler()))
+	admin.PUT("/log/level", gin.WrapH(logger.LevelHandler()))
+	admin.GET("/audit", exportAudit(audit))
+}
+
+// exportAudit serves the whole audit log, with whether its hash chain is
+// intact.
+func exportAudit(audit inbound.AuditService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		export, err := audit.Export(ctx.Request.Context())
+		if err != nil {
+			ctx.JSON(err.StatusCode, gin.H{"error": err.Message})
+			return
+		}
+		ctx.JSON(http.StatusOK, export)
+	}
+}
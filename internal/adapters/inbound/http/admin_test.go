@@ -0,0 +1,132 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+	"github.com/sMARCHz/secretaria-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegisterAdminRoutes(t *testing.T) {
+	testcases := []struct {
+		it                 string
+		adminToken         string
+		method             string
+		body               string
+		authorization      string
+		expectedHTTPStatus int
+		expectedBody       string
+		expectedLevel      string
+	}{
+		{
+			it:                 "returns the current level to an authenticated caller",
+			adminToken:         "admin-token",
+			method:             http.MethodGet,
+			authorization:      "Bearer admin-token",
+			expectedHTTPStatus: http.StatusOK,
+			expectedBody:       `{"level":"info"}`,
+			expectedLevel:      "info",
+		},
+		{
+			it:                 "changes the level for an authenticated caller",
+			adminToken:         "admin-token",
+			method:             http.MethodPut,
+			body:               `{"level":"debug"}`,
+			authorization:      "Bearer admin-token",
+			expectedHTTPStatus: http.StatusOK,
+			expectedBody:       `{"level":"debug"}`,
+			expectedLevel:      "debug",
+		},
+		{
+			it:                 "rejects a caller with a wrong token",
+			adminToken:         "admin-token",
+			method:             http.MethodPut,
+			body:               `{"level":"debug"}`,
+			authorization:      "Bearer guess",
+			expectedHTTPStatus: http.StatusUnauthorized,
+			expectedBody:       `{"error":"unauthorized"}`,
+			expectedLevel:      "info",
+		},
+		{
+			it:                 "does not expose the endpoint when no admin token is configured",
+			method:             http.MethodPut,
+			body:               `{"level":"debug"}`,
+			authorization:      "Bearer ",
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedLevel:      "info",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			assert.NoError(t, logger.SetLevel("info"))
+			defer logger.SetLevel("info")
+			router := gin.New()
+			registerAdminRoutes(router, tc.adminToken, nil)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tc.method, "/admin/log/level", strings.NewReader(tc.body))
+			req.Header.Set("Authorization", tc.authorization)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, w.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, w.Body.String())
+			}
+			assert.Equal(t, tc.expectedLevel, logger.GetLevel())
+		})
+	}
+}
+
+func TestExportAudit(t *testing.T) {
+	testcases := []struct {
+		it                 string
+		export             *domain.AuditExport
+		err                *errors.AppError
+		expectedHTTPStatus int
+		expectedBody       string
+	}{
+		{
+			it: "returns the verified log",
+			export: &domain.AuditExport{
+				Entries: []domain.AuditEntry{{Seq: 1, UserID: "owner", Action: domain.AuditWithdraw, Hash: "abc"}},
+				Intact:  true,
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedBody:       `{"entries":[{"seq":1,"time":"0001-01-01T00:00:00Z","user_id":"owner","action":"withdraw","prev_hash":"","hash":"abc"}],"intact":true}`,
+		},
+		{
+			it:                 "returns the error of the audit service",
+			err:                errors.InternalServerError("cannot read the audit log"),
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedBody:       `{"error":"cannot read the audit log"}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			audit := mocks.NewMockAuditService(t)
+			audit.EXPECT().Export(mock.Anything).Return(tc.export, tc.err).Once()
+			router := gin.New()
+			registerAdminRoutes(router, "admin-token", audit)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/admin/audit", nil)
+			req.Header.Set("Authorization", "Bearer admin-token")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, w.Code)
+			assert.JSONEq(t, tc.expectedBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+)
+
+const (
+	apiKeyHeader = "X-API-Key"
+	userKey      = "api_user"
+)
+
+// UserFinder returns the user an API key acts on behalf of.
+type UserFinder func(apiKey string) (domain.User, bool)
+
+// apiKeyMiddleware only lets through requests bearing a known API key,
+// and stores the key's user for the handlers.
+func apiKeyMiddleware(findUser UserFinder) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		user, ok := findUser(ctx.GetHeader(apiKeyHeader))
+		if !ok {
+			logger.Ctx(ctx.Request.Context()).Warnw("rejected api request", "path", ctx.Request.URL.Path)
+			abortWithError(ctx, errors.UnauthorizedError("missing or invalid API key"))
+			return
+		}
+		ctx.Set(userKey, user)
+		ctx.Next()
+	}
+}
+
+func callerFromContext(ctx *gin.Context) domain.User {
+	user, _ := ctx.MustGet(userKey).(domain.User)
+	return user
+}
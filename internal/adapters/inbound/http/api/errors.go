@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+)
+
+// ErrorResponse is the body of every failed API call.
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+type ErrorBody struct {
+	// Code is the snake-cased status text, e.g. "bad_request" or "forbidden".
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func newErrorResponse(err *errors.AppError) ErrorResponse {
+	return ErrorResponse{
+		Error: ErrorBody{
+			Code:    errorCode(err.StatusCode),
+			Message: err.Message,
+		},
+	}
+}
+
+func errorCode(statusCode int) string {
+	text := http.StatusText(statusCode)
+	if text == "" {
+		text = http.StatusText(http.StatusInternalServerError)
+	}
+	return strings.ReplaceAll(strings.ToLower(text), " ", "_")
+}
+
+func abortWithError(ctx *gin.Context, err *errors.AppError) {
+	ctx.AbortWithStatusJSON(err.StatusCode, newErrorResponse(err))
+}
@@ -0,0 +1,204 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/inbound"
+)
+
+const dateLayout = "2006-01-02"
+
+const (
+	transactionTypeWithdraw = "withdraw"
+	transactionTypeDeposit  = "deposit"
+)
+
+// Handler serves the versioned REST API (/api/v1) over the finance service.
+// The API is documented in openapi.yaml.
+type Handler struct {
+	service  inbound.FinanceService
+	imports  inbound.ImportService
+	location *time.Location
+}
+
+// NewHandler constructs the REST API handler. Query dates are days in
+// location.
+func NewHandler(service inbound.FinanceService, imports inbound.ImportService, location *time.Location) *Handler {
+	return &Handler{service: service, imports: imports, location: location}
+}
+
+// RegisterRoutes mounts /api/v1. Every endpoint but the OpenAPI document
+// requires an API key.
+func RegisterRoutes(router gin.IRouter, service inbound.FinanceService, imports inbound.ImportService, findUser UserFinder, location *time.Location) {
+	handler := NewHandler(service, imports, location)
+
+	v1 := router.Group("/api/v1")
+	v1.GET("/openapi.yaml", serveOpenAPIDocument)
+
+	authorized := v1.Group("", apiKeyMiddleware(findUser))
+	authorized.POST("/transactions", handler.createTransaction)
+	authorized.POST("/transfers", handler.createTransfer)
+	authorized.GET("/balances", handler.getBalances)
+	authorized.GET("/statements", handler.getStatement)
+	authorized.GET("/journal", handler.getJournal)
+	authorized.POST("/imports", handler.previewImport)
+}
+
+type transactionRequest struct {
+	Type        string  `json:"type"`
+	Account     string  `json:"account"`
+	Amount      float64 `json:"amount"`
+	Category    string  `json:"category"`
+	Description string  `json:"description"`
+}
+
+func (t transactionRequest) toDomain() *domain.TransactionRequest {
+	return &domain.TransactionRequest{
+		Account:     t.Account,
+		Amount:      t.Amount,
+		Category:    t.Category,
+		Description: t.Description,
+	}
+}
+
+type transferRequest struct {
+	FromAccount string  `json:"from_account"`
+	ToAccount   string  `json:"to_account"`
+	Amount      float64 `json:"amount"`
+	Description string  `json:"description"`
+}
+
+func (t transferRequest) toDomain() *domain.TransferRequest {
+	return &domain.TransferRequest{
+		FromAccount: t.FromAccount,
+		ToAccount:   t.ToAccount,
+		Amount:      t.Amount,
+		Description: t.Description,
+	}
+}
+
+func (h *Handler) createTransaction(ctx *gin.Context) {
+	var req transactionRequest
+	if err := bindJSON(ctx, &req); err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	var res *domain.TransactionResponse
+	var err *errors.AppError
+	switch req.Type {
+	case transactionTypeWithdraw:
+		res, err = h.service.Withdraw(ctx.Request.Context(), callerFromContext(ctx), req.toDomain())
+	case transactionTypeDeposit:
+		res, err = h.service.Deposit(ctx.Request.Context(), callerFromContext(ctx), req.toDomain())
+	default:
+		err = errors.BadRequestError(fmt.Sprintf("type must be '%s' or '%s'", transactionTypeWithdraw, transactionTypeDeposit))
+	}
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (h *Handler) createTransfer(ctx *gin.Context) {
+	var req transferRequest
+	if err := bindJSON(ctx, &req); err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	res, err := h.service.Transfer(ctx.Request.Context(), callerFromContext(ctx), req.toDomain())
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (h *Handler) getBalances(ctx *gin.Context) {
+	res, err := h.service.GetBalance(ctx.Request.Context(), callerFromContext(ctx))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) getStatement(ctx *gin.Context) {
+	req, err := h.parseRangeQuery(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	res, err := h.service.GetOverviewStatement(ctx.Request.Context(), callerFromContext(ctx), req)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// getJournal serves the transactions and transfers of the range as a
+// ledger-cli journal, to be read by ledger or hledger.
+func (h *Handler) getJournal(ctx *gin.Context) {
+	req, err := h.parseRangeQuery(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	res, err := h.service.GetJournal(ctx.Request.Context(), callerFromContext(ctx), req)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.Data(http.StatusOK, "text/plain; charset=utf-8", res)
+}
+
+// bindJSON decodes the request body, rejecting unknown fields so that
+// typos don't silently drop values.
+func bindJSON(ctx *gin.Context, v interface{}) *errors.AppError {
+	decoder := json.NewDecoder(ctx.Request.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return errors.BadRequestError(fmt.Sprintf("invalid request body: %v", err))
+	}
+	return nil
+}
+
+// parseRangeQuery returns the whole local days from the from query to the
+// to query, both included, in UTC.
+func (h *Handler) parseRangeQuery(ctx *gin.Context) (*domain.GetOverviewStatementRequest, *errors.AppError) {
+	from, err := h.parseDateQuery(ctx, "from")
+	if err != nil {
+		return nil, err
+	}
+	to, err := h.parseDateQuery(ctx, "to")
+	if err != nil {
+		return nil, err
+	}
+	if to.Before(from) {
+		return nil, errors.BadRequestError("to must not be before from")
+	}
+	return &domain.GetOverviewStatementRequest{From: from.UTC(), To: to.AddDate(0, 0, 1).UTC()}, nil
+}
+
+func (h *Handler) parseDateQuery(ctx *gin.Context, name string) (time.Time, *errors.AppError) {
+	value := ctx.Query(name)
+	if value == "" {
+		return time.Time{}, errors.BadRequestError(fmt.Sprintf("%s is required", name))
+	}
+	date, err := time.ParseInLocation(dateLayout, value, h.location)
+	if err != nil {
+		return time.Time{}, errors.BadRequestError(fmt.Sprintf("%s must be a date (YYYY-MM-DD), got '%s'", name, value))
+	}
+	return date, nil
+}
@@ -0,0 +1,404 @@
+package api
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const testAPIKey = "api-key"
+
+var apiCaller = domain.User{ID: "owner"}
+
+func findTestUser(apiKey string) (domain.User, bool) {
+	if apiKey == testAPIKey {
+		return apiCaller, true
+	}
+	return domain.User{}, false
+}
+
+func newTestRouter(service *mocks.MockFinanceService, imports *mocks.MockImportService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	RegisterRoutes(router, service, imports, findTestUser, time.UTC)
+	return router
+}
+
+func TestNewHandler(t *testing.T) {
+	service := mocks.NewMockFinanceService(t)
+	imports := mocks.NewMockImportService(t)
+
+	res := NewHandler(service, imports, time.UTC)
+
+	assert.Equal(t, &Handler{service: service, imports: imports, location: time.UTC}, res)
+}
+
+func TestRoutes(t *testing.T) {
+	testcases := []struct {
+		it                 string
+		method             string
+		path               string
+		body               string
+		apiKey             string
+		mock               func(service *mocks.MockFinanceService)
+		expectedHTTPStatus int
+		expectedBody       string
+	}{
+		{
+			it:     "records a withdrawal",
+			method: http.MethodPost,
+			path:   "/api/v1/transactions",
+			body:   `{"type":"withdraw","account":"debit1","amount":500,"category":"sh","description":"youtube membership"}`,
+			apiKey: testAPIKey,
+			mock: func(service *mocks.MockFinanceService) {
+				service.EXPECT().Withdraw(mock.Anything, apiCaller, &domain.TransactionRequest{
+					Account:     "debit1",
+					Amount:      500,
+					Category:    "sh",
+					Description: "youtube membership",
+				}).Return(&domain.TransactionResponse{Account: "debit1", Balance: 1000}, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedBody:       `{"account":"debit1","balance":1000}`,
+		},
+		{
+			it:     "records a deposit",
+			method: http.MethodPost,
+			path:   "/api/v1/transactions",
+			body:   `{"type":"deposit","account":"debit1","amount":30000,"category":"salary"}`,
+			apiKey: testAPIKey,
+			mock: func(service *mocks.MockFinanceService) {
+				service.EXPECT().Deposit(mock.Anything, apiCaller, &domain.TransactionRequest{
+					Account:  "debit1",
+					Amount:   30000,
+					Category: "salary",
+				}).Return(&domain.TransactionResponse{Account: "debit1", Balance: 31000}, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedBody:       `{"account":"debit1","balance":31000}`,
+		},
+		{
+			it:                 "rejects a transaction of an unknown type",
+			method:             http.MethodPost,
+			path:               "/api/v1/transactions",
+			body:               `{"type":"refund","account":"debit1","amount":1,"category":"sh"}`,
+			apiKey:             testAPIKey,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedBody:       `{"error":{"code":"bad_request","message":"type must be 'withdraw' or 'deposit'"}}`,
+		},
+		{
+			it:                 "rejects a body with unknown fields",
+			method:             http.MethodPost,
+			path:               "/api/v1/transactions",
+			body:               `{"type":"withdraw","acount":"debit1"}`,
+			apiKey:             testAPIKey,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedBody:       `{"error":{"code":"bad_request","message":"invalid request body: json: unknown field \"acount\""}}`,
+		},
+		{
+			it:     "records a transfer",
+			method: http.MethodPost,
+			path:   "/api/v1/transfers",
+			body:   `{"from_account":"debit1","to_account":"credit1","amount":200}`,
+			apiKey: testAPIKey,
+			mock: func(service *mocks.MockFinanceService) {
+				service.EXPECT().Transfer(mock.Anything, apiCaller, &domain.TransferRequest{
+					FromAccount: "debit1",
+					ToAccount:   "credit1",
+					Amount:      200,
+				}).Return(&domain.TransferResponse{FromAccount: "debit1", Balance: 800}, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedBody:       `{"from_account":"debit1","balance":800}`,
+		},
+		{
+			it:     "returns the error of the service layer",
+			method: http.MethodPost,
+			path:   "/api/v1/transfers",
+			body:   `{"from_account":"debit1","to_account":"credit1","amount":200}`,
+			apiKey: testAPIKey,
+			mock: func(service *mocks.MockFinanceService) {
+				service.EXPECT().Transfer(mock.Anything, apiCaller, mock.Anything).
+					Return(nil, errors.ForbiddenError("You are not permitted to run '!t'"))
+			},
+			expectedHTTPStatus: http.StatusForbidden,
+			expectedBody:       `{"error":{"code":"forbidden","message":"You are not permitted to run '!t'"}}`,
+		},
+		{
+			it:     "returns the balances",
+			method: http.MethodGet,
+			path:   "/api/v1/balances",
+			apiKey: testAPIKey,
+			mock: func(service *mocks.MockFinanceService) {
+				service.EXPECT().GetBalance(mock.Anything, apiCaller).Return(&domain.GetBalanceResponse{
+					Accounts: []domain.AccountBalance{{Account: "debit1", Balance: 1000}},
+				}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedBody:       `{"accounts":[{"account":"debit1","balance":1000}]}`,
+		},
+		{
+			it:     "returns the statement of the range",
+			method: http.MethodGet,
+			path:   "/api/v1/statements?from=2025-01-01&to=2025-01-31",
+			apiKey: testAPIKey,
+			mock: func(service *mocks.MockFinanceService) {
+				service.EXPECT().GetOverviewStatement(mock.Anything, apiCaller, &domain.GetOverviewStatementRequest{
+					From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					To:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+				}).Return(&domain.GetOverviewStatementResponse{
+					Revenue: &domain.GetOverviewStatementSection{Total: 100, Entries: []domain.CategorizedEntry{{Category: "salary", Amount: 100}}},
+					Expense: &domain.GetOverviewStatementSection{},
+					Profit:  100,
+				}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedBody:       `{"revenue":{"total":100,"entries":[{"category":"salary","amount":100}]},"expense":{"total":0,"entries":null},"profit":100}`,
+		},
+		{
+			it:     "returns the journal of the range",
+			method: http.MethodGet,
+			path:   "/api/v1/journal?from=2025-01-01&to=2025-01-31",
+			apiKey: testAPIKey,
+			mock: func(service *mocks.MockFinanceService) {
+				service.EXPECT().GetJournal(mock.Anything, apiCaller, &domain.GetOverviewStatementRequest{
+					From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					To:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+				}).Return([]byte("2025-01-05 rent\n    expenses:rent  100.00\n    assets:debit1  -100.00\n"), nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedBody:       "2025-01-05 rent\n    expenses:rent  100.00\n    assets:debit1  -100.00\n",
+		},
+		{
+			it:                 "rejects a journal without range",
+			method:             http.MethodGet,
+			path:               "/api/v1/journal?from=2025-01-01",
+			apiKey:             testAPIKey,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedBody:       `{"error":{"code":"bad_request","message":"to is required"}}`,
+		},
+		{
+			it:                 "rejects a statement without range",
+			method:             http.MethodGet,
+			path:               "/api/v1/statements?to=2025-01-31",
+			apiKey:             testAPIKey,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedBody:       `{"error":{"code":"bad_request","message":"from is required"}}`,
+		},
+		{
+			it:                 "rejects a statement with a malformed date",
+			method:             http.MethodGet,
+			path:               "/api/v1/statements?from=2025-01-01&to=31/01/2025",
+			apiKey:             testAPIKey,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedBody:       `{"error":{"code":"bad_request","message":"to must be a date (YYYY-MM-DD), got '31/01/2025'"}}`,
+		},
+		{
+			it:                 "rejects a statement whose range ends before it starts",
+			method:             http.MethodGet,
+			path:               "/api/v1/statements?from=2025-02-01&to=2025-01-31",
+			apiKey:             testAPIKey,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedBody:       `{"error":{"code":"bad_request","message":"to must not be before from"}}`,
+		},
+		{
+			it:                 "rejects a caller without API key",
+			method:             http.MethodGet,
+			path:               "/api/v1/balances",
+			expectedHTTPStatus: http.StatusUnauthorized,
+			expectedBody:       `{"error":{"code":"unauthorized","message":"missing or invalid API key"}}`,
+		},
+		{
+			it:                 "rejects a caller with an unknown API key",
+			method:             http.MethodPost,
+			path:               "/api/v1/transfers",
+			body:               `{"from_account":"debit1","to_account":"credit1","amount":200}`,
+			apiKey:             "guess",
+			expectedHTTPStatus: http.StatusUnauthorized,
+			expectedBody:       `{"error":{"code":"unauthorized","message":"missing or invalid API key"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			service := mocks.NewMockFinanceService(t)
+			if tc.mock != nil {
+				tc.mock(service)
+			}
+			router := newTestRouter(service, mocks.NewMockImportService(t))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			if tc.apiKey != "" {
+				req.Header.Set(apiKeyHeader, tc.apiKey)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, w.Code)
+			if strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+				assert.JSONEq(t, tc.expectedBody, w.Body.String())
+			} else {
+				assert.Equal(t, tc.expectedBody, w.Body.String())
+			}
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	router := newTestRouter(mocks.NewMockFinanceService(t), mocks.NewMockImportService(t))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.yaml", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var document struct {
+		Paths map[string]map[string]interface{} `yaml:"paths"`
+	}
+	require.NoError(t, yaml.Unmarshal(w.Body.Bytes(), &document))
+	for _, route := range router.Routes() {
+		path := strings.TrimPrefix(route.Path, "/api/v1")
+		assert.Contains(t, document.Paths, path, "%s %s is documented", route.Method, route.Path)
+		assert.Contains(t, document.Paths[path], strings.ToLower(route.Method), "%s %s is documented", route.Method, route.Path)
+	}
+}
+
+func TestPreviewImport(t *testing.T) {
+	preview := &domain.ImportPreview{Layout: "kbank", Summary: "Import preview"}
+	testcases := []struct {
+		it                 string
+		request            func() *http.Request
+		mock               func(imports *mocks.MockImportService)
+		expectedHTTPStatus int
+		expectedBody       string
+	}{
+		{
+			it: "previews a file sent as the raw body",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/imports", strings.NewReader("Date,Amount\n"))
+				req.Header.Set("Content-Type", "text/csv")
+				return req
+			},
+			mock: func(imports *mocks.MockImportService) {
+				imports.EXPECT().Preview(mock.Anything, apiCaller, &domain.File{
+					Name:        "import.csv",
+					ContentType: "text/csv",
+					Content:     []byte("Date,Amount\n"),
+				}).Return(preview, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedBody:       `{"layout":"kbank","rows":null,"summary":"Import preview"}`,
+		},
+		{
+			it: "previews a file sent as a multipart form",
+			request: func() *http.Request {
+				var body bytes.Buffer
+				writer := multipart.NewWriter(&body)
+				part, _ := writer.CreateFormFile("file", "kbank.csv")
+				part.Write([]byte("Date,Amount\n"))
+				writer.Close()
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/imports", &body)
+				req.Header.Set("Content-Type", writer.FormDataContentType())
+				return req
+			},
+			mock: func(imports *mocks.MockImportService) {
+				imports.EXPECT().Preview(mock.Anything, apiCaller, mock.MatchedBy(func(file *domain.File) bool {
+					return file.Name == "kbank.csv" && string(file.Content) == "Date,Amount\n"
+				})).Return(preview, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedBody:       `{"layout":"kbank","rows":null,"summary":"Import preview"}`,
+		},
+		{
+			it: "rejects a multipart form without file",
+			request: func() *http.Request {
+				var body bytes.Buffer
+				writer := multipart.NewWriter(&body)
+				writer.WriteField("name", "kbank.csv")
+				writer.Close()
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/imports", &body)
+				req.Header.Set("Content-Type", writer.FormDataContentType())
+				return req
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedBody:       `{"error":{"code":"bad_request","message":"The file is missing, please send it as the 'file' field"}}`,
+		},
+		{
+			it: "rejects a file larger than 1MB",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/api/v1/imports", strings.NewReader(strings.Repeat("a", maxImportSize+1)))
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedBody:       `{"error":{"code":"bad_request","message":"The file is larger than 1048576 bytes"}}`,
+		},
+		{
+			it: "returns the error of the service layer",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/api/v1/imports", strings.NewReader("hello"))
+			},
+			mock: func(imports *mocks.MockImportService) {
+				imports.EXPECT().Preview(mock.Anything, apiCaller, mock.Anything).
+					Return(nil, errors.BadRequestError("The columns of the file don't match any import layout"))
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedBody:       `{"error":{"code":"bad_request","message":"The columns of the file don't match any import layout"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			imports := mocks.NewMockImportService(t)
+			if tc.mock != nil {
+				tc.mock(imports)
+			}
+			router := newTestRouter(mocks.NewMockFinanceService(t), imports)
+
+			w := httptest.NewRecorder()
+			req := tc.request()
+			req.Header.Set(apiKeyHeader, testAPIKey)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, w.Code)
+			assert.JSONEq(t, tc.expectedBody, w.Body.String())
+			imports.AssertExpectations(t)
+		})
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	assert.Equal(t, "bad_request", errorCode(http.StatusBadRequest))
+	assert.Equal(t, "bad_gateway", errorCode(http.StatusBadGateway))
+	assert.Equal(t, "internal_server_error", errorCode(0))
+}
+
+func TestParseRangeQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bangkok, err := time.LoadLocation("Asia/Bangkok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/statements?from=2024-12-01&to=2024-12-31", nil)
+	handler := NewHandler(mocks.NewMockFinanceService(t), mocks.NewMockImportService(t), bangkok)
+
+	res, appErr := handler.parseRangeQuery(ctx)
+
+	assert.Nil(t, appErr)
+	assert.Equal(t, &domain.GetOverviewStatementRequest{
+		From: time.Date(2024, 11, 30, 17, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, 12, 31, 17, 0, 0, 0, time.UTC),
+	}, res)
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+)
+
+// maxImportSize bounds the size of an uploaded bank CSV file.
+const maxImportSize = 1 << 20
+
+// previewImport reads a bank CSV file, sent either as the raw body or as
+// the "file" field of a multipart form, and returns its import preview.
+// The user confirms the import in chat.
+func (h *Handler) previewImport(ctx *gin.Context) {
+	file, err := readImportFile(ctx)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	res, err := h.imports.Preview(ctx.Request.Context(), callerFromContext(ctx), file)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func readImportFile(ctx *gin.Context) (*domain.File, *errors.AppError) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportSize)
+	tooLargeErr := errors.BadRequestError(fmt.Sprintf("The file is larger than %d bytes", maxImportSize))
+
+	mediaType, _, _ := mime.ParseMediaType(ctx.GetHeader("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		content, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			return nil, tooLargeErr
+		}
+		return &domain.File{Name: "import.csv", ContentType: mediaType, Content: content}, nil
+	}
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		return nil, errors.BadRequestError("The file is missing, please send it as the 'file' field")
+	}
+	f, err := header.Open()
+	if err != nil {
+		return nil, errors.BadRequestError(fmt.Sprintf("cannot read the file: %v", err))
+	}
+	defer f.Close()
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return nil, tooLargeErr
+	}
+	return &domain.File{Name: header.Filename, ContentType: header.Header.Get("Content-Type"), Content: content}, nil
+}
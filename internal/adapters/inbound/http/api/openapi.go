@@ -0,0 +1,15 @@
+package api
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed openapi.yaml
+var openAPIDocument []byte
+
+func serveOpenAPIDocument(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "application/yaml", openAPIDocument)
+}
@@ -0,0 +1,185 @@
+package download
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+)
+
+const routePath = "/downloads/:token"
+
+// previewAgents are parts of the user agents of the link previews, which
+// fetch a link sent in chat before the user taps it.
+var previewAgents = []string{
+	"facebookexternalhit",
+	"line-poker",
+	"slackbot-linkexpanding",
+	"twitterbot",
+	"telegrambot",
+	"whatsapp",
+	"discordbot",
+}
+
+type storedFile struct {
+	file      *domain.File
+	expiresAt time.Time
+	// reusable files, the images shown in chat, are served until they
+	// expire instead of once.
+	reusable bool
+}
+
+// Store keeps published files in memory and serves each of them once,
+// through a signed link, until it expires. Images are served as often as
+// they are asked for until they expire, since chat apps fetch them more
+// than once. HEAD requests and link previews don't use the link up.
+type Store struct {
+	baseURL string
+	ttl     time.Duration
+	secret  []byte
+
+	mu    sync.Mutex
+	files map[string]storedFile
+	now   func() time.Time
+}
+
+// NewStore creates a store whose links start with baseURL, the public URL
+// of the bot's HTTP server, and expire after ttl.
+func NewStore(baseURL string, ttl time.Duration) *Store {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		logger.Fatal("cannot generate the download signing secret: ", err)
+	}
+	return &Store{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		ttl:     ttl,
+		secret:  secret,
+		files:   make(map[string]storedFile),
+		now:     time.Now,
+	}
+}
+
+// RegisterRoutes mounts GET and HEAD /downloads/:token.
+func RegisterRoutes(router gin.IRouter, store *Store) {
+	router.GET(routePath, store.serve)
+	router.HEAD(routePath, store.serve)
+}
+
+func (s *Store) Publish(ctx context.Context, file *domain.File) (*domain.PublishedFile, *errors.AppError) {
+	return s.publish(ctx, file, false)
+}
+
+func (s *Store) PublishImage(ctx context.Context, file *domain.File) (*domain.PublishedFile, *errors.AppError) {
+	return s.publish(ctx, file, true)
+}
+
+func (s *Store) publish(ctx context.Context, file *domain.File, reusable bool) (*domain.PublishedFile, *errors.AppError) {
+	if s.baseURL == "" {
+		logger.Ctx(ctx).Warn("cannot publish a file: app.public_url isn't configured")
+		return nil, errors.InternalServerError("Downloads are unavailable, please contact the administrator")
+	}
+	token := newToken()
+	if token == "" {
+		return nil, errors.InternalServerError("cannot generate a download link")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.prune(now)
+	expiresAt := now.Add(s.ttl)
+	s.files[token] = storedFile{file: file, expiresAt: expiresAt, reusable: reusable}
+
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	return &domain.PublishedFile{
+		URL:       fmt.Sprintf("%s/downloads/%s?expires=%s&signature=%s", s.baseURL, token, expires, s.sign(token, expires)),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// serve sends the file and forgets it, so that the link only works once,
+// unless the file is reusable or the request only previews it.
+func (s *Store) serve(ctx *gin.Context) {
+	token := ctx.Param("token")
+	expires := ctx.Query("expires")
+	signature, err := hex.DecodeString(ctx.Query("signature"))
+	if err != nil || !hmac.Equal(signature, s.mac(token, expires)) {
+		logger.Ctx(ctx.Request.Context()).Warn("rejected a download with an invalid signature")
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "invalid signature"})
+		return
+	}
+
+	s.mu.Lock()
+	now := s.now()
+	s.prune(now)
+	stored, exist := s.files[token]
+	if !stored.reusable && !isPreview(ctx.Request) {
+		delete(s.files, token)
+	}
+	s.mu.Unlock()
+	if !exist {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "the link has expired or was already used"})
+		return
+	}
+
+	disposition := "attachment"
+	if stored.reusable {
+		disposition = "inline"
+	}
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": stored.file.Name}))
+	ctx.Data(http.StatusOK, stored.file.ContentType, stored.file.Content)
+}
+
+// isPreview tells whether the request comes from a link preview, or only
+// asks for the headers.
+func isPreview(req *http.Request) bool {
+	if req.Method == http.MethodHead {
+		return true
+	}
+	agent := strings.ToLower(req.UserAgent())
+	return slices.ContainsFunc(previewAgents, func(preview string) bool {
+		return strings.Contains(agent, preview)
+	})
+}
+
+// prune forgets the expired files. The caller must hold mu.
+func (s *Store) prune(now time.Time) {
+	for token, stored := range s.files {
+		if !now.Before(stored.expiresAt) {
+			delete(s.files, token)
+		}
+	}
+}
+
+func (s *Store) mac(token, expires string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(token + "." + expires))
+	return h.Sum(nil)
+}
+
+func (s *Store) sign(token, expires string) string {
+	return hex.EncodeToString(s.mac(token, expires))
+}
+
+func newToken() string {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,168 @@
+package download
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFile = &domain.File{
+	Name:        "statement-monthly.csv",
+	ContentType: "text/csv; charset=utf-8",
+	Content:     []byte("section,category,amount\n"),
+}
+
+func newTestStore(now *time.Time) (*Store, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	store := NewStore("https://bot.example.com/", 10*time.Minute)
+	store.now = func() time.Time { return *now }
+	router := gin.New()
+	RegisterRoutes(router, store)
+	return store, router
+}
+
+func download(router *gin.Engine, link string) *httptest.ResponseRecorder {
+	u, _ := url.Parse(link)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, u.RequestURI(), nil))
+	return w
+}
+
+func TestStore(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	store, router := newTestStore(&now)
+
+	published, err := store.Publish(context.Background(), testFile)
+	require.Nil(t, err)
+	assert.Regexp(t, `^https://bot\.example\.com/downloads/[0-9a-f]{48}\?expires=1735733400&signature=[0-9a-f]{64}$`, published.URL)
+	assert.Equal(t, now.Add(10*time.Minute), published.ExpiresAt)
+
+	w := download(router, published.URL)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "section,category,amount\n", w.Body.String())
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename=statement-monthly.csv`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+
+	w = download(router, published.URL)
+	assert.Equal(t, http.StatusNotFound, w.Code, "the link only works once")
+}
+
+func TestStore_Preview(t *testing.T) {
+	testcases := []struct {
+		it        string
+		method    string
+		userAgent string
+	}{
+		{
+			it:     "keeps the link on a HEAD request",
+			method: http.MethodHead,
+		},
+		{
+			it:        "keeps the link on LINE's preview",
+			method:    http.MethodGet,
+			userAgent: "facebookexternalhit/1.1;line-poker/1.0",
+		},
+		{
+			it:        "keeps the link on Slack's preview",
+			method:    http.MethodGet,
+			userAgent: "Slackbot-LinkExpanding 1.0 (+https://api.slack.com/robots)",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+			store, router := newTestStore(&now)
+			published, err := store.Publish(context.Background(), testFile)
+			require.Nil(t, err)
+
+			u, _ := url.Parse(published.URL)
+			req := httptest.NewRequest(tc.method, u.RequestURI(), nil)
+			req.Header.Set("User-Agent", tc.userAgent)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			w = download(router, published.URL)
+			assert.Equal(t, http.StatusOK, w.Code, "the user can still download it")
+			assert.Equal(t, "section,category,amount\n", w.Body.String())
+			w = download(router, published.URL)
+			assert.Equal(t, http.StatusNotFound, w.Code, "the link only works once")
+		})
+	}
+}
+
+func TestStore_Image(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	store, router := newTestStore(&now)
+	image := &domain.File{Name: "expense.png", ContentType: "image/png", Content: []byte("png")}
+
+	published, err := store.PublishImage(context.Background(), image)
+	require.Nil(t, err)
+
+	for range 2 {
+		w := download(router, published.URL)
+		assert.Equal(t, http.StatusOK, w.Code, "the link works until it expires")
+		assert.Equal(t, "png", w.Body.String())
+		assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+		assert.Equal(t, `inline; filename=expense.png`, w.Header().Get("Content-Disposition"))
+	}
+
+	now = now.Add(10 * time.Minute)
+	w := download(router, published.URL)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestStore_Expired(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	store, router := newTestStore(&now)
+	published, err := store.Publish(context.Background(), testFile)
+	require.Nil(t, err)
+
+	now = now.Add(10 * time.Minute)
+	w := download(router, published.URL)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, store.files, "expired files are forgotten")
+}
+
+func TestStore_InvalidSignature(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	store, router := newTestStore(&now)
+	published, err := store.Publish(context.Background(), testFile)
+	require.Nil(t, err)
+
+	u, _ := url.Parse(published.URL)
+	query := u.Query()
+	query.Set("expires", "1999999999")
+	u.RawQuery = query.Encode()
+	w := download(router, u.String())
+	assert.Equal(t, http.StatusForbidden, w.Code, "the expiry can't be extended")
+
+	query.Del("signature")
+	u.RawQuery = query.Encode()
+	w = download(router, u.String())
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = download(router, published.URL)
+	assert.Equal(t, http.StatusOK, w.Code, "rejected attempts don't use the link")
+}
+
+func TestStore_NoPublicURL(t *testing.T) {
+	store := NewStore("", 10*time.Minute)
+
+	published, err := store.Publish(context.Background(), testFile)
+
+	assert.Nil(t, published)
+	assert.Equal(t, errors.InternalServerError("Downloads are unavailable, please contact the administrator"), err)
+}
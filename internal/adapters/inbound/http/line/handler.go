@@ -1,21 +1,31 @@
 package line
 
 import (
+	"context"
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/line/line-bot-sdk-go/v8/linebot"
 	"github.com/sMARCHz/secretaria-bot/internal/config"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/logger"
 	"github.com/sMARCHz/secretaria-bot/internal/ports/inbound"
 )
 
+// maxFileSize bounds the size of the files sent in chat.
+const maxFileSize = 1 << 20
+
+// maxReplyMessages is the number of messages LINE accepts in a reply.
+const maxReplyMessages = 5
+
 type LineHandler struct {
 	service inbound.BotService
+	imports inbound.ImportService
 	client  *linebot.Client
 }
 
-func NewLineHandler(service inbound.BotService) *LineHandler {
+func NewLineHandler(service inbound.BotService, imports inbound.ImportService) *LineHandler {
 	lineCfg := config.Get().Line
 	client, err := linebot.New(lineCfg.ChannelSecret, lineCfg.ChannelToken)
 	if err != nil {
@@ -23,6 +33,7 @@ func NewLineHandler(service inbound.BotService) *LineHandler {
 	}
 	return &LineHandler{
 		service: service,
+		imports: imports,
 		client:  client,
 	}
 }
@@ -34,18 +45,21 @@ func (b *LineHandler) HandleLineMessage(ctx *gin.Context) {
 		if err == linebot.ErrInvalidSignature {
 			code = http.StatusBadRequest
 		}
-		logger.Error("cannot parse line request: ", err)
+		logger.Ctx(ctx.Request.Context()).Error("cannot parse line request: ", err)
 		ctx.AbortWithError(code, err)
 		return
 	}
 
-	b.processEvents(events)
+	b.processEvents(ctx.Request.Context(), events)
 }
 
-func (b *LineHandler) processEvents(events []*linebot.Event) {
+func (b *LineHandler) processEvents(ctx context.Context, events []*linebot.Event) {
 	for _, event := range events {
-		if !isMyLineAccount(event) {
-			b.replyMessage(event, "Unauthorized action!")
+		eventCtx := logger.WithFields(ctx, "webhook_event_id", event.WebhookEventID)
+		user, allowed := findUser(event)
+		if !allowed {
+			logger.Ctx(eventCtx).Warn("received an event from a line account outside the allow-list")
+			b.replyMessage(eventCtx, event, "Unauthorized action!")
 			continue
 		}
 		if event.Type != linebot.EventTypeMessage {
@@ -54,24 +68,85 @@ func (b *LineHandler) processEvents(events []*linebot.Event) {
 
 		switch message := event.Message.(type) {
 		case *linebot.TextMessage:
-			res, err := b.service.HandleTextMessage(message.Text)
+			replyCtx, _ := domain.ContextWithReplyImages(eventCtx)
+			replyCtx, _ = domain.ContextWithQuickReplies(replyCtx)
+			res, err := b.service.HandleTextMessage(replyCtx, user, message.Text)
 			if err != nil {
-				b.replyMessage(event, err.Message)
+				b.replyMessage(eventCtx, event, err.Message)
 			} else {
-				b.replyMessage(event, res.ReplyMessage)
+				b.reply(eventCtx, event, replyMessages(res)...)
 			}
+		case *linebot.FileMessage:
+			b.replyMessage(eventCtx, event, b.previewImport(eventCtx, user, message))
 		default:
-			b.replyMessage(event, "Unknown message type")
+			b.replyMessage(eventCtx, event, "Unknown message type")
 		}
 	}
 }
 
-func (b *LineHandler) replyMessage(event *linebot.Event, replyMsg string) {
-	if _, err := b.client.ReplyMessage(event.ReplyToken, linebot.NewTextMessage(replyMsg)).Do(); err != nil {
-		logger.Error("cannot reply message: ", err)
+// previewImport treats a file sent in chat as a bank CSV file to import.
+func (b *LineHandler) previewImport(ctx context.Context, user domain.User, message *linebot.FileMessage) string {
+	if message.FileSize > maxFileSize {
+		return "The file is too large to import"
+	}
+	content, err := b.client.GetMessageContent(message.ID).WithContext(ctx).Do()
+	if err != nil {
+		logger.Ctx(ctx).Error("cannot get message content: ", err)
+		return "Cannot read the file, please send it again"
+	}
+	defer content.Content.Close()
+	data, err := io.ReadAll(io.LimitReader(content.Content, maxFileSize))
+	if err != nil {
+		logger.Ctx(ctx).Error("cannot read message content: ", err)
+		return "Cannot read the file, please send it again"
+	}
+
+	res, appErr := b.imports.Preview(ctx, user, &domain.File{
+		Name:        message.FileName,
+		ContentType: content.ContentType,
+		Content:     data,
+	})
+	if appErr != nil {
+		return appErr.Message
+	}
+	return res.Summary
+}
+
+func (b *LineHandler) replyMessage(ctx context.Context, event *linebot.Event, replyMsg string) {
+	b.reply(ctx, event, linebot.NewTextMessage(replyMsg))
+}
+
+func (b *LineHandler) reply(ctx context.Context, event *linebot.Event, messages ...linebot.SendingMessage) {
+	if _, err := b.client.ReplyMessage(event.ReplyToken, messages...).WithContext(ctx).Do(); err != nil {
+		logger.Ctx(ctx).Error("cannot reply message: ", err)
+	}
+}
+
+// replyMessages sends the reply text followed by its images, as many as
+// fit in a reply. The quick replies go on the last message, the only one
+// LINE shows them for.
+func replyMessages(res *domain.TextMessageResponse) []linebot.SendingMessage {
+	messages := []linebot.SendingMessage{linebot.NewTextMessage(res.ReplyMessage)}
+	for _, image := range res.Images {
+		if len(messages) == maxReplyMessages {
+			break
+		}
+		messages = append(messages, linebot.NewImageMessage(image.URL, image.PreviewURL))
 	}
+	if len(res.QuickReplies) > 0 {
+		buttons := make([]*linebot.QuickReplyButton, len(res.QuickReplies))
+		for i, answer := range res.QuickReplies {
+			buttons[i] = linebot.NewQuickReplyButton("", linebot.NewMessageAction(answer, answer))
+		}
+		last := len(messages) - 1
+		messages[last] = messages[last].WithQuickReplies(linebot.NewQuickReplyItems(buttons...))
+	}
+	return messages
 }
 
-func isMyLineAccount(event *linebot.Event) bool {
-	return event.Source.UserID == config.Get().Line.UserID
+func findUser(event *linebot.Event) (domain.User, bool) {
+	if event.Source == nil {
+		return domain.User{}, false
+	}
+	return config.Get().FindUserByLineID(event.Source.UserID)
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/line/line-bot-sdk-go/v8/linebot"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/logger"
 	"github.com/sMARCHz/secretaria-bot/test/mocks"
 	"github.com/sMARCHz/secretaria-bot/test/sandbox"
@@ -14,11 +15,13 @@ import (
 func TestNewLineHandler(t *testing.T) {
 	sandbox.Run(t)
 	bot := mocks.NewMockBotService(t)
+	imports := mocks.NewMockImportService(t)
 
-	handler := NewLineHandler(bot)
+	handler := NewLineHandler(bot, imports)
 
 	assert.IsType(t, &LineHandler{}, handler)
 	assert.Equal(t, bot, handler.service)
+	assert.Equal(t, imports, handler.imports)
 	assert.IsType(t, &linebot.Client{}, handler.client)
 	assert.NotEmpty(t, handler.client)
 }
@@ -28,12 +31,41 @@ func TestNewLineHandler_Error(t *testing.T) {
 	logger.SetLogger(testLogger)
 	bot := mocks.NewMockBotService(t)
 
-	NewLineHandler(bot)
+	NewLineHandler(bot, mocks.NewMockImportService(t))
 
 	assert.True(t, testLogger.called)
 	assert.Equal(t, "cannot create linebot client: missing channel secret", testLogger.msg)
 }
 
+func TestReplyMessages(t *testing.T) {
+	images := make([]domain.Image, 5)
+	for i := range images {
+		url := fmt.Sprintf("https://bot.example.com/downloads/%d", i)
+		images[i] = domain.Image{URL: url, PreviewURL: url}
+	}
+
+	res := replyMessages(&domain.TextMessageResponse{ReplyMessage: "Monthly Statement", Images: images})
+
+	assert.Equal(t, []linebot.SendingMessage{
+		linebot.NewTextMessage("Monthly Statement"),
+		linebot.NewImageMessage("https://bot.example.com/downloads/0", "https://bot.example.com/downloads/0"),
+		linebot.NewImageMessage("https://bot.example.com/downloads/1", "https://bot.example.com/downloads/1"),
+		linebot.NewImageMessage("https://bot.example.com/downloads/2", "https://bot.example.com/downloads/2"),
+		linebot.NewImageMessage("https://bot.example.com/downloads/3", "https://bot.example.com/downloads/3"),
+	}, res)
+}
+
+func TestReplyMessages_QuickReplies(t *testing.T) {
+	res := replyMessages(&domain.TextMessageResponse{ReplyMessage: "Confirm ฿20,000 to sh? yes/no", QuickReplies: []string{"yes", "no"}})
+
+	assert.Equal(t, []linebot.SendingMessage{
+		linebot.NewTextMessage("Confirm ฿20,000 to sh? yes/no").WithQuickReplies(linebot.NewQuickReplyItems(
+			linebot.NewQuickReplyButton("", linebot.NewMessageAction("yes", "yes")),
+			linebot.NewQuickReplyButton("", linebot.NewMessageAction("no", "no")),
+		)),
+	}, res)
+}
+
 type testLogger struct {
 	called bool
 	msg    string
@@ -51,8 +83,12 @@ func (t *testLogger) Fatal(args ...interface{}) {
 	t.msg = msg
 	t.called = true
 }
-func (t *testLogger) Debugf(format string, args ...interface{}) {}
-func (t *testLogger) Infof(format string, args ...interface{})  {}
-func (t *testLogger) Warnf(format string, args ...interface{})  {}
-func (t *testLogger) Errorf(format string, args ...interface{}) {}
-func (t *testLogger) Fatalf(format string, args ...interface{}) {}
+func (t *testLogger) Debugf(format string, args ...interface{})       {}
+func (t *testLogger) Infof(format string, args ...interface{})        {}
+func (t *testLogger) Warnf(format string, args ...interface{})        {}
+func (t *testLogger) Errorf(format string, args ...interface{})       {}
+func (t *testLogger) Fatalf(format string, args ...interface{})       {}
+func (t *testLogger) Debugw(msg string, keysAndValues ...interface{}) {}
+func (t *testLogger) Infow(msg string, keysAndValues ...interface{})  {}
+func (t *testLogger) Warnw(msg string, keysAndValues ...interface{})  {}
+func (t *testLogger) Errorw(msg string, keysAndValues ...interface{}) {}
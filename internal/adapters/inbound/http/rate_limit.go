@@ -0,0 +1,68 @@
+package http
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+	"golang.org/x/time/rate"
+)
+
+// idleClientTTL is how long the limiter of a client which stopped calling is kept.
+const idleClientTTL = 10 * time.Minute
+
+// rateLimiter limits the requests of each client IP with a token bucket.
+type rateLimiter struct {
+	mu      sync.Mutex
+	limit   rate.Limit
+	burst   int
+	clients map[string]*clientLimiter
+	now     func() time.Time
+}
+
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// newRateLimiter allows each client `requests` requests per `per`, with bursts of up to `burst`.
+func newRateLimiter(requests int, per time.Duration, burst int) *rateLimiter {
+	return &rateLimiter{
+		limit:   rate.Limit(float64(requests) / per.Seconds()),
+		burst:   burst,
+		clients: make(map[string]*clientLimiter),
+		now:     time.Now,
+	}
+}
+
+func (r *rateLimiter) allow(clientIP string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	for ip, c := range r.clients {
+		if now.Sub(c.lastSeen) > idleClientTTL {
+			delete(r.clients, ip)
+		}
+	}
+	c, exist := r.clients[clientIP]
+	if !exist {
+		c = &clientLimiter{limiter: rate.NewLimiter(r.limit, r.burst)}
+		r.clients[clientIP] = c
+	}
+	c.lastSeen = now
+	return c.limiter.AllowN(now, 1)
+}
+
+func (r *rateLimiter) middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !r.allow(ctx.ClientIP()) {
+			logger.Ctx(ctx.Request.Context()).Warnw("rate limit exceeded", "path", ctx.Request.URL.Path)
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "too many requests"})
+			return
+		}
+		ctx.Next()
+	}
+}
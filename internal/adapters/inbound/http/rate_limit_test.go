@@ -0,0 +1,47 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterAllow(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newRateLimiter(1, time.Minute, 2)
+	limiter.now = func() time.Time { return now }
+
+	assert.True(t, limiter.allow("10.0.0.1"))
+	assert.True(t, limiter.allow("10.0.0.1"))
+	assert.False(t, limiter.allow("10.0.0.1"), "the burst is used up")
+	assert.True(t, limiter.allow("10.0.0.2"), "other clients have their own bucket")
+
+	now = now.Add(time.Minute)
+	assert.True(t, limiter.allow("10.0.0.1"), "a token is refilled every minute")
+	assert.False(t, limiter.allow("10.0.0.1"))
+
+	now = now.Add(idleClientTTL + time.Second)
+	limiter.allow("10.0.0.3")
+	assert.NotContains(t, limiter.clients, "10.0.0.2", "idle clients are forgotten")
+}
+
+func TestRateLimiterMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/", newRateLimiter(1, time.Hour, 1).middleware(), func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	first := httptest.NewRecorder()
+	router.ServeHTTP(first, httptest.NewRequest("GET", "/", nil))
+	second := httptest.NewRecorder()
+	router.ServeHTTP(second, httptest.NewRequest("GET", "/", nil))
+
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusTooManyRequests, second.Code)
+	assert.JSONEq(t, `{"error":"too many requests"}`, second.Body.String())
+}
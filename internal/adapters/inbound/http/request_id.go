@@ -0,0 +1,23 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// requestIDMiddleware tags every request with a request ID, taken from the
+// X-Request-ID header when the caller provides one, so that the log lines
+// of a single request can be correlated. The ID is echoed in the response.
+func requestIDMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(requestIDHeader)
+		if !logger.IsValidRequestID(requestID) {
+			requestID = logger.NewRequestID()
+		}
+		ctx.Header(requestIDHeader, requestID)
+		ctx.Request = ctx.Request.WithContext(logger.WithRequestID(ctx.Request.Context(), requestID))
+		ctx.Next()
+	}
+}
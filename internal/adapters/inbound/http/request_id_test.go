@@ -0,0 +1,62 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	testcases := []struct {
+		it                string
+		incomingRequestID string
+		expectGenerated   bool
+	}{
+		{
+			it:              "generates a request id when the header is missing",
+			expectGenerated: true,
+		},
+		{
+			it:                "reuses the request id provided by the caller",
+			incomingRequestID: "abc-123",
+		},
+		{
+			it:                "generates a request id when the provided one is malformed",
+			incomingRequestID: "abc 123\n",
+			expectGenerated:   true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			var requestIDInContext string
+			router := gin.New()
+			router.Use(requestIDMiddleware())
+			router.GET("/", func(ctx *gin.Context) {
+				requestIDInContext = logger.RequestIDFromContext(ctx.Request.Context())
+				ctx.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/", nil)
+			if tc.incomingRequestID != "" {
+				req.Header.Set(requestIDHeader, tc.incomingRequestID)
+			}
+			router.ServeHTTP(w, req)
+
+			responseRequestID := w.Header().Get(requestIDHeader)
+			assert.NotEmpty(t, responseRequestID)
+			assert.Equal(t, responseRequestID, requestIDInContext)
+			if tc.expectGenerated {
+				assert.NotEqual(t, tc.incomingRequestID, responseRequestID)
+			} else {
+				assert.Equal(t, tc.incomingRequestID, responseRequestID)
+			}
+		})
+	}
+}
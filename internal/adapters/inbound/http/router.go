@@ -4,16 +4,19 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/sMARCHz/secretaria-bot/internal/adapters/client/finance"
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/inbound/http/api"
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/inbound/http/download"
 	"github.com/sMARCHz/secretaria-bot/internal/adapters/inbound/http/line"
-	"github.com/sMARCHz/secretaria-bot/internal/core/services"
+	"github.com/sMARCHz/secretaria-bot/internal/config"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/inbound"
 )
 
-func NewRouter() *gin.Engine {
+func NewRouter(service inbound.BotService, financeService inbound.FinanceService, imports inbound.ImportService, audit inbound.AuditService, downloads *download.Store) *gin.Engine {
+	cfg := config.Get()
 	router := gin.Default()
-	service := services.NewBotService(finance.NewFinanceServiceClient())
-	lineHandler := line.NewLineHandler(service)
-	testHandler := newTestHandler(service)
+	lineHandler := line.NewLineHandler(service, imports)
+
+	router.Use(requestIDMiddleware())
 
 	router.GET("/", func(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, gin.H{"status": "UP"})
@@ -21,9 +24,10 @@ func NewRouter() *gin.Engine {
 	router.POST("/line", func(ctx *gin.Context) {
 		lineHandler.HandleLineMessage(ctx)
 	})
-	router.POST("/__test", func(ctx *gin.Context) {
-		testHandler.handleTestMessage(ctx)
-	})
+	registerTestRoutes(router, service, cfg)
+	registerAdminRoutes(router, cfg.App.AdminToken, audit)
+	api.RegisterRoutes(router, financeService, imports, cfg.FindUserByAPIKey, cfg.Location())
+	download.RegisterRoutes(router, downloads)
 
 	return router
 }
@@ -4,18 +4,31 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sMARCHz/secretaria-bot/internal/config"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
 	"github.com/sMARCHz/secretaria-bot/internal/ports/inbound"
 )
 
+const (
+	testRateLimitRequests = 30
+	testRateLimitPer      = time.Minute
+	testRateLimitBurst    = 5
+)
+
 type testHandler struct {
 	service inbound.BotService
+	user    domain.User
 }
 
-func newTestHandler(service inbound.BotService) *testHandler {
+// newTestHandler creates a handler which talks to the bot on behalf of the given user.
+func newTestHandler(service inbound.BotService, user domain.User) *testHandler {
 	return &testHandler{
 		service: service,
+		user:    user,
 	}
 }
 
@@ -23,25 +36,60 @@ type testMessage struct {
 	Message string `json:"message"`
 }
 
+// registerTestRoutes mounts POST /__test, which lets a developer chat with the
+// bot without LINE. It's only mounted when enabled (by default in the dev
+// profile) and credentials are configured, and is protected by basic auth.
+func registerTestRoutes(router *gin.Engine, service inbound.BotService, cfg config.Configuration) {
+	if !cfg.App.TestEnabled {
+		return
+	}
+	if cfg.App.TestUsername == "" || cfg.App.TestPassword == "" {
+		logger.Warn("/__test is disabled: app.test_username and app.test_password are required")
+		return
+	}
+
+	handler := newTestHandler(service, testUser(cfg))
+	router.POST("/__test",
+		newRateLimiter(testRateLimitRequests, testRateLimitPer, testRateLimitBurst).middleware(),
+		gin.BasicAuth(gin.Accounts{cfg.App.TestUsername: cfg.App.TestPassword}),
+		handler.handleTestMessage,
+	)
+}
+
+// testUser is the user /__test acts on behalf of: the one named by
+// app.test_username, or the first configured user.
+func testUser(cfg config.Configuration) domain.User {
+	if user, ok := cfg.FindUserByID(cfg.App.TestUsername); ok {
+		return user
+	}
+	if len(cfg.Users) > 0 {
+		user, _ := cfg.FindUserByID(cfg.Users[0].ID)
+		return user
+	}
+	return domain.User{}
+}
+
+// handleTestMessage replies with the same model as the LINE adapter:
+// the reply message, or the error message with the error's status code.
 func (t *testHandler) handleTestMessage(ctx *gin.Context) {
 	body, err := io.ReadAll(ctx.Request.Body)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(http.StatusInternalServerError, domain.TextMessageResponse{ReplyMessage: err.Error()})
 		return
 	}
 
 	var msg testMessage
 	err = json.Unmarshal(body, &msg)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(http.StatusBadRequest, domain.TextMessageResponse{ReplyMessage: err.Error()})
 		return
 	}
 
-	res, appErr := t.service.HandleTextMessage(msg.Message)
+	res, appErr := t.service.HandleTextMessage(ctx.Request.Context(), t.user, msg.Message)
 	if appErr != nil {
-		ctx.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		ctx.JSON(appErr.StatusCode, domain.TextMessageResponse{ReplyMessage: appErr.Message})
 		return
 	}
 
-	ctx.JSON(200, gin.H{"message": res.ReplyMessage})
+	ctx.JSON(http.StatusOK, res)
 }
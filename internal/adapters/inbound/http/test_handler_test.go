@@ -9,23 +9,163 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sMARCHz/secretaria-bot/internal/config"
 	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	apperrors "github.com/sMARCHz/secretaria-bot/internal/core/errors"
 	"github.com/sMARCHz/secretaria-bot/test/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestNewTestHandler(t *testing.T) {
 	bot := mocks.NewMockBotService(t)
-	handler := newTestHandler(bot)
-	assert.Equal(t, &testHandler{service: bot}, handler)
+	user := domain.User{ID: "owner"}
+	handler := newTestHandler(bot, user)
+	assert.Equal(t, &testHandler{service: bot, user: user}, handler)
+}
+
+func TestRegisterTestRoutes(t *testing.T) {
+	testcases := []struct {
+		it                 string
+		app                config.AppConfiguration
+		username           string
+		password           string
+		mock               func(bot *mocks.MockBotService)
+		expectedHTTPStatus int
+		expectedBody       string
+	}{
+		{
+			it:       "lets an authenticated caller talk to the bot as the test user",
+			app:      config.AppConfiguration{TestEnabled: true, TestUsername: "tester", TestPassword: "p@ss"},
+			username: "tester",
+			password: "p@ss",
+			mock: func(bot *mocks.MockBotService) {
+				bot.EXPECT().HandleTextMessage(mock.Anything, mock.MatchedBy(func(user domain.User) bool {
+					return user.ID == "tester"
+				}), "hello").Return(&domain.TextMessageResponse{ReplyMessage: "world"}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedBody:       `{"message":"world"}`,
+		},
+		{
+			it:                 "rejects a caller with wrong credentials",
+			app:                config.AppConfiguration{TestEnabled: true, TestUsername: "tester", TestPassword: "p@ss"},
+			username:           "tester",
+			password:           "guess",
+			expectedHTTPStatus: http.StatusUnauthorized,
+		},
+		{
+			it:                 "rejects a caller without credentials",
+			app:                config.AppConfiguration{TestEnabled: true, TestUsername: "tester", TestPassword: "p@ss"},
+			expectedHTTPStatus: http.StatusUnauthorized,
+		},
+		{
+			it:                 "does not expose the endpoint when it is disabled",
+			app:                config.AppConfiguration{TestEnabled: false, TestUsername: "tester", TestPassword: "p@ss"},
+			username:           "tester",
+			password:           "p@ss",
+			expectedHTTPStatus: http.StatusNotFound,
+		},
+		{
+			it:                 "does not expose the endpoint when no password is configured",
+			app:                config.AppConfiguration{TestEnabled: true, TestUsername: "tester"},
+			username:           "tester",
+			expectedHTTPStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			bot := mocks.NewMockBotService(t)
+			if tc.mock != nil {
+				tc.mock(bot)
+			}
+			router := gin.New()
+			registerTestRoutes(router, bot, config.Configuration{
+				App:   tc.app,
+				Users: []config.UserConfiguration{{ID: "owner"}, {ID: "tester"}},
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/__test", strings.NewReader(`{"message":"hello"}`))
+			if tc.username != "" {
+				req.SetBasicAuth(tc.username, tc.password)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, w.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, w.Body.String())
+			}
+			bot.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRegisterTestRoutes_RateLimited(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bot := mocks.NewMockBotService(t)
+	router := gin.New()
+	registerTestRoutes(router, bot, config.Configuration{
+		App: config.AppConfiguration{TestEnabled: true, TestUsername: "tester", TestPassword: "p@ss"},
+	})
+
+	var lastCode int
+	for range testRateLimitBurst + 1 {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/__test", strings.NewReader(`{"message":"hello"}`))
+		req.SetBasicAuth("tester", "guess")
+		router.ServeHTTP(w, req)
+		lastCode = w.Code
+	}
+
+	assert.Equal(t, http.StatusTooManyRequests, lastCode)
+}
+
+func TestTestUser(t *testing.T) {
+	ownerRole := domain.Role{Name: "owner", Commands: []string{"*"}, Accounts: []string{"*"}}
+	users := []config.UserConfiguration{
+		{ID: "owner", LineUserID: "U1"},
+		{ID: "tester", LineUserID: "U2"},
+	}
+	testcases := []struct {
+		it       string
+		cfg      config.Configuration
+		expected domain.User
+	}{
+		{
+			it: "returns the user named by the test username",
+			cfg: config.Configuration{
+				App:   config.AppConfiguration{TestUsername: "tester"},
+				Users: users,
+			},
+			expected: domain.User{ID: "tester", LineUserID: "U2", Role: ownerRole},
+		},
+		{
+			it:       "returns the first user when the test username isn't a user",
+			cfg:      config.Configuration{Users: users},
+			expected: domain.User{ID: "owner", LineUserID: "U1", Role: ownerRole},
+		},
+		{
+			it:       "returns an anonymous user when no user is configured",
+			cfg:      config.Configuration{},
+			expected: domain.User{},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			assert.Equal(t, tc.expected, testUser(tc.cfg))
+		})
+	}
 }
 
 func TestHandleTextMessage(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	bot := mocks.NewMockBotService(t)
-	bot.EXPECT().HandleTextMessage("hello").Return(&domain.TextMessageResponse{
+	bot.EXPECT().HandleTextMessage(mock.Anything, testCaller, "hello").Return(&domain.TextMessageResponse{
 		ReplyMessage: "world",
 	}, nil)
 
@@ -34,7 +174,7 @@ func TestHandleTextMessage(t *testing.T) {
 	ctx, _ := gin.CreateTestContext(w)
 	ctx.Request = httptest.NewRequest("POST", "/__test", strings.NewReader(requestBody))
 
-	handler := &testHandler{service: bot}
+	handler := &testHandler{service: bot, user: testCaller}
 
 	handler.handleTestMessage(ctx)
 
@@ -55,22 +195,22 @@ func TestHandleTextMessage_Error(t *testing.T) {
 			it:                 "returns error with status 500 when fails to read request body",
 			body:               errorReader{},
 			expectedHTTPStatus: http.StatusInternalServerError,
-			expectedBody:       `{"error":"read error"}`,
+			expectedBody:       `{"message":"read error"}`,
 		},
 		{
-			it:                 "returns error with status 500 when fails to parse request body",
+			it:                 "returns error with status 400 when fails to parse request body",
 			body:               strings.NewReader(`{"message":}`),
-			expectedHTTPStatus: http.StatusInternalServerError,
-			expectedBody:       `{"error":"invalid character '}' looking for beginning of value"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedBody:       `{"message":"invalid character '}' looking for beginning of value"}`,
 		},
 		{
 			it:   "returns error with status and message from service layer when fails to handle the message",
 			body: strings.NewReader(`{"message":"hello"}`),
 			mock: func(bot *mocks.MockBotService) {
-				bot.EXPECT().HandleTextMessage("hello").Return(nil, apperrors.BadGatewayError("fail to handle message"))
+				bot.EXPECT().HandleTextMessage(mock.Anything, testCaller, "hello").Return(nil, apperrors.BadGatewayError("fail to handle message"))
 			},
 			expectedHTTPStatus: http.StatusBadGateway,
-			expectedBody:       `{"error":"fail to handle message"}`,
+			expectedBody:       `{"message":"fail to handle message"}`,
 		},
 	}
 
@@ -87,7 +227,7 @@ func TestHandleTextMessage_Error(t *testing.T) {
 			ctx, _ := gin.CreateTestContext(w)
 			ctx.Request = httptest.NewRequest("POST", "/__test", tc.body)
 
-			handler := &testHandler{service: bot}
+			handler := &testHandler{service: bot, user: testCaller}
 
 			handler.handleTestMessage(ctx)
 
@@ -98,6 +238,8 @@ func TestHandleTextMessage_Error(t *testing.T) {
 	}
 }
 
+var testCaller = domain.User{ID: "owner"}
+
 type errorReader struct{}
 
 func (errorReader) Read(p []byte) (n int, err error) { return 0, errors.New("read error") }
@@ -0,0 +1,89 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+)
+
+// Journal keeps the records as the lines of a file, e.g. JSON Lines, which
+// is only ever appended to.
+type Journal struct {
+	mu     sync.Mutex
+	file   *os.File
+	closed bool
+}
+
+// OpenJournal opens the journal kept in the file at path, or creates it. A
+// last line cut by a crash, without its newline, is dropped, so that the
+// next record starts a line of its own.
+func OpenJournal(path string) (*Journal, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create the journal directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the journal file: %w", err)
+	}
+	raw, err := os.ReadFile(path)
+	if err == nil && len(raw) > 0 && raw[len(raw)-1] != '\n' {
+		err = f.Truncate(int64(bytes.LastIndexByte(raw, '\n') + 1))
+	}
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to read the journal file: %w", err)
+	}
+	return &Journal{file: f}, nil
+}
+
+// Append writes the record as a line, synced before returning.
+func (j *Journal) Append(record []byte) error {
+	if bytes.ContainsRune(record, '\n') {
+		return errors.New("storage: the record contains a newline")
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.closed {
+		return storage.ErrClosed
+	}
+	// The full slice expression keeps the caller's array as it is
+	if _, err := j.file.Write(append(record[:len(record):len(record)], '\n')); err != nil {
+		return fmt.Errorf("failed to append to the journal file: %w", err)
+	}
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync the journal file: %w", err)
+	}
+	return nil
+}
+
+// Records reads the lines of the file.
+func (j *Journal) Records() ([][]byte, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.closed {
+		return nil, storage.ErrClosed
+	}
+	raw, err := os.ReadFile(j.file.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the journal file: %w", err)
+	}
+	lines := bytes.Split(raw, []byte{'\n'})
+	// The last element follows the last newline
+	return lines[:len(lines)-1], nil
+}
+
+// Close closes the file once the running calls return.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.closed {
+		return nil
+	}
+	j.closed = true
+	return j.file.Close()
+}
@@ -0,0 +1,55 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "audit.jsonl")
+	journal, err := OpenJournal(path)
+	require.NoError(t, err)
+	require.NoError(t, journal.Append([]byte(`{"seq":1}`)))
+	require.NoError(t, journal.Append([]byte(`{"seq":2}`)))
+	require.NoError(t, journal.Close())
+
+	// The records outlive the journal, and are appended to
+	journal, err = OpenJournal(path)
+	require.NoError(t, err)
+	require.NoError(t, journal.Append([]byte(`{"seq":3}`)))
+	records, err := journal.Records()
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte(`{"seq":1}`), []byte(`{"seq":2}`), []byte(`{"seq":3}`)}, records)
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"seq\":1}\n{\"seq\":2}\n{\"seq\":3}\n", string(raw))
+}
+
+func TestOpenJournal_CutLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("{\"seq\":1}\n{\"se"), 0o600))
+
+	journal, err := OpenJournal(path)
+	require.NoError(t, err)
+	require.NoError(t, journal.Append([]byte(`{"seq":2}`)))
+
+	records, err := journal.Records()
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte(`{"seq":1}`), []byte(`{"seq":2}`)}, records)
+}
+
+func TestJournal_Error(t *testing.T) {
+	journal, err := OpenJournal(filepath.Join(t.TempDir(), "audit.jsonl"))
+	require.NoError(t, err)
+
+	assert.EqualError(t, journal.Append([]byte("{\n}")), "storage: the record contains a newline")
+	require.NoError(t, journal.Close())
+	assert.ErrorIs(t, journal.Append([]byte("{}")), storage.ErrClosed)
+	_, err = journal.Records()
+	assert.ErrorIs(t, err, storage.ErrClosed)
+}
@@ -0,0 +1,84 @@
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
+)
+
+// formatVersion is the version of the file layout, which is independent
+// of the schema of the stored data.
+const formatVersion = 1
+
+// content is the layout of the file. Values are base64 encoded.
+type content struct {
+	Format      int                          `json:"format"`
+	Collections map[string]map[string][]byte `json:"collections"`
+}
+
+// Open loads the store kept in the file at path, or creates it. The whole
+// data is held in memory, and each Update rewrites the file.
+func Open(path string) (*memory.Store, error) {
+	data, err := load(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create the storage directory: %w", err)
+	}
+	return memory.NewPersistentStore(data, func(data memory.Data) error {
+		return save(path, data)
+	}), nil
+}
+
+func load(path string) (memory.Data, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the storage file: %w", err)
+	}
+	var c content
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, fmt.Errorf("failed to decode the storage file %s: %w", path, err)
+	}
+	if c.Format != formatVersion {
+		return nil, fmt.Errorf("the storage file %s has the unsupported format %d", path, c.Format)
+	}
+	return c.Collections, nil
+}
+
+// save replaces the file atomically, so that a crash leaves either the
+// previous data or the new one.
+func save(path string, data memory.Data) error {
+	raw, err := json.Marshal(content{Format: formatVersion, Collections: data})
+	if err != nil {
+		return fmt.Errorf("failed to encode the storage file: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write the storage file: %w", err)
+	}
+	// The temporary file no longer exists once renamed
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(raw)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write the storage file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace the storage file: %w", err)
+	}
+	return nil
+}
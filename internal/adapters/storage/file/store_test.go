@@ -0,0 +1,67 @@
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "state.json")
+	store, err := Open(path)
+	require.NoError(t, err)
+	require.NoError(t, store.Update(context.Background(), func(tx storage.Tx) error {
+		return tx.Put("aliases", "k", []byte("kbank-savings"))
+	}))
+	require.NoError(t, store.Close())
+
+	// The data outlives the store
+	store, err = Open(path)
+	require.NoError(t, err)
+	err = store.View(context.Background(), func(tx storage.Tx) error {
+		value, exist := tx.Get("aliases", "k")
+		assert.True(t, exist)
+		assert.Equal(t, []byte("kbank-savings"), value)
+		return nil
+	})
+	assert.NoError(t, err)
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "the temporary files are removed")
+}
+
+func TestOpen_Error(t *testing.T) {
+	testcases := []struct {
+		it          string
+		content     string
+		expectedErr string
+	}{
+		{
+			it:          "returns error when the file isn't JSON",
+			content:     "aliases: {}",
+			expectedErr: "failed to decode the storage file",
+		},
+		{
+			it:          "returns error when the format is unknown",
+			content:     `{"format": 2, "collections": {}}`,
+			expectedErr: "has the unsupported format 2",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "state.json")
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0o600))
+
+			store, err := Open(path)
+
+			assert.Nil(t, store)
+			assert.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
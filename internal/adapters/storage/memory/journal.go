@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+)
+
+// Journal keeps the records in memory, so a restart forgets them.
+type Journal struct {
+	mu      sync.RWMutex
+	records [][]byte
+	closed  bool
+}
+
+// NewJournal constructs a journal holding records.
+func NewJournal(records ...[]byte) *Journal {
+	return &Journal{records: records}
+}
+
+func (j *Journal) Append(record []byte) error {
+	if bytes.ContainsRune(record, '\n') {
+		return errors.New("storage: the record contains a newline")
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.closed {
+		return storage.ErrClosed
+	}
+	j.records = append(j.records, slices.Clone(record))
+	return nil
+}
+
+func (j *Journal) Records() ([][]byte, error) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	if j.closed {
+		return nil, storage.ErrClosed
+	}
+	return slices.Clone(j.records), nil
+}
+
+// Close makes the next calls fail.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.closed = true
+	return nil
+}
@@ -0,0 +1,26 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournal(t *testing.T) {
+	journal := NewJournal([]byte("first"))
+	record := []byte("second")
+	require.NoError(t, journal.Append(record))
+	record[0] = 'S'
+
+	records, err := journal.Records()
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("first"), []byte("second")}, records, "the appended record is copied")
+	assert.EqualError(t, journal.Append([]byte("a\nb")), "storage: the record contains a newline")
+	require.NoError(t, journal.Close())
+	assert.ErrorIs(t, journal.Append(record), storage.ErrClosed)
+	_, err = journal.Records()
+	assert.ErrorIs(t, err, storage.ErrClosed)
+}
@@ -0,0 +1,163 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+)
+
+// Data is the content of a store, by collection and key.
+type Data map[string]map[string][]byte
+
+// Store keeps the data in memory, so a restart forgets it. It serves the
+// tests, and the file store which persists its commits.
+type Store struct {
+	mu      sync.RWMutex
+	data    Data
+	persist func(Data) error
+	closed  bool
+}
+
+// NewStore constructs an empty store.
+func NewStore() *Store {
+	return NewPersistentStore(nil, nil)
+}
+
+// NewPersistentStore constructs a store holding data, which calls persist,
+// if any, with the data of each Update before committing it. A failing
+// persist rolls the transaction back.
+func NewPersistentStore(data Data, persist func(Data) error) *Store {
+	if data == nil {
+		data = make(Data)
+	}
+	return &Store{data: data, persist: persist}
+}
+
+func (s *Store) View(ctx context.Context, fn func(tx storage.Tx) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return storage.ErrClosed
+	}
+	return fn(&tx{data: s.data})
+}
+
+func (s *Store) Update(ctx context.Context, fn func(tx storage.Tx) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return storage.ErrClosed
+	}
+	t := &tx{data: s.data, writable: true, pending: make(Data)}
+	if err := fn(t); err != nil {
+		return err
+	}
+	if len(t.pending) == 0 {
+		return nil
+	}
+
+	// Copy on write, so that a failing persist leaves the data as it was
+	next := maps.Clone(s.data)
+	for collection, values := range t.pending {
+		merged := maps.Clone(next[collection])
+		if merged == nil {
+			merged = make(map[string][]byte, len(values))
+		}
+		for key, value := range values {
+			if value == nil {
+				delete(merged, key)
+			} else {
+				merged[key] = value
+			}
+		}
+		if len(merged) == 0 {
+			delete(next, collection)
+		} else {
+			next[collection] = merged
+		}
+	}
+	if s.persist != nil {
+		if err := s.persist(next); err != nil {
+			return err
+		}
+	}
+	s.data = next
+	return nil
+}
+
+// Close makes the next transactions fail. It waits for the running ones.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+// tx reads data through the pending writes of the transaction, where a
+// nil value is a deletion.
+type tx struct {
+	data     Data
+	writable bool
+	pending  Data
+}
+
+func (t *tx) Get(collection, key string) ([]byte, bool) {
+	if value, exist := t.pending[collection][key]; exist {
+		return value, value != nil
+	}
+	value, exist := t.data[collection][key]
+	return value, exist
+}
+
+func (t *tx) Put(collection, key string, value []byte) error {
+	if !t.writable {
+		return storage.ErrReadOnly
+	}
+	if value == nil {
+		value = []byte{}
+	}
+	t.write(collection, key, slices.Clone(value))
+	return nil
+}
+
+func (t *tx) Delete(collection, key string) error {
+	if !t.writable {
+		return storage.ErrReadOnly
+	}
+	t.write(collection, key, nil)
+	return nil
+}
+
+func (t *tx) Keys(collection string) []string {
+	keys := make(map[string]bool)
+	for key := range t.data[collection] {
+		keys[key] = true
+	}
+	for key, value := range t.pending[collection] {
+		keys[key] = value != nil
+	}
+	var sorted []string
+	for key, exist := range keys {
+		if exist {
+			sorted = append(sorted, key)
+		}
+	}
+	slices.Sort(sorted)
+	return sorted
+}
+
+func (t *tx) write(collection, key string, value []byte) {
+	if t.pending[collection] == nil {
+		t.pending[collection] = make(map[string][]byte)
+	}
+	t.pending[collection][key] = value
+}
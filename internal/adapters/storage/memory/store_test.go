@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+var ctx = context.Background()
+
+func TestUpdate(t *testing.T) {
+	store := NewStore()
+
+	err := store.Update(ctx, func(tx storage.Tx) error {
+		assert.NoError(t, tx.Put("aliases", "k", []byte("kbank-savings")))
+		assert.NoError(t, tx.Put("aliases", "d", []byte("debit1")))
+		assert.NoError(t, tx.Put("goals", "owner", []byte("[]")))
+		assert.NoError(t, tx.Delete("goals", "owner"))
+
+		// The transaction reads its own writes
+		value, exist := tx.Get("aliases", "k")
+		assert.True(t, exist)
+		assert.Equal(t, []byte("kbank-savings"), value)
+		_, exist = tx.Get("goals", "owner")
+		assert.False(t, exist)
+		assert.Equal(t, []string{"d", "k"}, tx.Keys("aliases"))
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = store.View(ctx, func(tx storage.Tx) error {
+		assert.Equal(t, []string{"d", "k"}, tx.Keys("aliases"))
+		assert.Empty(t, tx.Keys("goals"))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, Data{"aliases": {"d": []byte("debit1"), "k": []byte("kbank-savings")}}, store.data)
+}
+
+func TestUpdate_Rollback(t *testing.T) {
+	testcases := []struct {
+		it      string
+		fn      func(tx storage.Tx) error
+		persist func(Data) error
+	}{
+		{
+			it: "rolls back when fn fails",
+			fn: func(tx storage.Tx) error {
+				_ = tx.Put("aliases", "k", []byte("debit1"))
+				_ = tx.Delete("aliases", "d")
+				return errors.New("failed")
+			},
+		},
+		{
+			it: "rolls back when persist fails",
+			fn: func(tx storage.Tx) error {
+				_ = tx.Put("aliases", "k", []byte("debit1"))
+				return tx.Delete("aliases", "d")
+			},
+			persist: func(Data) error { return errors.New("failed") },
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			store := NewPersistentStore(Data{"aliases": {"d": []byte("debit1"), "k": []byte("kbank-savings")}}, tc.persist)
+
+			err := store.Update(ctx, tc.fn)
+
+			assert.EqualError(t, err, "failed")
+			assert.Equal(t, Data{"aliases": {"d": []byte("debit1"), "k": []byte("kbank-savings")}}, store.data)
+		})
+	}
+}
+
+func TestView_ReadOnly(t *testing.T) {
+	store := NewStore()
+
+	err := store.View(ctx, func(tx storage.Tx) error {
+		assert.ErrorIs(t, tx.Delete("aliases", "k"), storage.ErrReadOnly)
+		return tx.Put("aliases", "k", []byte("debit1"))
+	})
+
+	assert.ErrorIs(t, err, storage.ErrReadOnly)
+	assert.Empty(t, store.data)
+}
+
+func TestClose(t *testing.T) {
+	store := NewStore()
+
+	assert.NoError(t, store.Close())
+	assert.ErrorIs(t, store.View(ctx, func(storage.Tx) error { return nil }), storage.ErrClosed)
+	assert.ErrorIs(t, store.Update(ctx, func(storage.Tx) error { return nil }), storage.ErrClosed)
+}
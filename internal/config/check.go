@@ -2,13 +2,17 @@ package config
 
 import (
 	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 var requiredConfig = []string{
 	"app.port",
-	"line.user_id",
 	"line.channel_secret",
 	"line.channel_token",
 	"finance_url",
@@ -20,5 +24,253 @@ func checkMissingConfig() error {
 			return fmt.Errorf("%s is missing in the config", v)
 		}
 	}
+	if !viper.IsSet("users") && viper.GetString("line.user_id") == "" {
+		return fmt.Errorf("users is missing in the config")
+	}
+	return nil
+}
+
+func validateUsers(users []UserConfiguration, roles map[string]RoleConfiguration) error {
+	ids := make(map[string]struct{}, len(users))
+	lineUserIDs := make(map[string]struct{}, len(users))
+	for i, u := range users {
+		if u.ID == "" {
+			return fmt.Errorf("users[%d].id is missing in the config", i)
+		}
+		if _, exist := ids[u.ID]; exist {
+			return fmt.Errorf("users[%d].id '%s' is duplicated", i, u.ID)
+		}
+		ids[u.ID] = struct{}{}
+		if _, exist := roles[u.Role]; !exist && u.Role != "" && u.Role != OwnerRole {
+			return fmt.Errorf("users[%d].role '%s' isn't defined in roles", i, u.Role)
+		}
+		if u.LineUserID == "" {
+			continue
+		}
+		if _, exist := lineUserIDs[u.LineUserID]; exist {
+			return fmt.Errorf("users[%d].line_user_id is duplicated", i)
+		}
+		lineUserIDs[u.LineUserID] = struct{}{}
+	}
+	return nil
+}
+
+// validateAPIKeys checks the api.keys entries. Errors only refer to entries
+// by index since they hold the keys.
+func validateAPIKeys(entries []string, users []UserConfiguration) error {
+	keys := make(map[string]struct{}, len(entries))
+	for i, entry := range entries {
+		userID, key, found := strings.Cut(entry, ":")
+		if !found || userID == "" || key == "" {
+			return fmt.Errorf("api.keys[%d] must be <user_id>:<api_key>", i)
+		}
+		if !slices.ContainsFunc(users, func(u UserConfiguration) bool { return u.ID == userID }) {
+			return fmt.Errorf("api.keys[%d] refers to the unknown user '%s'", i, userID)
+		}
+		if _, exist := keys[key]; exist {
+			return fmt.Errorf("api.keys[%d] is duplicated", i)
+		}
+		keys[key] = struct{}{}
+	}
+	return nil
+}
+
+func validateImports(imports ImportsConfiguration) error {
+	names := make(map[string]struct{}, len(imports.Layouts))
+	for i, l := range imports.Layouts {
+		if l.Name == "" {
+			return fmt.Errorf("imports.layouts[%d].name is missing in the config", i)
+		}
+		if _, exist := names[l.Name]; exist {
+			return fmt.Errorf("imports.layouts[%d].name '%s' is duplicated", i, l.Name)
+		}
+		names[l.Name] = struct{}{}
+		required := []struct{ key, value string }{
+			{"account", l.Account},
+			{"date_column", l.DateColumn},
+			{"date_format", l.DateFormat},
+			{"description_column", l.DescriptionColumn},
+		}
+		for _, r := range required {
+			if r.value == "" {
+				return fmt.Errorf("imports.layouts[%d].%s is missing in the config", i, r.key)
+			}
+		}
+		if l.AmountColumn == "" && (l.DebitColumn == "" || l.CreditColumn == "") {
+			return fmt.Errorf("imports.layouts[%d] needs amount_column, or debit_column and credit_column", i)
+		}
+	}
+	for i, r := range imports.Rules {
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("imports.rules[%d].pattern is invalid: %w", i, err)
+		}
+		if r.Category == "" && r.Account == "" {
+			return fmt.Errorf("imports.rules[%d] needs a category or an account", i)
+		}
+	}
+	return nil
+}
+
+// validateLedger rejects the ledger account names which would break a
+// journal: two spaces or a tab end an account name, and ';' starts a comment.
+func validateLedger(ledger LedgerConfiguration) error {
+	names := []struct{ key, value string }{
+		{"ledger.assets_prefix", ledger.AssetsPrefix},
+		{"ledger.expenses_prefix", ledger.ExpensesPrefix},
+		{"ledger.income_prefix", ledger.IncomePrefix},
+	}
+	accounts := slices.Sorted(maps.Keys(ledger.Accounts))
+	for _, account := range accounts {
+		ledgerAccount := ledger.Accounts[account]
+		if ledgerAccount == "" {
+			return fmt.Errorf("ledger.accounts.%s is empty", account)
+		}
+		names = append(names, struct{ key, value string }{"ledger.accounts." + account, ledgerAccount})
+	}
+	for _, n := range names {
+		if strings.Contains(n.value, "  ") || strings.ContainsAny(n.value, "\t;") {
+			return fmt.Errorf("%s '%s' must not contain two spaces, tabs or ';'", n.key, n.value)
+		}
+	}
+	return nil
+}
+
+// validateAccounts rejects the aliases of nothing and of other aliases,
+// which aren't resolved any further.
+func validateAccounts(accounts AccountsConfiguration) error {
+	aliases := slices.Sorted(maps.Keys(accounts.Aliases))
+	for _, alias := range aliases {
+		account := accounts.Aliases[alias]
+		if account == "" {
+			return fmt.Errorf("accounts.aliases.%s is empty", alias)
+		}
+		if _, exist := accounts.Aliases[strings.ToLower(account)]; exist {
+			return fmt.Errorf("accounts.aliases.%s '%s' is an alias itself", alias, account)
+		}
+	}
+	return nil
+}
+
+var categoryPattern = regexp.MustCompile(`^[a-zA-Z]+$`)
+
+// checkCategory checks that the category can follow an amount: letters, not
+// starting with k, which the amount would read as thousands.
+func checkCategory(key, category string) error {
+	if !categoryPattern.MatchString(category) {
+		return fmt.Errorf("%s '%s' must only contain letters", key, category)
+	}
+	if strings.HasPrefix(strings.ToLower(category), "k") {
+		return fmt.Errorf("%s '%s' must not start with k, which multiplies the amount by 1,000", key, category)
+	}
+	return nil
+}
+
+// validateCategories checks that the codes and aliases can follow an
+// amount and are unique regardless of case, and that the groups exist and
+// don't contain themselves.
+func validateCategories(categories CategoriesConfiguration) error {
+	names := make(map[string]string, len(categories.Items)) // code or alias to its key
+	parents := make(map[string]string, len(categories.Items))
+	for i, c := range categories.Items {
+		key := fmt.Sprintf("categories.items[%d]", i)
+		if c.Code == "" {
+			return fmt.Errorf("%s.code is missing in the config", key)
+		}
+		entries := []struct{ key, value string }{{key + ".code", c.Code}}
+		for j, alias := range c.Aliases {
+			entries = append(entries, struct{ key, value string }{fmt.Sprintf("%s.aliases[%d]", key, j), alias})
+		}
+		for _, e := range entries {
+			if err := checkCategory(e.key, e.value); err != nil {
+				return err
+			}
+			if previous, exist := names[strings.ToLower(e.value)]; exist {
+				return fmt.Errorf("%s '%s' is already used by %s", e.key, e.value, previous)
+			}
+			names[strings.ToLower(e.value)] = e.key
+		}
+		parents[strings.ToLower(c.Code)] = strings.ToLower(c.Parent)
+	}
+	for i, c := range categories.Items {
+		if c.Parent == "" {
+			continue
+		}
+		if _, exist := parents[strings.ToLower(c.Parent)]; !exist {
+			return fmt.Errorf("categories.items[%d].parent '%s' isn't a category code", i, c.Parent)
+		}
+		// A chain of parents longer than the number of categories is a cycle
+		code := strings.ToLower(c.Code)
+		for range len(parents) {
+			code = parents[code]
+			if code == "" {
+				break
+			}
+		}
+		if code != "" {
+			return fmt.Errorf("categories.items[%d].parent '%s' makes a cycle of groups", i, c.Parent)
+		}
+	}
+	return nil
+}
+
+// validateDebts checks that the debt category could be typed after an
+// amount, like the other categories.
+func validateDebts(debts DebtsConfiguration) error {
+	return checkCategory("debts.category", debts.Category)
+}
+
+// validateConfirmations checks that the thresholds aren't negative, that
+// the anomaly factor holds amounts over the typical one, and that a held
+// transaction can be confirmed.
+func validateConfirmations(confirmations ConfirmationsConfiguration) error {
+	if confirmations.Threshold < 0 {
+		return fmt.Errorf("confirmations.threshold %v must not be negative", confirmations.Threshold)
+	}
+	for _, name := range slices.Sorted(maps.Keys(confirmations.Accounts)) {
+		if confirmations.Accounts[name] < 0 {
+			return fmt.Errorf("confirmations.accounts.%s %v must not be negative", name, confirmations.Accounts[name])
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(confirmations.Categories)) {
+		if confirmations.Categories[name] < 0 {
+			return fmt.Errorf("confirmations.categories.%s %v must not be negative", name, confirmations.Categories[name])
+		}
+	}
+	if confirmations.AnomalyFactor != 0 && confirmations.AnomalyFactor <= 1 {
+		return fmt.Errorf("confirmations.anomaly_factor %v must be 0 or greater than 1", confirmations.AnomalyFactor)
+	}
+	if confirmations.Timeout <= 0 {
+		return fmt.Errorf("confirmations.timeout must be positive")
+	}
+	return nil
+}
+
+// validateStorage checks that the driver is known, and that the file driver
+// has a path.
+func validateStorage(storage StorageConfiguration) error {
+	switch storage.Driver {
+	case StorageDriverFile:
+		if storage.Path == "" {
+			return fmt.Errorf("storage.path is empty")
+		}
+		if storage.AuditPath == "" {
+			return fmt.Errorf("storage.audit_path is empty")
+		}
+	case StorageDriverMemory:
+	default:
+		return fmt.Errorf("storage.driver '%s' must be %s or %s", storage.Driver, StorageDriverFile, StorageDriverMemory)
+	}
+	return nil
+}
+
+// validateTimezone checks that the time zone is a known IANA name. An empty
+// name would silently mean UTC.
+func validateTimezone(name string) error {
+	if name == "" {
+		return fmt.Errorf("app.timezone is empty")
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("app.timezone '%s' is not an IANA time zone: %w", name, err)
+	}
 	return nil
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -30,7 +31,28 @@ func TestCheckMissingConfig(t *testing.T) {
 			setup: func() {
 				viper.Set("app.port", "80")
 			},
-			expected: errors.New("line.user_id is missing in the config"),
+			expected: errors.New("line.channel_secret is missing in the config"),
+		},
+		{
+			it: "returns nil if users are configured instead of line.user_id",
+			setup: func() {
+				viper.Set("app.port", "80")
+				viper.Set("users", []map[string]string{{"id": "owner", "line_user_id": "line_uid"}})
+				viper.Set("line.channel_secret", "line_secret")
+				viper.Set("line.channel_token", "line_token")
+				viper.Set("finance_url", "127.0.0.1:8080")
+			},
+			expected: nil,
+		},
+		{
+			it: "returns error if no user is configured",
+			setup: func() {
+				viper.Set("app.port", "80")
+				viper.Set("line.channel_secret", "line_secret")
+				viper.Set("line.channel_token", "line_token")
+				viper.Set("finance_url", "127.0.0.1:8080")
+			},
+			expected: errors.New("users is missing in the config"),
 		},
 	}
 
@@ -48,3 +70,388 @@ func TestCheckMissingConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateUsers(t *testing.T) {
+	testcases := []struct {
+		it       string
+		users    []UserConfiguration
+		roles    map[string]RoleConfiguration
+		expected error
+	}{
+		{
+			it: "returns nil if every user is valid",
+			users: []UserConfiguration{
+				{ID: "owner", LineUserID: "U1"},
+				{ID: "partner", LineUserID: "U2"},
+				{ID: "api-only", Role: "member"},
+			},
+			roles:    map[string]RoleConfiguration{"member": {Commands: []string{"balance"}}},
+			expected: nil,
+		},
+		{
+			it:       "returns error if a user has no id",
+			users:    []UserConfiguration{{LineUserID: "U1"}},
+			expected: errors.New("users[0].id is missing in the config"),
+		},
+		{
+			it:       "returns error if two users share an id",
+			users:    []UserConfiguration{{ID: "owner"}, {ID: "owner"}},
+			expected: errors.New("users[1].id 'owner' is duplicated"),
+		},
+		{
+			it:       "returns error if two users share a line user id",
+			users:    []UserConfiguration{{ID: "owner", LineUserID: "U1"}, {ID: "partner", LineUserID: "U1"}},
+			expected: errors.New("users[1].line_user_id is duplicated"),
+		},
+		{
+			it:       "returns error if a user has an undefined role",
+			users:    []UserConfiguration{{ID: "owner", Role: "admin"}},
+			expected: errors.New("users[0].role 'admin' isn't defined in roles"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			err := validateUsers(tc.users, tc.roles)
+			if tc.expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expected.Error())
+			}
+		})
+	}
+}
+
+func TestValidateAPIKeys(t *testing.T) {
+	users := []UserConfiguration{{ID: "owner"}, {ID: "partner"}}
+	testcases := []struct {
+		it       string
+		keys     []string
+		expected error
+	}{
+		{
+			it:       "returns nil if every key is valid",
+			keys:     []string{"owner:k1", "partner:k2", "owner:k3"},
+			expected: nil,
+		},
+		{
+			it:       "returns error if an entry has no user",
+			keys:     []string{":k1"},
+			expected: errors.New("api.keys[0] must be <user_id>:<api_key>"),
+		},
+		{
+			it:       "returns error if an entry has no key",
+			keys:     []string{"owner:k1", "partner"},
+			expected: errors.New("api.keys[1] must be <user_id>:<api_key>"),
+		},
+		{
+			it:       "returns error if an entry refers to an unknown user",
+			keys:     []string{"stranger:k1"},
+			expected: errors.New("api.keys[0] refers to the unknown user 'stranger'"),
+		},
+		{
+			it:       "returns error if two entries share a key",
+			keys:     []string{"owner:k1", "partner:k1"},
+			expected: errors.New("api.keys[1] is duplicated"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			err := validateAPIKeys(tc.keys, users)
+			if tc.expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expected.Error())
+			}
+		})
+	}
+}
+
+func TestValidateImports(t *testing.T) {
+	layout := ImportLayoutConfiguration{
+		Name:              "kbank",
+		Account:           "debit1",
+		DateColumn:        "Date",
+		DateFormat:        "02/01/2006",
+		DescriptionColumn: "Details",
+		DebitColumn:       "Withdrawal",
+		CreditColumn:      "Deposit",
+	}
+	testcases := []struct {
+		it       string
+		modify   func(imports *ImportsConfiguration)
+		expected error
+	}{
+		{
+			it:       "returns nil if every layout and rule is valid",
+			modify:   func(imports *ImportsConfiguration) {},
+			expected: nil,
+		},
+		{
+			it: "returns error if two layouts share a name",
+			modify: func(imports *ImportsConfiguration) {
+				imports.Layouts = append(imports.Layouts, layout)
+			},
+			expected: errors.New("imports.layouts[1].name 'kbank' is duplicated"),
+		},
+		{
+			it: "returns error if a layout has no date format",
+			modify: func(imports *ImportsConfiguration) {
+				imports.Layouts[0].DateFormat = ""
+			},
+			expected: errors.New("imports.layouts[0].date_format is missing in the config"),
+		},
+		{
+			it: "returns error if a layout has no amount column",
+			modify: func(imports *ImportsConfiguration) {
+				imports.Layouts[0].CreditColumn = ""
+			},
+			expected: errors.New("imports.layouts[0] needs amount_column, or debit_column and credit_column"),
+		},
+		{
+			it: "returns error if a rule has an invalid pattern",
+			modify: func(imports *ImportsConfiguration) {
+				imports.Rules[0].Pattern = "("
+			},
+			expected: errors.New("imports.rules[0].pattern is invalid: error parsing regexp: missing closing ): `(`"),
+		},
+		{
+			it: "returns error if a rule sets nothing",
+			modify: func(imports *ImportsConfiguration) {
+				imports.Rules[0].Category = ""
+			},
+			expected: errors.New("imports.rules[0] needs a category or an account"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			imports := ImportsConfiguration{
+				Layouts: []ImportLayoutConfiguration{layout},
+				Rules:   []ImportRuleConfiguration{{Pattern: "(?i)starbucks", Category: "fd"}},
+			}
+			tc.modify(&imports)
+
+			err := validateImports(imports)
+			if tc.expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expected.Error())
+			}
+		})
+	}
+}
+
+func TestValidateLedger(t *testing.T) {
+	testcases := []struct {
+		it       string
+		ledger   LedgerConfiguration
+		expected error
+	}{
+		{
+			it: "returns nil if every ledger account is valid",
+			ledger: LedgerConfiguration{
+				Accounts:     map[string]string{"debit1": "assets:bank:debit1", "credit1": "liabilities:credit card"},
+				AssetsPrefix: "assets",
+			},
+			expected: nil,
+		},
+		{
+			it:       "returns error if an account is mapped to nothing",
+			ledger:   LedgerConfiguration{Accounts: map[string]string{"debit1": ""}},
+			expected: errors.New("ledger.accounts.debit1 is empty"),
+		},
+		{
+			it:       "returns error if a ledger account has two spaces",
+			ledger:   LedgerConfiguration{Accounts: map[string]string{"credit1": "liabilities:credit  card"}},
+			expected: errors.New("ledger.accounts.credit1 'liabilities:credit  card' must not contain two spaces, tabs or ';'"),
+		},
+		{
+			it:       "returns error if a prefix has a comment",
+			ledger:   LedgerConfiguration{ExpensesPrefix: "expenses;x"},
+			expected: errors.New("ledger.expenses_prefix 'expenses;x' must not contain two spaces, tabs or ';'"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			err := validateLedger(tc.ledger)
+			if tc.expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expected.Error())
+			}
+		})
+	}
+}
+
+func TestValidateAccounts(t *testing.T) {
+	testcases := []struct {
+		it       string
+		aliases  map[string]string
+		expected error
+	}{
+		{
+			it:       "returns nil if every alias names an account",
+			aliases:  map[string]string{"k": "kbank-savings", "d": "debit1"},
+			expected: nil,
+		},
+		{
+			it:       "returns error if an alias names nothing",
+			aliases:  map[string]string{"k": ""},
+			expected: errors.New("accounts.aliases.k is empty"),
+		},
+		{
+			it:       "returns error if an alias names another alias",
+			aliases:  map[string]string{"k": "kbank-savings", "s": "K"},
+			expected: errors.New("accounts.aliases.s 'K' is an alias itself"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			err := validateAccounts(AccountsConfiguration{Aliases: tc.aliases})
+			if tc.expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expected.Error())
+			}
+		})
+	}
+}
+
+func TestValidateCategories(t *testing.T) {
+	testcases := []struct {
+		it         string
+		categories []CategoryConfiguration
+		expected   error
+	}{
+		{
+			it: "returns nil if the categories are valid",
+			categories: []CategoryConfiguration{
+				{Code: "fd", Name: "Food", Aliases: []string{"food"}},
+				{Code: "gr", Name: "Groceries", Parent: "FD"},
+			},
+			expected: nil,
+		},
+		{
+			it:         "returns error if a code is missing",
+			categories: []CategoryConfiguration{{Name: "Food"}},
+			expected:   errors.New("categories.items[0].code is missing in the config"),
+		},
+		{
+			it:         "returns error if an alias isn't letters",
+			categories: []CategoryConfiguration{{Code: "fd", Aliases: []string{"food-1"}}},
+			expected:   errors.New("categories.items[0].aliases[0] 'food-1' must only contain letters"),
+		},
+		{
+			it:         "returns error if a code starts with k",
+			categories: []CategoryConfiguration{{Code: "Kid"}},
+			expected:   errors.New("categories.items[0].code 'Kid' must not start with k, which multiplies the amount by 1,000"),
+		},
+		{
+			it:         "returns error if an alias starts with k",
+			categories: []CategoryConfiguration{{Code: "fd", Aliases: []string{"kfc"}}},
+			expected:   errors.New("categories.items[0].aliases[0] 'kfc' must not start with k, which multiplies the amount by 1,000"),
+		},
+		{
+			it:         "returns error if an alias is another code",
+			categories: []CategoryConfiguration{{Code: "fd"}, {Code: "sh", Aliases: []string{"FD"}}},
+			expected:   errors.New("categories.items[1].aliases[0] 'FD' is already used by categories.items[0].code"),
+		},
+		{
+			it:         "returns error if a parent is unknown",
+			categories: []CategoryConfiguration{{Code: "gr", Parent: "fd"}},
+			expected:   errors.New("categories.items[0].parent 'fd' isn't a category code"),
+		},
+		{
+			it:         "returns error if the groups make a cycle",
+			categories: []CategoryConfiguration{{Code: "fd", Parent: "gr"}, {Code: "gr", Parent: "fd"}},
+			expected:   errors.New("categories.items[0].parent 'gr' makes a cycle of groups"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			err := validateCategories(CategoriesConfiguration{Items: tc.categories})
+			if tc.expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expected.Error())
+			}
+		})
+	}
+}
+
+func TestValidateDebts(t *testing.T) {
+	assert.NoError(t, validateDebts(DebtsConfiguration{Category: "lent"}))
+	assert.EqualError(t, validateDebts(DebtsConfiguration{Category: ""}), "debts.category '' must only contain letters")
+	assert.EqualError(t, validateDebts(DebtsConfiguration{Category: "lent1"}), "debts.category 'lent1' must only contain letters")
+	assert.EqualError(t, validateDebts(DebtsConfiguration{Category: "kept"}), "debts.category 'kept' must not start with k, which multiplies the amount by 1,000")
+}
+
+func TestValidateConfirmations(t *testing.T) {
+	valid := ConfirmationsConfiguration{Threshold: 10000, AnomalyFactor: 5, Timeout: 5 * time.Minute}
+	assert.NoError(t, validateConfirmations(valid))
+	assert.NoError(t, validateConfirmations(ConfirmationsConfiguration{Timeout: time.Minute}))
+
+	invalid := valid
+	invalid.Threshold = -1
+	assert.EqualError(t, validateConfirmations(invalid), "confirmations.threshold -1 must not be negative")
+	invalid = valid
+	invalid.Accounts = map[string]float64{"debit1": -5}
+	assert.EqualError(t, validateConfirmations(invalid), "confirmations.accounts.debit1 -5 must not be negative")
+	invalid = valid
+	invalid.Categories = map[string]float64{"sh": -5}
+	assert.EqualError(t, validateConfirmations(invalid), "confirmations.categories.sh -5 must not be negative")
+	invalid = valid
+	invalid.AnomalyFactor = 0.5
+	assert.EqualError(t, validateConfirmations(invalid), "confirmations.anomaly_factor 0.5 must be 0 or greater than 1")
+	invalid = valid
+	invalid.Timeout = 0
+	assert.EqualError(t, validateConfirmations(invalid), "confirmations.timeout must be positive")
+}
+
+func TestValidateStorage(t *testing.T) {
+	assert.NoError(t, validateStorage(StorageConfiguration{Driver: "file", Path: "data/state.json", AuditPath: "data/audit.jsonl"}))
+	assert.NoError(t, validateStorage(StorageConfiguration{Driver: "memory"}))
+	assert.EqualError(t, validateStorage(StorageConfiguration{Driver: "file"}), "storage.path is empty")
+	assert.EqualError(t, validateStorage(StorageConfiguration{Driver: "file", Path: "data/state.json"}), "storage.audit_path is empty")
+	assert.EqualError(t, validateStorage(StorageConfiguration{Driver: "bolt", Path: "data/state.db"}), "storage.driver 'bolt' must be file or memory")
+}
+
+func TestValidateTimezone(t *testing.T) {
+	testcases := []struct {
+		it       string
+		timezone string
+		expected error
+	}{
+		{
+			it:       "returns nil if the time zone is an IANA name",
+			timezone: "Asia/Bangkok",
+			expected: nil,
+		},
+		{
+			it:       "returns error if the time zone is empty",
+			timezone: "",
+			expected: errors.New("app.timezone is empty"),
+		},
+		{
+			it:       "returns error if the time zone is unknown",
+			timezone: "Mars/Olympus",
+			expected: errors.New("app.timezone 'Mars/Olympus' is not an IANA time zone: unknown time zone Mars/Olympus"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			err := validateTimezone(tc.timezone)
+			if tc.expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expected.Error())
+			}
+		})
+	}
+}
@@ -1,8 +1,14 @@
 package config
 
 import (
+	"crypto/subtle"
+	"regexp"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/logger"
 	"github.com/spf13/viper"
 )
@@ -12,23 +18,377 @@ var (
 	loadOnce sync.Once
 )
 
+const (
+	ProfileProduction = "production"
+	ProfileDev        = "dev"
+
+	// defaultUserID identifies the user configured through line.user_id
+	defaultUserID = "owner"
+
+	defaultDownloadTTL    = 10 * time.Minute
+	defaultImportCategory = "other"
+
+	defaultLedgerAssetsPrefix   = "assets"
+	defaultLedgerExpensesPrefix = "expenses"
+	defaultLedgerIncomePrefix   = "income"
+
+	defaultTimezone = "Asia/Bangkok"
+
+	defaultDebtCategory = "lent"
+
+	defaultConfirmationAnomalyFactor = 5
+	defaultConfirmationTimeout       = 5 * time.Minute
+
+	StorageDriverFile   = "file"
+	StorageDriverMemory = "memory"
+
+	defaultStoragePath      = "data/state.json"
+	defaultStorageAuditPath = "data/audit.jsonl"
+)
+
 type Configuration struct {
-	App               AppConfiguration  `mapstructure:"app"`
-	Line              LineConfiguration `mapstructure:"line"`
-	FinanceServiceURL string            `mapstructure:"finance_url"`
+	App               AppConfiguration             `mapstructure:"app"`
+	Line              LineConfiguration            `mapstructure:"line"`
+	Users             []UserConfiguration          `mapstructure:"users"`
+	Roles             map[string]RoleConfiguration `mapstructure:"roles"`
+	API               APIConfiguration             `mapstructure:"api"`
+	Downloads         DownloadsConfiguration       `mapstructure:"downloads"`
+	Imports           ImportsConfiguration         `mapstructure:"imports"`
+	Ledger            LedgerConfiguration          `mapstructure:"ledger"`
+	Accounts          AccountsConfiguration        `mapstructure:"accounts"`
+	Categories        CategoriesConfiguration      `mapstructure:"categories"`
+	Debts             DebtsConfiguration           `mapstructure:"debts"`
+	Confirmations     ConfirmationsConfiguration   `mapstructure:"confirmations"`
+	Storage           StorageConfiguration         `mapstructure:"storage"`
+	FinanceServiceURL string                       `mapstructure:"finance_url"`
+	Log               logger.Config                `mapstructure:"log"`
 }
 
 type AppConfiguration struct {
 	Port         string `mapstructure:"port"`
+	GRPCPort     string `mapstructure:"grpc_port"` // the gRPC BotService is only served when set
+	Profile      string `mapstructure:"profile"`
+	TestEnabled  bool   `mapstructure:"test_enabled"`
 	TestUsername string `mapstructure:"test_username"`
+	TestPassword string `mapstructure:"test_password"`
+	AdminToken   string `mapstructure:"admin_token"`
+	PublicURL    string `mapstructure:"public_url"` // the URL of this server, for download links
+	Timezone     string `mapstructure:"timezone"`   // the IANA time zone of relative periods, e.g. "last month"
 }
 
 type LineConfiguration struct {
+	// Deprecated: UserID is the single allowed user from before users were
+	// configurable. It is only used when no users are configured.
 	UserID        string `mapstructure:"user_id"`
 	ChannelSecret string `mapstructure:"channel_secret"`
 	ChannelToken  string `mapstructure:"channel_token"`
 }
 
+type APIConfiguration struct {
+	// Keys are "<user_id>:<api_key>" entries. An API key acts on behalf of its user.
+	Keys []string `mapstructure:"keys"`
+}
+
+type DownloadsConfiguration struct {
+	// TTL is how long a download link works, if it isn't used.
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
+// ImportsConfiguration describes the bank CSV files which can be imported.
+type ImportsConfiguration struct {
+	DefaultCategory string                      `mapstructure:"default_category"`
+	Layouts         []ImportLayoutConfiguration `mapstructure:"layouts"`
+	Rules           []ImportRuleConfiguration   `mapstructure:"rules"`
+}
+
+// ImportLayoutConfiguration names the header columns of a bank's CSV files.
+// Amounts are either in amount_column (negative for withdrawals) or split
+// into debit_column and credit_column.
+type ImportLayoutConfiguration struct {
+	Name              string `mapstructure:"name"`
+	Account           string `mapstructure:"account"`
+	DateColumn        string `mapstructure:"date_column"`
+	DateFormat        string `mapstructure:"date_format"`
+	DescriptionColumn string `mapstructure:"description_column"`
+	AmountColumn      string `mapstructure:"amount_column"`
+	DebitColumn       string `mapstructure:"debit_column"`
+	CreditColumn      string `mapstructure:"credit_column"`
+}
+
+// LedgerConfiguration names the ledger accounts of the journal export.
+// Accounts maps accounts to ledger accounts, e.g. debit1: assets:bank:debit1;
+// the others go under assets_prefix.
+type LedgerConfiguration struct {
+	Accounts       map[string]string `mapstructure:"accounts"`
+	AssetsPrefix   string            `mapstructure:"assets_prefix"`
+	ExpensesPrefix string            `mapstructure:"expenses_prefix"`
+	IncomePrefix   string            `mapstructure:"income_prefix"`
+}
+
+// AccountsConfiguration maps short names to accounts, e.g. k:
+// kbank-savings, which the chat commands accept instead of the accounts.
+type AccountsConfiguration struct {
+	Aliases map[string]string `mapstructure:"aliases"`
+}
+
+// CategoriesConfiguration is the category registry the bot starts with.
+// While it is empty, any category is accepted.
+type CategoriesConfiguration struct {
+	// CreateUnknown registers the unknown categories of the transactions
+	// instead of rejecting them.
+	CreateUnknown bool                    `mapstructure:"create_unknown"`
+	Items         []CategoryConfiguration `mapstructure:"items"`
+}
+
+// CategoryConfiguration names the category typed after the amounts, e.g.
+// code: fd, name: Food. Parent is the code of the group it rolls up into.
+type CategoryConfiguration struct {
+	Code    string   `mapstructure:"code"`
+	Name    string   `mapstructure:"name"`
+	Parent  string   `mapstructure:"parent"`
+	Aliases []string `mapstructure:"aliases"`
+}
+
+// DebtsConfiguration describes how the split expenses are recorded.
+// Category is given to the part others owe, and to their settlements.
+type DebtsConfiguration struct {
+	Category string `mapstructure:"category"`
+}
+
+// ConfirmationsConfiguration sets which transactions sent in chat wait for
+// a yes/no confirmation: those over the threshold of their category, else
+// of their account, else the default one, and those over anomaly_factor
+// times the typical amount of their category. 0 turns either check off.
+type ConfirmationsConfiguration struct {
+	Threshold     float64            `mapstructure:"threshold"`
+	Accounts      map[string]float64 `mapstructure:"accounts"`
+	Categories    map[string]float64 `mapstructure:"categories"`
+	AnomalyFactor float64            `mapstructure:"anomaly_factor"`
+	Timeout       time.Duration      `mapstructure:"timeout"`
+}
+
+// StorageConfiguration selects where the bot keeps its state, e.g. the
+// account aliases or the debts: in the file at path, or in memory, which a
+// restart forgets. The file driver appends the audit log to the file at
+// audit_path.
+type StorageConfiguration struct {
+	Driver    string `mapstructure:"driver"`
+	Path      string `mapstructure:"path"`
+	AuditPath string `mapstructure:"audit_path"`
+}
+
+// ImportRuleConfiguration sets the category and/or the account of the rows
+// whose description matches the regular expression. The first matching rule wins.
+type ImportRuleConfiguration struct {
+	Pattern  string `mapstructure:"pattern"`
+	Category string `mapstructure:"category"`
+	Account  string `mapstructure:"account"`
+}
+
+// UserConfiguration is an entry of the allow-list of users who can talk to the bot.
+type UserConfiguration struct {
+	ID               string `mapstructure:"id"`
+	Name             string `mapstructure:"name"`
+	LineUserID       string `mapstructure:"line_user_id"`
+	AccountNamespace string `mapstructure:"account_namespace"`
+	// Role names an entry of roles. Users without role are granted OwnerRole.
+	Role string `mapstructure:"role"`
+	// DefaultAccount is the account of the chat commands which leave it out.
+	DefaultAccount string `mapstructure:"default_account"`
+}
+
+// RoleConfiguration grants commands (e.g. "!p", "balance" or "*") on the
+// accounts matching the glob patterns (e.g. "shared-*" or "*").
+type RoleConfiguration struct {
+	Commands []string `mapstructure:"commands"`
+	Accounts []string `mapstructure:"accounts"`
+}
+
+// OwnerRole is the built-in role which grants everything, unless
+// redefined in roles.
+const OwnerRole = "owner"
+
+func (c Configuration) toDomainUser(u UserConfiguration) domain.User {
+	return domain.User{
+		ID:               u.ID,
+		Name:             u.Name,
+		LineUserID:       u.LineUserID,
+		AccountNamespace: u.AccountNamespace,
+		Role:             c.role(u.Role),
+	}
+}
+
+func (c Configuration) role(name string) domain.Role {
+	if name == "" {
+		name = OwnerRole
+	}
+	if r, exist := c.Roles[name]; exist {
+		return domain.Role{Name: name, Commands: r.Commands, Accounts: r.Accounts}
+	}
+	if name == OwnerRole {
+		return domain.Role{
+			Name:     OwnerRole,
+			Commands: []string{domain.Wildcard},
+			Accounts: []string{domain.Wildcard},
+		}
+	}
+	// Unknown roles are rejected when the configuration is loaded
+	return domain.Role{Name: name}
+}
+
+// FindUserByLineID returns the allowed user with the given LINE user ID.
+func (c Configuration) FindUserByLineID(lineUserID string) (domain.User, bool) {
+	for _, u := range c.Users {
+		if u.LineUserID != "" && u.LineUserID == lineUserID {
+			return c.toDomainUser(u), true
+		}
+	}
+	return domain.User{}, false
+}
+
+// FindUserByID returns the allowed user with the given ID.
+func (c Configuration) FindUserByID(id string) (domain.User, bool) {
+	for _, u := range c.Users {
+		if u.ID == id {
+			return c.toDomainUser(u), true
+		}
+	}
+	return domain.User{}, false
+}
+
+// AccountNamespaces returns the account namespaces of the users, sorted.
+func (c Configuration) AccountNamespaces() []string {
+	var namespaces []string
+	for _, u := range c.Users {
+		if !slices.Contains(namespaces, u.AccountNamespace) {
+			namespaces = append(namespaces, u.AccountNamespace)
+		}
+	}
+	slices.Sort(namespaces)
+	return namespaces
+}
+
+// FindUserByAPIKey returns the user the given API key acts on behalf of.
+func (c Configuration) FindUserByAPIKey(apiKey string) (domain.User, bool) {
+	if apiKey == "" {
+		return domain.User{}, false
+	}
+	for _, entry := range c.API.Keys {
+		userID, key, _ := strings.Cut(entry, ":")
+		if subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) == 1 {
+			return c.FindUserByID(userID)
+		}
+	}
+	return domain.User{}, false
+}
+
+// ImportConfig returns the import settings. The rules must have been validated.
+func (c Configuration) ImportConfig() domain.ImportConfig {
+	cfg := domain.ImportConfig{
+		DefaultCategory: strings.ToLower(c.Imports.DefaultCategory),
+		Location:        c.Location(),
+	}
+	for _, l := range c.Imports.Layouts {
+		cfg.Layouts = append(cfg.Layouts, domain.ImportLayout{
+			Name:              l.Name,
+			Account:           strings.ToLower(l.Account),
+			DateColumn:        l.DateColumn,
+			DateFormat:        l.DateFormat,
+			DescriptionColumn: l.DescriptionColumn,
+			AmountColumn:      l.AmountColumn,
+			DebitColumn:       l.DebitColumn,
+			CreditColumn:      l.CreditColumn,
+		})
+	}
+	for _, r := range c.Imports.Rules {
+		cfg.Rules = append(cfg.Rules, domain.ImportRule{
+			Pattern:  regexp.MustCompile(r.Pattern),
+			Category: strings.ToLower(r.Category),
+			Account:  strings.ToLower(r.Account),
+		})
+	}
+	return cfg
+}
+
+// LedgerConfig returns the journal export settings.
+func (c Configuration) LedgerConfig() domain.LedgerConfig {
+	accounts := make(map[string]string, len(c.Ledger.Accounts))
+	for account, ledgerAccount := range c.Ledger.Accounts {
+		accounts[strings.ToLower(account)] = ledgerAccount
+	}
+	return domain.LedgerConfig{
+		Accounts:       accounts,
+		AssetsPrefix:   c.Ledger.AssetsPrefix,
+		ExpensesPrefix: c.Ledger.ExpensesPrefix,
+		IncomePrefix:   c.Ledger.IncomePrefix,
+	}
+}
+
+// AccountConfig returns the account aliases and the default account of
+// each user.
+func (c Configuration) AccountConfig() domain.AccountConfig {
+	cfg := domain.AccountConfig{
+		Aliases:  make(map[string]string, len(c.Accounts.Aliases)),
+		Defaults: make(map[string]string),
+	}
+	for alias, account := range c.Accounts.Aliases {
+		cfg.Aliases[strings.ToLower(alias)] = strings.ToLower(account)
+	}
+	for _, u := range c.Users {
+		if u.DefaultAccount != "" {
+			cfg.Defaults[u.ID] = strings.ToLower(u.DefaultAccount)
+		}
+	}
+	return cfg
+}
+
+// CategoryConfig returns the category registry settings. The categories
+// must have been validated.
+func (c Configuration) CategoryConfig() domain.CategoryConfig {
+	cfg := domain.CategoryConfig{CreateUnknown: c.Categories.CreateUnknown}
+	for _, item := range c.Categories.Items {
+		category := domain.Category{
+			Code:   strings.ToLower(item.Code),
+			Name:   item.Name,
+			Parent: strings.ToLower(item.Parent),
+		}
+		if category.Name == "" {
+			category.Name = category.Code
+		}
+		for _, alias := range item.Aliases {
+			category.Aliases = append(category.Aliases, strings.ToLower(alias))
+		}
+		cfg.Categories = append(cfg.Categories, category)
+	}
+	return cfg
+}
+
+// DebtConfig returns the split expense settings.
+func (c Configuration) DebtConfig() domain.DebtConfig {
+	return domain.DebtConfig{Category: strings.ToLower(c.Debts.Category)}
+}
+
+// ConfirmationConfig returns the confirmation settings.
+func (c Configuration) ConfirmationConfig() domain.ConfirmationConfig {
+	return domain.ConfirmationConfig{
+		Threshold:          c.Confirmations.Threshold,
+		AccountThresholds:  c.Confirmations.Accounts,
+		CategoryThresholds: c.Confirmations.Categories,
+		AnomalyFactor:      c.Confirmations.AnomalyFactor,
+		Timeout:            c.Confirmations.Timeout,
+	}
+}
+
+// Location returns the time zone of app.timezone. It is validated on load,
+// UTC is only returned for configurations that weren't loaded.
+func (c Configuration) Location() *time.Location {
+	loc, err := time.LoadLocation(c.App.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
 func Get() Configuration {
 	loadOnce.Do(func() {
 		data = loadConfig()
@@ -59,6 +419,47 @@ func loadConfig() Configuration {
 	if err := viper.BindEnv("app.test_username", "APP_TEST_USERNAME"); err != nil {
 		logger.Fatal("failed to bind APP_TEST_USERNAME env: ", err)
 	}
+	if err := viper.BindEnv("app.test_password", "APP_TEST_PASSWORD"); err != nil {
+		logger.Fatal("failed to bind APP_TEST_PASSWORD env: ", err)
+	}
+	if err := viper.BindEnv("app.test_enabled", "APP_TEST_ENABLED"); err != nil {
+		logger.Fatal("failed to bind APP_TEST_ENABLED env: ", err)
+	}
+	if err := viper.BindEnv("app.grpc_port", "APP_GRPC_PORT"); err != nil {
+		logger.Fatal("failed to bind APP_GRPC_PORT env: ", err)
+	}
+	if err := viper.BindEnv("app.profile", "APP_PROFILE"); err != nil {
+		logger.Fatal("failed to bind APP_PROFILE env: ", err)
+	}
+	if err := viper.BindEnv("app.admin_token", "APP_ADMIN_TOKEN"); err != nil {
+		logger.Fatal("failed to bind APP_ADMIN_TOKEN env: ", err)
+	}
+	if err := viper.BindEnv("app.public_url", "APP_PUBLIC_URL"); err != nil {
+		logger.Fatal("failed to bind APP_PUBLIC_URL env: ", err)
+	}
+	if err := viper.BindEnv("app.timezone", "APP_TIMEZONE"); err != nil {
+		logger.Fatal("failed to bind APP_TIMEZONE env: ", err)
+	}
+	if err := viper.BindEnv("api.keys", "API_KEYS"); err != nil {
+		logger.Fatal("failed to bind API_KEYS env: ", err)
+	}
+	if err := viper.BindEnv("storage.path", "STORAGE_PATH"); err != nil {
+		logger.Fatal("failed to bind STORAGE_PATH env: ", err)
+	}
+	if err := viper.BindEnv("storage.audit_path", "STORAGE_AUDIT_PATH"); err != nil {
+		logger.Fatal("failed to bind STORAGE_AUDIT_PATH env: ", err)
+	}
+	if err := viper.BindEnv("log.level", "LOG_LEVEL"); err != nil {
+		logger.Fatal("failed to bind LOG_LEVEL env: ", err)
+	}
+	if err := viper.BindEnv("log.format", "LOG_FORMAT"); err != nil {
+		logger.Fatal("failed to bind LOG_FORMAT env: ", err)
+	}
+	if err := viper.BindEnv("log.output_paths", "LOG_OUTPUT_PATHS"); err != nil {
+		logger.Fatal("failed to bind LOG_OUTPUT_PATHS env: ", err)
+	}
+
+	setDefaults()
 
 	if err := checkMissingConfig(); err != nil {
 		logger.Fatal(err)
@@ -68,6 +469,78 @@ func loadConfig() Configuration {
 	if err := viper.Unmarshal(&configuration); err != nil {
 		logger.Fatal("failed to unmarshal configuration: ", err)
 	}
+	if len(configuration.Users) == 0 {
+		configuration.Users = []UserConfiguration{{ID: defaultUserID, LineUserID: configuration.Line.UserID}}
+	}
+	if err := validateUsers(configuration.Users, configuration.Roles); err != nil {
+		logger.Fatal(err)
+	}
+	if err := validateAPIKeys(configuration.API.Keys, configuration.Users); err != nil {
+		logger.Fatal(err)
+	}
+	if err := validateImports(configuration.Imports); err != nil {
+		logger.Fatal(err)
+	}
+	if err := validateLedger(configuration.Ledger); err != nil {
+		logger.Fatal(err)
+	}
+	if err := validateAccounts(configuration.Accounts); err != nil {
+		logger.Fatal(err)
+	}
+	if err := validateCategories(configuration.Categories); err != nil {
+		logger.Fatal(err)
+	}
+	if err := validateDebts(configuration.Debts); err != nil {
+		logger.Fatal(err)
+	}
+	if err := validateConfirmations(configuration.Confirmations); err != nil {
+		logger.Fatal(err)
+	}
+	if err := validateStorage(configuration.Storage); err != nil {
+		logger.Fatal(err)
+	}
+	if err := validateTimezone(configuration.App.Timezone); err != nil {
+		logger.Fatal(err)
+	}
+	configuration.Log.Redaction.Secrets = []string{
+		configuration.Line.ChannelSecret,
+		configuration.Line.ChannelToken,
+		configuration.App.TestPassword,
+		configuration.App.AdminToken,
+	}
+	for _, entry := range configuration.API.Keys {
+		_, key, _ := strings.Cut(entry, ":")
+		configuration.Log.Redaction.Secrets = append(configuration.Log.Redaction.Secrets, key)
+	}
 
 	return configuration
 }
+
+// setDefaults must be called after the env bindings since some defaults
+// depend on other settings.
+func setDefaults() {
+	viper.SetDefault("app.profile", ProfileProduction)
+	viper.SetDefault("app.test_enabled", viper.GetString("app.profile") == ProfileDev)
+	viper.SetDefault("app.timezone", defaultTimezone)
+	viper.SetDefault("downloads.ttl", defaultDownloadTTL)
+	viper.SetDefault("imports.default_category", defaultImportCategory)
+	viper.SetDefault("debts.category", defaultDebtCategory)
+	viper.SetDefault("confirmations.anomaly_factor", defaultConfirmationAnomalyFactor)
+	viper.SetDefault("confirmations.timeout", defaultConfirmationTimeout)
+	viper.SetDefault("storage.driver", StorageDriverFile)
+	viper.SetDefault("storage.path", defaultStoragePath)
+	viper.SetDefault("storage.audit_path", defaultStorageAuditPath)
+	viper.SetDefault("ledger.assets_prefix", defaultLedgerAssetsPrefix)
+	viper.SetDefault("ledger.expenses_prefix", defaultLedgerExpensesPrefix)
+	viper.SetDefault("ledger.income_prefix", defaultLedgerIncomePrefix)
+
+	logDefaults := logger.DefaultConfig()
+	viper.SetDefault("log.level", logDefaults.Level)
+	viper.SetDefault("log.format", logDefaults.Format)
+	viper.SetDefault("log.output_paths", logDefaults.OutputPaths)
+	viper.SetDefault("log.rotation.max_size_mb", logDefaults.Rotation.MaxSizeMB)
+	viper.SetDefault("log.rotation.max_age_days", logDefaults.Rotation.MaxAgeDays)
+	viper.SetDefault("log.rotation.max_backups", logDefaults.Rotation.MaxBackups)
+	viper.SetDefault("log.rotation.compress", logDefaults.Rotation.Compress)
+	viper.SetDefault("log.redaction.enabled", viper.GetString("app.profile") != ProfileDev)
+}
@@ -4,8 +4,13 @@ import (
 	"os"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGet(t *testing.T) {
@@ -31,9 +36,241 @@ line:
 	assert.Equal(t, "8080", config.App.Port)
 	assert.Equal(t, "secret", config.Line.ChannelSecret)
 	assert.Equal(t, "token", config.Line.ChannelToken)
+	assert.Equal(t, ProfileProduction, config.App.Profile)
+	assert.False(t, config.App.TestEnabled)
+	assert.Equal(t, 10*time.Minute, config.Downloads.TTL)
+	assert.Equal(t, "Asia/Bangkok", config.Location().String())
+	assert.Equal(t, StorageConfiguration{Driver: StorageDriverFile, Path: "data/state.json", AuditPath: "data/audit.jsonl"}, config.Storage)
+	expectedLog := logger.DefaultConfig()
+	expectedLog.Redaction.Secrets = []string{"secret", "token", "", ""}
+	assert.Equal(t, expectedLog, config.Log)
 	os.Chdir(originalDir)
 }
 
+func TestGet_DevProfile(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	yaml := `
+app:
+  port: "8080"
+  profile: dev
+line:
+  user_id: "line_uid"
+finance_url: "127.0.0.1:8080"
+`
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(tmpDir+"/config.yaml", []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	os.Chdir(tmpDir)
+	defer os.Chdir(originalDir)
+	t.Setenv("LINE_CHANNEL_SECRET", "secret")
+	t.Setenv("LINE_CHANNEL_TOKEN", "token")
+	Reset()
+	defer Reset()
+	defer viper.Reset()
+
+	config := Get()
+
+	assert.Equal(t, ProfileDev, config.App.Profile)
+	assert.False(t, config.Log.Redaction.Enabled)
+	assert.True(t, config.App.TestEnabled)
+	assert.Equal(t, []UserConfiguration{{ID: "owner", LineUserID: "line_uid"}}, config.Users)
+}
+
+func TestFindUser(t *testing.T) {
+	config := Configuration{
+		Users: []UserConfiguration{
+			{ID: "owner", Name: "Owner", LineUserID: "U1", AccountNamespace: "owner"},
+			{ID: "partner", Name: "Partner", LineUserID: "U2", AccountNamespace: "partner", Role: "member"},
+			{ID: "dashboard"},
+		},
+		Roles: map[string]RoleConfiguration{
+			"member": {Commands: []string{"!p", "balance"}, Accounts: []string{"shared-*"}},
+		},
+	}
+	partner := domain.User{
+		ID:               "partner",
+		Name:             "Partner",
+		LineUserID:       "U2",
+		AccountNamespace: "partner",
+		Role: domain.Role{
+			Name:     "member",
+			Commands: []string{"!p", "balance"},
+			Accounts: []string{"shared-*"},
+		},
+	}
+
+	res, ok := config.FindUserByLineID("U2")
+	assert.True(t, ok)
+	assert.Equal(t, partner, res)
+
+	res, ok = config.FindUserByID("partner")
+	assert.True(t, ok)
+	assert.Equal(t, partner, res)
+
+	res, ok = config.FindUserByID("owner")
+	assert.True(t, ok)
+	assert.Equal(t, domain.Role{Name: "owner", Commands: []string{"*"}, Accounts: []string{"*"}}, res.Role, "users without role are owners")
+
+	_, ok = config.FindUserByLineID("")
+	assert.False(t, ok, "users without a line account can't be matched by an empty id")
+	_, ok = config.FindUserByLineID("U3")
+	assert.False(t, ok)
+	_, ok = config.FindUserByID("stranger")
+	assert.False(t, ok)
+}
+
+func TestAccountNamespaces(t *testing.T) {
+	config := Configuration{
+		Users: []UserConfiguration{
+			{ID: "partner", AccountNamespace: "home"},
+			{ID: "owner", AccountNamespace: "owner"},
+			{ID: "spouse", AccountNamespace: "home"},
+		},
+	}
+
+	assert.Equal(t, []string{"home", "owner"}, config.AccountNamespaces())
+}
+
+func TestFindUserByAPIKey(t *testing.T) {
+	config := Configuration{
+		Users: []UserConfiguration{{ID: "owner"}, {ID: "partner", Role: "member"}},
+		Roles: map[string]RoleConfiguration{"member": {Commands: []string{"balance"}}},
+		API:   APIConfiguration{Keys: []string{"owner:k1", "partner:k2"}},
+	}
+
+	res, ok := config.FindUserByAPIKey("k2")
+	assert.True(t, ok)
+	assert.Equal(t, domain.User{ID: "partner", Role: domain.Role{Name: "member", Commands: []string{"balance"}}}, res)
+
+	_, ok = config.FindUserByAPIKey("k3")
+	assert.False(t, ok)
+	_, ok = config.FindUserByAPIKey("")
+	assert.False(t, ok)
+}
+
+func TestGet_APIKeys(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	yaml := `
+app:
+  port: "8080"
+users:
+  - id: owner
+    line_user_id: U1
+finance_url: "127.0.0.1:8080"
+`
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(tmpDir+"/config.yaml", []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	os.Chdir(tmpDir)
+	defer os.Chdir(originalDir)
+	t.Setenv("LINE_CHANNEL_SECRET", "secret")
+	t.Setenv("LINE_CHANNEL_TOKEN", "token")
+	t.Setenv("API_KEYS", "owner:k1,owner:k2")
+	Reset()
+	defer Reset()
+	defer viper.Reset()
+
+	config := Get()
+
+	assert.Equal(t, []string{"owner:k1", "owner:k2"}, config.API.Keys)
+	assert.Subset(t, config.Log.Redaction.Secrets, []string{"k1", "k2"})
+}
+
+func TestImportConfig(t *testing.T) {
+	config := Configuration{
+		Imports: ImportsConfiguration{
+			DefaultCategory: "Other",
+			Layouts: []ImportLayoutConfiguration{
+				{Name: "card", Account: "Credit1", DateColumn: "Date", DateFormat: "2006-01-02", DescriptionColumn: "Merchant", AmountColumn: "Amount"},
+			},
+			Rules: []ImportRuleConfiguration{{Pattern: "(?i)starbucks", Category: "FD"}},
+		},
+	}
+
+	res := config.ImportConfig()
+
+	assert.Equal(t, "other", res.DefaultCategory)
+	assert.Equal(t, []domain.ImportLayout{
+		{Name: "card", Account: "credit1", DateColumn: "Date", DateFormat: "2006-01-02", DescriptionColumn: "Merchant", AmountColumn: "Amount"},
+	}, res.Layouts)
+	require.Len(t, res.Rules, 1)
+	assert.Equal(t, "fd", res.Rules[0].Category)
+	assert.True(t, res.Rules[0].Pattern.MatchString("STARBUCKS SIAM"))
+	assert.Equal(t, time.UTC, res.Location, "the dates are read in app.timezone")
+}
+
+func TestLedgerConfig(t *testing.T) {
+	config := Configuration{
+		Ledger: LedgerConfiguration{
+			Accounts:       map[string]string{"Debit1": "assets:bank:debit1"},
+			AssetsPrefix:   "assets",
+			ExpensesPrefix: "expenses",
+			IncomePrefix:   "revenues",
+		},
+	}
+
+	res := config.LedgerConfig()
+
+	assert.Equal(t, domain.LedgerConfig{
+		Accounts:       map[string]string{"debit1": "assets:bank:debit1"},
+		AssetsPrefix:   "assets",
+		ExpensesPrefix: "expenses",
+		IncomePrefix:   "revenues",
+	}, res)
+}
+
+func TestAccountConfig(t *testing.T) {
+	config := Configuration{
+		Users: []UserConfiguration{
+			{ID: "owner", DefaultAccount: "Debit1"},
+			{ID: "partner"},
+		},
+		Accounts: AccountsConfiguration{Aliases: map[string]string{"k": "KBank-Savings"}},
+	}
+
+	res := config.AccountConfig()
+
+	assert.Equal(t, domain.AccountConfig{
+		Aliases:  map[string]string{"k": "kbank-savings"},
+		Defaults: map[string]string{"owner": "debit1"},
+	}, res)
+}
+
+func TestCategoryConfig(t *testing.T) {
+	config := Configuration{
+		Categories: CategoriesConfiguration{
+			CreateUnknown: true,
+			Items: []CategoryConfiguration{
+				{Code: "FD", Name: "Food", Aliases: []string{"Food"}},
+				{Code: "gr", Parent: "FD"},
+			},
+		},
+	}
+
+	res := config.CategoryConfig()
+
+	assert.Equal(t, domain.CategoryConfig{
+		Categories: []domain.Category{
+			{Code: "fd", Name: "Food", Aliases: []string{"food"}},
+			{Code: "gr", Name: "gr", Parent: "fd"},
+		},
+		CreateUnknown: true,
+	}, res)
+}
+
+func TestDebtConfig(t *testing.T) {
+	config := Configuration{Debts: DebtsConfiguration{Category: "Lent"}}
+
+	assert.Equal(t, domain.DebtConfig{Category: "lent"}, config.DebtConfig())
+}
+
+func TestLocation(t *testing.T) {
+	assert.Equal(t, "America/New_York", Configuration{App: AppConfiguration{Timezone: "America/New_York"}}.Location().String())
+	assert.Equal(t, time.UTC, Configuration{}.Location())
+}
+
 func TestReset(t *testing.T) {
 	loadOnce.Do(func() {
 		data = Configuration{
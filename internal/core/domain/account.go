@@ -0,0 +1,11 @@
+package domain
+
+// AccountConfig is the account shorthand the bot starts with.
+type AccountConfig struct {
+	// Aliases maps a short name to the account it stands for, e.g. k to
+	// kbank-savings.
+	Aliases map[string]string
+	// Defaults maps a user ID to the account of the commands which leave
+	// it out, e.g. "!p 120sh lunch".
+	Defaults map[string]string
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Audit actions are the calls which change the finance service's data,
+// and the commands denied to the caller's role.
+const (
+	AuditWithdraw = "withdraw"
+	AuditDeposit  = "deposit"
+	AuditTransfer = "transfer"
+	AuditUpdate   = "update"
+	AuditDenied   = "denied"
+)
+
+// AuditEntry records a call which changed, or tried to change, the
+// finance service's data, or a command denied to the caller. Entries are chained by hash: Hash covers the
+// entry, including PrevHash, the hash of the entry before it.
+type AuditEntry struct {
+	Seq    int       `json:"seq"`
+	Time   time.Time `json:"time"`
+	UserID string    `json:"user_id"`
+	// Message is the chat message the call was made for, which is empty
+	// for the calls of the APIs.
+	Message string `json:"message,omitempty"`
+	Action  string `json:"action"`
+	// Command and Account are what a denied entry was refused: the
+	// command, and the account it isn't granted, if any.
+	Command     string                    `json:"command,omitempty"`
+	Account     string                    `json:"account,omitempty"`
+	Transaction *TransactionRequest       `json:"transaction,omitempty"`
+	Transfer    *TransferRequest          `json:"transfer,omitempty"`
+	Update      *UpdateTransactionRequest `json:"update,omitempty"`
+	// Balance is the resulting balance of the account, or of the source
+	// account of a transfer, when the call succeeded.
+	Balance    *float64 `json:"balance,omitempty"`
+	StatusCode int      `json:"status_code,omitempty"`
+	Error      string   `json:"error,omitempty"`
+	PrevHash   string   `json:"prev_hash"`
+	Hash       string   `json:"hash"`
+}
+
+// AuditExport is the whole audit log, with the result of its verification.
+type AuditExport struct {
+	Entries []AuditEntry `json:"entries"`
+	// Intact reports whether the hash chain is unbroken. Otherwise BrokenAt
+	// is the sequence number of the first entry which doesn't match.
+	Intact   bool `json:"intact"`
+	BrokenAt int  `json:"broken_at,omitempty"`
+}
+
+type messageContextKey struct{}
+
+// ContextWithMessage returns a copy of ctx carrying the chat message which
+// is being handled, as it was received.
+func ContextWithMessage(ctx context.Context, msg string) context.Context {
+	return context.WithValue(ctx, messageContextKey{}, msg)
+}
+
+// MessageFromContext returns the chat message attached to ctx, if any.
+func MessageFromContext(ctx context.Context) (string, bool) {
+	msg, ok := ctx.Value(messageContextKey{}).(string)
+	return msg, ok
+}
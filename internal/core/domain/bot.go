@@ -1,9 +1,88 @@
 package domain
 
+import "context"
+
 type TextMessageRequest struct {
 	Message string `json:"message"`
 }
 
 type TextMessageResponse struct {
 	ReplyMessage string `json:"message"`
+	// Images are sent after the reply message, e.g. the charts of a statement.
+	Images []Image `json:"images,omitempty"`
+	// QuickReplies are the answers the reply message suggests, e.g. yes and
+	// no, which channels like LINE show as buttons.
+	QuickReplies []string `json:"quick_replies,omitempty"`
+}
+
+// Image is an image published for the reply. PreviewURL is a smaller
+// version, or the same URL.
+type Image struct {
+	URL        string `json:"url"`
+	PreviewURL string `json:"preview_url"`
+}
+
+// ReplyImages collects the images the command handlers attach to the reply.
+type ReplyImages struct {
+	images []Image
+}
+
+// Add attaches the image to the reply.
+func (r *ReplyImages) Add(image Image) {
+	r.images = append(r.images, image)
+}
+
+// Images returns the attached images, in order.
+func (r *ReplyImages) Images() []Image {
+	return r.images
+}
+
+type replyImagesContextKey struct{}
+
+// ContextWithReplyImages returns a copy of ctx collecting reply images.
+// Channels that can show images, like LINE, ask for them this way; command
+// handlers don't render images when ctx carries no collector.
+func ContextWithReplyImages(ctx context.Context) (context.Context, *ReplyImages) {
+	images := &ReplyImages{}
+	return context.WithValue(ctx, replyImagesContextKey{}, images), images
+}
+
+// ReplyImagesFromContext returns the collector attached to ctx by
+// ContextWithReplyImages.
+func ReplyImagesFromContext(ctx context.Context) (*ReplyImages, bool) {
+	images, ok := ctx.Value(replyImagesContextKey{}).(*ReplyImages)
+	return images, ok
+}
+
+// QuickReplies collects the answers the command handlers suggest in the
+// reply.
+type QuickReplies struct {
+	answers []string
+}
+
+// Add suggests the answers.
+func (q *QuickReplies) Add(answers ...string) {
+	q.answers = append(q.answers, answers...)
+}
+
+// Answers returns the suggested answers, in order.
+func (q *QuickReplies) Answers() []string {
+	return q.answers
+}
+
+type quickRepliesContextKey struct{}
+
+// ContextWithQuickReplies returns a copy of ctx collecting quick replies.
+// Channels that can show them as buttons ask for them this way; the reply
+// message always spells the answers out.
+func ContextWithQuickReplies(ctx context.Context) (context.Context, *QuickReplies) {
+	replies := &QuickReplies{}
+	return context.WithValue(ctx, quickRepliesContextKey{}, replies), replies
+}
+
+// QuickRepliesFromContext returns the collector attached to ctx by
+// ContextWithQuickReplies.
+func QuickRepliesFromContext(ctx context.Context) (*QuickReplies, bool) {
+	replies, ok := ctx.Value(quickRepliesContextKey{}).(*QuickReplies)
+	return replies, ok
 }
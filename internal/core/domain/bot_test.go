@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplyImagesFromContext(t *testing.T) {
+	ctx, images := ContextWithReplyImages(context.Background())
+
+	res, ok := ReplyImagesFromContext(ctx)
+	res.Add(Image{URL: "https://example.com/1.png", PreviewURL: "https://example.com/1.png"})
+
+	assert.True(t, ok)
+	assert.Same(t, images, res)
+	assert.Equal(t, []Image{{URL: "https://example.com/1.png", PreviewURL: "https://example.com/1.png"}}, images.Images())
+}
+
+func TestReplyImagesFromContext_Missing(t *testing.T) {
+	res, ok := ReplyImagesFromContext(context.Background())
+
+	assert.False(t, ok)
+	assert.Nil(t, res)
+}
+
+func TestQuickRepliesFromContext(t *testing.T) {
+	ctx, replies := ContextWithQuickReplies(context.Background())
+
+	res, ok := QuickRepliesFromContext(ctx)
+	res.Add("yes", "no")
+
+	assert.True(t, ok)
+	assert.Same(t, replies, res)
+	assert.Equal(t, []string{"yes", "no"}, replies.Answers())
+}
+
+func TestQuickRepliesFromContext_Missing(t *testing.T) {
+	res, ok := QuickRepliesFromContext(context.Background())
+
+	assert.False(t, ok)
+	assert.Nil(t, res)
+}
@@ -0,0 +1,21 @@
+package domain
+
+// Category describes the code typed after the amounts, e.g. "fd" in
+// "!p debit1 120fd".
+type Category struct {
+	Code string
+	// Name is shown instead of the code in statements.
+	Name string
+	// Parent is the code of the group the category rolls up into, if any.
+	Parent string
+	// Aliases can be typed instead of the code.
+	Aliases []string
+}
+
+// CategoryConfig is the category registry the bot starts with.
+type CategoryConfig struct {
+	Categories []Category
+	// CreateUnknown registers the unknown categories of the transactions
+	// instead of rejecting them.
+	CreateUnknown bool
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// ConfirmationConfig describes which transactions sent in chat wait for the
+// user's confirmation before they are sent to the finance service.
+type ConfirmationConfig struct {
+	// Threshold is the amount over which a transaction is held, unless its
+	// category or its account has its own threshold. 0 holds none.
+	Threshold          float64
+	AccountThresholds  map[string]float64
+	CategoryThresholds map[string]float64
+	// AnomalyFactor holds the transactions whose amount is over this many
+	// times the user's typical amount for their category. 0 holds none.
+	AnomalyFactor float64
+	// Timeout is how long a held transaction waits for the confirmation.
+	Timeout time.Duration
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// DebtConfig describes how the shared expenses are recorded.
+type DebtConfig struct {
+	// Category is given to the part of a split expense which others owe,
+	// and to their settlements, so that both cancel out in the statements.
+	Category string
+}
+
+// DebtEntry changes what a person owes the user: a split expense adds to
+// it, and a settlement, whose amount is negative, takes from it.
+type DebtEntry struct {
+	Person      string
+	Amount      float64
+	Description string
+	Date        time.Time
+}
+
+// DebtBalance is what a person owes the user in total.
+type DebtBalance struct {
+	Person string
+	Amount float64
+}
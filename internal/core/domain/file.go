@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// File is a file generated for the user, e.g. an export.
+type File struct {
+	Name        string
+	ContentType string
+	Content     []byte
+}
+
+// PublishedFile is where a published file can be downloaded from, until it expires.
+type PublishedFile struct {
+	URL       string
+	ExpiresAt time.Time
+}
@@ -13,6 +13,8 @@ type TransactionRequest struct {
 	Amount      float64 `json:"amount"`
 	Category    string  `json:"category"`
 	Description string  `json:"description,omitempty"`
+	// Timestamp is when the transaction happened, now when zero.
+	Timestamp time.Time `json:"timestamp,omitzero"`
 }
 
 type TransactionResponse struct {
@@ -21,12 +23,16 @@ type TransactionResponse struct {
 }
 
 func (t *TransactionRequest) ToProto() *pb.TransactionRequest {
-	return &pb.TransactionRequest{
+	req := &pb.TransactionRequest{
 		AccountName: t.Account,
 		Amount:      t.Amount,
 		Category:    t.Category,
 		Description: t.Description,
 	}
+	if !t.Timestamp.IsZero() {
+		req.Timestamp = timestamppb.New(t.Timestamp)
+	}
+	return req
 }
 
 // Transfer
@@ -89,3 +95,99 @@ type CategorizedEntry struct {
 	Category string  `json:"category"`
 	Amount   float64 `json:"amount"`
 }
+
+// GetDetailedStatement
+type GetDetailedStatementResponse struct {
+	Revenue   *GetDetailedStatementSection `json:"revenue"`
+	Expense   *GetDetailedStatementSection `json:"expense"`
+	Transfers []TransferEntry              `json:"transfers"`
+	Profit    float64                      `json:"profit"`
+}
+
+type GetDetailedStatementSection struct {
+	Total   float64 `json:"total"`
+	Entries []Entry `json:"entries"`
+}
+
+type Entry struct {
+	Timestamp   time.Time `json:"timestamp"`
+	Account     string    `json:"account"`
+	Category    string    `json:"category"`
+	Amount      float64   `json:"amount"`
+	Description string    `json:"description"`
+}
+
+type TransferEntry struct {
+	Timestamp   time.Time `json:"timestamp"`
+	FromAccount string    `json:"from_account"`
+	ToAccount   string    `json:"to_account"`
+	Amount      float64   `json:"amount"`
+	Description string    `json:"description"`
+}
+
+// ListTransactions
+type ListTransactionsRequest struct {
+	PageSize int `json:"page_size"`
+	// PageToken is the NextPageToken of the previous page, empty for the
+	// first page.
+	PageToken string `json:"page_token,omitempty"`
+}
+
+func (l *ListTransactionsRequest) ToProto() *pb.ListTransactionsRequest {
+	return &pb.ListTransactionsRequest{
+		PageSize:  int32(l.PageSize),
+		PageToken: l.PageToken,
+	}
+}
+
+type ListTransactionsResponse struct {
+	// Transactions are ordered newest first.
+	Transactions []Transaction `json:"transactions"`
+	// NextPageToken is empty on the last page.
+	NextPageToken string `json:"next_page_token,omitempty"`
+}
+
+// Transaction types
+const (
+	TransactionWithdrawal = "withdrawal"
+	TransactionDeposit    = "deposit"
+	TransactionTransfer   = "transfer"
+)
+
+// Transaction is a withdrawal, a deposit or a transfer recorded by the
+// finance service. Account is the source account of a transfer, which has
+// no category.
+type Transaction struct {
+	ID          string    `json:"id"`
+	Type        string    `json:"type"`
+	Timestamp   time.Time `json:"timestamp"`
+	Account     string    `json:"account"`
+	ToAccount   string    `json:"to_account,omitempty"`
+	Category    string    `json:"category,omitempty"`
+	Amount      float64   `json:"amount"`
+	Description string    `json:"description"`
+}
+
+// UpdateTransaction
+// UpdateTransactionRequest patches the fields of the transaction which are
+// set. Transfers have no category.
+type UpdateTransactionRequest struct {
+	ID          string   `json:"id"`
+	Category    *string  `json:"category,omitempty"`
+	Amount      *float64 `json:"amount,omitempty"`
+	Description *string  `json:"description,omitempty"`
+}
+
+func (u *UpdateTransactionRequest) ToProto() *pb.UpdateTransactionRequest {
+	return &pb.UpdateTransactionRequest{
+		Id:          u.ID,
+		Category:    u.Category,
+		Amount:      u.Amount,
+		Description: u.Description,
+	}
+}
+
+type UpdateTransactionResponse struct {
+	Before Transaction `json:"before"`
+	After  Transaction `json:"after"`
+}
@@ -28,6 +28,15 @@ func TestTransactionRequestToProto(t *testing.T) {
 	assert.Equal(t, expected, res)
 }
 
+func TestTransactionRequestToProto_Timestamp(t *testing.T) {
+	timestamp := time.Date(2025, 1, 3, 0, 0, 0, 0, time.FixedZone("ICT", 7*60*60))
+	req := &TransactionRequest{Account: "credit1", Amount: 150, Category: "fd", Timestamp: timestamp}
+
+	res := req.ToProto()
+
+	assert.Equal(t, timestamppb.New(timestamp), res.Timestamp)
+}
+
 func TestTransferRequestToProto(t *testing.T) {
 	req := &TransferRequest{
 		FromAccount: "debit2",
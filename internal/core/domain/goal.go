@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// Goal is an amount a user saves up on an account by a deadline.
+type Goal struct {
+	Name    string
+	Account string
+	Target  float64
+	// StartBalance is the account's balance when the goal was set, from
+	// which the expected progress is measured.
+	StartBalance float64
+	Created      time.Time
+	// Deadline is the end of the goal's last day.
+	Deadline time.Time
+}
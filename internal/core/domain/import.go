@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"regexp"
+	"time"
+)
+
+// ImportConfig describes the bank CSV files which can be imported.
+type ImportConfig struct {
+	// DefaultCategory is given to the rows which no rule matches.
+	DefaultCategory string
+	Layouts         []ImportLayout
+	Rules           []ImportRule
+	// Location is the time zone of the dates in the files.
+	Location *time.Location
+}
+
+// ImportLayout names the columns of a bank's CSV header. A file is parsed
+// with the first layout whose columns are all in its header.
+type ImportLayout struct {
+	Name string
+	// Account is the account of the rows, unless a rule says otherwise.
+	Account           string
+	DateColumn        string
+	DateFormat        string
+	DescriptionColumn string
+	// AmountColumn holds signed amounts, negative ones being withdrawals.
+	// Layouts without it have separate DebitColumn and CreditColumn.
+	AmountColumn string
+	DebitColumn  string
+	CreditColumn string
+}
+
+// ImportRule sets the category and/or the account of the rows whose
+// description matches Pattern.
+type ImportRule struct {
+	Pattern  *regexp.Regexp
+	Category string
+	Account  string
+}
+
+type TransactionType string
+
+const (
+	TransactionTypeWithdraw TransactionType = "withdraw"
+	TransactionTypeDeposit  TransactionType = "deposit"
+)
+
+type ImportRow struct {
+	Line        int             `json:"line"`
+	Date        time.Time       `json:"date"`
+	Description string          `json:"description"`
+	Type        TransactionType `json:"type"`
+	Account     string          `json:"account"`
+	Category    string          `json:"category"`
+	Amount      float64         `json:"amount"`
+	// Categorized tells whether a rule gave the row its category.
+	Categorized bool `json:"categorized"`
+	// Duplicate rows were already imported and are skipped.
+	Duplicate bool `json:"duplicate"`
+}
+
+// ImportPreview is a parsed file waiting for the user's confirmation.
+type ImportPreview struct {
+	Layout  string      `json:"layout"`
+	Rows    []ImportRow `json:"rows"`
+	Summary string      `json:"summary"`
+}
@@ -0,0 +1,13 @@
+package domain
+
+// LedgerConfig names the ledger accounts of the journal export.
+type LedgerConfig struct {
+	// Accounts maps an account to its ledger account, e.g. debit1 to
+	// assets:bank:debit1. Other accounts go under AssetsPrefix.
+	Accounts map[string]string
+	// AssetsPrefix, ExpensesPrefix and IncomePrefix are the parents of the
+	// unmapped accounts, the expense categories and the revenue categories.
+	AssetsPrefix   string
+	ExpensesPrefix string
+	IncomePrefix   string
+}
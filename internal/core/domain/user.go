@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"context"
+	"path"
+	"slices"
+)
+
+// Wildcard grants every command or account of a role.
+const Wildcard = "*"
+
+// User is a person allowed to talk to the bot.
+type User struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	LineUserID string `json:"-"`
+	// AccountNamespace scopes the user's accounts in the finance service.
+	AccountNamespace string `json:"account_namespace"`
+	Role             Role   `json:"role"`
+}
+
+// Role grants a set of commands on a set of accounts.
+type Role struct {
+	Name string `json:"name"`
+	// Commands are command names (e.g. "!p", "balance") or Wildcard.
+	Commands []string `json:"commands"`
+	// Accounts are glob patterns (e.g. "shared-*") of the accounts the role can use.
+	Accounts []string `json:"accounts"`
+}
+
+// CanRun reports whether the role grants the command.
+func (r Role) CanRun(command string) bool {
+	return slices.Contains(r.Commands, Wildcard) || slices.Contains(r.Commands, command)
+}
+
+// CanAccessAll reports whether the role grants every account, as it must
+// for what totals them all, e.g. the overview statements.
+func (r Role) CanAccessAll() bool {
+	return slices.Contains(r.Accounts, Wildcard)
+}
+
+// CanAccess reports whether the account matches one of the role's account patterns.
+func (r Role) CanAccess(account string) bool {
+	for _, pattern := range r.Accounts {
+		if matched, _ := path.Match(pattern, account); matched {
+			return true
+		}
+	}
+	return false
+}
+
+type userContextKey struct{}
+
+// ContextWithUser returns a copy of ctx carrying the user on whose behalf the request is made.
+func ContextWithUser(ctx context.Context, user User) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// UserFromContext returns the user attached to ctx by ContextWithUser.
+func UserFromContext(ctx context.Context) (User, bool) {
+	user, ok := ctx.Value(userContextKey{}).(User)
+	return user, ok
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserFromContext(t *testing.T) {
+	user := User{ID: "owner", Name: "Owner", LineUserID: "U1", AccountNamespace: "owner"}
+	ctx := ContextWithUser(context.Background(), user)
+
+	res, ok := UserFromContext(ctx)
+
+	assert.True(t, ok)
+	assert.Equal(t, user, res)
+}
+
+func TestUserFromContext_Missing(t *testing.T) {
+	res, ok := UserFromContext(context.Background())
+
+	assert.False(t, ok)
+	assert.Equal(t, User{}, res)
+}
+
+func TestRoleCanRun(t *testing.T) {
+	testcases := []struct {
+		it       string
+		role     Role
+		command  string
+		expected bool
+	}{
+		{
+			it:       "returns true for a granted command",
+			role:     Role{Commands: []string{"!p", "balance"}},
+			command:  "balance",
+			expected: true,
+		},
+		{
+			it:       "returns true for any command when the role has the wildcard",
+			role:     Role{Commands: []string{"*"}},
+			command:  "!t",
+			expected: true,
+		},
+		{
+			it:       "returns false for a command which isn't granted",
+			role:     Role{Commands: []string{"!p", "balance"}},
+			command:  "!t",
+			expected: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.role.CanRun(tc.command))
+		})
+	}
+}
+
+func TestRoleCanAccess(t *testing.T) {
+	testcases := []struct {
+		it       string
+		role     Role
+		account  string
+		expected bool
+	}{
+		{
+			it:       "returns true for an account matching a pattern",
+			role:     Role{Accounts: []string{"debit1", "shared-*"}},
+			account:  "shared-kbank",
+			expected: true,
+		},
+		{
+			it:       "returns true for any account when the role has the wildcard",
+			role:     Role{Accounts: []string{"*"}},
+			account:  "debit1",
+			expected: true,
+		},
+		{
+			it:       "returns false for an account matching no pattern",
+			role:     Role{Accounts: []string{"shared-*"}},
+			account:  "debit1",
+			expected: false,
+		},
+		{
+			it:       "returns false when the role has no account",
+			role:     Role{},
+			account:  "debit1",
+			expected: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.role.CanAccess(tc.account))
+		})
+	}
+}
+
+func TestRoleCanAccessAll(t *testing.T) {
+	assert.True(t, Role{Accounts: []string{"debit1", "*"}}.CanAccessAll())
+	assert.False(t, Role{Accounts: []string{"shared-*"}}.CanAccessAll())
+	assert.False(t, Role{}.CanAccessAll())
+}
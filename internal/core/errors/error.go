@@ -17,6 +17,14 @@ func BadRequestError(msg string) *AppError {
 	return &AppError{StatusCode: http.StatusBadRequest, Message: msg}
 }
 
+func UnauthorizedError(msg string) *AppError {
+	return &AppError{StatusCode: http.StatusUnauthorized, Message: msg}
+}
+
+func ForbiddenError(msg string) *AppError {
+	return &AppError{StatusCode: http.StatusForbidden, Message: msg}
+}
+
 func NotFoundError(msg string) *AppError {
 	return &AppError{StatusCode: http.StatusNotFound, Message: msg}
 }
@@ -18,6 +18,18 @@ func TestBadRequestError(t *testing.T) {
 	assert.Equal(t, "Bad request", err.Message)
 }
 
+func TestUnauthorizedError(t *testing.T) {
+	err := UnauthorizedError("Unauthorized")
+	assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
+	assert.Equal(t, "Unauthorized", err.Message)
+}
+
+func TestForbiddenError(t *testing.T) {
+	err := ForbiddenError("Forbidden")
+	assert.Equal(t, http.StatusForbidden, err.StatusCode)
+	assert.Equal(t, "Forbidden", err.Message)
+}
+
 func TestNotFoundError(t *testing.T) {
 	err := NotFoundError("Not found")
 	assert.Equal(t, http.StatusNotFound, err.StatusCode)
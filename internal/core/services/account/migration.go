@@ -0,0 +1,28 @@
+package account
+
+import (
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+)
+
+// KeyByNamespace returns the migration which moves the account aliases,
+// shared by all the users, to each of the namespaces, see aliasKey, so
+// that they resolve as before until edited.
+func KeyByNamespace(namespaces []string) func(tx storage.Tx) error {
+	return func(tx storage.Tx) error {
+		for _, alias := range tx.Keys(aliasCollection.Name()) {
+			account, _, err := aliasCollection.Get(tx, alias)
+			if err != nil {
+				return err
+			}
+			if err := aliasCollection.Delete(tx, alias); err != nil {
+				return err
+			}
+			for _, namespace := range namespaces {
+				if err := aliasCollection.Put(tx, namespace+"/"+alias, account); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,36 @@
+package account
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyByNamespace(t *testing.T) {
+	store := memory.NewStore()
+	require.NoError(t, store.Update(context.Background(), func(tx storage.Tx) error {
+		if err := aliasCollection.Put(tx, "k", "kbank-savings"); err != nil {
+			return err
+		}
+		return aliasCollection.Put(tx, "s", "shared-kbank")
+	}))
+
+	err := store.Update(context.Background(), KeyByNamespace([]string{"home", "owner"}))
+
+	require.NoError(t, err)
+	require.NoError(t, store.View(context.Background(), func(tx storage.Tx) error {
+		all, err := aliasCollection.All(tx)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"home/k":  "kbank-savings",
+			"home/s":  "shared-kbank",
+			"owner/k": "kbank-savings",
+			"owner/s": "shared-kbank",
+		}, all)
+		return nil
+	}))
+}
@@ -0,0 +1,249 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/permission"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/client"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+)
+
+const invalidAccountMsg = "Invalid command's arguments.\nPlease recheck the syntax (account [list], account alias <alias> <account> or account default <account>)"
+
+var (
+	aliasCollection   = storage.NewCollection[string]("account_aliases")  // by aliasKey
+	defaultCollection = storage.NewCollection[string]("default_accounts") // by user ID
+)
+
+// Service keeps the account aliases, e.g. k for kbank-savings, and the
+// default account of each user, which the finance commands fall back on.
+// It is edited through the "account" command, which only accepts the
+// accounts the finance service knows.
+//
+// The aliases set through the command are kept by account namespace, the
+// users of a namespace sharing them, while the configured ones are shared
+// by all the users. What is set through the command is stored, and
+// overrides the configured settings.
+type Service struct {
+	client client.FinanceServiceClient
+	store  storage.Store
+
+	aliases  map[string]string
+	defaults map[string]string // by user ID
+}
+
+// NewService constructs the account registry.
+func NewService(client client.FinanceServiceClient, cfg domain.AccountConfig, store storage.Store) *Service {
+	return &Service{
+		client:   client,
+		store:    store,
+		aliases:  maps.Clone(cfg.Aliases),
+		defaults: maps.Clone(cfg.Defaults),
+	}
+}
+
+// Resolve returns the account the name stands for, which is the name
+// itself unless it's an alias in the namespace of the user attached to ctx.
+func (s *Service) Resolve(ctx context.Context, name string) (string, *errors.AppError) {
+	user, _ := domain.UserFromContext(ctx)
+	account, exist := s.aliases[name]
+	err := s.store.View(ctx, func(tx storage.Tx) error {
+		stored, found, err := aliasCollection.Get(tx, aliasKey(user, name))
+		if found {
+			account, exist = stored, true
+		}
+		return err
+	})
+	if err != nil {
+		logger.Ctx(ctx).Errorw("failed to read account alias", "alias", name, "error", err)
+		return "", errors.InternalServerError("cannot read the account aliases")
+	}
+	if !exist {
+		return name, nil
+	}
+	return account, nil
+}
+
+// Exists reports whether the finance service knows the account.
+func (s *Service) Exists(ctx context.Context, account string) (bool, *errors.AppError) {
+	accounts, err := s.accounts(ctx)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(accounts, account), nil
+}
+
+// Default returns the default account of the user attached to ctx, which
+// is empty when they have none.
+func (s *Service) Default(ctx context.Context) (string, *errors.AppError) {
+	user, ok := domain.UserFromContext(ctx)
+	if !ok {
+		return "", nil
+	}
+	account := s.defaults[user.ID]
+	err := s.store.View(ctx, func(tx storage.Tx) error {
+		stored, found, err := defaultCollection.Get(tx, user.ID)
+		if found {
+			account = stored
+		}
+		return err
+	})
+	if err != nil {
+		logger.Ctx(ctx).Errorw("failed to read default account", "error", err)
+		return "", errors.InternalServerError("cannot read the default account")
+	}
+	return account, nil
+}
+
+func (s *Service) Match(cmd string) bool {
+	return cmd == "account"
+}
+
+// Handle serves "account [list]", "account alias <alias> <account>" and
+// "account default <account>".
+func (s *Service) Handle(ctx context.Context, tokenizedMsg []string) (string, *errors.AppError) {
+	switch {
+	case len(tokenizedMsg) < 2 || (tokenizedMsg[1] == "list" && len(tokenizedMsg) == 2):
+		return s.list(ctx)
+	case tokenizedMsg[1] == "alias" && len(tokenizedMsg) == 4:
+		return s.alias(ctx, tokenizedMsg[2], tokenizedMsg[3])
+	case tokenizedMsg[1] == "default" && len(tokenizedMsg) == 3:
+		return s.setDefault(ctx, tokenizedMsg[2])
+	default:
+		return "", errors.BadRequestError(invalidAccountMsg)
+	}
+}
+
+// list prints the accounts the caller's role grants, with their aliases.
+func (s *Service) list(ctx context.Context) (string, *errors.AppError) {
+	accounts, err := s.accounts(ctx)
+	if err != nil {
+		return "", err
+	}
+	user, _ := domain.UserFromContext(ctx)
+	defaultAccount, err := s.Default(ctx)
+	if err != nil {
+		return "", err
+	}
+	targets := maps.Clone(s.aliases)
+	if err := s.store.View(ctx, func(tx storage.Tx) error {
+		stored, err := aliasCollection.All(tx)
+		for key, account := range stored {
+			if alias, found := strings.CutPrefix(key, aliasKey(user, "")); found {
+				targets[alias] = account
+			}
+		}
+		return err
+	}); err != nil {
+		logger.Ctx(ctx).Errorw("failed to read account aliases", "error", err)
+		return "", errors.InternalServerError("cannot read the account aliases")
+	}
+
+	aliases := make(map[string][]string)
+	for _, alias := range slices.Sorted(maps.Keys(targets)) {
+		aliases[targets[alias]] = append(aliases[targets[alias]], alias)
+	}
+	var sb strings.Builder
+	sb.WriteString("Accounts\n================")
+	for _, account := range accounts {
+		if !user.Role.CanAccess(account) {
+			continue
+		}
+		sb.WriteString("\n" + account)
+		if len(aliases[account]) > 0 {
+			sb.WriteString(fmt.Sprintf(" (%s)", strings.Join(aliases[account], ", ")))
+		}
+		if defaultAccount == account {
+			sb.WriteString(" - default")
+		}
+	}
+	return sb.String(), nil
+}
+
+func (s *Service) alias(ctx context.Context, alias, name string) (string, *errors.AppError) {
+	accounts, err := s.accounts(ctx)
+	if err != nil {
+		return "", err
+	}
+	if slices.Contains(accounts, alias) {
+		return "", errors.BadRequestError(fmt.Sprintf("'%s' is already an account", alias))
+	}
+	account, err := s.validate(ctx, accounts, name)
+	if err != nil {
+		return "", err
+	}
+
+	user, _ := domain.UserFromContext(ctx)
+
+	if err := s.store.Update(ctx, func(tx storage.Tx) error {
+		return aliasCollection.Put(tx, aliasKey(user, alias), account)
+	}); err != nil {
+		logger.Ctx(ctx).Errorw("failed to save account alias", "alias", alias, "error", err)
+		return "", errors.InternalServerError("cannot save the alias")
+	}
+	logger.Ctx(ctx).Infow("added account alias", "account", account, "alias", alias)
+	return fmt.Sprintf("'%s' is now an alias of '%s'", alias, account), nil
+}
+
+func (s *Service) setDefault(ctx context.Context, name string) (string, *errors.AppError) {
+	accounts, err := s.accounts(ctx)
+	if err != nil {
+		return "", err
+	}
+	account, err := s.validate(ctx, accounts, name)
+	if err != nil {
+		return "", err
+	}
+	user, _ := domain.UserFromContext(ctx)
+
+	if err := s.store.Update(ctx, func(tx storage.Tx) error {
+		return defaultCollection.Put(tx, user.ID, account)
+	}); err != nil {
+		logger.Ctx(ctx).Errorw("failed to save default account", "error", err)
+		return "", errors.InternalServerError("cannot save the default account")
+	}
+	logger.Ctx(ctx).Infow("set default account", "account", account)
+	return fmt.Sprintf("'%s' is now your default account", account), nil
+}
+
+// aliasKey is the key of the user's alias, which is stored in their
+// account namespace.
+func aliasKey(user domain.User, alias string) string {
+	return user.AccountNamespace + "/" + alias
+}
+
+// validate resolves the name, and checks that the account exists and that
+// the caller's role grants it.
+func (s *Service) validate(ctx context.Context, accounts []string, name string) (string, *errors.AppError) {
+	account, err := s.Resolve(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if !slices.Contains(accounts, account) {
+		return "", errors.NotFoundError(fmt.Sprintf("Unknown account '%s'", name))
+	}
+	if err := permission.CheckCaller(ctx, "account", account); err != nil {
+		return "", err
+	}
+	return account, nil
+}
+
+// accounts returns the accounts of the finance service.
+func (s *Service) accounts(ctx context.Context) ([]string, *errors.AppError) {
+	res, err := s.client.GetBalance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]string, 0, len(res.Accounts))
+	for _, v := range res.Accounts {
+		accounts = append(accounts, v.Account)
+	}
+	return accounts, nil
+}
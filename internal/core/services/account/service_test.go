@@ -0,0 +1,241 @@
+package account
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	owner = domain.User{
+		ID:   "owner",
+		Role: domain.Role{Name: "owner", Commands: []string{"*"}, Accounts: []string{"*"}},
+	}
+	member = domain.User{
+		ID:   "partner",
+		Role: domain.Role{Name: "member", Commands: []string{"account"}, Accounts: []string{"shared-*"}},
+	}
+	testAccountConfig = domain.AccountConfig{
+		Aliases:  map[string]string{"k": "kbank-savings", "s": "shared-kbank"},
+		Defaults: map[string]string{"owner": "debit1"},
+	}
+	balance = &domain.GetBalanceResponse{
+		Accounts: []domain.AccountBalance{
+			{Account: "debit1", Balance: 1000},
+			{Account: "kbank-savings", Balance: 50000},
+			{Account: "shared-kbank", Balance: 2000},
+		},
+	}
+)
+
+func TestResolve(t *testing.T) {
+	service := NewService(nil, testAccountConfig, memory.NewStore())
+
+	account, err := service.Resolve(context.Background(), "k")
+	assert.Nil(t, err)
+	assert.Equal(t, "kbank-savings", account)
+	account, err = service.Resolve(context.Background(), "debit1")
+	assert.Nil(t, err)
+	assert.Equal(t, "debit1", account)
+}
+
+func TestExists(t *testing.T) {
+	client := mocks.NewMockFinanceServiceClient(t)
+	client.EXPECT().GetBalance(mock.Anything).Return(balance, nil)
+	service := NewService(client, testAccountConfig, memory.NewStore())
+
+	exist, err := service.Exists(context.Background(), "debit1")
+	assert.Nil(t, err)
+	assert.True(t, exist)
+	exist, err = service.Exists(context.Background(), "k")
+	assert.Nil(t, err)
+	assert.False(t, exist)
+}
+
+func TestDefault(t *testing.T) {
+	service := NewService(nil, testAccountConfig, memory.NewStore())
+
+	account, err := service.Default(domain.ContextWithUser(context.Background(), owner))
+	assert.Nil(t, err)
+	assert.Equal(t, "debit1", account)
+
+	account, err = service.Default(domain.ContextWithUser(context.Background(), member))
+	assert.Nil(t, err)
+	assert.Empty(t, account)
+	account, err = service.Default(context.Background())
+	assert.Nil(t, err)
+	assert.Empty(t, account)
+}
+
+func TestHandle(t *testing.T) {
+	testcases := []struct {
+		it           string
+		user         domain.User
+		msg          []string
+		expected     string
+		expectedList string
+	}{
+		{
+			it:           "lists the accounts with their aliases and the default account",
+			user:         owner,
+			msg:          []string{"list"},
+			expected:     "Accounts\n================\ndebit1 - default\nkbank-savings (k)\nshared-kbank (s)",
+			expectedList: "Accounts\n================\ndebit1 - default\nkbank-savings (k)\nshared-kbank (s)",
+		},
+		{
+			it:           "only lists the accounts the role grants",
+			user:         member,
+			msg:          []string{},
+			expected:     "Accounts\n================\nshared-kbank (s)",
+			expectedList: "Accounts\n================\nshared-kbank (s)",
+		},
+		{
+			it:           "adds an alias",
+			user:         owner,
+			msg:          []string{"alias", "d", "debit1"},
+			expected:     "'d' is now an alias of 'debit1'",
+			expectedList: "Accounts\n================\ndebit1 (d) - default\nkbank-savings (k)\nshared-kbank (s)",
+		},
+		{
+			it:           "sets the default account by alias",
+			user:         member,
+			msg:          []string{"default", "s"},
+			expected:     "'shared-kbank' is now your default account",
+			expectedList: "Accounts\n================\nshared-kbank (s) - default",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			client := mocks.NewMockFinanceServiceClient(t)
+			client.EXPECT().GetBalance(mock.Anything).Return(balance, nil)
+			service := NewService(client, testAccountConfig, memory.NewStore())
+			ctx := domain.ContextWithUser(context.Background(), tc.user)
+
+			res, err := service.Handle(ctx, append([]string{"account"}, tc.msg...))
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, res)
+
+			list, err := service.Handle(ctx, []string{"account"})
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedList, list)
+		})
+	}
+}
+
+func TestHandle_Error(t *testing.T) {
+	testcases := []struct {
+		it          string
+		user        domain.User
+		msg         []string
+		mock        func(client *mocks.MockFinanceServiceClient)
+		expectedErr *errors.AppError
+	}{
+		{
+			it:          "returns error when the action is unknown",
+			user:        owner,
+			msg:         []string{"remove", "k"},
+			expectedErr: errors.BadRequestError(invalidAccountMsg),
+		},
+		{
+			it:   "returns error when the account is unknown",
+			user: owner,
+			msg:  []string{"default", "credit1"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().GetBalance(mock.Anything).Return(balance, nil)
+			},
+			expectedErr: errors.NotFoundError("Unknown account 'credit1'"),
+		},
+		{
+			it:   "returns error when the alias is an account",
+			user: owner,
+			msg:  []string{"alias", "debit1", "kbank-savings"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().GetBalance(mock.Anything).Return(balance, nil)
+			},
+			expectedErr: errors.BadRequestError("'debit1' is already an account"),
+		},
+		{
+			it:   "returns error when the role doesn't grant the account",
+			user: member,
+			msg:  []string{"alias", "d", "debit1"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().GetBalance(mock.Anything).Return(balance, nil)
+			},
+			expectedErr: errors.ForbiddenError("You are not permitted to use the account 'debit1'"),
+		},
+		{
+			it:   "returns error when the balance can't be fetched",
+			user: owner,
+			msg:  []string{"list"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().GetBalance(mock.Anything).Return(nil, errors.InternalServerError("failed to get balance"))
+			},
+			expectedErr: errors.InternalServerError("failed to get balance"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			client := mocks.NewMockFinanceServiceClient(t)
+			if tc.mock != nil {
+				tc.mock(client)
+			}
+			service := NewService(client, testAccountConfig, memory.NewStore())
+			ctx := domain.ContextWithUser(context.Background(), tc.user)
+
+			res, err := service.Handle(ctx, append([]string{"account"}, tc.msg...))
+
+			assert.Empty(t, res)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestHandle_Stored(t *testing.T) {
+	client := mocks.NewMockFinanceServiceClient(t)
+	client.EXPECT().GetBalance(mock.Anything).Return(balance, nil)
+	store := memory.NewStore()
+	ctx := domain.ContextWithUser(context.Background(), owner)
+	_, err := NewService(client, testAccountConfig, store).Handle(ctx, []string{"account", "alias", "k", "debit1"})
+	assert.Nil(t, err)
+	_, err = NewService(client, testAccountConfig, store).Handle(ctx, []string{"account", "default", "kbank-savings"})
+	assert.Nil(t, err)
+
+	// The stored settings outlive the service, and override the configured ones
+	service := NewService(client, testAccountConfig, store)
+	account, err := service.Resolve(ctx, "k")
+	assert.Nil(t, err)
+	assert.Equal(t, "debit1", account)
+	account, err = service.Default(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "kbank-savings", account)
+}
+
+func TestHandle_Namespaces(t *testing.T) {
+	client := mocks.NewMockFinanceServiceClient(t)
+	client.EXPECT().GetBalance(mock.Anything).Return(balance, nil)
+	service := NewService(client, testAccountConfig, memory.NewStore())
+	_, err := service.Handle(domain.ContextWithUser(context.Background(), owner), []string{"account", "alias", "d", "debit1"})
+	require.Nil(t, err)
+
+	// The aliases of another namespace are only names, but the configured ones are shared
+	stranger := domain.User{ID: "stranger", AccountNamespace: "stranger", Role: owner.Role}
+	ctx := domain.ContextWithUser(context.Background(), stranger)
+	account, err := service.Resolve(ctx, "d")
+	assert.Nil(t, err)
+	assert.Equal(t, "d", account)
+	account, err = service.Resolve(ctx, "k")
+	assert.Nil(t, err)
+	assert.Equal(t, "kbank-savings", account)
+	list, err := service.Handle(ctx, []string{"account"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Accounts\n================\ndebit1\nkbank-savings (k)\nshared-kbank (s)", list)
+}
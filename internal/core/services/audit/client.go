@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/client"
+)
+
+// auditedClient records the withdrawals, deposits, transfers and updates
+// sent to the finance service, whatever command or API sends them, in the log.
+type auditedClient struct {
+	client.FinanceServiceClient
+	log *Log
+}
+
+// NewClient wraps the finance service client so that its calls which
+// change data are recorded in the log. A failing record doesn't fail the
+// call, whose money has already moved.
+func NewClient(next client.FinanceServiceClient, log *Log) client.FinanceServiceClient {
+	return &auditedClient{FinanceServiceClient: next, log: log}
+}
+
+func (c *auditedClient) Withdraw(ctx context.Context, req *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError) {
+	res, err := c.FinanceServiceClient.Withdraw(ctx, req)
+	c.recordTransaction(ctx, domain.AuditWithdraw, req, res, err)
+	return res, err
+}
+
+func (c *auditedClient) Deposit(ctx context.Context, req *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError) {
+	res, err := c.FinanceServiceClient.Deposit(ctx, req)
+	c.recordTransaction(ctx, domain.AuditDeposit, req, res, err)
+	return res, err
+}
+
+func (c *auditedClient) Transfer(ctx context.Context, req *domain.TransferRequest) (*domain.TransferResponse, *errors.AppError) {
+	res, err := c.FinanceServiceClient.Transfer(ctx, req)
+	entry := domain.AuditEntry{Action: domain.AuditTransfer, Transfer: copyOf(req)}
+	if res != nil {
+		entry.Balance = &res.Balance
+	}
+	c.record(ctx, entry, err)
+	return res, err
+}
+
+func (c *auditedClient) UpdateTransaction(ctx context.Context, req *domain.UpdateTransactionRequest) (*domain.UpdateTransactionResponse, *errors.AppError) {
+	res, err := c.FinanceServiceClient.UpdateTransaction(ctx, req)
+	c.record(ctx, domain.AuditEntry{Action: domain.AuditUpdate, Update: copyOf(req)}, err)
+	return res, err
+}
+
+func (c *auditedClient) recordTransaction(ctx context.Context, action string, req *domain.TransactionRequest, res *domain.TransactionResponse, err *errors.AppError) {
+	entry := domain.AuditEntry{Action: action, Transaction: copyOf(req)}
+	if res != nil {
+		entry.Balance = &res.Balance
+	}
+	c.record(ctx, entry, err)
+}
+
+func (c *auditedClient) record(ctx context.Context, entry domain.AuditEntry, err *errors.AppError) {
+	if err != nil {
+		entry.Balance = nil
+		entry.StatusCode = err.StatusCode
+		entry.Error = err.Message
+	}
+	// Record logs its failures
+	c.log.Record(ctx, entry)
+}
+
+// copyOf keeps the request as it was sent, should the caller reuse it.
+func copyOf[T any](req *T) *T {
+	if req == nil {
+		return nil
+	}
+	c := *req
+	return &c
+}
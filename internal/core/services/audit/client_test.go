@@ -0,0 +1,120 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Withdraw(t *testing.T) {
+	testcases := []struct {
+		it                 string
+		res                *domain.TransactionResponse
+		err                *errors.AppError
+		expectedBalance    *float64
+		expectedStatusCode int
+		expectedError      string
+	}{
+		{
+			it:              "records the resulting balance",
+			res:             &domain.TransactionResponse{Balance: 1880},
+			expectedBalance: func() *float64 { b := 1880.0; return &b }(),
+		},
+		{
+			it:                 "records the error",
+			err:                errors.BadRequestError("insufficient balance"),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "insufficient balance",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			next := mocks.NewMockFinanceServiceClient(t)
+			req := &domain.TransactionRequest{Account: "debit1", Amount: 120, Category: "fd", Description: "lunch"}
+			next.EXPECT().Withdraw(mock.Anything, req).Return(tc.res, tc.err).Once()
+			log := newTestLog()
+			client := NewClient(next, log)
+
+			res, err := client.Withdraw(userContext(owner, "!p debit1 120fd lunch"), req)
+
+			assert.Equal(t, tc.res, res)
+			assert.Equal(t, tc.err, err)
+			export, appErr := log.Export(context.Background())
+			require.Nil(t, appErr)
+			require.Len(t, export.Entries, 1)
+			entry := export.Entries[0]
+			assert.Equal(t, domain.AuditWithdraw, entry.Action)
+			assert.Equal(t, "owner", entry.UserID)
+			assert.Equal(t, "!p debit1 120fd lunch", entry.Message)
+			assert.Equal(t, req, entry.Transaction)
+			assert.Equal(t, tc.expectedBalance, entry.Balance)
+			assert.Equal(t, tc.expectedStatusCode, entry.StatusCode)
+			assert.Equal(t, tc.expectedError, entry.Error)
+		})
+	}
+}
+
+func TestClient_DepositAndTransfer(t *testing.T) {
+	next := mocks.NewMockFinanceServiceClient(t)
+	deposit := &domain.TransactionRequest{Account: "debit1", Amount: 1000, Category: "salary"}
+	transfer := &domain.TransferRequest{FromAccount: "debit1", ToAccount: "savings", Amount: 300}
+	next.EXPECT().Deposit(mock.Anything, deposit).Return(&domain.TransactionResponse{Balance: 3000}, nil).Once()
+	next.EXPECT().Transfer(mock.Anything, transfer).Return(&domain.TransferResponse{Balance: 2700}, nil).Once()
+	log := newTestLog()
+	client := NewClient(next, log)
+	ctx := userContext(owner, "")
+
+	_, err := client.Deposit(ctx, deposit)
+	require.Nil(t, err)
+	_, err = client.Transfer(ctx, transfer)
+	require.Nil(t, err)
+	// The log keeps the requests as they were sent
+	deposit.Amount = 1
+
+	export, err := log.Export(context.Background())
+	require.Nil(t, err)
+	assert.True(t, export.Intact)
+	require.Len(t, export.Entries, 2)
+	assert.Equal(t, domain.AuditDeposit, export.Entries[0].Action)
+	assert.Equal(t, 1000.0, export.Entries[0].Transaction.Amount)
+	assert.Equal(t, 3000.0, *export.Entries[0].Balance)
+	assert.Equal(t, domain.AuditTransfer, export.Entries[1].Action)
+	assert.Equal(t, transfer, export.Entries[1].Transfer)
+	assert.Equal(t, 2700.0, *export.Entries[1].Balance)
+}
+
+func TestClient_UpdateTransaction(t *testing.T) {
+	next := mocks.NewMockFinanceServiceClient(t)
+	category := "sh"
+	req := &domain.UpdateTransactionRequest{ID: "tx-3", Category: &category}
+	next.EXPECT().UpdateTransaction(mock.Anything, req).Return(&domain.UpdateTransactionResponse{}, nil).Once()
+	log := newTestLog()
+
+	_, err := NewClient(next, log).UpdateTransaction(userContext(owner, "edit eea1a category sh"), req)
+	require.Nil(t, err)
+
+	res, err := log.Handle(userContext(owner, ""), []string{"history"})
+	require.Nil(t, err)
+	assert.Equal(t, "History\n================\n#1 2025-03-15 19:05\nedit eea1a category sh\nupdate tx-3: category sh", res)
+}
+
+func TestClient_PassesOtherCalls(t *testing.T) {
+	next := mocks.NewMockFinanceServiceClient(t)
+	next.EXPECT().GetBalance(mock.Anything).Return(&domain.GetBalanceResponse{}, nil).Once()
+	log := newTestLog()
+
+	_, err := NewClient(next, log).GetBalance(userContext(owner, ""))
+
+	require.Nil(t, err)
+	export, err := log.Export(context.Background())
+	require.Nil(t, err)
+	assert.Empty(t, export.Entries)
+}
@@ -0,0 +1,328 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+)
+
+const (
+	invalidHistoryMsg = "Invalid command's arguments.\nPlease recheck the syntax (history [count])"
+
+	defaultHistoryCount = 10
+	maxHistoryCount     = 50
+
+	timeLayout = "2006-01-02 15:04"
+)
+
+var headCollection = storage.NewCollection[head]("audit_head")
+
+const headKey = "head"
+
+// head is the last entry of the chain, kept in the store, so that
+// appending doesn't read the whole log, and that a truncated log is
+// detected. PrevHash tells Reconcile which entry it follows.
+type head struct {
+	Seq      int    `json:"seq"`
+	Hash     string `json:"hash"`
+	PrevHash string `json:"prev_hash,omitempty"`
+}
+
+// Log is the append-only audit log of the calls which change the finance
+// service's data, and of the commands denied to the callers. The entries
+// are hash-chained, so that editing, removing or reordering entries is
+// detected by Export. The callers read their own entries through the
+// "history" command.
+//
+// The entries are appended to the journal as JSON, and only the head of
+// the chain is kept in the store. The head is committed before the entry
+// is appended, so that a failing store never leaves an entry the head
+// doesn't account for; an entry which fails to be appended is taken back
+// from the head, there and then or by Reconcile.
+type Log struct {
+	mu       sync.Mutex // serializes the appends
+	store    storage.Store
+	journal  storage.Journal
+	location *time.Location
+	now      func() time.Time
+}
+
+// NewLog constructs the audit log. Times are shown in location.
+func NewLog(store storage.Store, journal storage.Journal, location *time.Location) *Log {
+	return &Log{store: store, journal: journal, location: location, now: time.Now}
+}
+
+// Record appends the entry, made by the user attached to ctx for the chat
+// message it carries, if any. Seq, Time and the hashes are set here.
+func (l *Log) Record(ctx context.Context, entry domain.AuditEntry) *errors.AppError {
+	user, _ := domain.UserFromContext(ctx)
+	entry.UserID = user.ID
+	entry.Message, _ = domain.MessageFromContext(ctx)
+	entry.Time = l.now().UTC()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var last head
+	err := l.store.Update(ctx, func(tx storage.Tx) error {
+		var err error
+		if last, _, err = headCollection.Get(tx, headKey); err != nil {
+			return err
+		}
+		entry.Seq = last.Seq + 1
+		entry.PrevHash = last.Hash
+		if entry.Hash, err = hash(entry); err != nil {
+			return err
+		}
+		return headCollection.Put(tx, headKey, head{Seq: entry.Seq, Hash: entry.Hash, PrevHash: entry.PrevHash})
+	})
+	if err == nil {
+		err = l.append(ctx, entry, last)
+	}
+	if err != nil {
+		logger.Ctx(ctx).Errorw("failed to record audit entry", "action", entry.Action, "error", err)
+		return errors.InternalServerError("cannot record the audit entry")
+	}
+	return nil
+}
+
+// append writes the entry to the journal, and puts the head back on last
+// when it can't.
+func (l *Log) append(ctx context.Context, entry domain.AuditEntry, last head) error {
+	record, err := json.Marshal(entry)
+	if err == nil {
+		err = l.journal.Append(record)
+	}
+	if err == nil {
+		return nil
+	}
+	if restoreErr := l.store.Update(ctx, func(tx storage.Tx) error {
+		return headCollection.Put(tx, headKey, last)
+	}); restoreErr != nil {
+		// Reconcile takes it back on the next start
+		logger.Ctx(ctx).Errorw("failed to restore audit head", "seq", last.Seq, "error", restoreErr)
+	}
+	return err
+}
+
+// Reconcile puts the head back on the last entry of the journal when the
+// entry the head ends with was never appended, e.g. the process stopped
+// in between. It's called on start.
+func (l *Log) Reconcile(ctx context.Context) *errors.AppError {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var last head
+	var reconciled bool
+	err := l.store.Update(ctx, func(tx storage.Tx) error {
+		current, _, err := headCollection.Get(tx, headKey)
+		if err != nil {
+			return err
+		}
+		entries, err := l.entries()
+		if err != nil {
+			return err
+		}
+		if len(entries) > 0 {
+			e := entries[len(entries)-1]
+			last = head{Seq: e.Seq, Hash: e.Hash, PrevHash: e.PrevHash}
+		}
+		if current.Seq != last.Seq+1 || current.PrevHash != last.Hash {
+			return nil
+		}
+		reconciled = true
+		return headCollection.Put(tx, headKey, last)
+	})
+	if err != nil {
+		logger.Ctx(ctx).Errorw("failed to reconcile audit head", "error", err)
+		return errors.InternalServerError("cannot reconcile the audit log")
+	}
+	if reconciled {
+		logger.Ctx(ctx).Warnw("audit entry was never appended, the head is put back", "seq", last.Seq+1)
+	}
+	return nil
+}
+
+// Export returns the whole log, verified.
+func (l *Log) Export(ctx context.Context) (*domain.AuditExport, *errors.AppError) {
+	var export domain.AuditExport
+	// Record doesn't append while the head and the entries are read
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	err := l.store.View(ctx, func(tx storage.Tx) error {
+		last, _, err := headCollection.Get(tx, headKey)
+		if err != nil {
+			return err
+		}
+		if export.Entries, err = l.entries(); err != nil {
+			return err
+		}
+		export.BrokenAt = verify(export.Entries, last)
+		export.Intact = export.BrokenAt == 0
+		return nil
+	})
+	if err != nil {
+		logger.Ctx(ctx).Errorw("failed to read audit log", "error", err)
+		return nil, errors.InternalServerError("cannot read the audit log")
+	}
+	if !export.Intact {
+		logger.Ctx(ctx).Warnw("audit log is broken", "seq", export.BrokenAt)
+	}
+	return &export, nil
+}
+
+// verify checks the chain of the entries, ordered by Seq, against the
+// head. It returns the sequence number of the first entry which doesn't
+// match, or 0 when the chain is intact.
+func verify(entries []domain.AuditEntry, last head) int {
+	var prev head
+	for _, e := range entries {
+		expected, err := hash(e)
+		if e.Seq != prev.Seq+1 || e.PrevHash != prev.Hash || err != nil || e.Hash != expected {
+			return prev.Seq + 1
+		}
+		prev = head{Seq: e.Seq, Hash: e.Hash}
+	}
+	if prev.Seq != last.Seq || prev.Hash != last.Hash {
+		// Entries were removed from the end
+		return prev.Seq + 1
+	}
+	return 0
+}
+
+// entries decodes the entries of the journal, oldest first. A record which
+// isn't an entry is returned empty, which breaks the chain.
+func (l *Log) entries() ([]domain.AuditEntry, error) {
+	records, err := l.journal.Records()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]domain.AuditEntry, len(records))
+	for i, r := range records {
+		if err := json.Unmarshal(r, &entries[i]); err != nil {
+			entries[i] = domain.AuditEntry{}
+		}
+	}
+	return entries, nil
+}
+
+// recent returns the last n entries of the user, newest first.
+func (l *Log) recent(ctx context.Context, userID string, n int) ([]domain.AuditEntry, *errors.AppError) {
+	all, err := l.entries()
+	if err != nil {
+		logger.Ctx(ctx).Errorw("failed to read audit log", "error", err)
+		return nil, errors.InternalServerError("cannot read the history")
+	}
+	var entries []domain.AuditEntry
+	for i := len(all) - 1; i >= 0 && len(entries) < n; i-- {
+		if all[i].UserID == userID {
+			entries = append(entries, all[i])
+		}
+	}
+	return entries, nil
+}
+
+func (l *Log) Match(cmd string) bool {
+	return cmd == "history"
+}
+
+// Handle serves "history [count]", which prints the caller's last calls
+// to the finance service, newest first.
+func (l *Log) Handle(ctx context.Context, tokenizedMsg []string) (string, *errors.AppError) {
+	n := defaultHistoryCount
+	switch len(tokenizedMsg) {
+	case 1:
+	case 2:
+		var err error
+		if n, err = strconv.Atoi(tokenizedMsg[1]); err != nil || n < 1 || n > maxHistoryCount {
+			return "", errors.BadRequestError(fmt.Sprintf("Invalid count '%s', it must be between 1 and %d", tokenizedMsg[1], maxHistoryCount))
+		}
+	default:
+		return "", errors.BadRequestError(invalidHistoryMsg)
+	}
+
+	user, _ := domain.UserFromContext(ctx)
+	entries, err := l.recent(ctx, user.ID, n)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 0 {
+		return "You have no history yet", nil
+	}
+	parts := make([]string, 0, len(entries))
+	for _, e := range entries {
+		parts = append(parts, l.printEntry(e))
+	}
+	return "History\n================\n" + strings.Join(parts, "\n\n"), nil
+}
+
+// printEntry shows when and what was sent, and how it went, e.g.
+//
+//	#12 2025-03-15 19:04
+//	!p debit1 120fd lunch
+//	withdraw ฿120 (fd) from debit1
+//	Balance: ฿1880
+func (l *Log) printEntry(e domain.AuditEntry) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("#%d %s", e.Seq, e.Time.In(l.location).Format(timeLayout)))
+	if e.Message != "" {
+		sb.WriteString("\n" + e.Message)
+	}
+	switch {
+	case e.Transaction != nil && e.Action == domain.AuditDeposit:
+		sb.WriteString(fmt.Sprintf("\ndeposit ฿%v (%s) to %s", e.Transaction.Amount, e.Transaction.Category, e.Transaction.Account))
+	case e.Transaction != nil:
+		sb.WriteString(fmt.Sprintf("\n%s ฿%v (%s) from %s", e.Action, e.Transaction.Amount, e.Transaction.Category, e.Transaction.Account))
+	case e.Transfer != nil:
+		sb.WriteString(fmt.Sprintf("\ntransfer ฿%v from %s to %s", e.Transfer.Amount, e.Transfer.FromAccount, e.Transfer.ToAccount))
+	case e.Update != nil:
+		sb.WriteString("\nupdate " + e.Update.ID + ": " + printChanges(e.Update))
+	case e.Action == domain.AuditDenied:
+		sb.WriteString("\ndenied " + e.Command)
+		if e.Account != "" {
+			sb.WriteString(" on " + e.Account)
+		}
+	}
+	if e.Error != "" {
+		sb.WriteString("\nFailed: " + e.Error)
+	} else if e.Balance != nil {
+		sb.WriteString(fmt.Sprintf("\nBalance: ฿%v", *e.Balance))
+	}
+	return sb.String()
+}
+
+// printChanges lists the fields an update sets, e.g.
+// "category fd, amount ฿120".
+func printChanges(u *domain.UpdateTransactionRequest) string {
+	var changes []string
+	if u.Category != nil {
+		changes = append(changes, "category "+*u.Category)
+	}
+	if u.Amount != nil {
+		changes = append(changes, fmt.Sprintf("amount ฿%v", *u.Amount))
+	}
+	if u.Description != nil {
+		changes = append(changes, fmt.Sprintf("description '%s'", *u.Description))
+	}
+	return strings.Join(changes, ", ")
+}
+
+// hash returns the SHA-256 of the entry without its own hash.
+func hash(e domain.AuditEntry) (string, error) {
+	e.Hash = ""
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,367 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	bangkok, _ = time.LoadLocation("Asia/Bangkok")
+	owner      = domain.User{ID: "owner"}
+	partner    = domain.User{ID: "partner"}
+)
+
+func newTestLog() *Log {
+	log := NewLog(memory.NewStore(), memory.NewJournal(), bangkok)
+	now := time.Date(2025, 3, 15, 12, 4, 0, 0, time.UTC)
+	log.now = func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}
+	return log
+}
+
+func userContext(user domain.User, msg string) context.Context {
+	ctx := domain.ContextWithUser(context.Background(), user)
+	if msg != "" {
+		ctx = domain.ContextWithMessage(ctx, msg)
+	}
+	return ctx
+}
+
+func withdrawal(amount float64) domain.AuditEntry {
+	balance := 2000 - amount
+	return domain.AuditEntry{
+		Action:      domain.AuditWithdraw,
+		Transaction: &domain.TransactionRequest{Account: "debit1", Amount: amount, Category: "fd"},
+		Balance:     &balance,
+	}
+}
+
+func record(t *testing.T, log *Log, ctx context.Context, entries ...domain.AuditEntry) {
+	for _, e := range entries {
+		require.Nil(t, log.Record(ctx, e))
+	}
+}
+
+func TestRecord(t *testing.T) {
+	log := newTestLog()
+	record(t, log, userContext(owner, "!p debit1 120fd lunch"), withdrawal(120))
+	record(t, log, userContext(partner, ""), withdrawal(80))
+
+	export, err := log.Export(context.Background())
+
+	require.Nil(t, err)
+	assert.True(t, export.Intact)
+	assert.Zero(t, export.BrokenAt)
+	require.Len(t, export.Entries, 2)
+	first, second := export.Entries[0], export.Entries[1]
+	assert.Equal(t, 1, first.Seq)
+	assert.Equal(t, "owner", first.UserID)
+	assert.Equal(t, "!p debit1 120fd lunch", first.Message)
+	assert.Equal(t, time.Date(2025, 3, 15, 12, 5, 0, 0, time.UTC), first.Time)
+	assert.Empty(t, first.PrevHash)
+	assert.NotEmpty(t, first.Hash)
+	assert.Equal(t, 2, second.Seq)
+	assert.Equal(t, "partner", second.UserID)
+	assert.Empty(t, second.Message)
+	assert.Equal(t, first.Hash, second.PrevHash)
+}
+
+func TestExport_Tampered(t *testing.T) {
+	// editEntry changes the amount of the record's entry, and rehashes it
+	editEntry := func(t *testing.T, record []byte, rehash bool) []byte {
+		var entry domain.AuditEntry
+		require.NoError(t, json.Unmarshal(record, &entry))
+		entry.Transaction.Amount = 1
+		if rehash {
+			var err error
+			entry.Hash, err = hash(entry)
+			require.NoError(t, err)
+		}
+		edited, err := json.Marshal(entry)
+		require.NoError(t, err)
+		return edited
+	}
+
+	testcases := []struct {
+		it               string
+		tamper           func(t *testing.T, records [][]byte) [][]byte
+		expectedBrokenAt int
+	}{
+		{
+			it: "detects an edited entry",
+			tamper: func(t *testing.T, records [][]byte) [][]byte {
+				records[1] = editEntry(t, records[1], false)
+				return records
+			},
+			expectedBrokenAt: 2,
+		},
+		{
+			it: "detects a removed entry",
+			tamper: func(t *testing.T, records [][]byte) [][]byte {
+				return slices.Delete(records, 1, 2)
+			},
+			expectedBrokenAt: 2,
+		},
+		{
+			it: "detects a truncated log",
+			tamper: func(t *testing.T, records [][]byte) [][]byte {
+				return records[:2]
+			},
+			expectedBrokenAt: 3,
+		},
+		{
+			it: "detects a rehashed entry whose successor still points to the original",
+			tamper: func(t *testing.T, records [][]byte) [][]byte {
+				records[0] = editEntry(t, records[0], true)
+				return records
+			},
+			expectedBrokenAt: 2,
+		},
+		{
+			it: "detects a record which isn't an entry",
+			tamper: func(t *testing.T, records [][]byte) [][]byte {
+				records[2] = []byte("{")
+				return records
+			},
+			expectedBrokenAt: 3,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			log := newTestLog()
+			record(t, log, userContext(owner, ""), withdrawal(100), withdrawal(200), withdrawal(300))
+			records, err := log.journal.Records()
+			require.NoError(t, err)
+			log.journal = memory.NewJournal(tc.tamper(t, records)...)
+
+			export, err := log.Export(context.Background())
+
+			require.Nil(t, err)
+			assert.False(t, export.Intact)
+			assert.Equal(t, tc.expectedBrokenAt, export.BrokenAt)
+		})
+	}
+}
+
+func TestRecord_Closed(t *testing.T) {
+	journal := memory.NewJournal()
+	log := NewLog(memory.NewStore(), journal, bangkok)
+	require.NoError(t, journal.Close())
+
+	err := log.Record(userContext(owner, ""), withdrawal(100))
+
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+	assert.Equal(t, "cannot record the audit entry", err.Message)
+}
+
+// failingJournal fails the appends while fail is set.
+type failingJournal struct {
+	storage.Journal
+	fail bool
+}
+
+func (j *failingJournal) Append(record []byte) error {
+	if j.fail {
+		return errors.New("disk full")
+	}
+	return j.Journal.Append(record)
+}
+
+func TestRecord_Failures(t *testing.T) {
+	testcases := []struct {
+		it  string
+		new func() (*Log, *bool)
+	}{
+		{
+			it: "leaves the journal alone when the head can't be saved",
+			new: func() (*Log, *bool) {
+				var failing bool
+				store := memory.NewPersistentStore(nil, func(memory.Data) error {
+					if failing {
+						return errors.New("disk full")
+					}
+					return nil
+				})
+				return NewLog(store, memory.NewJournal(), bangkok), &failing
+			},
+		},
+		{
+			it: "puts the head back when the entry can't be appended",
+			new: func() (*Log, *bool) {
+				journal := &failingJournal{Journal: memory.NewJournal()}
+				return NewLog(memory.NewStore(), journal, bangkok), &journal.fail
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			log, failing := tc.new()
+			ctx := userContext(owner, "")
+			record(t, log, ctx, withdrawal(100))
+			*failing = true
+			require.NotNil(t, log.Record(ctx, withdrawal(120)))
+			*failing = false
+
+			record(t, log, ctx, withdrawal(80))
+
+			export, err := log.Export(context.Background())
+			require.Nil(t, err)
+			assert.True(t, export.Intact)
+			require.Len(t, export.Entries, 2)
+			assert.Equal(t, 2, export.Entries[1].Seq)
+			assert.Equal(t, 80.0, export.Entries[1].Transaction.Amount)
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	store := memory.NewStore()
+	log := NewLog(store, memory.NewJournal(), bangkok)
+	ctx := userContext(owner, "")
+	record(t, log, ctx, withdrawal(100))
+	export, err := log.Export(context.Background())
+	require.Nil(t, err)
+	// The head of an entry the process stopped before appending
+	require.NoError(t, store.Update(context.Background(), func(tx storage.Tx) error {
+		return headCollection.Put(tx, headKey, head{Seq: 2, Hash: "lost", PrevHash: export.Entries[0].Hash})
+	}))
+
+	require.Nil(t, log.Reconcile(context.Background()))
+	record(t, log, ctx, withdrawal(80))
+
+	export, err = log.Export(context.Background())
+	require.Nil(t, err)
+	assert.True(t, export.Intact)
+	require.Len(t, export.Entries, 2)
+	assert.Equal(t, 2, export.Entries[1].Seq)
+}
+
+func TestReconcile_Truncated(t *testing.T) {
+	store := memory.NewStore()
+	log := NewLog(store, memory.NewJournal(), bangkok)
+	record(t, log, userContext(owner, ""), withdrawal(100))
+	// The head of an entry which isn't the next one
+	require.NoError(t, store.Update(context.Background(), func(tx storage.Tx) error {
+		return headCollection.Put(tx, headKey, head{Seq: 3, Hash: "removed", PrevHash: "removed"})
+	}))
+
+	require.Nil(t, log.Reconcile(context.Background()))
+
+	export, err := log.Export(context.Background())
+	require.Nil(t, err)
+	assert.False(t, export.Intact)
+	assert.Equal(t, 2, export.BrokenAt)
+}
+
+func TestHandle(t *testing.T) {
+	failed := withdrawal(500)
+	failed.Balance = nil
+	failed.StatusCode = http.StatusBadRequest
+	failed.Error = "insufficient balance"
+	denied := domain.AuditEntry{
+		Action:     domain.AuditDenied,
+		Command:    "!t",
+		Account:    "shared-savings",
+		StatusCode: http.StatusForbidden,
+		Error:      "You are not permitted to use the account 'shared-savings'",
+	}
+
+	testcases := []struct {
+		it               string
+		msg              []string
+		expectedReplyMsg string
+		expectedErr      string
+	}{
+		{
+			it:  "prints the caller's entries, newest first",
+			msg: []string{"history"},
+			expectedReplyMsg: "History\n================\n" +
+				"#6 2025-03-15 19:10\n!t debit1 shared-savings 100\ndenied !t on shared-savings\nFailed: You are not permitted to use the account 'shared-savings'\n\n" +
+				"#5 2025-03-15 19:09\n!p debit1 500fd\nwithdraw ฿500 (fd) from debit1\nFailed: insufficient balance\n\n" +
+				"#4 2025-03-15 19:08\ntransfer ฿300 from debit1 to savings\nBalance: ฿1700\n\n" +
+				"#3 2025-03-15 19:07\n!e debit1 1000salary\ndeposit ฿1000 (salary) to debit1\nBalance: ฿3000\n\n" +
+				"#1 2025-03-15 19:05\n!p debit1 120fd lunch\nwithdraw ฿120 (fd) from debit1\nBalance: ฿1880",
+		},
+		{
+			it:  "limits the entries to the count",
+			msg: []string{"history", "2"},
+			expectedReplyMsg: "History\n================\n" +
+				"#6 2025-03-15 19:10\n!t debit1 shared-savings 100\ndenied !t on shared-savings\nFailed: You are not permitted to use the account 'shared-savings'\n\n" +
+				"#5 2025-03-15 19:09\n!p debit1 500fd\nwithdraw ฿500 (fd) from debit1\nFailed: insufficient balance",
+		},
+		{
+			it:          "rejects a count out of range",
+			msg:         []string{"history", "51"},
+			expectedErr: "Invalid count '51', it must be between 1 and 50",
+		},
+		{
+			it:          "rejects a count which isn't a number",
+			msg:         []string{"history", "all"},
+			expectedErr: "Invalid count 'all', it must be between 1 and 50",
+		},
+		{
+			it:          "rejects extra arguments",
+			msg:         []string{"history", "1", "2"},
+			expectedErr: invalidHistoryMsg,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			log := newTestLog()
+			deposit := domain.AuditEntry{
+				Action:      domain.AuditDeposit,
+				Transaction: &domain.TransactionRequest{Account: "debit1", Amount: 1000, Category: "salary"},
+				Balance:     func() *float64 { b := 3000.0; return &b }(),
+			}
+			transfer := domain.AuditEntry{
+				Action:   domain.AuditTransfer,
+				Transfer: &domain.TransferRequest{FromAccount: "debit1", ToAccount: "savings", Amount: 300},
+				Balance:  func() *float64 { b := 1700.0; return &b }(),
+			}
+			record(t, log, userContext(owner, "!p debit1 120fd lunch"), withdrawal(120))
+			record(t, log, userContext(partner, "!p debit1 80fd"), withdrawal(80))
+			record(t, log, userContext(owner, "!e debit1 1000salary"), deposit)
+			record(t, log, userContext(owner, ""), transfer)
+			record(t, log, userContext(owner, "!p debit1 500fd"), failed)
+			record(t, log, userContext(owner, "!t debit1 shared-savings 100"), denied)
+
+			res, err := log.Handle(userContext(owner, ""), tc.msg)
+
+			if tc.expectedErr != "" {
+				require.NotNil(t, err)
+				assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+				assert.Equal(t, tc.expectedErr, err.Message)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, tc.expectedReplyMsg, res)
+		})
+	}
+}
+
+func TestHandle_NoHistory(t *testing.T) {
+	log := newTestLog()
+	record(t, log, userContext(partner, ""), withdrawal(80))
+
+	res, err := log.Handle(userContext(owner, ""), []string{"history"})
+
+	require.Nil(t, err)
+	assert.Equal(t, "You have no history yet", res)
+}
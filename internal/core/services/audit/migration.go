@@ -0,0 +1,31 @@
+package audit
+
+import "github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+
+// storedEntries is the collection which held the entries, by sequence
+// number, before they moved to the journal.
+const storedEntries = "audit"
+
+// MoveToJournal returns the migration which appends the entries held in the
+// store to the journal, oldest first, and deletes them from the store. The
+// entries a rolled back attempt already appended aren't appended again.
+func MoveToJournal(journal storage.Journal) func(tx storage.Tx) error {
+	return func(tx storage.Tx) error {
+		records, err := journal.Records()
+		if err != nil {
+			return err
+		}
+		for i, key := range tx.Keys(storedEntries) {
+			if i >= len(records) {
+				value, _ := tx.Get(storedEntries, key)
+				if err := journal.Append(value); err != nil {
+					return err
+				}
+			}
+			if err := tx.Delete(storedEntries, key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
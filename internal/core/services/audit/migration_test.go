@@ -0,0 +1,36 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveToJournal(t *testing.T) {
+	store := memory.NewStore()
+	require.NoError(t, store.Update(context.Background(), func(tx storage.Tx) error {
+		for key, value := range map[string]string{"000000000001": `{"seq":1}`, "000000000002": `{"seq":2}`, "000000000003": `{"seq":3}`} {
+			if err := tx.Put(storedEntries, key, []byte(value)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	// A rolled back attempt appended the first entry
+	journal := memory.NewJournal([]byte(`{"seq":1}`))
+
+	err := store.Update(context.Background(), MoveToJournal(journal))
+
+	require.NoError(t, err)
+	records, err := journal.Records()
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte(`{"seq":1}`), []byte(`{"seq":2}`), []byte(`{"seq":3}`)}, records)
+	require.NoError(t, store.View(context.Background(), func(tx storage.Tx) error {
+		assert.Empty(t, tx.Keys(storedEntries), "the entries are deleted from the store")
+		return nil
+	}))
+}
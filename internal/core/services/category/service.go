@@ -0,0 +1,352 @@
+package category
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+)
+
+const invalidCategoryMsg = "Invalid command's arguments.\nPlease recheck the syntax (category [list], category add <code> <name>, category alias <alias> <code>, category group <code> <parent> or category ungroup <code>)"
+
+// categoryCollection holds, under editedKey, the categories edited
+// through the command, in the order they were first edited. They override
+// the configured ones of the same code.
+var categoryCollection = storage.NewCollection[[]domain.Category]("categories")
+
+const editedKey = "edited"
+
+// Service is the category registry. It resolves the categories typed in
+// the transactions and names them in the statements, and is edited through
+// the "category" command.
+//
+// While no category is registered, any category is accepted as it is.
+type Service struct {
+	configured    []domain.Category
+	createUnknown bool
+	store         storage.Store
+}
+
+// NewService constructs the category registry. The configuration must
+// have been validated.
+func NewService(cfg domain.CategoryConfig, store storage.Store) *Service {
+	return &Service{configured: cfg.Categories, createUnknown: cfg.CreateUnknown, store: store}
+}
+
+// Registry is a snapshot of the categories.
+type Registry struct {
+	categories map[string]*domain.Category // by code
+	codes      []string                    // in the order they were registered
+	aliases    map[string]string           // alias to code
+	edited     []string                    // the codes to store, see categoryCollection
+}
+
+// Registry returns the current categories.
+func (s *Service) Registry(ctx context.Context) (*Registry, *errors.AppError) {
+	var r *Registry
+	if err := s.store.View(ctx, func(tx storage.Tx) error {
+		var err error
+		r, err = s.load(tx)
+		return err
+	}); err != nil {
+		logger.Ctx(ctx).Errorw("failed to read categories", "error", err)
+		return nil, errors.InternalServerError("cannot read the categories")
+	}
+	return r, nil
+}
+
+// Resolve returns the code of the category typed as input, which is either
+// a code or an alias. Unknown categories are rejected, unless the registry
+// is empty or creates them.
+func (s *Service) Resolve(ctx context.Context, input string) (string, *errors.AppError) {
+	input = strings.ToLower(input)
+	r, err := s.Registry(ctx)
+	if err != nil {
+		return "", err
+	}
+	if code, exist := r.lookup(input); exist {
+		return code, nil
+	}
+	if len(r.categories) == 0 {
+		return input, nil
+	}
+	if !s.createUnknown {
+		logger.Ctx(ctx).Infow("unknown category", "category", input)
+		return "", errors.BadRequestError(fmt.Sprintf("Unknown category '%s'.\nAdd it with 'category add %s <name>' or use one of: %s", input, input, strings.Join(r.codes, ", ")))
+	}
+	if err := validateCode(input); err != nil {
+		return "", err
+	}
+	return s.update(ctx, func(r *Registry) (string, *errors.AppError) {
+		if code, exist := r.lookup(input); exist {
+			return code, nil
+		}
+		r.register(input, input)
+		r.edit(input)
+		logger.Ctx(ctx).Infow("created category", "category", input)
+		return input, nil
+	})
+}
+
+// DisplayName returns the name of the category, or the code itself if it
+// isn't registered.
+func (r *Registry) DisplayName(code string) string {
+	if c, exist := r.categories[code]; exist {
+		return c.Name
+	}
+	return code
+}
+
+// Group returns the code of the top-level group the category rolls up
+// into, which is the category itself when it has no parent.
+func (r *Registry) Group(code string) string {
+	for {
+		c, exist := r.categories[code]
+		if !exist || c.Parent == "" {
+			return code
+		}
+		code = c.Parent
+	}
+}
+
+func (s *Service) Match(cmd string) bool {
+	return cmd == "category"
+}
+
+// Handle serves "category [list]", "category add <code> <name>",
+// "category alias <alias> <code>", "category group <code> <parent>" and
+// "category ungroup <code>".
+func (s *Service) Handle(ctx context.Context, tokenizedMsg []string) (string, *errors.AppError) {
+	switch {
+	case len(tokenizedMsg) < 2 || (tokenizedMsg[1] == "list" && len(tokenizedMsg) == 2):
+		return s.list(ctx)
+	case tokenizedMsg[1] == "add" && len(tokenizedMsg) >= 4:
+		return s.add(ctx, tokenizedMsg[2], strings.Join(tokenizedMsg[3:], " "))
+	case tokenizedMsg[1] == "alias" && len(tokenizedMsg) == 4:
+		return s.alias(ctx, tokenizedMsg[2], tokenizedMsg[3])
+	case tokenizedMsg[1] == "group" && len(tokenizedMsg) == 4:
+		return s.group(ctx, tokenizedMsg[2], tokenizedMsg[3])
+	case tokenizedMsg[1] == "ungroup" && len(tokenizedMsg) == 3:
+		return s.group(ctx, tokenizedMsg[2], "")
+	default:
+		return "", errors.BadRequestError(invalidCategoryMsg)
+	}
+}
+
+// add registers the category, or renames it if it exists. Chat messages
+// are lowercased, so the name is capitalized.
+func (s *Service) add(ctx context.Context, code, name string) (string, *errors.AppError) {
+	if err := validateCode(code); err != nil {
+		return "", err
+	}
+	first, size := utf8.DecodeRuneInString(name)
+	name = string(unicode.ToUpper(first)) + name[size:]
+
+	return s.update(ctx, func(r *Registry) (string, *errors.AppError) {
+		if target, exist := r.aliases[code]; exist {
+			return "", errors.BadRequestError(fmt.Sprintf("'%s' is already an alias of '%s'", code, target))
+		}
+		if c, exist := r.categories[code]; exist {
+			c.Name = name
+			r.edit(code)
+			logger.Ctx(ctx).Infow("renamed category", "category", code)
+			return fmt.Sprintf("Renamed '%s' to %s", code, name), nil
+		}
+		r.register(code, name)
+		r.edit(code)
+		logger.Ctx(ctx).Infow("added category", "category", code)
+		return fmt.Sprintf("Added '%s': %s", code, name), nil
+	})
+}
+
+func (s *Service) alias(ctx context.Context, alias, target string) (string, *errors.AppError) {
+	if err := validateCode(alias); err != nil {
+		return "", err
+	}
+
+	return s.update(ctx, func(r *Registry) (string, *errors.AppError) {
+		code, exist := r.lookup(target)
+		if !exist {
+			return "", errors.NotFoundError(fmt.Sprintf("Unknown category '%s'", target))
+		}
+		if _, exist := r.categories[alias]; exist {
+			return "", errors.BadRequestError(fmt.Sprintf("'%s' is already a category", alias))
+		}
+		if previous, exist := r.aliases[alias]; exist {
+			c := r.categories[previous]
+			c.Aliases = slices.DeleteFunc(c.Aliases, func(a string) bool { return a == alias })
+			r.edit(previous)
+		}
+		r.aliases[alias] = code
+		r.categories[code].Aliases = append(r.categories[code].Aliases, alias)
+		r.edit(code)
+		logger.Ctx(ctx).Infow("added category alias", "category", code, "alias", alias)
+		return fmt.Sprintf("'%s' is now an alias of '%s'", alias, code), nil
+	})
+}
+
+// group moves the category under parent, or to the top level when parent
+// is empty.
+func (s *Service) group(ctx context.Context, target, parent string) (string, *errors.AppError) {
+	return s.update(ctx, func(r *Registry) (string, *errors.AppError) {
+		code, exist := r.lookup(target)
+		if !exist {
+			return "", errors.NotFoundError(fmt.Sprintf("Unknown category '%s'", target))
+		}
+		if parent == "" {
+			r.categories[code].Parent = ""
+			r.edit(code)
+			logger.Ctx(ctx).Infow("ungrouped category", "category", code)
+			return fmt.Sprintf("'%s' is no longer in a group", code), nil
+		}
+
+		parentCode, exist := r.lookup(parent)
+		if !exist {
+			return "", errors.NotFoundError(fmt.Sprintf("Unknown category '%s'", parent))
+		}
+		for ancestor := parentCode; ancestor != ""; ancestor = r.categories[ancestor].Parent {
+			if ancestor == code {
+				return "", errors.BadRequestError(fmt.Sprintf("'%s' can't be in its own group", code))
+			}
+		}
+		r.categories[code].Parent = parentCode
+		r.edit(code)
+		logger.Ctx(ctx).Infow("grouped category", "category", code, "parent", parentCode)
+		return fmt.Sprintf("%s > %s", r.categories[parentCode].Name, r.categories[code].Name), nil
+	})
+}
+
+// list prints the categories as a tree of groups.
+func (s *Service) list(ctx context.Context) (string, *errors.AppError) {
+	r, err := s.Registry(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(r.codes) == 0 {
+		return "No category is registered, so any category is accepted.\nAdd one with 'category add <code> <name>'", nil
+	}
+
+	children := make(map[string][]string)
+	for _, code := range r.codes {
+		parent := r.categories[code].Parent
+		children[parent] = append(children[parent], code)
+	}
+	var sb strings.Builder
+	sb.WriteString("Categories\n================")
+	var write func(parent string, depth int)
+	write = func(parent string, depth int) {
+		for _, code := range children[parent] {
+			c := r.categories[code]
+			sb.WriteString(fmt.Sprintf("\n%s%s = %s", strings.Repeat("  ", depth), c.Code, c.Name))
+			if len(c.Aliases) > 0 {
+				sb.WriteString(fmt.Sprintf(" (%s)", strings.Join(c.Aliases, ", ")))
+			}
+			write(code, depth+1)
+		}
+	}
+	write("", 0)
+	return sb.String(), nil
+}
+
+// update applies fn to the registry in a transaction, and stores the
+// categories it edits unless it fails.
+func (s *Service) update(ctx context.Context, fn func(r *Registry) (string, *errors.AppError)) (string, *errors.AppError) {
+	var res string
+	var appErr *errors.AppError
+	err := s.store.Update(ctx, func(tx storage.Tx) error {
+		r, err := s.load(tx)
+		if err != nil {
+			return err
+		}
+		if res, appErr = fn(r); appErr != nil {
+			return appErr
+		}
+		edited := make([]domain.Category, 0, len(r.edited))
+		for _, code := range r.edited {
+			edited = append(edited, *r.categories[code])
+		}
+		return categoryCollection.Put(tx, editedKey, edited)
+	})
+	if appErr != nil {
+		return "", appErr
+	}
+	if err != nil {
+		logger.Ctx(ctx).Errorw("failed to save categories", "error", err)
+		return "", errors.InternalServerError("cannot save the categories")
+	}
+	return res, nil
+}
+
+// load builds the registry from the configured categories and the edited
+// ones.
+func (s *Service) load(tx storage.Tx) (*Registry, error) {
+	edited, _, err := categoryCollection.Get(tx, editedKey)
+	if err != nil {
+		return nil, err
+	}
+	r := &Registry{
+		categories: make(map[string]*domain.Category, len(s.configured)+len(edited)),
+		aliases:    make(map[string]string),
+	}
+	for _, c := range s.configured {
+		r.register(c.Code, c.Name)
+		r.categories[c.Code].Parent = c.Parent
+		r.categories[c.Code].Aliases = slices.Clone(c.Aliases)
+	}
+	for _, c := range edited {
+		if _, exist := r.categories[c.Code]; !exist {
+			r.register(c.Code, c.Name)
+		}
+		*r.categories[c.Code] = c
+		r.edit(c.Code)
+	}
+	for _, code := range r.codes {
+		for _, alias := range r.categories[code].Aliases {
+			r.aliases[alias] = code
+		}
+	}
+	return r, nil
+}
+
+// lookup returns the code of the category or alias.
+func (r *Registry) lookup(input string) (string, bool) {
+	if _, exist := r.categories[input]; exist {
+		return input, true
+	}
+	code, exist := r.aliases[input]
+	return code, exist
+}
+
+// register adds a top-level category.
+func (r *Registry) register(code, name string) {
+	r.categories[code] = &domain.Category{Code: code, Name: name}
+	r.codes = append(r.codes, code)
+}
+
+// edit marks the category to be stored.
+func (r *Registry) edit(code string) {
+	if !slices.Contains(r.edited, code) {
+		r.edited = append(r.edited, code)
+	}
+}
+
+// validateCode accepts the codes which can follow an amount, i.e. letters
+// not starting with k, which the amount would read as thousands.
+func validateCode(code string) *errors.AppError {
+	if strings.HasPrefix(strings.ToLower(code), "k") {
+		return errors.BadRequestError(fmt.Sprintf("Invalid category '%s', it must not start with k, which multiplies the amount by 1,000", code))
+	}
+	for _, r := range code {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return errors.BadRequestError(fmt.Sprintf("Invalid category '%s', it must only contain letters", code))
+		}
+	}
+	return nil
+}
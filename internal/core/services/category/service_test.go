@@ -0,0 +1,266 @@
+package category
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var testCategoryConfig = domain.CategoryConfig{
+	Categories: []domain.Category{
+		{Code: "fd", Name: "Food", Aliases: []string{"food"}},
+		{Code: "gr", Name: "Groceries", Parent: "fd"},
+		{Code: "sh", Name: "Shopping"},
+	},
+}
+
+func handle(service *Service, msg ...string) (string, *errors.AppError) {
+	return service.Handle(context.Background(), append([]string{"category"}, msg...))
+}
+
+func TestResolve(t *testing.T) {
+	testcases := []struct {
+		it          string
+		cfg         domain.CategoryConfig
+		input       string
+		expected    string
+		expectedErr *errors.AppError
+	}{
+		{
+			it:       "returns the code of a category",
+			cfg:      testCategoryConfig,
+			input:    "sh",
+			expected: "sh",
+		},
+		{
+			it:       "returns the code of an alias",
+			cfg:      testCategoryConfig,
+			input:    "FOOD",
+			expected: "fd",
+		},
+		{
+			it:          "rejects an unknown category",
+			cfg:         testCategoryConfig,
+			input:       "tx",
+			expectedErr: errors.BadRequestError("Unknown category 'tx'.\nAdd it with 'category add tx <name>' or use one of: fd, gr, sh"),
+		},
+		{
+			it:       "accepts any category while none is registered",
+			cfg:      domain.CategoryConfig{},
+			input:    "tx",
+			expected: "tx",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			service := NewService(tc.cfg, memory.NewStore())
+
+			res, err := service.Resolve(context.Background(), tc.input)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, res)
+		})
+	}
+}
+
+func TestResolve_CreateUnknown(t *testing.T) {
+	service := NewService(domain.CategoryConfig{Categories: testCategoryConfig.Categories, CreateUnknown: true}, memory.NewStore())
+
+	res, err := service.Resolve(context.Background(), "tx")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "tx", res)
+	registry, err := service.Registry(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "tx", registry.DisplayName("tx"))
+	list, _ := handle(service, "list")
+	assert.Contains(t, list, "\ntx = tx")
+
+	res, err = service.Resolve(context.Background(), "kid")
+
+	assert.Empty(t, res)
+	assert.Equal(t, errors.BadRequestError("Invalid category 'kid', it must not start with k, which multiplies the amount by 1,000"), err)
+	registry, _ = service.Registry(context.Background())
+	assert.Equal(t, "kid", registry.DisplayName("kid"), "the category isn't created")
+}
+
+func TestDisplayNameAndGroup(t *testing.T) {
+	registry, err := NewService(testCategoryConfig, memory.NewStore()).Registry(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Groceries", registry.DisplayName("gr"))
+	assert.Equal(t, "unknown", registry.DisplayName("unknown"))
+	assert.Equal(t, "fd", registry.Group("gr"))
+	assert.Equal(t, "sh", registry.Group("sh"))
+	assert.Equal(t, "unknown", registry.Group("unknown"))
+}
+
+func TestHandle(t *testing.T) {
+	testcases := []struct {
+		it           string
+		commands     [][]string
+		expected     string
+		expectedList string
+	}{
+		{
+			it:           "lists the categories by group",
+			expected:     "Categories\n================\nfd = Food (food)\n  gr = Groceries\nsh = Shopping",
+			expectedList: "Categories\n================\nfd = Food (food)\n  gr = Groceries\nsh = Shopping",
+		},
+		{
+			it:           "adds a category with a capitalized name",
+			commands:     [][]string{{"add", "tv", "travel", "abroad"}},
+			expected:     "Added 'tv': Travel abroad",
+			expectedList: "Categories\n================\nfd = Food (food)\n  gr = Groceries\nsh = Shopping\ntv = Travel abroad",
+		},
+		{
+			it:           "renames an existing category",
+			commands:     [][]string{{"add", "sh", "shops"}},
+			expected:     "Renamed 'sh' to Shops",
+			expectedList: "Categories\n================\nfd = Food (food)\n  gr = Groceries\nsh = Shops",
+		},
+		{
+			it:           "adds an alias",
+			commands:     [][]string{{"alias", "shop", "sh"}},
+			expected:     "'shop' is now an alias of 'sh'",
+			expectedList: "Categories\n================\nfd = Food (food)\n  gr = Groceries\nsh = Shopping (shop)",
+		},
+		{
+			it:           "moves an alias to another category",
+			commands:     [][]string{{"alias", "food", "gr"}},
+			expected:     "'food' is now an alias of 'gr'",
+			expectedList: "Categories\n================\nfd = Food\n  gr = Groceries (food)\nsh = Shopping",
+		},
+		{
+			it:           "groups a category by alias",
+			commands:     [][]string{{"add", "rs", "restaurants"}, {"group", "rs", "food"}},
+			expected:     "Food > Restaurants",
+			expectedList: "Categories\n================\nfd = Food (food)\n  gr = Groceries\n  rs = Restaurants\nsh = Shopping",
+		},
+		{
+			it:           "ungroups a category",
+			commands:     [][]string{{"ungroup", "gr"}},
+			expected:     "'gr' is no longer in a group",
+			expectedList: "Categories\n================\nfd = Food (food)\ngr = Groceries\nsh = Shopping",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			service := NewService(testCategoryConfig, memory.NewStore())
+
+			var res string
+			var err *errors.AppError
+			for _, cmd := range tc.commands {
+				res, err = handle(service, cmd...)
+				assert.Nil(t, err)
+			}
+			list, err := handle(service)
+
+			assert.Nil(t, err)
+			if tc.commands == nil {
+				res = list
+			}
+			assert.Equal(t, tc.expected, res)
+			assert.Equal(t, tc.expectedList, list)
+		})
+	}
+}
+
+func TestHandle_Stored(t *testing.T) {
+	store := memory.NewStore()
+	for _, cmd := range [][]string{{"add", "tv", "travel"}, {"alias", "food", "tv"}, {"group", "sh", "tv"}} {
+		_, err := handle(NewService(testCategoryConfig, store), cmd...)
+		assert.Nil(t, err)
+	}
+
+	// The edits outlive the service, and override the configured categories
+	list, err := handle(NewService(testCategoryConfig, store))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Categories\n================\nfd = Food\n  gr = Groceries\ntv = Travel (food)\n  sh = Shopping", list)
+}
+
+func TestHandle_Empty(t *testing.T) {
+	service := NewService(domain.CategoryConfig{}, memory.NewStore())
+
+	res, err := handle(service, "list")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "No category is registered, so any category is accepted.\nAdd one with 'category add <code> <name>'", res)
+}
+
+func TestHandle_Error(t *testing.T) {
+	testcases := []struct {
+		it          string
+		msg         []string
+		expectedErr *errors.AppError
+	}{
+		{
+			it:          "returns error when the action is unknown",
+			msg:         []string{"remove", "sh"},
+			expectedErr: errors.BadRequestError(invalidCategoryMsg),
+		},
+		{
+			it:          "returns error when the name is missing",
+			msg:         []string{"add", "sh"},
+			expectedErr: errors.BadRequestError(invalidCategoryMsg),
+		},
+		{
+			it:          "returns error when the code isn't letters",
+			msg:         []string{"add", "sh1", "shopping"},
+			expectedErr: errors.BadRequestError("Invalid category 'sh1', it must only contain letters"),
+		},
+		{
+			it:          "returns error when the code starts with k",
+			msg:         []string{"add", "kid", "children"},
+			expectedErr: errors.BadRequestError("Invalid category 'kid', it must not start with k, which multiplies the amount by 1,000"),
+		},
+		{
+			it:          "returns error when the alias starts with k",
+			msg:         []string{"alias", "kfc", "fd"},
+			expectedErr: errors.BadRequestError("Invalid category 'kfc', it must not start with k, which multiplies the amount by 1,000"),
+		},
+		{
+			it:          "returns error when the code is an alias",
+			msg:         []string{"add", "food", "food"},
+			expectedErr: errors.BadRequestError("'food' is already an alias of 'fd'"),
+		},
+		{
+			it:          "returns error when the alias is a category",
+			msg:         []string{"alias", "sh", "fd"},
+			expectedErr: errors.BadRequestError("'sh' is already a category"),
+		},
+		{
+			it:          "returns error when the aliased category is unknown",
+			msg:         []string{"alias", "tax", "tx"},
+			expectedErr: errors.NotFoundError("Unknown category 'tx'"),
+		},
+		{
+			it:          "returns error when the group is unknown",
+			msg:         []string{"group", "sh", "tx"},
+			expectedErr: errors.NotFoundError("Unknown category 'tx'"),
+		},
+		{
+			it:          "returns error when the group is in the category",
+			msg:         []string{"group", "fd", "gr"},
+			expectedErr: errors.BadRequestError("'fd' can't be in its own group"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			service := NewService(testCategoryConfig, memory.NewStore())
+
+			res, err := handle(service, tc.msg...)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Empty(t, res)
+		})
+	}
+}
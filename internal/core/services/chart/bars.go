@@ -0,0 +1,107 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+)
+
+const (
+	barsHeight     = 560
+	barsPlotTop    = 110
+	barsPlotBottom = 480
+	barsPlotLeft   = 150
+	barsGroupWidth = 80
+	barWidth       = 28
+
+	// gridLines is the number of horizontal lines above the axis.
+	gridLines = 4
+)
+
+var (
+	revenueColor = color.RGBA{R: 0x59, G: 0xa1, B: 0x4f, A: 0xff}
+	expenseColor = color.RGBA{R: 0xe1, G: 0x57, B: 0x59, A: 0xff}
+)
+
+// MonthlyStatement is the statement of the month starting at Month.
+type MonthlyStatement struct {
+	Month     time.Time
+	Statement *domain.GetOverviewStatementResponse
+}
+
+// MonthlyBars renders a revenue and an expense bar per month, in the given
+// order.
+func MonthlyBars(title string, months []MonthlyStatement) ([]byte, error) {
+	width := barsPlotLeft + len(months)*barsGroupWidth + 40
+	img := newCanvas(max(width, 600), barsHeight)
+	drawText(img, 20, 16, title, foreground)
+	fillRect(img, image.Rect(20, 60, 38, 78), revenueColor)
+	drawText(img, 46, 56, "revenue", foreground)
+	fillRect(img, image.Rect(220, 60, 238, 78), expenseColor)
+	drawText(img, 246, 56, "expense", foreground)
+
+	var top float64
+	for _, m := range months {
+		revenue, expense := totals(m.Statement)
+		top = math.Max(top, math.Max(revenue, expense))
+	}
+	top = niceCeil(top)
+
+	plotHeight := barsPlotBottom - barsPlotTop
+	for i := 0; i <= gridLines; i++ {
+		y := barsPlotBottom - plotHeight*i/gridLines
+		fillRect(img, image.Rect(barsPlotLeft-6, y, img.Bounds().Dx()-20, y+1), gridColor)
+		label := formatAmount(top * float64(i) / gridLines)
+		drawText(img, barsPlotLeft-12-textWidth(label), y-textHeight/2, label, foreground)
+	}
+
+	barHeight := func(amount float64) int {
+		if top == 0 || amount <= 0 {
+			return 0
+		}
+		return int(math.Round(amount / top * float64(plotHeight)))
+	}
+	for i, m := range months {
+		revenue, expense := totals(m.Statement)
+		x := barsPlotLeft + i*barsGroupWidth + (barsGroupWidth-2*barWidth)/2
+		fillRect(img, image.Rect(x, barsPlotBottom-barHeight(revenue), x+barWidth, barsPlotBottom), revenueColor)
+		fillRect(img, image.Rect(x+barWidth, barsPlotBottom-barHeight(expense), x+2*barWidth, barsPlotBottom), expenseColor)
+		label := m.Month.Format("Jan")
+		if i == 0 || m.Month.Month() == time.January {
+			label = m.Month.Format("Jan'06")
+		}
+		centre := barsPlotLeft + i*barsGroupWidth + barsGroupWidth/2
+		drawText(img, centre-textWidth(label)/2, barsPlotBottom+12, label, foreground)
+	}
+	fillRect(img, image.Rect(barsPlotLeft-6, barsPlotBottom, img.Bounds().Dx()-20, barsPlotBottom+2), foreground)
+	return encode(img)
+}
+
+func totals(res *domain.GetOverviewStatementResponse) (float64, float64) {
+	var revenue, expense float64
+	if res.Revenue != nil {
+		revenue = res.Revenue.Total
+	}
+	if res.Expense != nil {
+		expense = res.Expense.Total
+	}
+	return revenue, expense
+}
+
+// niceCeil rounds the top of the axis up to 1, 2 or 5 times a power of ten,
+// e.g. 37,400 to 50,000.
+func niceCeil(amount float64) float64 {
+	if amount <= 0 {
+		return 0
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(amount)))
+	for _, step := range []float64{1, 2, 5, 10} {
+		if amount <= step*magnitude {
+			return step * magnitude
+		}
+	}
+	return 10 * magnitude
+}
@@ -0,0 +1,108 @@
+// Package chart renders statements as PNG images to be sent in chat: a pie
+// of the expense categories and bars of the monthly revenue and expense.
+// It only uses the standard library and the fixed-size basic font, so the
+// same statement always renders to the same pixels.
+package chart
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// textScale enlarges the 7x13 basic font to be readable on phones.
+const textScale = 2
+
+var (
+	background = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	foreground = color.RGBA{R: 0x33, G: 0x33, B: 0x33, A: 0xff}
+	gridColor  = color.RGBA{R: 0xdd, G: 0xdd, B: 0xdd, A: 0xff}
+
+	// palette colors the slices of the pie, in order.
+	palette = []color.RGBA{
+		{R: 0x4e, G: 0x79, B: 0xa7, A: 0xff},
+		{R: 0xf2, G: 0x8e, B: 0x2b, A: 0xff},
+		{R: 0xe1, G: 0x57, B: 0x59, A: 0xff},
+		{R: 0x76, G: 0xb7, B: 0xb2, A: 0xff},
+		{R: 0x59, G: 0xa1, B: 0x4f, A: 0xff},
+		{R: 0xed, G: 0xc9, B: 0x48, A: 0xff},
+		{R: 0xb0, G: 0x7a, B: 0xa1, A: 0xff},
+		{R: 0x9c, G: 0x75, B: 0x5f, A: 0xff},
+	}
+)
+
+func newCanvas(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+	return img
+}
+
+func encode(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("cannot encode chart: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func fillRect(img *image.RGBA, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// drawText writes s with its top-left corner at (x, y). The basic font only
+// has ASCII glyphs, other characters are drawn as boxes.
+func drawText(img *image.RGBA, x, y int, s string, c color.Color) {
+	face := basicfont.Face7x13
+	width := font.MeasureString(face, s).Ceil()
+	if width == 0 {
+		return
+	}
+	small := image.NewRGBA(image.Rect(0, 0, width, face.Height))
+	d := &font.Drawer{
+		Dst:  small,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(0, face.Ascent),
+	}
+	d.DrawString(s)
+	dst := image.Rect(x, y, x+width*textScale, y+face.Height*textScale)
+	draw.NearestNeighbor.Scale(img, dst, small, small.Bounds(), draw.Over, nil)
+}
+
+// textWidth returns the width of s as drawn by drawText.
+func textWidth(s string) int {
+	return font.MeasureString(basicfont.Face7x13, s).Ceil() * textScale
+}
+
+const textHeight = 13 * textScale
+
+// formatAmount writes the amount in baht with thousands separators, e.g.
+// 1,234.5. The font has no baht sign.
+func formatAmount(amount float64) string {
+	s := strconv.FormatFloat(math.Round(math.Abs(amount)*100)/100, 'f', -1, 64)
+	integer, fraction, hasFraction := strings.Cut(s, ".")
+	var sb strings.Builder
+	if amount < 0 {
+		sb.WriteString("-")
+	}
+	for i, r := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteRune(r)
+	}
+	if hasFraction {
+		sb.WriteString("." + fraction)
+	}
+	return sb.String()
+}
@@ -0,0 +1,140 @@
+package chart
+
+import (
+	"bytes"
+	"flag"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden images")
+
+// assertGolden compares the pixels of the PNG with testdata/<name>.png.
+// Run the tests with -update to accept a new rendering.
+func assertGolden(t *testing.T, name string, content []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".png")
+	if *update {
+		require.NoError(t, os.MkdirAll("testdata", 0o755))
+		require.NoError(t, os.WriteFile(path, content, 0o644))
+	}
+	golden, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	expected, err := png.Decode(bytes.NewReader(golden))
+	require.NoError(t, err)
+	actual, err := png.Decode(bytes.NewReader(content))
+	require.NoError(t, err)
+	require.Equal(t, expected.Bounds(), actual.Bounds())
+	for y := expected.Bounds().Min.Y; y < expected.Bounds().Max.Y; y++ {
+		for x := expected.Bounds().Min.X; x < expected.Bounds().Max.X; x++ {
+			if expected.At(x, y) != actual.At(x, y) {
+				t.Fatalf("%s differs from the golden image at (%d, %d), run the tests with -update if the change is intended", name, x, y)
+			}
+		}
+	}
+}
+
+func TestExpensePie(t *testing.T) {
+	testcases := []struct {
+		it      string
+		golden  string
+		entries []domain.CategorizedEntry
+	}{
+		{
+			it:     "renders a slice per category",
+			golden: "pie",
+			entries: []domain.CategorizedEntry{
+				{Category: "sh", Amount: 1200},
+				{Category: "fd", Amount: 6500.5},
+				{Category: "travel", Amount: 3000},
+			},
+		},
+		{
+			it:     "merges the smallest categories into other",
+			golden: "pie_other",
+			entries: []domain.CategorizedEntry{
+				{Category: "fd", Amount: 9000},
+				{Category: "rent", Amount: 8000},
+				{Category: "travel", Amount: 7000},
+				{Category: "sh", Amount: 6000},
+				{Category: "bills", Amount: 5000},
+				{Category: "health", Amount: 4000},
+				{Category: "gift", Amount: 300},
+				{Category: "tax", Amount: 200},
+				{Category: "fee", Amount: 100},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			res, err := ExpensePie("Expense 2025-03", &domain.GetOverviewStatementResponse{
+				Expense: &domain.GetOverviewStatementSection{Entries: tc.entries},
+			})
+
+			require.NoError(t, err)
+			assertGolden(t, tc.golden, res)
+		})
+	}
+}
+
+func TestExpensePie_NoExpense(t *testing.T) {
+	res, err := ExpensePie("Expense", &domain.GetOverviewStatementResponse{})
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	res, err = ExpensePie("Expense", &domain.GetOverviewStatementResponse{
+		Expense: &domain.GetOverviewStatementSection{Entries: []domain.CategorizedEntry{{Category: "refund", Amount: -10}}},
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestMonthlyBars(t *testing.T) {
+	month := func(year int, m time.Month, revenue, expense float64) MonthlyStatement {
+		return MonthlyStatement{
+			Month: time.Date(year, m, 1, 0, 0, 0, 0, time.UTC),
+			Statement: &domain.GetOverviewStatementResponse{
+				Revenue: &domain.GetOverviewStatementSection{Total: revenue},
+				Expense: &domain.GetOverviewStatementSection{Total: expense},
+			},
+		}
+	}
+
+	res, err := MonthlyBars("2024-11 to 2025-02", []MonthlyStatement{
+		month(2024, time.November, 30000, 18000),
+		month(2024, time.December, 45000, 37400),
+		month(2025, time.January, 30000, 21000),
+		{Month: time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), Statement: &domain.GetOverviewStatementResponse{}},
+	})
+
+	require.NoError(t, err)
+	assertGolden(t, "bars", res)
+	img, err := png.Decode(bytes.NewReader(res))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 600, barsHeight), img.Bounds())
+}
+
+func TestFormatAmount(t *testing.T) {
+	assert.Equal(t, "0", formatAmount(0))
+	assert.Equal(t, "999", formatAmount(999))
+	assert.Equal(t, "1,000", formatAmount(1000))
+	assert.Equal(t, "1,234,567.5", formatAmount(1234567.5))
+	assert.Equal(t, "-12,000.25", formatAmount(-12000.25))
+}
+
+func TestNiceCeil(t *testing.T) {
+	assert.Equal(t, 0.0, niceCeil(0))
+	assert.Equal(t, 50000.0, niceCeil(37400))
+	assert.Equal(t, 100000.0, niceCeil(50001))
+	assert.Equal(t, 20.0, niceCeil(20))
+}
@@ -0,0 +1,98 @@
+package chart
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"sort"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+)
+
+const (
+	pieWidth  = 900
+	pieHeight = 560
+	pieRadius = 220
+
+	// maxSlices is the number of categories shown, the smaller ones are
+	// merged into "other".
+	maxSlices = 7
+)
+
+type slice struct {
+	label  string
+	amount float64
+}
+
+// ExpensePie renders the expense categories of the statement as a pie with
+// a legend, largest first. It returns nil when there are no expenses.
+func ExpensePie(title string, res *domain.GetOverviewStatementResponse) ([]byte, error) {
+	if res.Expense == nil {
+		return nil, nil
+	}
+	slices := expenseSlices(res.Expense.Entries)
+	var total float64
+	for _, s := range slices {
+		total += s.amount
+	}
+	if total <= 0 {
+		return nil, nil
+	}
+
+	img := newCanvas(pieWidth, pieHeight)
+	drawText(img, 20, 16, title, foreground)
+
+	cx, cy := 40+pieRadius, 70+pieRadius
+	ends := make([]float64, len(slices))
+	var cumulated float64
+	for i, s := range slices {
+		cumulated += s.amount
+		ends[i] = cumulated / total
+	}
+	for y := cy - pieRadius; y <= cy+pieRadius; y++ {
+		for x := cx - pieRadius; x <= cx+pieRadius; x++ {
+			dx, dy := float64(x-cx), float64(y-cy)
+			if dx*dx+dy*dy > pieRadius*pieRadius {
+				continue
+			}
+			// clockwise from 12 o'clock, in [0, 1)
+			turn := math.Atan2(dx, -dy) / (2 * math.Pi)
+			if turn < 0 {
+				turn++
+			}
+			i := sort.SearchFloat64s(ends, turn)
+			i = min(i, len(slices)-1)
+			img.SetRGBA(x, y, palette[i%len(palette)])
+		}
+	}
+
+	legendX, legendY := cx+pieRadius+40, 90
+	for i, s := range slices {
+		y := legendY + i*(textHeight+14)
+		fillRect(img, image.Rect(legendX, y+4, legendX+18, y+22), palette[i%len(palette)])
+		label := fmt.Sprintf("%s %s (%.1f%%)", s.label, formatAmount(s.amount), s.amount/total*100)
+		drawText(img, legendX+28, y, label, foreground)
+	}
+	drawText(img, legendX, legendY+maxSlices*(textHeight+14)+20, "total "+formatAmount(total), foreground)
+	return encode(img)
+}
+
+// expenseSlices sorts the positive entries, largest first, and merges the
+// ones after maxSlices-1 into "other".
+func expenseSlices(entries []domain.CategorizedEntry) []slice {
+	var slices []slice
+	for _, v := range entries {
+		if v.Amount > 0 {
+			slices = append(slices, slice{label: v.Category, amount: v.Amount})
+		}
+	}
+	sort.SliceStable(slices, func(i, j int) bool { return slices[i].amount > slices[j].amount })
+	if len(slices) <= maxSlices {
+		return slices
+	}
+	other := slice{label: "other"}
+	for _, s := range slices[maxSlices-1:] {
+		other.amount += s.amount
+	}
+	return append(slices[:maxSlices-1], other)
+}
@@ -1,6 +1,10 @@
 package services
 
-import "github.com/sMARCHz/secretaria-bot/internal/core/errors"
+import (
+	"context"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+)
 
 // CommandHandler handles a specific command namespace (e.g. finance).
 type CommandHandler interface {
@@ -8,5 +12,5 @@ type CommandHandler interface {
 	Match(cmd string) bool
 
 	// Handle executes the command. msgArgs is tokenized input (fields).
-	Handle(msgArgs []string) (string, *errors.AppError)
+	Handle(ctx context.Context, msgArgs []string) (string, *errors.AppError)
 }
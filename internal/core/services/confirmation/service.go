@@ -0,0 +1,270 @@
+package confirmation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/client"
+)
+
+const (
+	invalidConfirmMsg = "Invalid command's arguments.\nPlease answer yes or no"
+
+	// anomalyWindow is how far back the typical amount of a category is
+	// taken from.
+	anomalyWindow = 90 * 24 * time.Hour
+	// minSamples is how many transactions of the category make a typical
+	// amount.
+	minSamples = 5
+	// typicalTTL is how long the typical amounts of a user are reused before
+	// the statement is fetched again.
+	typicalTTL = 10 * time.Minute
+)
+
+// SubmitFunc sends a transaction to the finance service and returns the
+// reply to the user.
+type SubmitFunc func(ctx context.Context) (string, *errors.AppError)
+
+type pendingTransaction struct {
+	transaction domain.Transaction
+	message     string
+	submit      SubmitFunc
+	expiresAt   time.Time
+}
+
+// typicalAmounts are the medians of a user's withdrawals, or deposits, by
+// category. The categories with too few samples are left out.
+type typicalAmounts struct {
+	medians   map[string]float64
+	expiresAt time.Time
+}
+
+// Service holds the transactions which cross a threshold, or which are
+// unusually large for their category, until the user confirms them with
+// "yes" or drops them with "no".
+//
+// Held transactions are kept in memory, so a restart forgets them.
+type Service struct {
+	client client.FinanceServiceClient
+	cfg    domain.ConfirmationConfig
+
+	mu       sync.Mutex
+	pending  map[string]pendingTransaction // by user ID
+	typicals map[string]typicalAmounts     // by user ID and transaction type
+	now      func() time.Time
+}
+
+// NewService constructs the confirmation service. The typical amounts of
+// the categories are taken from the client's statements.
+func NewService(client client.FinanceServiceClient, cfg domain.ConfirmationConfig) *Service {
+	return &Service{
+		client:   client,
+		cfg:      cfg,
+		pending:  make(map[string]pendingTransaction),
+		typicals: make(map[string]typicalAmounts),
+		now:      time.Now,
+	}
+}
+
+// Submit sends the transaction through submit, unless it must be
+// confirmed first. It is then held, replacing the user's previous held
+// transaction, and the user is asked to confirm it.
+func (s *Service) Submit(ctx context.Context, t domain.Transaction, submit SubmitFunc) (string, *errors.AppError) {
+	reason := s.check(ctx, t)
+	if reason == "" {
+		return submit(ctx)
+	}
+
+	user, _ := domain.UserFromContext(ctx)
+	message, _ := domain.MessageFromContext(ctx)
+	s.mu.Lock()
+	s.prune()
+	s.pending[user.ID] = pendingTransaction{transaction: t, message: message, submit: submit, expiresAt: s.now().Add(s.cfg.Timeout)}
+	s.mu.Unlock()
+
+	logger.Ctx(ctx).Infow("held transaction", "type", t.Type, "amount", t.Amount, "reason", reason)
+	if replies, ok := domain.QuickRepliesFromContext(ctx); ok {
+		replies.Add("yes", "no")
+	}
+	return reason + "\n" + prompt(t), nil
+}
+
+// check returns why the transaction must be confirmed, or "" when it can
+// be sent.
+func (s *Service) check(ctx context.Context, t domain.Transaction) string {
+	if threshold, of := s.threshold(t); threshold > 0 && t.Amount > threshold {
+		if of != "" {
+			of = " of " + of
+		}
+		return fmt.Sprintf("%s is over the %s threshold%s.", formatAmount(t.Amount), formatAmount(threshold), of)
+	}
+	if s.cfg.AnomalyFactor > 0 && t.Category != "" {
+		if typical := s.typical(ctx, t); typical > 0 && t.Amount > s.cfg.AnomalyFactor*typical {
+			ratio := strconv.FormatFloat(math.Round(t.Amount/typical*10)/10, 'f', -1, 64)
+			return fmt.Sprintf("%s is %sx your typical %s for %s.", formatAmount(t.Amount), ratio, formatAmount(typical), t.Category)
+		}
+	}
+	return ""
+}
+
+// threshold returns the threshold of the transaction's category, else of
+// its account, else the default one, and what it is set for.
+func (s *Service) threshold(t domain.Transaction) (float64, string) {
+	if threshold, exist := s.cfg.CategoryThresholds[t.Category]; exist && t.Category != "" {
+		return threshold, t.Category
+	}
+	if threshold, exist := s.cfg.AccountThresholds[t.Account]; exist {
+		return threshold, t.Account
+	}
+	return s.cfg.Threshold, ""
+}
+
+// typical returns the median amount of the user's withdrawals, or deposits,
+// in the transaction's category over the anomaly window, or 0 when there
+// are too few of them. The medians of all the categories are kept for
+// typicalTTL, so the statement is only fetched once in a while. A failing
+// statement doesn't hold the transaction.
+func (s *Service) typical(ctx context.Context, t domain.Transaction) float64 {
+	user, _ := domain.UserFromContext(ctx)
+	key := user.ID + "/" + t.Type
+	s.mu.Lock()
+	cached, exist := s.typicals[key]
+	s.mu.Unlock()
+	if exist && s.now().Before(cached.expiresAt) {
+		return cached.medians[t.Category]
+	}
+
+	now := s.now()
+	res, err := s.client.GetDetailedStatement(ctx, &domain.GetOverviewStatementRequest{From: now.Add(-anomalyWindow).UTC(), To: now.UTC()})
+	if err != nil {
+		logger.Ctx(ctx).Warnw("cannot get the typical amount, not checking for anomalies", "category", t.Category, "error", err)
+		return 0
+	}
+	section := res.Expense
+	if t.Type == domain.TransactionDeposit {
+		section = res.Revenue
+	}
+	medians := typicalMedians(ctx, section)
+	s.mu.Lock()
+	s.typicals[key] = typicalAmounts{medians: medians, expiresAt: now.Add(typicalTTL)}
+	s.mu.Unlock()
+	return medians[t.Category]
+}
+
+// typicalMedians returns the median amount of each category of the section,
+// only counting the accounts the caller's role grants.
+func typicalMedians(ctx context.Context, section *domain.GetDetailedStatementSection) map[string]float64 {
+	if section == nil {
+		return nil
+	}
+	user, hasUser := domain.UserFromContext(ctx)
+	amounts := make(map[string][]float64)
+	for _, e := range section.Entries {
+		if !hasUser || user.Role.CanAccess(e.Account) {
+			amounts[e.Category] = append(amounts[e.Category], e.Amount)
+		}
+	}
+	medians := make(map[string]float64, len(amounts))
+	for category, a := range amounts {
+		if len(a) < minSamples {
+			continue
+		}
+		slices.Sort(a)
+		mid := len(a) / 2
+		if len(a)%2 == 0 {
+			medians[category] = (a[mid-1] + a[mid]) / 2
+		} else {
+			medians[category] = a[mid]
+		}
+	}
+	return medians
+}
+
+func (s *Service) Match(cmd string) bool {
+	return cmd == "yes" || cmd == "no"
+}
+
+// Handle serves "yes", which sends the held transaction, and "no", which
+// drops it.
+func (s *Service) Handle(ctx context.Context, tokenizedMsg []string) (string, *errors.AppError) {
+	if len(tokenizedMsg) != 1 {
+		return "", errors.BadRequestError(invalidConfirmMsg)
+	}
+	user, _ := domain.UserFromContext(ctx)
+
+	// Answering takes the transaction, so that it's only sent once
+	s.mu.Lock()
+	s.prune()
+	pending, exist := s.pending[user.ID]
+	delete(s.pending, user.ID)
+	s.mu.Unlock()
+	if !exist {
+		return "", errors.NotFoundError("There is no transaction to confirm")
+	}
+
+	if tokenizedMsg[0] == "no" {
+		logger.Ctx(ctx).Infow("dropped held transaction", "type", pending.transaction.Type)
+		return "The transaction is cancelled", nil
+	}
+	logger.Ctx(ctx).Infow("confirmed held transaction", "type", pending.transaction.Type)
+	// Recorded for the message which sent the transaction
+	return pending.submit(domain.ContextWithMessage(ctx, pending.message))
+}
+
+// prune forgets the expired transactions and typical amounts. The caller
+// must hold mu.
+func (s *Service) prune() {
+	now := s.now()
+	for userID, pending := range s.pending {
+		if !now.Before(pending.expiresAt) {
+			delete(s.pending, userID)
+		}
+	}
+	for key, typical := range s.typicals {
+		if !now.Before(typical.expiresAt) {
+			delete(s.typicals, key)
+		}
+	}
+}
+
+// prompt asks to confirm the transaction, e.g. "Confirm ฿20,000 to sh?
+// yes/no".
+func prompt(t domain.Transaction) string {
+	var to string
+	switch t.Type {
+	case domain.TransactionWithdrawal:
+		to = "to " + t.Category
+	case domain.TransactionDeposit:
+		to = "from " + t.Category
+	case domain.TransactionTransfer:
+		to = "to " + t.ToAccount
+	}
+	return fmt.Sprintf("Confirm %s %s? yes/no", formatAmount(t.Amount), to)
+}
+
+// formatAmount shows the amount with its thousands separated, e.g.
+// ฿20,000 or ฿1,234.5.
+func formatAmount(amount float64) string {
+	s := strconv.FormatFloat(math.Round(amount*100)/100, 'f', -1, 64)
+	whole, fraction, hasFraction := strings.Cut(s, ".")
+	var sb strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteRune(digit)
+	}
+	if hasFraction {
+		sb.WriteString("." + fraction)
+	}
+	return "฿" + sb.String()
+}
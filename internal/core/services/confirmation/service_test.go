@@ -0,0 +1,292 @@
+package confirmation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	owner = domain.User{
+		ID:   "owner",
+		Role: domain.Role{Name: "owner", Commands: []string{"*"}, Accounts: []string{"*"}},
+	}
+	testConfig = domain.ConfirmationConfig{
+		Threshold:          10000,
+		AccountThresholds:  map[string]float64{"credit1": 5000},
+		CategoryThresholds: map[string]float64{"rent": 20000},
+		Timeout:            5 * time.Minute,
+	}
+	shopping = domain.Transaction{Type: domain.TransactionWithdrawal, Account: "debit1", Category: "sh", Amount: 20000}
+)
+
+// submitter records whether the transaction was sent, and with which
+// message.
+type submitter struct {
+	calls   int
+	message string
+}
+
+func (s *submitter) submit(ctx context.Context) (string, *errors.AppError) {
+	s.calls++
+	s.message, _ = domain.MessageFromContext(ctx)
+	return "Successfully withdraw", nil
+}
+
+func userContext(message string) context.Context {
+	return domain.ContextWithMessage(domain.ContextWithUser(context.Background(), owner), message)
+}
+
+func TestSubmit_Threshold(t *testing.T) {
+	testcases := []struct {
+		it               string
+		transaction      domain.Transaction
+		expectedReplyMsg string
+	}{
+		{
+			it:               "sends a transaction under the threshold",
+			transaction:      domain.Transaction{Type: domain.TransactionWithdrawal, Account: "debit1", Category: "sh", Amount: 2000},
+			expectedReplyMsg: "Successfully withdraw",
+		},
+		{
+			it:               "holds a transaction over the default threshold",
+			transaction:      shopping,
+			expectedReplyMsg: "฿20,000 is over the ฿10,000 threshold.\nConfirm ฿20,000 to sh? yes/no",
+		},
+		{
+			it:               "holds a transaction over the account's threshold",
+			transaction:      domain.Transaction{Type: domain.TransactionWithdrawal, Account: "credit1", Category: "sh", Amount: 6000},
+			expectedReplyMsg: "฿6,000 is over the ฿5,000 threshold of credit1.\nConfirm ฿6,000 to sh? yes/no",
+		},
+		{
+			it:               "prefers the category's threshold to the account's",
+			transaction:      domain.Transaction{Type: domain.TransactionWithdrawal, Account: "credit1", Category: "rent", Amount: 15000},
+			expectedReplyMsg: "Successfully withdraw",
+		},
+		{
+			it:               "asks where a deposit comes from",
+			transaction:      domain.Transaction{Type: domain.TransactionDeposit, Account: "debit1", Category: "salary", Amount: 30000},
+			expectedReplyMsg: "฿30,000 is over the ฿10,000 threshold.\nConfirm ฿30,000 from salary? yes/no",
+		},
+		{
+			it:               "asks where a transfer goes to",
+			transaction:      domain.Transaction{Type: domain.TransactionTransfer, Account: "debit1", ToAccount: "savings", Amount: 12345.5},
+			expectedReplyMsg: "฿12,345.5 is over the ฿10,000 threshold.\nConfirm ฿12,345.5 to savings? yes/no",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			service := NewService(mocks.NewMockFinanceServiceClient(t), testConfig)
+			s := &submitter{}
+
+			res, err := service.Submit(userContext(""), tc.transaction, s.submit)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedReplyMsg, res)
+		})
+	}
+}
+
+func TestSubmit_Anomaly(t *testing.T) {
+	typical := &domain.GetDetailedStatementResponse{
+		Expense: &domain.GetDetailedStatementSection{Entries: []domain.Entry{
+			{Category: "fd", Amount: 100},
+			{Category: "fd", Amount: 150},
+			{Category: "fd", Amount: 120},
+			{Category: "fd", Amount: 90},
+			{Category: "fd", Amount: 400},
+			{Category: "sh", Amount: 5000},
+		}},
+	}
+	testcases := []struct {
+		it               string
+		amount           float64
+		res              *domain.GetDetailedStatementResponse
+		err              *errors.AppError
+		expectedReplyMsg string
+	}{
+		{
+			it:               "holds a transaction many times the category's median",
+			amount:           1200,
+			res:              typical,
+			expectedReplyMsg: "฿1,200 is 10x your typical ฿120 for fd.\nConfirm ฿1,200 to fd? yes/no",
+		},
+		{
+			it:               "sends a transaction close to the category's median",
+			amount:           500,
+			res:              typical,
+			expectedReplyMsg: "Successfully withdraw",
+		},
+		{
+			it:     "sends the transaction when the category has too few samples",
+			amount: 1200,
+			res: &domain.GetDetailedStatementResponse{
+				Expense: &domain.GetDetailedStatementSection{Entries: []domain.Entry{{Category: "fd", Amount: 100}}},
+			},
+			expectedReplyMsg: "Successfully withdraw",
+		},
+		{
+			it:               "sends the transaction when the statement fails",
+			amount:           1200,
+			err:              errors.BadGatewayError("cannot get detailed statement"),
+			expectedReplyMsg: "Successfully withdraw",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			client := mocks.NewMockFinanceServiceClient(t)
+			now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+			client.EXPECT().GetDetailedStatement(mock.Anything, &domain.GetOverviewStatementRequest{From: now.Add(-anomalyWindow), To: now}).
+				Return(tc.res, tc.err).Once()
+			service := NewService(client, domain.ConfirmationConfig{AnomalyFactor: 5, Timeout: time.Minute})
+			service.now = func() time.Time { return now }
+			s := &submitter{}
+
+			res, err := service.Submit(userContext(""), domain.Transaction{Type: domain.TransactionWithdrawal, Account: "debit1", Category: "fd", Amount: tc.amount}, s.submit)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedReplyMsg, res)
+		})
+	}
+}
+
+func TestSubmit_AnomalyCache(t *testing.T) {
+	member := domain.User{
+		ID:   "partner",
+		Role: domain.Role{Name: "member", Commands: []string{"*"}, Accounts: []string{"shared-*"}},
+	}
+	statement := &domain.GetDetailedStatementResponse{
+		Expense: &domain.GetDetailedStatementSection{Entries: []domain.Entry{
+			{Account: "shared-debit", Category: "fd", Amount: 100},
+			{Account: "shared-debit", Category: "fd", Amount: 150},
+			{Account: "shared-debit", Category: "fd", Amount: 120},
+			{Account: "shared-debit", Category: "fd", Amount: 90},
+			{Account: "shared-debit", Category: "fd", Amount: 400},
+			{Account: "debit1", Category: "fd", Amount: 5000},
+			{Account: "debit1", Category: "fd", Amount: 5000},
+		}},
+	}
+	client := mocks.NewMockFinanceServiceClient(t)
+	service := NewService(client, domain.ConfirmationConfig{AnomalyFactor: 5, Timeout: time.Minute})
+	now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	ctx := domain.ContextWithUser(context.Background(), member)
+	submit := func(category string, amount float64) string {
+		res, err := service.Submit(ctx, domain.Transaction{Type: domain.TransactionWithdrawal, Account: "shared-debit", Category: category, Amount: amount}, (&submitter{}).submit)
+		require.Nil(t, err)
+		return res
+	}
+
+	client.EXPECT().GetDetailedStatement(mock.Anything, mock.Anything).Return(statement, nil).Once()
+	assert.Equal(t, "฿1,200 is 10x your typical ฿120 for fd.\nConfirm ฿1,200 to fd? yes/no", submit("fd", 1200), "only the caller's accounts make the median")
+	assert.Equal(t, "Successfully withdraw", submit("fd", 500), "the medians are reused")
+	assert.Equal(t, "Successfully withdraw", submit("sh", 5000), "the medians of all the categories are kept")
+
+	client.EXPECT().GetDetailedStatement(mock.Anything, mock.Anything).Return(statement, nil).Once()
+	now = now.Add(typicalTTL)
+	assert.Equal(t, "Successfully withdraw", submit("fd", 500), "the medians are fetched again once they expire")
+}
+
+func TestSubmit_QuickReplies(t *testing.T) {
+	service := NewService(mocks.NewMockFinanceServiceClient(t), testConfig)
+	ctx, replies := domain.ContextWithQuickReplies(userContext(""))
+
+	_, err := service.Submit(ctx, shopping, (&submitter{}).submit)
+
+	require.Nil(t, err)
+	assert.Equal(t, []string{"yes", "no"}, replies.Answers())
+}
+
+func TestHandle(t *testing.T) {
+	testcases := []struct {
+		it               string
+		answer           []string
+		elapsed          time.Duration
+		expectedCalls    int
+		expectedReplyMsg string
+		expectedErr      *errors.AppError
+	}{
+		{
+			it:               "sends the held transaction on yes",
+			answer:           []string{"yes"},
+			expectedCalls:    1,
+			expectedReplyMsg: "Successfully withdraw",
+		},
+		{
+			it:               "drops the held transaction on no",
+			answer:           []string{"no"},
+			expectedReplyMsg: "The transaction is cancelled",
+		},
+		{
+			it:          "forgets the held transaction once it expires",
+			answer:      []string{"yes"},
+			elapsed:     5 * time.Minute,
+			expectedErr: errors.NotFoundError("There is no transaction to confirm"),
+		},
+		{
+			it:          "rejects extra arguments",
+			answer:      []string{"yes", "please"},
+			expectedErr: errors.BadRequestError(invalidConfirmMsg),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			service := NewService(mocks.NewMockFinanceServiceClient(t), testConfig)
+			now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+			service.now = func() time.Time { return now }
+			s := &submitter{}
+			_, err := service.Submit(userContext("!p debit1 20000sh"), shopping, s.submit)
+			require.Nil(t, err)
+			now = now.Add(tc.elapsed)
+
+			res, err := service.Handle(userContext("yes"), tc.answer)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedReplyMsg, res)
+			assert.Equal(t, tc.expectedCalls, s.calls)
+			if tc.expectedCalls > 0 {
+				// Recorded for the message which sent the transaction
+				assert.Equal(t, "!p debit1 20000sh", s.message)
+			}
+		})
+	}
+}
+
+func TestHandle_OnlyOnce(t *testing.T) {
+	service := NewService(mocks.NewMockFinanceServiceClient(t), testConfig)
+	s := &submitter{}
+	_, err := service.Submit(userContext(""), shopping, s.submit)
+	require.Nil(t, err)
+
+	_, err = service.Handle(userContext(""), []string{"yes"})
+	require.Nil(t, err)
+	res, err := service.Handle(userContext(""), []string{"yes"})
+
+	assert.Empty(t, res)
+	assert.Equal(t, errors.NotFoundError("There is no transaction to confirm"), err)
+	assert.Equal(t, 1, s.calls)
+}
+
+func TestMatch(t *testing.T) {
+	service := NewService(nil, domain.ConfirmationConfig{})
+
+	assert.True(t, service.Match("yes"))
+	assert.True(t, service.Match("no"))
+	assert.False(t, service.Match("y"))
+}
+
+func TestFormatAmount(t *testing.T) {
+	assert.Equal(t, "฿120", formatAmount(120))
+	assert.Equal(t, "฿20,000", formatAmount(20000))
+	assert.Equal(t, "฿1,234,567.89", formatAmount(1234567.891))
+}
@@ -0,0 +1,258 @@
+// Package daterange resolves the periods of the statement commands, e.g.
+// "last month", "2025-03", "q1" or "มีนาคม", and explicit dates into UTC
+// time ranges of whole local days.
+package daterange
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+)
+
+// DateLayout is the layout of explicit dates, e.g. 2025-01-31.
+const DateLayout = "2006-01-02"
+
+const (
+	// buddhistEraOffset converts Thai years, e.g. 2568, to AD years.
+	buddhistEraOffset = 543
+	minBuddhistYear   = 2400
+
+	// maxRollingDays bounds "last <n>d|w|m" to about ten years.
+	maxRollingDays = 3660
+)
+
+// Syntax lists the accepted periods, for error messages.
+const Syntax = "today, yesterday, this|last week|month|year, last 30d|2w|3m, 2025-03, 2025, q1 [2025] or a month name [2025|2568]"
+
+var (
+	monthPattern   = regexp.MustCompile(`^(\d{4})-(\d{2})$`)
+	yearPattern    = regexp.MustCompile(`^\d{4}$`)
+	quarterPattern = regexp.MustCompile(`^q([1-4])$`)
+	rollingPattern = regexp.MustCompile(`^(\d{1,4})(d|w|m)$`)
+)
+
+// months maps the English and Thai month names, with their abbreviations,
+// to their months.
+var months = map[string]time.Month{}
+
+func init() {
+	names := []struct {
+		month time.Month
+		names []string
+	}{
+		{time.January, []string{"january", "jan", "มกราคม", "ม.ค.", "มค"}},
+		{time.February, []string{"february", "feb", "กุมภาพันธ์", "ก.พ.", "กพ"}},
+		{time.March, []string{"march", "mar", "มีนาคม", "มี.ค.", "มีค"}},
+		{time.April, []string{"april", "apr", "เมษายน", "เม.ย.", "เมย"}},
+		{time.May, []string{"may", "พฤษภาคม", "พ.ค.", "พค"}},
+		{time.June, []string{"june", "jun", "มิถุนายน", "มิ.ย.", "มิย"}},
+		{time.July, []string{"july", "jul", "กรกฎาคม", "ก.ค.", "กค"}},
+		{time.August, []string{"august", "aug", "สิงหาคม", "ส.ค.", "สค"}},
+		{time.September, []string{"september", "sep", "กันยายน", "ก.ย.", "กย"}},
+		{time.October, []string{"october", "oct", "ตุลาคม", "ต.ค.", "ตค"}},
+		{time.November, []string{"november", "nov", "พฤศจิกายน", "พ.ย.", "พย"}},
+		{time.December, []string{"december", "dec", "ธันวาคม", "ธ.ค.", "ธค"}},
+	}
+	for _, n := range names {
+		for _, name := range n.names {
+			months[name] = n.month
+		}
+	}
+}
+
+// Parse resolves the period given by args relative to now, in now's time
+// zone, and returns it in UTC. The range is half-open: it starts at From and
+// ends before To. Weeks start on Monday, and a month name without year is
+// its latest occurrence. Words may be joined by '-', e.g. last-month.
+func Parse(args []string, now time.Time) (*domain.GetOverviewStatementRequest, *errors.AppError) {
+	tokens := tokenize(args)
+	if from, to, ok := resolve(tokens, now); ok {
+		return &domain.GetOverviewStatementRequest{From: from.UTC(), To: to.UTC()}, nil
+	}
+	return nil, errors.BadRequestError(fmt.Sprintf("Unknown period '%s'.\nPlease use %s", strings.Join(args, " "), Syntax))
+}
+
+// Days returns the whole days from the day of from to the day of to, both
+// included, as a UTC range: [from 00:00, to+1 00:00) in the dates' time zone.
+// The midnights are taken in the dates' zone, so days of 23 or 25 hours
+// around a DST change are whole as well.
+func Days(from, to time.Time) (*domain.GetOverviewStatementRequest, *errors.AppError) {
+	start := startOfDay(from)
+	end := startOfDay(to).AddDate(0, 0, 1)
+	if !start.Before(end) {
+		return nil, errors.BadRequestError(fmt.Sprintf("The end date %s is before the start date %s", to.Format(DateLayout), from.Format(DateLayout)))
+	}
+	return &domain.GetOverviewStatementRequest{From: start.UTC(), To: end.UTC()}, nil
+}
+
+// tokenize splits the words joined by '-', but not the dates like 2025-03.
+func tokenize(args []string) []string {
+	var tokens []string
+	for _, arg := range args {
+		if strings.ContainsFunc(arg, unicode.IsLetter) {
+			for _, t := range strings.Split(arg, "-") {
+				if t != "" {
+					tokens = append(tokens, t)
+				}
+			}
+			continue
+		}
+		tokens = append(tokens, arg)
+	}
+	return tokens
+}
+
+func resolve(tokens []string, now time.Time) (time.Time, time.Time, bool) {
+	today := startOfDay(now)
+	switch len(tokens) {
+	case 1:
+		return resolveOne(tokens[0], today)
+	case 2:
+		return resolveTwo(tokens[0], tokens[1], today)
+	default:
+		return time.Time{}, time.Time{}, false
+	}
+}
+
+func resolveOne(token string, today time.Time) (time.Time, time.Time, bool) {
+	switch token {
+	case "today":
+		return today, today.AddDate(0, 0, 1), true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), today, true
+	}
+	if year, ok := parseYear(token); ok {
+		return yearRange(year, today.Location())
+	}
+	if m := monthPattern.FindStringSubmatch(token); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		if month < 1 || month > 12 {
+			return time.Time{}, time.Time{}, false
+		}
+		return monthRange(year, time.Month(month), today.Location())
+	}
+	if quarter, ok := parseQuarter(token); ok {
+		return quarterRange(today.Year(), quarter, today.Location())
+	}
+	if month, ok := months[token]; ok {
+		year := today.Year()
+		if month > today.Month() {
+			year--
+		}
+		return monthRange(year, month, today.Location())
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+func resolveTwo(first, second string, today time.Time) (time.Time, time.Time, bool) {
+	switch first {
+	case "this", "last":
+		back := 0
+		if first == "last" {
+			back = 1
+		}
+		switch second {
+		case "week":
+			start := today.AddDate(0, 0, -(int(today.Weekday())+6)%7-7*back)
+			return start, start.AddDate(0, 0, 7), true
+		case "month":
+			start := time.Date(today.Year(), today.Month()-time.Month(back), 1, 0, 0, 0, 0, today.Location())
+			return start, start.AddDate(0, 1, 0), true
+		case "year":
+			return yearRange(today.Year()-back, today.Location())
+		}
+		if first == "last" {
+			return rollingRange(second, today)
+		}
+		return time.Time{}, time.Time{}, false
+	}
+
+	// q1 2025 or 2025 q1
+	if quarter, ok := parseQuarter(first); ok {
+		if year, ok := parseYear(second); ok {
+			return quarterRange(year, quarter, today.Location())
+		}
+	}
+	if quarter, ok := parseQuarter(second); ok {
+		if year, ok := parseYear(first); ok {
+			return quarterRange(year, quarter, today.Location())
+		}
+	}
+	// มีนาคม 2568 or march 2025
+	if month, ok := months[first]; ok {
+		if year, ok := parseYear(second); ok {
+			return monthRange(year, month, today.Location())
+		}
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// rollingRange resolves <n>d, <n>w and <n>m into the range ending with today.
+func rollingRange(token string, today time.Time) (time.Time, time.Time, bool) {
+	m := rollingPattern.FindStringSubmatch(token)
+	if m == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	n, _ := strconv.Atoi(m[1])
+	end := today.AddDate(0, 0, 1)
+	var start time.Time
+	switch m[2] {
+	case "d":
+		start = end.AddDate(0, 0, -n)
+	case "w":
+		start = end.AddDate(0, 0, -7*n)
+	case "m":
+		start = end.AddDate(0, -n, 0)
+	}
+	if n == 0 || end.Sub(start) > maxRollingDays*24*time.Hour {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, end, true
+}
+
+// parseYear accepts AD years and Buddhist Era years, e.g. 2568.
+func parseYear(token string) (int, bool) {
+	if !yearPattern.MatchString(token) {
+		return 0, false
+	}
+	year, _ := strconv.Atoi(token)
+	if year >= minBuddhistYear {
+		year -= buddhistEraOffset
+	}
+	return year, year >= 1900
+}
+
+func parseQuarter(token string) (int, bool) {
+	m := quarterPattern.FindStringSubmatch(token)
+	if m == nil {
+		return 0, false
+	}
+	quarter, _ := strconv.Atoi(m[1])
+	return quarter, true
+}
+
+func yearRange(year int, loc *time.Location) (time.Time, time.Time, bool) {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+	return start, start.AddDate(1, 0, 0), true
+}
+
+func monthRange(year int, month time.Month, loc *time.Location) (time.Time, time.Time, bool) {
+	start := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	return start, start.AddDate(0, 1, 0), true
+}
+
+func quarterRange(year, quarter int, loc *time.Location) (time.Time, time.Time, bool) {
+	start := time.Date(year, time.Month(3*(quarter-1)+1), 1, 0, 0, 0, 0, loc)
+	return start, start.AddDate(0, 3, 0), true
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
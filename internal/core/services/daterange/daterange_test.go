@@ -0,0 +1,198 @@
+package daterange
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	bangkok, err := time.LoadLocation("Asia/Bangkok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Wednesday
+	now := time.Date(2025, 3, 12, 9, 30, 0, 0, bangkok)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, bangkok).UTC()
+	}
+	testcases := []struct {
+		it           string
+		args         []string
+		expectedFrom time.Time
+		expectedTo   time.Time
+	}{
+		{it: "returns today", args: []string{"today"}, expectedFrom: date(2025, 3, 12), expectedTo: date(2025, 3, 13)},
+		{it: "returns yesterday", args: []string{"yesterday"}, expectedFrom: date(2025, 3, 11), expectedTo: date(2025, 3, 12)},
+		{it: "returns this week from Monday", args: []string{"this", "week"}, expectedFrom: date(2025, 3, 10), expectedTo: date(2025, 3, 17)},
+		{it: "returns last week", args: []string{"last", "week"}, expectedFrom: date(2025, 3, 3), expectedTo: date(2025, 3, 10)},
+		{it: "returns this month", args: []string{"this", "month"}, expectedFrom: date(2025, 3, 1), expectedTo: date(2025, 4, 1)},
+		{it: "returns last month joined by '-'", args: []string{"last-month"}, expectedFrom: date(2025, 2, 1), expectedTo: date(2025, 3, 1)},
+		{it: "returns this year", args: []string{"this-year"}, expectedFrom: date(2025, 1, 1), expectedTo: date(2026, 1, 1)},
+		{it: "returns last year", args: []string{"last", "year"}, expectedFrom: date(2024, 1, 1), expectedTo: date(2025, 1, 1)},
+		{it: "returns a month", args: []string{"2024-02"}, expectedFrom: date(2024, 2, 1), expectedTo: date(2024, 3, 1)},
+		{it: "returns a year", args: []string{"2024"}, expectedFrom: date(2024, 1, 1), expectedTo: date(2025, 1, 1)},
+		{it: "returns a Buddhist Era year", args: []string{"2567"}, expectedFrom: date(2024, 1, 1), expectedTo: date(2025, 1, 1)},
+		{it: "returns a quarter of this year", args: []string{"q2"}, expectedFrom: date(2025, 4, 1), expectedTo: date(2025, 7, 1)},
+		{it: "returns a quarter of a year", args: []string{"q4", "2024"}, expectedFrom: date(2024, 10, 1), expectedTo: date(2025, 1, 1)},
+		{it: "returns a quarter after the year", args: []string{"2024", "q1"}, expectedFrom: date(2024, 1, 1), expectedTo: date(2024, 4, 1)},
+		{it: "returns the last days including today", args: []string{"last", "30d"}, expectedFrom: date(2025, 2, 11), expectedTo: date(2025, 3, 13)},
+		{it: "returns the last weeks", args: []string{"last", "2w"}, expectedFrom: date(2025, 2, 27), expectedTo: date(2025, 3, 13)},
+		{it: "returns the last months", args: []string{"last-3m"}, expectedFrom: date(2024, 12, 13), expectedTo: date(2025, 3, 13)},
+		{it: "returns a Thai month of this year", args: []string{"มีนาคม"}, expectedFrom: date(2025, 3, 1), expectedTo: date(2025, 4, 1)},
+		{it: "returns the latest occurrence of a later month", args: []string{"ธันวาคม"}, expectedFrom: date(2024, 12, 1), expectedTo: date(2025, 1, 1)},
+		{it: "returns an abbreviated Thai month", args: []string{"ก.พ."}, expectedFrom: date(2025, 2, 1), expectedTo: date(2025, 3, 1)},
+		{it: "returns an abbreviated Thai month without dots", args: []string{"มค"}, expectedFrom: date(2025, 1, 1), expectedTo: date(2025, 2, 1)},
+		{it: "returns a Thai month of a Buddhist Era year", args: []string{"ม.ค.", "2568"}, expectedFrom: date(2025, 1, 1), expectedTo: date(2025, 2, 1)},
+		{it: "returns an English month of a year", args: []string{"sep", "2024"}, expectedFrom: date(2024, 9, 1), expectedTo: date(2024, 10, 1)},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			res, err := Parse(tc.args, now)
+
+			assert.Nil(t, err)
+			assert.Equal(t, &domain.GetOverviewStatementRequest{From: tc.expectedFrom, To: tc.expectedTo}, res)
+		})
+	}
+}
+
+func TestParse_DST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// DST starts on 2025-03-09, so the month is an hour shorter
+	now := time.Date(2025, 3, 20, 12, 0, 0, 0, newYork)
+
+	res, err := Parse([]string{"this", "month"}, now)
+
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2025, 3, 1, 5, 0, 0, 0, time.UTC), res.From)
+	assert.Equal(t, time.Date(2025, 4, 1, 4, 0, 0, 0, time.UTC), res.To)
+}
+
+func TestParse_WeekStartsOnMonday(t *testing.T) {
+	sunday := time.Date(2025, 3, 16, 23, 0, 0, 0, time.UTC)
+
+	res, err := Parse([]string{"this", "week"}, sunday)
+
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), res.From)
+	assert.Equal(t, time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC), res.To)
+}
+
+func TestParse_Error(t *testing.T) {
+	now := time.Date(2025, 3, 12, 9, 30, 0, 0, time.UTC)
+	testcases := []struct {
+		it   string
+		args []string
+	}{
+		{it: "returns error when no period is given", args: nil},
+		{it: "returns error for an unknown word", args: []string{"someday"}},
+		{it: "returns error for an invalid month", args: []string{"2025-13"}},
+		{it: "returns error for an invalid quarter", args: []string{"q5"}},
+		{it: "returns error for 'this' with a rolling period", args: []string{"this", "30d"}},
+		{it: "returns error for an empty rolling period", args: []string{"last", "0d"}},
+		{it: "returns error for a rolling period over ten years", args: []string{"last", "999m"}},
+		{it: "returns error for a year before 1900", args: []string{"1500"}},
+		{it: "returns error for too many words", args: []string{"last", "month", "please"}},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			res, err := Parse(tc.args, now)
+
+			assert.Nil(t, res)
+			assert.Equal(t, errors.BadRequestError("Unknown period '"+strings.Join(tc.args, " ")+"'.\nPlease use "+Syntax), err)
+		})
+	}
+}
+
+func TestDays(t *testing.T) {
+	bangkok, err := time.LoadLocation("Asia/Bangkok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testcases := []struct {
+		it           string
+		from         time.Time
+		to           time.Time
+		expectedFrom time.Time
+		expectedTo   time.Time
+	}{
+		{
+			it:           "includes the whole last day",
+			from:         time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:           time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+			expectedFrom: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			it:           "returns a single day when the dates are the same",
+			from:         time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:           time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedFrom: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			it:           "converts local days to UTC",
+			from:         time.Date(2025, 1, 1, 0, 0, 0, 0, bangkok),
+			to:           time.Date(2025, 1, 31, 0, 0, 0, 0, bangkok),
+			expectedFrom: time.Date(2024, 12, 31, 17, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2025, 1, 31, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			it:           "crosses the year boundary",
+			from:         time.Date(2024, 12, 31, 0, 0, 0, 0, bangkok),
+			to:           time.Date(2024, 12, 31, 0, 0, 0, 0, bangkok),
+			expectedFrom: time.Date(2024, 12, 30, 17, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2024, 12, 31, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			it:           "returns a 23 hour day when DST starts",
+			from:         time.Date(2025, 3, 9, 0, 0, 0, 0, newYork),
+			to:           time.Date(2025, 3, 9, 0, 0, 0, 0, newYork),
+			expectedFrom: time.Date(2025, 3, 9, 5, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2025, 3, 10, 4, 0, 0, 0, time.UTC),
+		},
+		{
+			it:           "returns a 25 hour day when DST ends",
+			from:         time.Date(2025, 11, 2, 0, 0, 0, 0, newYork),
+			to:           time.Date(2025, 11, 2, 0, 0, 0, 0, newYork),
+			expectedFrom: time.Date(2025, 11, 2, 4, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2025, 11, 3, 5, 0, 0, 0, time.UTC),
+		},
+		{
+			it:           "spans the New Year across DST",
+			from:         time.Date(2024, 10, 1, 0, 0, 0, 0, newYork),
+			to:           time.Date(2025, 3, 31, 0, 0, 0, 0, newYork),
+			expectedFrom: time.Date(2024, 10, 1, 4, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2025, 4, 1, 4, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			res, err := Days(tc.from, tc.to)
+
+			assert.Nil(t, err)
+			assert.Equal(t, &domain.GetOverviewStatementRequest{From: tc.expectedFrom, To: tc.expectedTo}, res)
+		})
+	}
+}
+
+func TestDays_Error(t *testing.T) {
+	res, err := Days(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC))
+
+	assert.Nil(t, res)
+	assert.Equal(t, errors.BadRequestError("The end date 2025-01-31 is before the start date 2025-02-01"), err)
+}
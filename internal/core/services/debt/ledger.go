@@ -0,0 +1,157 @@
+package debt
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+)
+
+var entryCollection = storage.NewCollection[[]domain.DebtEntry]("debts") // by user ID
+
+// Ledger keeps, for each user, what the people they paid for owe them.
+// The money itself goes through the finance service; the ledger only
+// knows who owes what.
+type Ledger struct {
+	cfg   domain.DebtConfig
+	store storage.Store
+}
+
+// NewLedger constructs the debt ledger.
+func NewLedger(cfg domain.DebtConfig, store storage.Store) *Ledger {
+	return &Ledger{cfg: cfg, store: store}
+}
+
+// Category returns the category of the lent money and its settlements.
+func (l *Ledger) Category() string {
+	return l.cfg.Category
+}
+
+// Add records what people owe the user.
+func (l *Ledger) Add(ctx context.Context, userID string, entries ...domain.DebtEntry) *errors.AppError {
+	err := l.store.Update(ctx, func(tx storage.Tx) error {
+		stored, _, err := entryCollection.Get(tx, userID)
+		if err != nil {
+			return err
+		}
+		return entryCollection.Put(tx, userID, append(stored, entries...))
+	})
+	if err != nil {
+		logger.Ctx(ctx).Errorw("failed to save debts", "error", err)
+		return errors.InternalServerError("cannot save the debts")
+	}
+	return nil
+}
+
+// Settle records that the person paid back amount, which must not be more
+// than they owe. It returns what they still owe.
+func (l *Ledger) Settle(ctx context.Context, userID string, entry domain.DebtEntry) (float64, *errors.AppError) {
+	var owed float64
+	var appErr *errors.AppError
+	err := l.store.Update(ctx, func(tx storage.Tx) error {
+		entries, _, err := entryCollection.Get(tx, userID)
+		if err != nil {
+			return err
+		}
+		owed = owedBy(entries, entry.Person)
+		if appErr = validateSettlement(entry.Person, owed, entry.Amount); appErr != nil {
+			return appErr
+		}
+		entry.Amount = -entry.Amount
+		return entryCollection.Put(tx, userID, append(entries, entry))
+	})
+	if appErr != nil {
+		return 0, appErr
+	}
+	if err != nil {
+		logger.Ctx(ctx).Errorw("failed to save settlement", "person", entry.Person, "error", err)
+		return 0, errors.InternalServerError("cannot save the settlement")
+	}
+	return round(owed + entry.Amount), nil
+}
+
+// CheckSettlement returns the error Settle would return, without recording
+// anything.
+func (l *Ledger) CheckSettlement(ctx context.Context, userID, person string, amount float64) *errors.AppError {
+	entries, err := l.entries(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return validateSettlement(person, owedBy(entries, person), amount)
+}
+
+// Balances returns what each person owes the user, by name, leaving out
+// those who owe nothing.
+func (l *Ledger) Balances(ctx context.Context, userID string) ([]domain.DebtBalance, *errors.AppError) {
+	entries, err := l.entries(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	totals := make(map[string]float64)
+	for _, e := range entries {
+		totals[e.Person] += e.Amount
+	}
+	var balances []domain.DebtBalance
+	for person, amount := range totals {
+		if amount = round(amount); amount != 0 {
+			balances = append(balances, domain.DebtBalance{Person: person, Amount: amount})
+		}
+	}
+	slices.SortFunc(balances, func(a, b domain.DebtBalance) int { return strings.Compare(a.Person, b.Person) })
+	return balances, nil
+}
+
+// Entries returns the user's entries with the person, oldest first.
+func (l *Ledger) Entries(ctx context.Context, userID, person string) ([]domain.DebtEntry, *errors.AppError) {
+	entries, err := l.entries(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(entries, func(e domain.DebtEntry) bool { return e.Person != person }), nil
+}
+
+// entries returns all the entries of the user.
+func (l *Ledger) entries(ctx context.Context, userID string) ([]domain.DebtEntry, *errors.AppError) {
+	var entries []domain.DebtEntry
+	if err := l.store.View(ctx, func(tx storage.Tx) error {
+		var err error
+		entries, _, err = entryCollection.Get(tx, userID)
+		return err
+	}); err != nil {
+		logger.Ctx(ctx).Errorw("failed to read debts", "error", err)
+		return nil, errors.InternalServerError("cannot read the debts")
+	}
+	return entries, nil
+}
+
+// owedBy returns what the person owes, from the entries.
+func owedBy(entries []domain.DebtEntry, person string) float64 {
+	var owed float64
+	for _, e := range entries {
+		if e.Person == person {
+			owed += e.Amount
+		}
+	}
+	return round(owed)
+}
+
+func validateSettlement(person string, owed, amount float64) *errors.AppError {
+	if owed <= 0 {
+		return errors.BadRequestError(fmt.Sprintf("@%s doesn't owe you anything", person))
+	}
+	if amount > owed {
+		return errors.BadRequestError(fmt.Sprintf("@%s only owes you ฿%v", person, owed))
+	}
+	return nil
+}
+
+// round rounds to satang, so that the sums of splits don't leave crumbs.
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,97 @@
+package debt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var date = time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+var ctx = context.Background()
+
+func newTestLedger(t *testing.T) *Ledger {
+	ledger := NewLedger(domain.DebtConfig{Category: "lent"}, memory.NewStore())
+	require.Nil(t, ledger.Add(ctx, "owner",
+		domain.DebtEntry{Person: "bob", Amount: 300, Description: "dinner", Date: date},
+		domain.DebtEntry{Person: "alice", Amount: 300, Description: "dinner", Date: date},
+		domain.DebtEntry{Person: "alice", Amount: 0.1, Description: "tip", Date: date},
+		domain.DebtEntry{Person: "alice", Amount: 0.2, Description: "tip", Date: date},
+	))
+	require.Nil(t, ledger.Add(ctx, "partner", domain.DebtEntry{Person: "carol", Amount: 50, Description: "taxi", Date: date}))
+	return ledger
+}
+
+func TestBalances(t *testing.T) {
+	ledger := newTestLedger(t)
+
+	balances, err := ledger.Balances(ctx, "owner")
+	assert.Nil(t, err)
+	assert.Equal(t, []domain.DebtBalance{{Person: "alice", Amount: 300.3}, {Person: "bob", Amount: 300}}, balances)
+	balances, err = ledger.Balances(ctx, "partner")
+	assert.Nil(t, err)
+	assert.Equal(t, []domain.DebtBalance{{Person: "carol", Amount: 50}}, balances)
+	balances, err = ledger.Balances(ctx, "unknown")
+	assert.Nil(t, err)
+	assert.Empty(t, balances)
+}
+
+func TestSettle(t *testing.T) {
+	ledger := newTestLedger(t)
+
+	owed, err := ledger.Settle(ctx, "owner", domain.DebtEntry{Person: "bob", Amount: 100, Description: "settled", Date: date})
+	assert.Nil(t, err)
+	assert.Equal(t, 200.0, owed)
+
+	owed, err = ledger.Settle(ctx, "owner", domain.DebtEntry{Person: "bob", Amount: 200, Description: "settled", Date: date})
+	assert.Nil(t, err)
+	assert.Equal(t, 0.0, owed)
+	balances, err := ledger.Balances(ctx, "owner")
+	assert.Nil(t, err)
+	assert.Equal(t, []domain.DebtBalance{{Person: "alice", Amount: 300.3}}, balances)
+	entries, err := ledger.Entries(ctx, "owner", "bob")
+	assert.Nil(t, err)
+	assert.Equal(t, []domain.DebtEntry{
+		{Person: "bob", Amount: 300, Description: "dinner", Date: date},
+		{Person: "bob", Amount: -100, Description: "settled", Date: date},
+		{Person: "bob", Amount: -200, Description: "settled", Date: date},
+	}, entries)
+}
+
+func TestSettle_Error(t *testing.T) {
+	testcases := []struct {
+		it          string
+		person      string
+		amount      float64
+		expectedErr *errors.AppError
+	}{
+		{
+			it:          "returns error when the person owes nothing",
+			person:      "carol",
+			amount:      10,
+			expectedErr: errors.BadRequestError("@carol doesn't owe you anything"),
+		},
+		{
+			it:          "returns error when the amount is more than owed",
+			person:      "alice",
+			amount:      400,
+			expectedErr: errors.BadRequestError("@alice only owes you ฿300.3"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			ledger := newTestLedger(t)
+
+			assert.Equal(t, tc.expectedErr, ledger.CheckSettlement(ctx, "owner", tc.person, tc.amount))
+			_, err := ledger.Settle(ctx, "owner", domain.DebtEntry{Person: tc.person, Amount: tc.amount})
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
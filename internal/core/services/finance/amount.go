@@ -0,0 +1,224 @@
+package finance
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// maxAmountLength and maxAmountDepth bound the work done on an input.
+	maxAmountLength = 64
+	maxAmountDepth  = 8
+)
+
+// amountError tells what is wrong with an amount expression and where.
+type amountError struct {
+	msg string
+	// pos is the 1-based position of the offending character, 0 if the
+	// error is about the whole expression.
+	pos int
+}
+
+func (e *amountError) Error() string {
+	if e.pos == 0 {
+		return e.msg
+	}
+	return fmt.Sprintf("%s at position %d", e.msg, e.pos)
+}
+
+// amountParser evaluates the amount of transaction commands, e.g. 120+45.5,
+// 1.2k, 3x40, (120+45)/3 or 1,200. It is a recursive descent parser of:
+//
+//	expression = term { ("+" | "-") term }
+//	term       = factor { ("*" | "x" | "/") factor }
+//	factor     = number ["k"] | "(" expression ")"
+//	number     = digit { digit | "," digit } ["." digit { digit }]
+//
+// "x" is only an operator when a number or "(" follows it, and "k" right
+// after a number means thousands when the input ends or an operator or ")"
+// follows it. Otherwise the amount is ambiguous, e.g. 50kfc, which is
+// written (50k)fc.
+type amountParser struct {
+	input string
+	pos   int
+	depth int
+}
+
+// parseAmount evaluates the expression at the start of s and returns its
+// value, rounded to satang, and the rest of s.
+func parseAmount(s string) (float64, string, *amountError) {
+	if len(s) > maxAmountLength {
+		return 0, "", &amountError{msg: fmt.Sprintf("the amount is longer than %d characters", maxAmountLength)}
+	}
+	p := &amountParser{input: s}
+	value, err := p.expression()
+	if err != nil {
+		return 0, "", err
+	}
+	// The length limit keeps the value finite
+	value = math.Round(value*100) / 100
+	if value <= 0 {
+		return 0, "", &amountError{msg: "the amount must be greater than 0"}
+	}
+	return value, s[p.pos:], nil
+}
+
+func (p *amountParser) expression() (float64, *amountError) {
+	value, err := p.term()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		switch p.peek() {
+		case '+':
+			p.pos++
+			v, err := p.term()
+			if err != nil {
+				return 0, err
+			}
+			value += v
+		case '-':
+			p.pos++
+			v, err := p.term()
+			if err != nil {
+				return 0, err
+			}
+			value -= v
+		default:
+			return value, nil
+		}
+	}
+}
+
+func (p *amountParser) term() (float64, *amountError) {
+	value, err := p.factor()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		op := p.peek()
+		if op != '*' && op != '/' && !(op == 'x' && p.startsFactor(p.pos+1)) {
+			return value, nil
+		}
+		opPos := p.position()
+		p.pos++
+		v, err := p.factor()
+		if err != nil {
+			return 0, err
+		}
+		if op == '/' {
+			if v == 0 {
+				return 0, &amountError{msg: "division by zero", pos: opPos}
+			}
+			value /= v
+		} else {
+			value *= v
+		}
+	}
+}
+
+func (p *amountParser) factor() (float64, *amountError) {
+	if p.peek() != '(' {
+		return p.number()
+	}
+	if p.depth == maxAmountDepth {
+		return 0, &amountError{msg: "too many nested parentheses", pos: p.position()}
+	}
+	p.depth++
+	p.pos++
+	value, err := p.expression()
+	if err != nil {
+		return 0, err
+	}
+	if p.peek() != ')' {
+		return 0, p.unexpected("missing ')'")
+	}
+	p.pos++
+	p.depth--
+	return value, nil
+}
+
+func (p *amountParser) number() (float64, *amountError) {
+	start := p.pos
+	if !isDigit(p.peek()) {
+		return 0, p.unexpected("expected a number")
+	}
+	for isDigit(p.peek()) || p.peek() == ',' {
+		p.pos++
+		if p.input[p.pos-1] == ',' && !isDigit(p.peek()) {
+			return 0, p.unexpected("expected a digit after ','")
+		}
+	}
+	if p.peek() == '.' {
+		p.pos++
+		if !isDigit(p.peek()) {
+			return 0, p.unexpected("expected a digit after '.'")
+		}
+		for isDigit(p.peek()) {
+			p.pos++
+		}
+	}
+	value, err := strconv.ParseFloat(strings.ReplaceAll(p.input[start:p.pos], ",", ""), 64)
+	if err != nil {
+		return 0, &amountError{msg: err.Error(), pos: start + 1}
+	}
+	if p.peek() == 'k' {
+		if !p.endsFactor(p.pos + 1) {
+			// 50kfc could be ฿50,000 of fc or ฿50 of kfc
+			return 0, &amountError{msg: fmt.Sprintf("ambiguous amount, write %s(%sk)%s for thousands", p.input[:start], p.input[start:p.pos], p.input[p.pos+1:])}
+		}
+		p.pos++
+		value *= 1000
+	}
+	return value, nil
+}
+
+// endsFactor tells whether the factor ends before i: at the end of the
+// input, or before an operator or a ')'.
+func (p *amountParser) endsFactor(i int) bool {
+	switch p.peekAt(i) {
+	case 0, '+', '-', '*', '/', ')':
+		return true
+	case 'x':
+		return p.startsFactor(i + 1)
+	}
+	return false
+}
+
+// unexpected reports the character at the current position, or the end of
+// the input.
+func (p *amountParser) unexpected(expected string) *amountError {
+	if p.pos >= len(p.input) {
+		return &amountError{msg: expected + ", got the end", pos: p.position()}
+	}
+	r, _ := utf8.DecodeRuneInString(p.input[p.pos:])
+	return &amountError{msg: fmt.Sprintf("%s, got '%c'", expected, r), pos: p.position()}
+}
+
+func (p *amountParser) startsFactor(i int) bool {
+	c := p.peekAt(i)
+	return isDigit(c) || c == '('
+}
+
+func (p *amountParser) peek() byte {
+	return p.peekAt(p.pos)
+}
+
+func (p *amountParser) peekAt(i int) byte {
+	if i >= len(p.input) {
+		return 0
+	}
+	return p.input[i]
+}
+
+// position is the 1-based position of the current character in runes.
+func (p *amountParser) position() int {
+	return utf8.RuneCountInString(p.input[:p.pos]) + 1
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,68 @@
+package finance
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAmount(t *testing.T) {
+	testcases := []struct {
+		it           string
+		input        string
+		expected     float64
+		expectedRest string
+		expectedErr  string
+	}{
+		{it: "returns the rest after the expression", input: "(12.5+1k)fd", expected: 1012.5, expectedRest: "fd"},
+		{it: "multiplies by 1,000 at the end", input: "1.5k", expected: 1500},
+		{it: "multiplies by 1,000 before an operator", input: "2kx3+1k", expected: 7000},
+		{it: "returns error when a letter follows k", input: "12.5+1kfd", expectedErr: "ambiguous amount, write 12.5+(1k)fd for thousands"},
+		{it: "evaluates nested parentheses", input: "((1+2)x(3+4))", expected: 21},
+		{it: "returns error when a decimal point has no digit", input: "12.sh", expectedErr: "expected a digit after '.', got 's' at position 4"},
+		{it: "returns error when a separator has no digit", input: "1,sh", expectedErr: "expected a digit after ',', got 's' at position 3"},
+		{it: "returns error at the end of the input", input: "12+", expectedErr: "expected a number, got the end at position 4"},
+		{it: "returns error when the amount rounds to 0", input: "0.001sh", expectedErr: "the amount must be greater than 0"},
+		{it: "counts positions in characters", input: "฿12", expectedErr: "expected a number, got '฿' at position 1"},
+		{it: "returns error when the expression is too long", input: strings.Repeat("1", maxAmountLength+1), expectedErr: "the amount is longer than 64 characters"},
+		{it: "returns error when the parentheses are too deep", input: strings.Repeat("(", maxAmountDepth+1) + "1", expectedErr: "too many nested parentheses at position 9"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			res, rest, err := parseAmount(tc.input)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, res)
+			assert.Equal(t, tc.expectedRest, rest)
+		})
+	}
+}
+
+func FuzzParseAmount(f *testing.F) {
+	for _, seed := range []string{"200sh", "120+45.5sh", "(1.2k)sh", "50kfc", "3x40fd", "1,200sh", "(120+45)/3fd", "1/0", "((((1", "12.", "-5", "0.0000100"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		res, rest, err := parseAmount(input)
+		if err != nil {
+			if err.pos < 0 || err.pos > len([]rune(input))+1 {
+				t.Fatalf("position %d is outside of %q", err.pos, input)
+			}
+			return
+		}
+		if res <= 0 || math.IsInf(res, 0) || math.IsNaN(res) {
+			t.Fatalf("%q evaluates to %v", input, res)
+		}
+		if !strings.HasSuffix(input, rest) || len(rest) == len(input) {
+			t.Fatalf("%q isn't the rest of %q", rest, input)
+		}
+	})
+}
@@ -1,21 +1,29 @@
 package finance
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
 )
 
-func (h *Handler) getBalance() (string, *errors.AppError) {
-	res, err := h.client.GetBalance()
+func (h *Handler) getBalance(ctx context.Context) (string, *errors.AppError) {
+	res, err := h.client.GetBalance(ctx)
 	if err != nil {
 		return "", err
 	}
 
+	// Only show the accounts the caller's role grants
+	user, hasUser := domain.UserFromContext(ctx)
+
 	var sb strings.Builder
 	sb.WriteString("Your balance\n\n")
 	for _, v := range res.Accounts {
+		if hasUser && !user.Role.CanAccess(v.Account) {
+			continue
+		}
 		sb.WriteString(fmt.Sprintf("Account: %v => Balance: ฿%v\n", v.Account, v.Balance))
 	}
 	return sb.String(), nil
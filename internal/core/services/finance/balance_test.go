@@ -1,17 +1,19 @@
 package finance
 
 import (
+	"context"
 	"testing"
 
 	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
 	"github.com/sMARCHz/secretaria-bot/test/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGetBalance(t *testing.T) {
 	client := mocks.NewMockFinanceServiceClient(t)
-	client.EXPECT().GetBalance().Return(&domain.GetBalanceResponse{
+	client.EXPECT().GetBalance(mock.Anything).Return(&domain.GetBalanceResponse{
 		Accounts: []domain.AccountBalance{
 			{
 				Account: "debit1",
@@ -19,21 +21,42 @@ func TestGetBalance(t *testing.T) {
 			},
 		},
 	}, nil)
-	handler := NewHandler(client)
+	handler := newTestHandler(t, client)
 
-	res, err := handler.getBalance()
+	res, err := handler.getBalance(context.Background())
 
 	assert.Nil(t, err)
 	assert.Equal(t, "Your balance\n\nAccount: debit1 => Balance: ฿5000\n", res)
 	client.AssertExpectations(t)
 }
 
+func TestGetBalance_OnlyPermittedAccounts(t *testing.T) {
+	client := mocks.NewMockFinanceServiceClient(t)
+	client.EXPECT().GetBalance(mock.Anything).Return(&domain.GetBalanceResponse{
+		Accounts: []domain.AccountBalance{
+			{Account: "debit1", Balance: 5000},
+			{Account: "shared-kbank", Balance: 1000},
+		},
+	}, nil)
+	handler := newTestHandler(t, client)
+	ctx := domain.ContextWithUser(context.Background(), domain.User{
+		ID:   "partner",
+		Role: domain.Role{Accounts: []string{"shared-*"}},
+	})
+
+	res, err := handler.getBalance(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Your balance\n\nAccount: shared-kbank => Balance: ฿1000\n", res)
+	client.AssertExpectations(t)
+}
+
 func TestGetBalance_Error(t *testing.T) {
 	client := mocks.NewMockFinanceServiceClient(t)
-	client.EXPECT().GetBalance().Return(nil, errors.InternalServerError("something went wrong"))
-	handler := NewHandler(client)
+	client.EXPECT().GetBalance(mock.Anything).Return(nil, errors.InternalServerError("something went wrong"))
+	handler := newTestHandler(t, client)
 
-	res, err := handler.getBalance()
+	res, err := handler.getBalance(context.Background())
 
 	assert.Empty(t, res)
 	assert.EqualError(t, err, "something went wrong")
@@ -0,0 +1,89 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/chart"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+)
+
+const (
+	chartContentType = "image/png"
+
+	// maxChartMonths bounds the monthly statements fetched for the bars.
+	maxChartMonths = 24
+)
+
+// attachCharts attaches the charts of the statement to the reply, when the
+// channel shows images: a pie of the expense categories and, when the
+// range spans several months, bars of each month's revenue and expense.
+// The charts only add to the text reply, so failing to make them is logged
+// rather than returned.
+func (h *Handler) attachCharts(ctx context.Context, rangeArgs []string, res *domain.GetOverviewStatementResponse) {
+	images, ok := domain.ReplyImagesFromContext(ctx)
+	if !ok {
+		return
+	}
+	req, err := detailedStatementRange(rangeArgs, h.now())
+	if err != nil {
+		return
+	}
+	from, to := req.From.In(h.location), req.To.In(h.location)
+	period := fmt.Sprintf("%s - %s", from.Format("2006-01-02"), to.Add(-time.Nanosecond).Format("2006-01-02"))
+
+	pie, renderErr := chart.ExpensePie("Expense "+period, res)
+	if renderErr != nil {
+		logger.Ctx(ctx).Error("cannot render the expense chart: ", renderErr)
+	} else if pie != nil {
+		h.attachChart(ctx, images, "expense.png", pie)
+	}
+
+	months := monthRanges(from, to)
+	if len(months) < 2 || len(months) > maxChartMonths {
+		return
+	}
+	statements := make([]chart.MonthlyStatement, len(months))
+	for i, month := range months {
+		statement, err := h.client.GetOverviewStatement(ctx, month)
+		if err != nil {
+			logger.Ctx(ctx).Warn("cannot get the monthly statements of the chart: ", err.Message)
+			return
+		}
+		statements[i] = chart.MonthlyStatement{Month: month.From.In(h.location), Statement: statement}
+	}
+	bars, renderErr := chart.MonthlyBars("Revenue and expense "+period, statements)
+	if renderErr != nil {
+		logger.Ctx(ctx).Error("cannot render the monthly chart: ", renderErr)
+		return
+	}
+	h.attachChart(ctx, images, "monthly.png", bars)
+}
+
+func (h *Handler) attachChart(ctx context.Context, images *domain.ReplyImages, name string, content []byte) {
+	published, err := h.publisher.PublishImage(ctx, &domain.File{Name: name, ContentType: chartContentType, Content: content})
+	if err != nil {
+		logger.Ctx(ctx).Warn("cannot publish the chart: ", err.Message)
+		return
+	}
+	images.Add(domain.Image{URL: published.URL, PreviewURL: published.URL})
+}
+
+// monthRanges splits [from, to) by calendar month, in from's time zone.
+// The first and last months are cut to the range.
+func monthRanges(from, to time.Time) []*domain.GetOverviewStatementRequest {
+	var months []*domain.GetOverviewStatementRequest
+	start := from
+	for start.Before(to) {
+		next := time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, start.Location())
+		end := next
+		if to.Before(end) {
+			end = to
+		}
+		months = append(months, &domain.GetOverviewStatementRequest{From: start.UTC(), To: end.UTC()})
+		start = next
+	}
+	return months
+}
@@ -0,0 +1,116 @@
+package finance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func isPNG(file *domain.File) bool {
+	return file.ContentType == "image/png" && len(file.Content) > 8 && string(file.Content[1:4]) == "PNG"
+}
+
+func TestGetStatement_Charts(t *testing.T) {
+	originalTimeNow := timeNow
+	timeNow = func() time.Time { return time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = originalTimeNow }()
+	expense := &domain.GetOverviewStatementSection{Total: 300, Entries: []domain.CategorizedEntry{{Category: "fd", Amount: 300}}}
+
+	testcases := []struct {
+		it             string
+		tokenizedMsg   []string
+		mock           func(client *mocks.MockFinanceServiceClient, publisher *mocks.MockFilePublisher)
+		expectedImages []domain.Image
+	}{
+		{
+			it:           "attaches the expense pie of a month",
+			tokenizedMsg: []string{"statement"},
+			mock: func(client *mocks.MockFinanceServiceClient, publisher *mocks.MockFilePublisher) {
+				client.EXPECT().GetOverviewMonthlyStatement(mock.Anything).Return(&domain.GetOverviewStatementResponse{Expense: expense}, nil)
+				publisher.EXPECT().PublishImage(mock.Anything, mock.MatchedBy(func(file *domain.File) bool {
+					return file.Name == "expense.png" && isPNG(file)
+				})).Return(&domain.PublishedFile{URL: "https://bot.example.com/downloads/1"}, nil)
+			},
+			expectedImages: []domain.Image{{URL: "https://bot.example.com/downloads/1", PreviewURL: "https://bot.example.com/downloads/1"}},
+		},
+		{
+			it:           "attaches the monthly bars of a range over several months",
+			tokenizedMsg: []string{"statement", "2025-01-15", "2025-02-28"},
+			mock: func(client *mocks.MockFinanceServiceClient, publisher *mocks.MockFilePublisher) {
+				client.EXPECT().GetOverviewStatement(mock.Anything, &domain.GetOverviewStatementRequest{
+					From: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+					To:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+				}).Return(&domain.GetOverviewStatementResponse{}, nil)
+				client.EXPECT().GetOverviewStatement(mock.Anything, &domain.GetOverviewStatementRequest{
+					From: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+					To:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+				}).Return(&domain.GetOverviewStatementResponse{Expense: expense}, nil)
+				client.EXPECT().GetOverviewStatement(mock.Anything, &domain.GetOverviewStatementRequest{
+					From: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+					To:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+				}).Return(&domain.GetOverviewStatementResponse{}, nil)
+				publisher.EXPECT().PublishImage(mock.Anything, mock.MatchedBy(func(file *domain.File) bool {
+					return file.Name == "monthly.png" && isPNG(file)
+				})).Return(&domain.PublishedFile{URL: "https://bot.example.com/downloads/2"}, nil)
+			},
+			expectedImages: []domain.Image{{URL: "https://bot.example.com/downloads/2", PreviewURL: "https://bot.example.com/downloads/2"}},
+		},
+		{
+			it:           "replies without chart when the chart cannot be published",
+			tokenizedMsg: []string{"statement"},
+			mock: func(client *mocks.MockFinanceServiceClient, publisher *mocks.MockFilePublisher) {
+				client.EXPECT().GetOverviewMonthlyStatement(mock.Anything).Return(&domain.GetOverviewStatementResponse{Expense: expense}, nil)
+				publisher.EXPECT().PublishImage(mock.Anything, mock.Anything).Return(nil, errors.InternalServerError("Downloads are unavailable"))
+			},
+		},
+		{
+			it:           "skips the monthly bars when a monthly statement fails",
+			tokenizedMsg: []string{"statement", "q1"},
+			mock: func(client *mocks.MockFinanceServiceClient, publisher *mocks.MockFilePublisher) {
+				client.EXPECT().GetOverviewStatement(mock.Anything, &domain.GetOverviewStatementRequest{
+					From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					To:   time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+				}).Return(&domain.GetOverviewStatementResponse{}, nil)
+				client.EXPECT().GetOverviewStatement(mock.Anything, mock.Anything).Return(nil, errors.BadGatewayError("unavailable")).Once()
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			client := mocks.NewMockFinanceServiceClient(t)
+			publisher := mocks.NewMockFilePublisher(t)
+			tc.mock(client, publisher)
+			handler := newTestHandler(t, client, usingPublisher(publisher))
+			ctx, images := domain.ContextWithReplyImages(ownerContext())
+
+			res, err := handler.getStatement(ctx, tc.tokenizedMsg)
+
+			assert.Nil(t, err)
+			assert.NotEmpty(t, res)
+			assert.Equal(t, tc.expectedImages, images.Images())
+			client.AssertExpectations(t)
+			publisher.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMonthRanges(t *testing.T) {
+	bangkok, err := time.LoadLocation("Asia/Bangkok")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := monthRanges(time.Date(2024, 12, 20, 0, 0, 0, 0, bangkok), time.Date(2025, 2, 10, 0, 0, 0, 0, bangkok))
+
+	assert.Equal(t, []*domain.GetOverviewStatementRequest{
+		{From: time.Date(2024, 12, 19, 17, 0, 0, 0, time.UTC), To: time.Date(2024, 12, 31, 17, 0, 0, 0, time.UTC)},
+		{From: time.Date(2024, 12, 31, 17, 0, 0, 0, time.UTC), To: time.Date(2025, 1, 31, 17, 0, 0, 0, time.UTC)},
+		{From: time.Date(2025, 1, 31, 17, 0, 0, 0, time.UTC), To: time.Date(2025, 2, 9, 17, 0, 0, 0, time.UTC)},
+	}, res)
+}
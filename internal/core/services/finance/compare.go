@@ -0,0 +1,155 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/category"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/daterange"
+)
+
+const invalidCompareMsg = "Invalid command's arguments.\nPlease recheck the syntax (statement compare <period> <period>), e.g. statement compare last-month this-month"
+
+// compareStatements replies with the changes between the statements of
+// two periods, e.g. "last-month this-month" or "2024 2025".
+func (h *Handler) compareStatements(ctx context.Context, periods []string) (string, *errors.AppError) {
+	if len(periods) != 2 {
+		return "", errors.BadRequestError(invalidCompareMsg)
+	}
+	var reqs [2]*domain.GetOverviewStatementRequest
+	for i, period := range periods {
+		req, err := daterange.Parse([]string{period}, h.now())
+		if err != nil {
+			return "", err
+		}
+		reqs[i] = req
+	}
+	categories, err := h.categories.Registry(ctx)
+	if err != nil {
+		return "", err
+	}
+	var statements [2]*domain.GetOverviewStatementResponse
+	for i, req := range reqs {
+		res, err := h.client.GetOverviewStatement(ctx, req)
+		if err != nil {
+			return "", err
+		}
+		statements[i] = res
+	}
+	return printComparison(periods[0], periods[1], statements[0], statements[1], categories), nil
+}
+
+// printComparison writes the totals and categories of both statements
+// with their changes, by category name and rolled up into their groups
+// like printStatement. Categories only found in one statement are marked
+// as new or gone.
+func printComparison(fromLabel, toLabel string, from, to *domain.GetOverviewStatementResponse, categories *category.Registry) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%v → %v\n================\n", fromLabel, toLabel))
+	writeSectionComparison(&sb, "Revenue", from.Revenue, to.Revenue, categories)
+	sb.WriteString("\n")
+	writeSectionComparison(&sb, "Expense", from.Expense, to.Expense, categories)
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("Profit: ฿%v → ฿%v (%s)", from.Profit, to.Profit, formatDelta(from.Profit, to.Profit)))
+	return sb.String()
+}
+
+func writeSectionComparison(sb *strings.Builder, name string, from, to *domain.GetOverviewStatementSection, categories *category.Registry) {
+	if from == nil {
+		from = &domain.GetOverviewStatementSection{}
+	}
+	if to == nil {
+		to = &domain.GetOverviewStatementSection{}
+	}
+	sb.WriteString(fmt.Sprintf("%s: ฿%v → ฿%v (%s)\n", name, from.Total, to.Total, formatChange(from.Total, to.Total)))
+
+	fromGroups := groupEntries(from.Entries, categories)
+	toGroups := groupEntries(to.Entries, categories)
+	findGroup := func(groups []*entryGroup, code string) *entryGroup {
+		i := slices.IndexFunc(groups, func(g *entryGroup) bool { return g.code == code })
+		if i < 0 {
+			return nil
+		}
+		return groups[i]
+	}
+	for _, g := range fromGroups {
+		writeGroupComparison(sb, g, findGroup(toGroups, g.code), categories)
+	}
+	for _, g := range toGroups {
+		if findGroup(fromGroups, g.code) == nil {
+			writeGroupComparison(sb, nil, g, categories)
+		}
+	}
+}
+
+// writeGroupComparison writes the change of the group's total, and of its
+// categories below it when it rolls others up. Either group may be nil.
+func writeGroupComparison(sb *strings.Builder, from, to *entryGroup, categories *category.Registry) {
+	if from == nil {
+		from = &entryGroup{code: to.code}
+	}
+	if to == nil {
+		to = &entryGroup{code: from.code}
+	}
+	writeChange(sb, categories.DisplayName(from.code), from.total, len(from.entries) > 0, to.total, len(to.entries) > 0)
+	if (len(from.entries) == 0 || !from.rolledUp()) && (len(to.entries) == 0 || !to.rolledUp()) {
+		return
+	}
+
+	toAmounts := make(map[string]float64, len(to.entries))
+	for _, v := range to.entries {
+		toAmounts[v.Category] = v.Amount
+	}
+	fromAmounts := make(map[string]float64, len(from.entries))
+	for _, v := range from.entries {
+		fromAmounts[v.Category] = v.Amount
+		amount, found := toAmounts[v.Category]
+		writeChange(sb, "- "+categories.DisplayName(v.Category), v.Amount, true, amount, found)
+	}
+	for _, v := range to.entries {
+		if _, found := fromAmounts[v.Category]; !found {
+			writeChange(sb, "- "+categories.DisplayName(v.Category), 0, false, v.Amount, true)
+		}
+	}
+}
+
+// writeChange writes the amounts of a line in both statements, or marks
+// it as gone or new when it's only in one of them.
+func writeChange(sb *strings.Builder, name string, from float64, inFrom bool, to float64, inTo bool) {
+	from, to = math.Round(from*100)/100, math.Round(to*100)/100
+	switch {
+	case !inTo:
+		sb.WriteString(fmt.Sprintf("%v = ฿%v (gone)\n", name, from))
+	case !inFrom:
+		sb.WriteString(fmt.Sprintf("%v = ฿%v (new)\n", name, to))
+	default:
+		sb.WriteString(fmt.Sprintf("%v = ฿%v → ฿%v (%s)\n", name, from, to, formatChange(from, to)))
+	}
+}
+
+// formatChange formats the absolute and percentage change, e.g.
+// "+฿500, +25.0%". The percentage is left out when from is 0.
+func formatChange(from, to float64) string {
+	delta := formatDelta(from, to)
+	if from == 0 {
+		return delta
+	}
+	return fmt.Sprintf("%s, %+.1f%%", delta, (to-from)/math.Abs(from)*100)
+}
+
+// formatDelta formats the absolute change, e.g. "+฿500" or "-฿12.5".
+func formatDelta(from, to float64) string {
+	delta := math.Round((to-from)*100) / 100
+	if delta == 0 {
+		return "+฿0"
+	}
+	if delta < 0 {
+		return fmt.Sprintf("-฿%v", -delta)
+	}
+	return fmt.Sprintf("+฿%v", delta)
+}
@@ -0,0 +1,204 @@
+package finance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/category"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/daterange"
+	"github.com/sMARCHz/secretaria-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareStatements(t *testing.T) {
+	originalTimeNow := timeNow
+	timeNow = func() time.Time { return time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = originalTimeNow }()
+
+	client := mocks.NewMockFinanceServiceClient(t)
+	client.EXPECT().GetOverviewStatement(mock.Anything, &domain.GetOverviewStatementRequest{
+		From: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	}).Return(&domain.GetOverviewStatementResponse{
+		Revenue: &domain.GetOverviewStatementSection{Total: 20000, Entries: []domain.CategorizedEntry{{Category: "salary", Amount: 20000}}},
+		Expense: &domain.GetOverviewStatementSection{Total: 4000, Entries: []domain.CategorizedEntry{
+			{Category: "fd", Amount: 3000},
+			{Category: "sh", Amount: 1000},
+		}},
+		Profit: 16000,
+	}, nil)
+	client.EXPECT().GetOverviewStatement(mock.Anything, &domain.GetOverviewStatementRequest{
+		From: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+	}).Return(&domain.GetOverviewStatementResponse{
+		Revenue: &domain.GetOverviewStatementSection{Total: 20000, Entries: []domain.CategorizedEntry{{Category: "salary", Amount: 20000}}},
+		Expense: &domain.GetOverviewStatementSection{Total: 5500, Entries: []domain.CategorizedEntry{
+			{Category: "fd", Amount: 2500},
+			{Category: "travel", Amount: 3000},
+		}},
+		Profit: 14500,
+	}, nil)
+	handler := newTestHandler(t, client)
+
+	res, err := handler.getStatement(ownerContext(), []string{"statement", "compare", "last-month", "this-month"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "last-month → this-month\n================\n"+
+		"Revenue: ฿20000 → ฿20000 (+฿0, +0.0%)\n"+
+		"salary = ฿20000 → ฿20000 (+฿0, +0.0%)\n"+
+		"\n"+
+		"Expense: ฿4000 → ฿5500 (+฿1500, +37.5%)\n"+
+		"fd = ฿3000 → ฿2500 (-฿500, -16.7%)\n"+
+		"sh = ฿1000 (gone)\n"+
+		"travel = ฿3000 (new)\n"+
+		"\n"+
+		"Profit: ฿16000 → ฿14500 (-฿1500)", res)
+	client.AssertExpectations(t)
+}
+
+func TestCompareStatements_Error(t *testing.T) {
+	testcases := []struct {
+		it           string
+		tokenizedMsg []string
+		mock         func(client *mocks.MockFinanceServiceClient)
+		expectedErr  *errors.AppError
+	}{
+		{
+			it:           "returns error when a period is missing",
+			tokenizedMsg: []string{"statement", "compare", "2024"},
+			expectedErr:  errors.BadRequestError(invalidCompareMsg),
+		},
+		{
+			it:           "returns error when a period is unknown",
+			tokenizedMsg: []string{"statement", "compare", "2024", "someday"},
+			expectedErr:  errors.BadRequestError("Unknown period 'someday'.\nPlease use " + daterange.Syntax),
+		},
+		{
+			it:           "returns error when fails to get a statement",
+			tokenizedMsg: []string{"statement", "compare", "2024", "2025"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().GetOverviewStatement(mock.Anything, mock.Anything).Return(nil, errors.InternalServerError("failed to get statement"))
+			},
+			expectedErr: errors.InternalServerError("failed to get statement"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			client := mocks.NewMockFinanceServiceClient(t)
+			if tc.mock != nil {
+				tc.mock(client)
+			}
+			handler := newTestHandler(t, client)
+
+			res, err := handler.getStatement(ownerContext(), tc.tokenizedMsg)
+
+			assert.Empty(t, res)
+			assert.Equal(t, tc.expectedErr, err)
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPrintComparison(t *testing.T) {
+	testcases := []struct {
+		it       string
+		from     *domain.GetOverviewStatementResponse
+		to       *domain.GetOverviewStatementResponse
+		expected string
+	}{
+		{
+			it:   "leaves out the percentage when the first period is empty",
+			from: &domain.GetOverviewStatementResponse{},
+			to: &domain.GetOverviewStatementResponse{
+				Revenue: &domain.GetOverviewStatementSection{Total: 100.5, Entries: []domain.CategorizedEntry{{Category: "salary", Amount: 100.5}}},
+				Profit:  100.5,
+			},
+			expected: "2024 → 2025\n================\n" +
+				"Revenue: ฿0 → ฿100.5 (+฿100.5)\n" +
+				"salary = ฿100.5 (new)\n" +
+				"\n" +
+				"Expense: ฿0 → ฿0 (+฿0)\n" +
+				"\n" +
+				"Profit: ฿0 → ฿100.5 (+฿100.5)",
+		},
+		{
+			it: "rounds the changes to the satang",
+			from: &domain.GetOverviewStatementResponse{
+				Expense: &domain.GetOverviewStatementSection{Total: 0.3, Entries: []domain.CategorizedEntry{{Category: "fd", Amount: 0.3}}},
+				Profit:  -0.3,
+			},
+			to: &domain.GetOverviewStatementResponse{
+				Expense: &domain.GetOverviewStatementSection{Total: 0.1, Entries: []domain.CategorizedEntry{{Category: "fd", Amount: 0.1}}},
+				Profit:  -0.1,
+			},
+			expected: "2024 → 2025\n================\n" +
+				"Revenue: ฿0 → ฿0 (+฿0)\n" +
+				"\n" +
+				"Expense: ฿0.3 → ฿0.1 (-฿0.2, -66.7%)\n" +
+				"fd = ฿0.3 → ฿0.1 (-฿0.2, -66.7%)\n" +
+				"\n" +
+				"Profit: ฿-0.3 → ฿-0.1 (+฿0.2)",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			categories, err := category.NewService(domain.CategoryConfig{}, memory.NewStore()).Registry(context.Background())
+			require.Nil(t, err)
+
+			assert.Equal(t, tc.expected, printComparison("2024", "2025", tc.from, tc.to, categories))
+		})
+	}
+}
+
+func TestPrintComparison_Categories(t *testing.T) {
+	categories, err := category.NewService(domain.CategoryConfig{
+		Categories: []domain.Category{
+			{Code: "fd", Name: "Food"},
+			{Code: "gr", Name: "Groceries", Parent: "fd"},
+			{Code: "rs", Name: "Restaurants", Parent: "fd"},
+			{Code: "sh", Name: "Shopping"},
+			{Code: "salary", Name: "Salary"},
+		},
+	}, memory.NewStore()).Registry(context.Background())
+	require.Nil(t, err)
+	from := &domain.GetOverviewStatementResponse{
+		Revenue: &domain.GetOverviewStatementSection{Total: 30000, Entries: []domain.CategorizedEntry{{Category: "salary", Amount: 30000}}},
+		Expense: &domain.GetOverviewStatementSection{Total: 13000.1, Entries: []domain.CategorizedEntry{
+			{Category: "gr", Amount: 5000.1},
+			{Category: "sh", Amount: 8000},
+		}},
+		Profit: 16999.9,
+	}
+	to := &domain.GetOverviewStatementResponse{
+		Revenue: &domain.GetOverviewStatementSection{Total: 30000, Entries: []domain.CategorizedEntry{{Category: "salary", Amount: 30000}}},
+		Expense: &domain.GetOverviewStatementSection{Total: 8700, Entries: []domain.CategorizedEntry{
+			{Category: "gr", Amount: 4000},
+			{Category: "rs", Amount: 4000},
+			{Category: "tx", Amount: 700},
+		}},
+		Profit: 21300,
+	}
+
+	res := printComparison("2024", "2025", from, to, categories)
+
+	assert.Equal(t, "2024 → 2025\n================\n"+
+		"Revenue: ฿30000 → ฿30000 (+฿0, +0.0%)\n"+
+		"Salary = ฿30000 → ฿30000 (+฿0, +0.0%)\n"+
+		"\n"+
+		"Expense: ฿13000.1 → ฿8700 (-฿4300.1, -33.1%)\n"+
+		"Food = ฿5000.1 → ฿8000 (+฿2999.9, +60.0%)\n"+
+		"- Groceries = ฿5000.1 → ฿4000 (-฿1000.1, -20.0%)\n"+
+		"- Restaurants = ฿4000 (new)\n"+
+		"Shopping = ฿8000 (gone)\n"+
+		"tx = ฿700 (new)\n"+
+		"\n"+
+		"Profit: ฿16999.9 → ฿21300 (+฿4300.1)", res)
+}
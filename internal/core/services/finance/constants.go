@@ -6,6 +6,12 @@ var commandPrefixSet = map[string]struct{}{
 	"!t":        {},
 	"balance":   {},
 	"statement": {},
+	"export":    {},
+	"split":     {},
+	"settle":    {},
+	"owe":       {},
+	"last":      {},
+	"edit":      {},
 }
 
 const (
@@ -1,18 +1,20 @@
 package finance
 
 import (
+	"context"
 	"testing"
 
 	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
 	"github.com/sMARCHz/secretaria-bot/test/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestDeposit(t *testing.T) {
 	tokenizedMsg := []string{"!e", "debit1", "20000s"}
 	client := mocks.NewMockFinanceServiceClient(t)
-	client.EXPECT().Deposit(&domain.TransactionRequest{
+	client.EXPECT().Deposit(mock.Anything, &domain.TransactionRequest{
 		Account:     "debit1",
 		Amount:      20000,
 		Category:    "s",
@@ -21,9 +23,9 @@ func TestDeposit(t *testing.T) {
 		Account: "debit1",
 		Balance: 25000,
 	}, nil)
-	handler := NewHandler(client)
+	handler := newTestHandler(t, client)
 
-	res, err := handler.deposit(tokenizedMsg)
+	res, err := handler.deposit(context.Background(), tokenizedMsg)
 
 	assert.Nil(t, err)
 	assert.Equal(t, "Succesfully deposit\n================\nResult\nAccount: debit1\nBalance: ฿25000", res)
@@ -46,7 +48,7 @@ func TestDeposit_Error(t *testing.T) {
 			it:           "return error when deposit fails",
 			tokenizedMsg: []string{"!e", "debit1", "20000s"},
 			mock: func(client *mocks.MockFinanceServiceClient) {
-				client.EXPECT().Deposit(&domain.TransactionRequest{
+				client.EXPECT().Deposit(mock.Anything, &domain.TransactionRequest{
 					Account:     "debit1",
 					Amount:      20000,
 					Category:    "s",
@@ -63,9 +65,9 @@ func TestDeposit_Error(t *testing.T) {
 			if tc.mock != nil {
 				tc.mock(client)
 			}
-			handler := NewHandler(client)
+			handler := newTestHandler(t, client)
 
-			res, err := handler.deposit(tc.tokenizedMsg)
+			res, err := handler.deposit(context.Background(), tc.tokenizedMsg)
 
 			assert.Empty(t, res)
 			assert.EqualError(t, err, tc.expectedErr.Message)
@@ -0,0 +1,81 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+)
+
+const invalidEditMsg = "Invalid command's arguments.\nPlease recheck the syntax (edit <ref> category <category>, edit <ref> amount <amount> or edit <ref> desc [description])"
+
+// edit patches a transaction listed by last, e.g. "edit 3fa9c category fd",
+// "edit 3fa9c amount 120" or "edit 3fa9c desc lunch with bob". A left out
+// description clears it. The references are only given to the
+// transactions of the accounts the caller's role grants. A new amount is
+// confirmed like the one of a new transaction.
+func (h *Handler) edit(ctx context.Context, tokenizedMsg []string) (string, *errors.AppError) {
+	if len(tokenizedMsg) < 3 {
+		return "", errors.BadRequestError(invalidEditMsg)
+	}
+	req, err := h.parseEdit(ctx, tokenizedMsg[2], tokenizedMsg[3:])
+	if err != nil {
+		return "", err
+	}
+	ref, err := h.lookupRef(ctx, tokenizedMsg[1])
+	if err != nil {
+		return "", err
+	}
+	req.ID = ref.ID
+	update := func(ctx context.Context) (string, *errors.AppError) {
+		res, err := h.client.UpdateTransaction(ctx, req)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Successfully edited [%s]\n================\nBefore: %s\nAfter: %s", tokenizedMsg[1], h.printTransaction(res.Before), h.printTransaction(res.After)), nil
+	}
+	if req.Amount == nil {
+		return update(ctx)
+	}
+	t := domain.Transaction{Type: ref.Type, Account: ref.Account, ToAccount: ref.ToAccount, Category: ref.Category, Amount: *req.Amount}
+	return h.confirmations.Submit(ctx, t, update)
+}
+
+// parseEdit validates the new value of the field the way the transaction
+// commands do.
+func (h *Handler) parseEdit(ctx context.Context, field string, args []string) (*domain.UpdateTransactionRequest, *errors.AppError) {
+	req := &domain.UpdateTransactionRequest{}
+	switch field {
+	case "category":
+		if len(args) != 1 {
+			return nil, errors.BadRequestError(invalidEditMsg)
+		}
+		if err := unexpectedRest(args[0], args[0], isCategory); err != nil {
+			logger.Ctx(ctx).Errorw("invalid category", "input", args[0], "error", err)
+			return nil, errors.BadRequestError(fmt.Sprintf("Invalid category '%s': %v", args[0], err))
+		}
+		category, err := h.categories.Resolve(ctx, args[0])
+		if err != nil {
+			return nil, err
+		}
+		req.Category = &category
+	case "amount":
+		if len(args) != 1 {
+			return nil, errors.BadRequestError(invalidEditMsg)
+		}
+		amount, err := parsePlainAmount(ctx, args[0])
+		if err != nil {
+			return nil, err
+		}
+		req.Amount = &amount
+	case "desc":
+		description := strings.Join(args, " ")
+		req.Description = &description
+	default:
+		return nil, errors.BadRequestError(invalidEditMsg)
+	}
+	return req, nil
+}
@@ -0,0 +1,179 @@
+package finance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/category"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/confirmation"
+	"github.com/sMARCHz/secretaria-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestEdit(t *testing.T) {
+	edited := lunch
+	edited.Category = "sh"
+
+	testcases := []struct {
+		it               string
+		tokenizedMsg     []string
+		mock             func(client *mocks.MockFinanceServiceClient)
+		expectedReplyMsg string
+		expectedErr      *errors.AppError
+	}{
+		{
+			it:           "recategorizes the transaction through the category aliases",
+			tokenizedMsg: []string{"edit", "eea1a", "category", "shop"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().UpdateTransaction(callerIs(serviceOwner), &domain.UpdateTransactionRequest{ID: "tx-3", Category: ptr("sh")}).
+					Return(&domain.UpdateTransactionResponse{Before: lunch, After: edited}, nil).Once()
+			},
+			expectedReplyMsg: "Successfully edited [eea1a]\n================\n" +
+				"Before: 2025-03-15 19:04 withdraw ฿120 (fd) from debit1: lunch\n" +
+				"After: 2025-03-15 19:04 withdraw ฿120 (sh) from debit1: lunch",
+		},
+		{
+			it:           "changes the amount, which is parsed like the transactions'",
+			tokenizedMsg: []string{"edit", "eea1a", "amount", "100+20.5"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().UpdateTransaction(callerIs(serviceOwner), &domain.UpdateTransactionRequest{ID: "tx-3", Amount: ptr(120.5)}).
+					Return(&domain.UpdateTransactionResponse{Before: lunch, After: lunch}, nil).Once()
+			},
+			expectedReplyMsg: "Successfully edited [eea1a]\n================\n" +
+				"Before: 2025-03-15 19:04 withdraw ฿120 (fd) from debit1: lunch\n" +
+				"After: 2025-03-15 19:04 withdraw ฿120 (fd) from debit1: lunch",
+		},
+		{
+			it:           "changes the description",
+			tokenizedMsg: []string{"edit", "eea1a", "desc", "lunch", "with", "bob"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().UpdateTransaction(callerIs(serviceOwner), &domain.UpdateTransactionRequest{ID: "tx-3", Description: ptr("lunch with bob")}).
+					Return(&domain.UpdateTransactionResponse{Before: lunch, After: lunch}, nil).Once()
+			},
+			expectedReplyMsg: "Successfully edited [eea1a]\n================\n" +
+				"Before: 2025-03-15 19:04 withdraw ฿120 (fd) from debit1: lunch\n" +
+				"After: 2025-03-15 19:04 withdraw ฿120 (fd) from debit1: lunch",
+		},
+		{
+			it:           "clears the description when it is left out",
+			tokenizedMsg: []string{"edit", "eea1a", "desc"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().UpdateTransaction(callerIs(serviceOwner), &domain.UpdateTransactionRequest{ID: "tx-3", Description: ptr("")}).
+					Return(&domain.UpdateTransactionResponse{Before: lunch, After: lunch}, nil).Once()
+			},
+			expectedReplyMsg: "Successfully edited [eea1a]\n================\n" +
+				"Before: 2025-03-15 19:04 withdraw ฿120 (fd) from debit1: lunch\n" +
+				"After: 2025-03-15 19:04 withdraw ฿120 (fd) from debit1: lunch",
+		},
+		{
+			it:           "rejects an unknown reference",
+			tokenizedMsg: []string{"edit", "abcde", "amount", "120"},
+			expectedErr:  errors.NotFoundError("Unknown reference 'abcde'.\nList the transactions with 'last' first"),
+		},
+		{
+			it:           "rejects an invalid amount",
+			tokenizedMsg: []string{"edit", "eea1a", "amount", "120fd"},
+			expectedErr:  errors.BadRequestError("Invalid amount '120fd': unexpected 'f' at position 4"),
+		},
+		{
+			it:           "rejects an invalid category",
+			tokenizedMsg: []string{"edit", "eea1a", "category", "f1"},
+			expectedErr:  errors.BadRequestError("Invalid category 'f1': unexpected '1' at position 2"),
+		},
+		{
+			it:           "rejects an unknown category",
+			tokenizedMsg: []string{"edit", "eea1a", "category", "tx"},
+			expectedErr:  errors.BadRequestError("Unknown category 'tx'.\nAdd it with 'category add tx <name>' or use one of: fd, sh"),
+		},
+		{
+			it:           "rejects an unknown field",
+			tokenizedMsg: []string{"edit", "eea1a", "account", "debit2"},
+			expectedErr:  errors.BadRequestError(invalidEditMsg),
+		},
+		{
+			it:           "rejects a missing value",
+			tokenizedMsg: []string{"edit", "eea1a", "amount"},
+			expectedErr:  errors.BadRequestError(invalidEditMsg),
+		},
+		{
+			it:           "rejects a missing field",
+			tokenizedMsg: []string{"edit", "eea1a"},
+			expectedErr:  errors.BadRequestError(invalidEditMsg),
+		},
+		{
+			it:           "returns the error of the finance service",
+			tokenizedMsg: []string{"edit", "eea1a", "amount", "120"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().UpdateTransaction(callerIs(serviceOwner), &domain.UpdateTransactionRequest{ID: "tx-3", Amount: ptr(120.0)}).
+					Return(nil, errors.BadGatewayError("cannot update transaction")).Once()
+			},
+			expectedErr: errors.BadGatewayError("cannot update transaction"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			bangkok, _ := time.LoadLocation("Asia/Bangkok")
+			client := mocks.NewMockFinanceServiceClient(t)
+			if tc.mock != nil {
+				tc.mock(client)
+			}
+			categories := category.NewService(domain.CategoryConfig{
+				Categories: []domain.Category{{Code: "fd", Name: "Food"}, {Code: "sh", Name: "Shopping", Aliases: []string{"shop"}}},
+			}, memory.NewStore())
+			handler := newTestHandler(t, client, usingCategories(categories), usingLocation(bangkok))
+			ctx := domain.ContextWithUser(context.Background(), serviceOwner)
+			_, err := handler.rememberRefs(ctx, []domain.Transaction{lunch})
+			require.Nil(t, err)
+
+			res, err := handler.Handle(ctx, tc.tokenizedMsg)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedReplyMsg, res)
+		})
+	}
+}
+
+func TestEdit_Confirmation(t *testing.T) {
+	client := mocks.NewMockFinanceServiceClient(t)
+	confirmations := confirmation.NewService(client, domain.ConfirmationConfig{
+		Threshold:         10000,
+		AccountThresholds: map[string]float64{"debit1": 5000},
+		Timeout:           time.Minute,
+	})
+	handler := newTestHandler(t, client, usingConfirmations(confirmations))
+	ctx := domain.ContextWithUser(context.Background(), serviceOwner)
+	_, err := handler.rememberRefs(ctx, []domain.Transaction{lunch})
+	require.Nil(t, err)
+
+	res, err := handler.Handle(ctx, []string{"edit", "eea1a", "amount", "12000"})
+	assert.Nil(t, err)
+	assert.Equal(t, "฿12,000 is over the ฿5,000 threshold of debit1.\nConfirm ฿12,000 to fd? yes/no", res, "held like a withdrawal of the listed account and category")
+
+	client.EXPECT().UpdateTransaction(callerIs(serviceOwner), &domain.UpdateTransactionRequest{ID: "tx-3", Amount: ptr(12000.0)}).
+		Return(&domain.UpdateTransactionResponse{Before: lunch, After: lunch}, nil).Once()
+	res, err = confirmations.Handle(ctx, []string{"yes"})
+	assert.Nil(t, err)
+	assert.Contains(t, res, "Successfully edited [eea1a]")
+}
+
+func TestEdit_OtherUsersReference(t *testing.T) {
+	client := mocks.NewMockFinanceServiceClient(t)
+	handler := newLastHandler(t, client)
+	_, err := handler.rememberRefs(domain.ContextWithUser(context.Background(), serviceOwner), []domain.Transaction{lunch})
+	require.Nil(t, err)
+
+	res, err := handler.Handle(domain.ContextWithUser(context.Background(), serviceMember), []string{"edit", "eea1a", "amount", "1"})
+
+	assert.Empty(t, res)
+	assert.Equal(t, errors.NotFoundError("Unknown reference 'eea1a'.\nList the transactions with 'last' first"), err)
+}
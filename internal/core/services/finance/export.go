@@ -0,0 +1,171 @@
+package finance
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/permission"
+)
+
+const (
+	exportFormatCSV    = "csv"
+	exportFormatExcel  = "excel"
+	exportFormatLedger = "ledger"
+
+	// utf8BOM makes Excel read the file as UTF-8, e.g. Thai categories.
+	utf8BOM = "\ufeff"
+)
+
+const invalidExportMsg = "Invalid command's arguments.\nPlease recheck the syntax (export <m|a|period|from_date to_date> <csv|excel|ledger>)"
+
+// export writes the statement of the range and its transactions as a CSV
+// file, or its transactions as a ledger journal, and replies with a
+// single-use link to download it.
+func (h *Handler) export(ctx context.Context, tokenizedMsg []string) (string, *errors.AppError) {
+	rangeArgs, format := parseExportArgs(tokenizedMsg[1:])
+	if len(rangeArgs) > 2 {
+		return "", errors.BadRequestError(invalidExportMsg)
+	}
+
+	var file *domain.File
+	var err *errors.AppError
+	if format == exportFormatLedger {
+		file, err = h.journalFile(ctx, rangeArgs)
+	} else if err = permission.CheckCallerAllAccounts(ctx, "export"); err == nil {
+		file, err = h.statementCSVFile(ctx, rangeArgs, format)
+	}
+	if err != nil {
+		return "", err
+	}
+	published, err := h.publisher.Publish(ctx, file)
+	if err != nil {
+		return "", err
+	}
+
+	expiresIn := time.Until(published.ExpiresAt).Round(time.Minute)
+	return fmt.Sprintf("Your export is ready\n================\n%v\n\nThe link works once and expires in %v minutes.", published.URL, int(expiresIn.Minutes())), nil
+}
+
+func (h *Handler) statementCSVFile(ctx context.Context, rangeArgs []string, format string) (*domain.File, *errors.AppError) {
+	res, _, err := h.fetchStatement(ctx, rangeArgs)
+	if err != nil {
+		return nil, err
+	}
+	req, err := detailedStatementRange(rangeArgs, h.now())
+	if err != nil {
+		return nil, err
+	}
+	details, err := h.client.GetDetailedStatement(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if user, ok := domain.UserFromContext(ctx); ok {
+		details = visibleStatement(details, user.Role)
+	}
+	content, writeErr := writeStatementCSV(res, details, format, h.location)
+	if writeErr != nil {
+		return nil, errors.InternalServerError(fmt.Sprintf("cannot write the export: %v", writeErr))
+	}
+	return &domain.File{
+		Name:        exportFileName(rangeArgs, "csv"),
+		ContentType: "text/csv; charset=utf-8",
+		Content:     content,
+	}, nil
+}
+
+// parseExportArgs splits the optional trailing format from the range arguments.
+func parseExportArgs(args []string) ([]string, string) {
+	if len(args) == 0 {
+		return args, exportFormatCSV
+	}
+	switch last := args[len(args)-1]; last {
+	case exportFormatCSV, exportFormatExcel, exportFormatLedger:
+		return args[:len(args)-1], last
+	default:
+		return args, exportFormatCSV
+	}
+}
+
+func exportFileName(rangeArgs []string, extension string) string {
+	switch len(rangeArgs) {
+	case 0:
+		return "statement-monthly." + extension
+	case 1:
+		switch rangeArgs[0] {
+		case "a":
+			return "statement-annual." + extension
+		case "m":
+			return "statement-monthly." + extension
+		}
+	}
+	return fmt.Sprintf("statement-%s.%s", strings.Join(rangeArgs, "_"), extension)
+}
+
+// writeStatementCSV writes a row per category of each section, followed
+// by the totals and, after a blank line, a row per transaction and
+// transfer. The excel format adds a BOM and uses CRLF line endings.
+func writeStatementCSV(res *domain.GetOverviewStatementResponse, details *domain.GetDetailedStatementResponse, format string, loc *time.Location) ([]byte, error) {
+	revenue, expense := res.Revenue, res.Expense
+	if revenue == nil {
+		revenue = &domain.GetOverviewStatementSection{}
+	}
+	if expense == nil {
+		expense = &domain.GetOverviewStatementSection{}
+	}
+
+	var buf bytes.Buffer
+	if format == exportFormatExcel {
+		buf.WriteString(utf8BOM)
+	}
+	w := csv.NewWriter(&buf)
+	w.UseCRLF = format == exportFormatExcel
+
+	rows := [][]string{{"section", "category", "amount"}}
+	for _, v := range revenue.Entries {
+		rows = append(rows, []string{"revenue", v.Category, formatAmount(v.Amount)})
+	}
+	for _, v := range expense.Entries {
+		rows = append(rows, []string{"expense", v.Category, formatAmount(v.Amount)})
+	}
+	rows = append(rows,
+		[]string{"total", "revenue", formatAmount(revenue.Total)},
+		[]string{"total", "expense", formatAmount(expense.Total)},
+		[]string{"total", "profit", formatAmount(res.Profit)},
+	)
+	rows = append(rows, transactionRows(details, loc)...)
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// transactionRows returns the transactions and transfers of the statement
+// under their own header.
+func transactionRows(details *domain.GetDetailedStatementResponse, loc *time.Location) [][]string {
+	rows := [][]string{{}, {"date", "section", "account", "to_account", "category", "amount", "description"}}
+	entryRows := func(section string, s *domain.GetDetailedStatementSection) {
+		if s == nil {
+			return
+		}
+		for _, v := range s.Entries {
+			rows = append(rows, []string{v.Timestamp.In(loc).Format(lastTimeLayout), section, v.Account, "", v.Category, formatAmount(v.Amount), v.Description})
+		}
+	}
+	entryRows("revenue", details.Revenue)
+	entryRows("expense", details.Expense)
+	for _, v := range details.Transfers {
+		rows = append(rows, []string{v.Timestamp.In(loc).Format(lastTimeLayout), "transfer", v.FromAccount, v.ToAccount, "", formatAmount(v.Amount), v.Description})
+	}
+	return rows
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', -1, 64)
+}
@@ -0,0 +1,265 @@
+package finance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/daterange"
+	"github.com/sMARCHz/secretaria-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var exportStatement = &domain.GetOverviewStatementResponse{
+	Revenue: &domain.GetOverviewStatementSection{
+		Total:   30000,
+		Entries: []domain.CategorizedEntry{{Category: "salary", Amount: 30000}},
+	},
+	Expense: &domain.GetOverviewStatementSection{
+		Total:   700.5,
+		Entries: []domain.CategorizedEntry{{Category: "sh", Amount: 500}, {Category: "fd, drinks", Amount: 200.5}},
+	},
+	Profit: 29299.5,
+}
+
+var exportDetails = &domain.GetDetailedStatementResponse{
+	Revenue: &domain.GetDetailedStatementSection{
+		Total: 30000,
+		Entries: []domain.Entry{{
+			Timestamp: time.Date(2025, 1, 25, 9, 0, 0, 0, time.UTC),
+			Account:   "debit1", Category: "salary", Amount: 30000, Description: "january salary",
+		}},
+	},
+	Expense: &domain.GetDetailedStatementSection{
+		Total: 500,
+		Entries: []domain.Entry{{
+			Timestamp: time.Date(2025, 1, 26, 12, 30, 0, 0, time.UTC),
+			Account:   "credit1", Category: "sh", Amount: 500, Description: "shoes, socks",
+		}},
+	},
+	Transfers: []domain.TransferEntry{{
+		Timestamp:   time.Date(2025, 1, 27, 8, 0, 0, 0, time.UTC),
+		FromAccount: "debit1", ToAccount: "savings", Amount: 5000,
+	}},
+}
+
+const emptyTransactionRows = "\ndate,section,account,to_account,category,amount,description\n"
+
+func TestExport(t *testing.T) {
+	testcases := []struct {
+		it           string
+		tokenizedMsg []string
+		mock         func(client *mocks.MockFinanceServiceClient)
+		expectedFile *domain.File
+	}{
+		{
+			it:           "exports the monthly statement as csv if no argument is provided",
+			tokenizedMsg: []string{"export"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().GetOverviewMonthlyStatement(mock.Anything).Return(exportStatement, nil)
+				client.EXPECT().GetDetailedStatement(mock.Anything, mock.Anything).Return(exportDetails, nil)
+			},
+			expectedFile: &domain.File{
+				Name:        "statement-monthly.csv",
+				ContentType: "text/csv; charset=utf-8",
+				Content: []byte("section,category,amount\n" +
+					"revenue,salary,30000\n" +
+					"expense,sh,500\n" +
+					"expense,\"fd, drinks\",200.5\n" +
+					"total,revenue,30000\n" +
+					"total,expense,700.5\n" +
+					"total,profit,29299.5\n" +
+					"\n" +
+					"date,section,account,to_account,category,amount,description\n" +
+					"2025-01-25 09:00,revenue,debit1,,salary,30000,january salary\n" +
+					"2025-01-26 12:30,expense,credit1,,sh,500,\"shoes, socks\"\n" +
+					"2025-01-27 08:00,transfer,debit1,savings,,5000,\n"),
+			},
+		},
+		{
+			it:           "exports the annual statement as excel-compatible csv",
+			tokenizedMsg: []string{"export", "a", "excel"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().GetOverviewAnnualStatement(mock.Anything).Return(&domain.GetOverviewStatementResponse{Profit: 1}, nil)
+				client.EXPECT().GetDetailedStatement(mock.Anything, mock.Anything).Return(&domain.GetDetailedStatementResponse{}, nil)
+			},
+			expectedFile: &domain.File{
+				Name:        "statement-annual.csv",
+				ContentType: "text/csv; charset=utf-8",
+				Content: []byte("\ufeffsection,category,amount\r\n" +
+					"total,revenue,0\r\n" +
+					"total,expense,0\r\n" +
+					"total,profit,1\r\n" +
+					"\r\n" +
+					"date,section,account,to_account,category,amount,description\r\n"),
+			},
+		},
+		{
+			it:           "exports the statement of the selected range",
+			tokenizedMsg: []string{"export", "2025-01-01", "2025-03-31", "csv"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().GetOverviewStatement(mock.Anything, &domain.GetOverviewStatementRequest{
+					From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					To:   time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+				}).Return(&domain.GetOverviewStatementResponse{}, nil)
+				client.EXPECT().GetDetailedStatement(mock.Anything, &domain.GetOverviewStatementRequest{
+					From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					To:   time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+				}).Return(&domain.GetDetailedStatementResponse{}, nil)
+			},
+			expectedFile: &domain.File{
+				Name:        "statement-2025-01-01_2025-03-31.csv",
+				ContentType: "text/csv; charset=utf-8",
+				Content:     []byte("section,category,amount\ntotal,revenue,0\ntotal,expense,0\ntotal,profit,0\n" + emptyTransactionRows),
+			},
+		},
+		{
+			it:           "exports the statement of a period",
+			tokenizedMsg: []string{"export", "q1", "2024", "excel"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().GetOverviewStatement(mock.Anything, &domain.GetOverviewStatementRequest{
+					From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					To:   time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+				}).Return(&domain.GetOverviewStatementResponse{}, nil)
+				client.EXPECT().GetDetailedStatement(mock.Anything, &domain.GetOverviewStatementRequest{
+					From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					To:   time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+				}).Return(&domain.GetDetailedStatementResponse{}, nil)
+			},
+			expectedFile: &domain.File{
+				Name:        "statement-q1_2024.csv",
+				ContentType: "text/csv; charset=utf-8",
+				Content:     []byte("\ufeffsection,category,amount\r\ntotal,revenue,0\r\ntotal,expense,0\r\ntotal,profit,0\r\n\r\ndate,section,account,to_account,category,amount,description\r\n"),
+			},
+		},
+		{
+			it:           "exports the transactions of the selected range as a ledger journal",
+			tokenizedMsg: []string{"export", "2025-01-01", "2025-01-31", "ledger"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().GetDetailedStatement(mock.Anything, &domain.GetOverviewStatementRequest{
+					From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					To:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+				}).Return(&domain.GetDetailedStatementResponse{
+					Revenue: &domain.GetDetailedStatementSection{
+						Total: 30000,
+						Entries: []domain.Entry{{
+							Timestamp: time.Date(2025, 1, 25, 9, 0, 0, 0, time.UTC),
+							Account:   "debit1", Category: "salary", Amount: 30000, Description: "january salary",
+						}},
+					},
+				}, nil)
+			},
+			expectedFile: &domain.File{
+				Name:        "statement-2025-01-01_2025-01-31.journal",
+				ContentType: "text/plain; charset=utf-8",
+				Content: []byte("2025-01-25 january salary\n" +
+					"    assets:bank:debit1                    30000.00\n" +
+					"    income:salary                         -30000.00\n"),
+			},
+		},
+		{
+			it:           "exports the transactions of this month as a ledger journal",
+			tokenizedMsg: []string{"export", "ledger"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().GetDetailedStatement(mock.Anything, mock.MatchedBy(func(req *domain.GetOverviewStatementRequest) bool {
+					return req.From.Day() == 1 && !req.To.Before(req.From)
+				})).Return(&domain.GetDetailedStatementResponse{}, nil)
+			},
+			expectedFile: &domain.File{
+				Name:        "statement-monthly.journal",
+				ContentType: "text/plain; charset=utf-8",
+				Content:     nil,
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			client := mocks.NewMockFinanceServiceClient(t)
+			tc.mock(client)
+			publisher := mocks.NewMockFilePublisher(t)
+			publisher.EXPECT().Publish(mock.Anything, tc.expectedFile).Return(&domain.PublishedFile{
+				URL:       "https://bot.example.com/downloads/abc",
+				ExpiresAt: time.Now().Add(10 * time.Minute),
+			}, nil)
+			handler := newTestHandler(t, client, usingPublisher(publisher), usingLedger(domain.LedgerConfig{
+				Accounts:     map[string]string{"debit1": "assets:bank:debit1"},
+				IncomePrefix: "income",
+			}))
+
+			res, err := handler.export(ownerContext(), tc.tokenizedMsg)
+
+			assert.Nil(t, err)
+			assert.Equal(t, "Your export is ready\n================\nhttps://bot.example.com/downloads/abc\n\nThe link works once and expires in 10 minutes.", res)
+			client.AssertExpectations(t)
+			publisher.AssertExpectations(t)
+		})
+	}
+}
+
+func TestExport_Error(t *testing.T) {
+	testcases := []struct {
+		it           string
+		tokenizedMsg []string
+		mock         func(client *mocks.MockFinanceServiceClient, publisher *mocks.MockFilePublisher)
+		expectedErr  *errors.AppError
+	}{
+		{
+			it:           "return error when too many arguments are provided",
+			tokenizedMsg: []string{"export", "2025-01-01", "2025-03-31", "2025-04-01"},
+			expectedErr:  errors.BadRequestError(invalidExportMsg),
+		},
+		{
+			it:           "return error when the range is invalid",
+			tokenizedMsg: []string{"export", "x", "excel"},
+			expectedErr:  errors.BadRequestError("Unknown period 'x'.\nPlease use " + daterange.Syntax),
+		},
+		{
+			it:           "return error when the statement can't be fetched",
+			tokenizedMsg: []string{"export"},
+			mock: func(client *mocks.MockFinanceServiceClient, _ *mocks.MockFilePublisher) {
+				client.EXPECT().GetOverviewMonthlyStatement(mock.Anything).Return(nil, errors.BadGatewayError("cannot get statement"))
+			},
+			expectedErr: errors.BadGatewayError("cannot get statement"),
+		},
+		{
+			it:           "return error when the transactions can't be fetched",
+			tokenizedMsg: []string{"export", "csv"},
+			mock: func(client *mocks.MockFinanceServiceClient, _ *mocks.MockFilePublisher) {
+				client.EXPECT().GetOverviewMonthlyStatement(mock.Anything).Return(exportStatement, nil)
+				client.EXPECT().GetDetailedStatement(mock.Anything, mock.Anything).Return(nil, errors.BadGatewayError("cannot get detailed statement"))
+			},
+			expectedErr: errors.BadGatewayError("cannot get detailed statement"),
+		},
+		{
+			it:           "return error when the file can't be published",
+			tokenizedMsg: []string{"export"},
+			mock: func(client *mocks.MockFinanceServiceClient, publisher *mocks.MockFilePublisher) {
+				client.EXPECT().GetOverviewMonthlyStatement(mock.Anything).Return(exportStatement, nil)
+				client.EXPECT().GetDetailedStatement(mock.Anything, mock.Anything).Return(exportDetails, nil)
+				publisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil, errors.InternalServerError("downloads are unavailable"))
+			},
+			expectedErr: errors.InternalServerError("downloads are unavailable"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			client := mocks.NewMockFinanceServiceClient(t)
+			publisher := mocks.NewMockFilePublisher(t)
+			if tc.mock != nil {
+				tc.mock(client, publisher)
+			}
+			handler := newTestHandler(t, client, usingPublisher(publisher))
+
+			res, err := handler.export(ownerContext(), tc.tokenizedMsg)
+
+			assert.Empty(t, res)
+			assert.Equal(t, tc.expectedErr, err)
+			client.AssertExpectations(t)
+			publisher.AssertExpectations(t)
+		})
+	}
+}
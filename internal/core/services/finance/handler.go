@@ -1,18 +1,69 @@
 package finance
 
 import (
+	"context"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/account"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/category"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/confirmation"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/debt"
 	"github.com/sMARCHz/secretaria-bot/internal/ports/client"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
 )
 
 // Handler implements command handling for finance-related commands.
 type Handler struct {
-	client client.FinanceServiceClient
+	client        client.FinanceServiceClient
+	publisher     client.FilePublisher
+	accounts      *account.Service
+	categories    *category.Service
+	debts         *debt.Ledger
+	confirmations *confirmation.Service
+	store         storage.Store
+	ledger        domain.LedgerConfig
+	location      *time.Location
+}
+
+// timeNow is replaced by the tests to pin the relative periods.
+var timeNow = time.Now
+
+// Deps are what the finance commands work with.
+type Deps struct {
+	Client client.FinanceServiceClient
+	// Publisher makes the exports downloadable.
+	Publisher     client.FilePublisher
+	Accounts      *account.Service
+	Categories    *category.Service
+	Debts         *debt.Ledger
+	Confirmations *confirmation.Service
+	// Store keeps the references of the listed transactions.
+	Store  storage.Store
+	Ledger domain.LedgerConfig
+	// Location resolves the relative periods such as "last month".
+	Location *time.Location
 }
 
 // NewHandler constructs a finance command handler.
-func NewHandler(client client.FinanceServiceClient) *Handler {
-	return &Handler{client: client}
+func NewHandler(deps Deps) *Handler {
+	return &Handler{
+		client:        deps.Client,
+		publisher:     deps.Publisher,
+		accounts:      deps.Accounts,
+		categories:    deps.Categories,
+		debts:         deps.Debts,
+		confirmations: deps.Confirmations,
+		store:         deps.Store,
+		ledger:        deps.Ledger,
+		location:      deps.Location,
+	}
+}
+
+// now returns the current time in the handler's time zone.
+func (h *Handler) now() time.Time {
+	return timeNow().In(h.location)
 }
 
 func (h *Handler) Match(cmd string) bool {
@@ -22,22 +73,59 @@ func (h *Handler) Match(cmd string) bool {
 	return false
 }
 
-func (h *Handler) Handle(tokenizedMsg []string) (string, *errors.AppError) {
+func (h *Handler) Handle(ctx context.Context, tokenizedMsg []string) (string, *errors.AppError) {
 	if len(tokenizedMsg) == 0 {
 		return "", errors.BadRequestError(commandNotFoundMsg)
 	}
 	switch tokenizedMsg[0] {
 	case "!p":
-		return h.withdraw(tokenizedMsg)
+		return h.withdraw(ctx, tokenizedMsg)
 	case "!e":
-		return h.deposit(tokenizedMsg)
+		return h.deposit(ctx, tokenizedMsg)
 	case "!t":
-		return h.transfer(tokenizedMsg)
+		return h.transfer(ctx, tokenizedMsg)
 	case "balance":
-		return h.getBalance()
+		return h.getBalance(ctx)
 	case "statement":
-		return h.getStatement(tokenizedMsg)
+		return h.getStatement(ctx, tokenizedMsg)
+	case "export":
+		return h.export(ctx, tokenizedMsg)
+	case "split":
+		return h.split(ctx, tokenizedMsg)
+	case "settle":
+		return h.settle(ctx, tokenizedMsg)
+	case "owe":
+		return h.owe(ctx, tokenizedMsg)
+	case "last":
+		return h.last(ctx, tokenizedMsg)
+	case "edit":
+		return h.edit(ctx, tokenizedMsg)
 	default:
 		return "", errors.BadRequestError(invalidCommandMsg)
 	}
 }
+
+// Accounts returns the accounts the command refers to, once resolved
+// from their aliases and the caller's default account.
+func (h *Handler) Accounts(ctx context.Context, tokenizedMsg []string) []string {
+	if len(tokenizedMsg) == 0 {
+		return nil
+	}
+	n, allowed := 0, isPlain
+	switch tokenizedMsg[0] {
+	case "!p", "!e", "split":
+		n, allowed = 1, isCategory
+	case "settle":
+		n = 1
+	case "!t":
+		n = 2
+	default:
+		return nil
+	}
+	// Without a default account the command fails once handled
+	tokenizedMsg, err := withAccounts(ctx, tokenizedMsg, n, allowed, h.accounts)
+	if err != nil {
+		return nil
+	}
+	return tokenizedMsg[1:min(len(tokenizedMsg), n+1)]
+}
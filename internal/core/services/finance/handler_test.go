@@ -1,23 +1,94 @@
 package finance
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
 	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/account"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/category"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/confirmation"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/debt"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/client"
 	"github.com/sMARCHz/secretaria-bot/test/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestNewHandler(t *testing.T) {
 	client := mocks.NewMockFinanceServiceClient(t)
+	publisher := mocks.NewMockFilePublisher(t)
+	accounts := account.NewService(client, domain.AccountConfig{}, memory.NewStore())
+	categories := category.NewService(domain.CategoryConfig{}, memory.NewStore())
+	debts := debt.NewLedger(domain.DebtConfig{Category: "lent"}, memory.NewStore())
+	confirmations := confirmation.NewService(client, domain.ConfirmationConfig{})
+	store := memory.NewStore()
 
-	res := NewHandler(client)
+	res := NewHandler(Deps{
+		Client:        client,
+		Publisher:     publisher,
+		Accounts:      accounts,
+		Categories:    categories,
+		Debts:         debts,
+		Confirmations: confirmations,
+		Store:         store,
+		Location:      time.UTC,
+	})
 
-	expected := &Handler{client: client}
+	expected := &Handler{client: client, publisher: publisher, accounts: accounts, categories: categories, debts: debts, confirmations: confirmations, store: store, location: time.UTC}
 	assert.Equal(t, expected, res)
 }
 
+// newTestHandler constructs a handler on client with empty registries and
+// stores, in UTC, unless the options replace them.
+func newTestHandler(t *testing.T, client *mocks.MockFinanceServiceClient, opts ...func(*Deps)) *Handler {
+	t.Helper()
+	deps := Deps{
+		Client:        client,
+		Accounts:      account.NewService(client, domain.AccountConfig{}, memory.NewStore()),
+		Categories:    category.NewService(domain.CategoryConfig{}, memory.NewStore()),
+		Debts:         debt.NewLedger(domain.DebtConfig{Category: "lent"}, memory.NewStore()),
+		Confirmations: confirmation.NewService(client, domain.ConfirmationConfig{}),
+		Store:         memory.NewStore(),
+		Location:      time.UTC,
+	}
+	for _, opt := range opts {
+		opt(&deps)
+	}
+	return NewHandler(deps)
+}
+
+func usingPublisher(publisher client.FilePublisher) func(*Deps) {
+	return func(d *Deps) { d.Publisher = publisher }
+}
+
+func usingAccounts(accounts *account.Service) func(*Deps) {
+	return func(d *Deps) { d.Accounts = accounts }
+}
+
+func usingCategories(categories *category.Service) func(*Deps) {
+	return func(d *Deps) { d.Categories = categories }
+}
+
+func usingDebts(debts *debt.Ledger) func(*Deps) {
+	return func(d *Deps) { d.Debts = debts }
+}
+
+func usingConfirmations(confirmations *confirmation.Service) func(*Deps) {
+	return func(d *Deps) { d.Confirmations = confirmations }
+}
+
+func usingLedger(ledger domain.LedgerConfig) func(*Deps) {
+	return func(d *Deps) { d.Ledger = ledger }
+}
+
+func usingLocation(location *time.Location) func(*Deps) {
+	return func(d *Deps) { d.Location = location }
+}
+
 func TestMatch(t *testing.T) {
 	testcases := []struct {
 		it       string
@@ -39,7 +110,7 @@ func TestMatch(t *testing.T) {
 	for _, tc := range testcases {
 		t.Run(tc.it, func(t *testing.T) {
 			client := mocks.NewMockFinanceServiceClient(t)
-			handler := NewHandler(client)
+			handler := newTestHandler(t, client)
 
 			res := handler.Match(tc.cmd)
 
@@ -59,7 +130,7 @@ func TestHandle(t *testing.T) {
 			it:           "return reply message for withdraw command",
 			tokenizedMsg: []string{"!p", "debit1", "500sh", "youtube membership"},
 			mock: func(client *mocks.MockFinanceServiceClient) {
-				client.EXPECT().Withdraw(&domain.TransactionRequest{
+				client.EXPECT().Withdraw(mock.Anything, &domain.TransactionRequest{
 					Account:     "debit1",
 					Amount:      500,
 					Category:    "sh",
@@ -75,7 +146,7 @@ func TestHandle(t *testing.T) {
 			it:           "return reply message for deposit command",
 			tokenizedMsg: []string{"!e", "debit1", "20000s"},
 			mock: func(client *mocks.MockFinanceServiceClient) {
-				client.EXPECT().Deposit(&domain.TransactionRequest{
+				client.EXPECT().Deposit(mock.Anything, &domain.TransactionRequest{
 					Account:     "debit1",
 					Amount:      20000,
 					Category:    "s",
@@ -91,7 +162,7 @@ func TestHandle(t *testing.T) {
 			it:           "return reply message for transfer command",
 			tokenizedMsg: []string{"!t", "debit2", "debit1", "20000"},
 			mock: func(client *mocks.MockFinanceServiceClient) {
-				client.EXPECT().Transfer(&domain.TransferRequest{
+				client.EXPECT().Transfer(mock.Anything, &domain.TransferRequest{
 					FromAccount: "debit2",
 					ToAccount:   "debit1",
 					Amount:      20000,
@@ -107,7 +178,7 @@ func TestHandle(t *testing.T) {
 			it:           "return reply message for balance command",
 			tokenizedMsg: []string{"balance"},
 			mock: func(client *mocks.MockFinanceServiceClient) {
-				client.EXPECT().GetBalance().Return(&domain.GetBalanceResponse{
+				client.EXPECT().GetBalance(mock.Anything).Return(&domain.GetBalanceResponse{
 					Accounts: []domain.AccountBalance{
 						{
 							Account: "debit1",
@@ -122,7 +193,7 @@ func TestHandle(t *testing.T) {
 			it:           "return reply message for statement command",
 			tokenizedMsg: []string{"statement"},
 			mock: func(client *mocks.MockFinanceServiceClient) {
-				client.EXPECT().GetOverviewMonthlyStatement().Return(&domain.GetOverviewStatementResponse{
+				client.EXPECT().GetOverviewMonthlyStatement(mock.Anything).Return(&domain.GetOverviewStatementResponse{
 					Revenue: &domain.GetOverviewStatementSection{
 						Total: 20000,
 					},
@@ -140,9 +211,9 @@ func TestHandle(t *testing.T) {
 		t.Run(tc.it, func(t *testing.T) {
 			client := mocks.NewMockFinanceServiceClient(t)
 			tc.mock(client)
-			handler := NewHandler(client)
+			handler := newTestHandler(t, client)
 
-			replyMsg, err := handler.Handle(tc.tokenizedMsg)
+			replyMsg, err := handler.Handle(ownerContext(), tc.tokenizedMsg)
 
 			assert.Nil(t, err)
 			assert.Equal(t, tc.expectedReplyMsg, replyMsg)
@@ -177,9 +248,9 @@ func TestHandle_Error(t *testing.T) {
 	for _, tc := range testcases {
 		t.Run(tc.it, func(t *testing.T) {
 			client := mocks.NewMockFinanceServiceClient(t)
-			handler := NewHandler(client)
+			handler := newTestHandler(t, client)
 
-			res, err := handler.Handle(tc.tokenizedMsg)
+			res, err := handler.Handle(ownerContext(), tc.tokenizedMsg)
 
 			assert.Empty(t, res)
 			assert.EqualError(t, err, tc.expectedErr.Message)
@@ -187,3 +258,65 @@ func TestHandle_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestAccounts(t *testing.T) {
+	testcases := []struct {
+		it           string
+		userID       string
+		tokenizedMsg []string
+		expected     []string
+	}{
+		{
+			it:           "returns the account of a withdraw or deposit command",
+			tokenizedMsg: []string{"!p", "debit1", "500sh", "youtube membership"},
+			expected:     []string{"debit1"},
+		},
+		{
+			it:           "returns both accounts of a transfer command",
+			tokenizedMsg: []string{"!t", "debit2", "debit1", "20000"},
+			expected:     []string{"debit2", "debit1"},
+		},
+		{
+			it:           "returns the accounts given so far of an incomplete command",
+			tokenizedMsg: []string{"!t", "debit2"},
+			expected:     []string{"debit2"},
+		},
+		{
+			it:           "resolves the aliases",
+			tokenizedMsg: []string{"!t", "k", "debit1", "20000"},
+			expected:     []string{"kbank-savings", "debit1"},
+		},
+		{
+			it:           "returns the default account when it's left out",
+			userID:       "owner",
+			tokenizedMsg: []string{"!p", "500sh", "lunch"},
+			expected:     []string{"debit1"},
+		},
+		{
+			it:           "returns no account when it's left out without default account",
+			userID:       "partner",
+			tokenizedMsg: []string{"!p", "500sh", "lunch"},
+			expected:     nil,
+		},
+		{
+			it:           "returns no account for commands without account",
+			tokenizedMsg: []string{"statement", "a"},
+			expected:     nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			accounts := account.NewService(nil, domain.AccountConfig{
+				Aliases:  map[string]string{"k": "kbank-savings"},
+				Defaults: map[string]string{"owner": "debit1"},
+			}, memory.NewStore())
+			handler := newTestHandler(t, mocks.NewMockFinanceServiceClient(t), usingAccounts(accounts))
+			ctx := domain.ContextWithUser(context.Background(), domain.User{ID: tc.userID})
+
+			res := handler.Accounts(ctx, tc.tokenizedMsg)
+
+			assert.Equal(t, tc.expected, res)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package finance
+
+import (
+	"context"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/daterange"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/ledger"
+)
+
+const journalContentType = "text/plain; charset=utf-8"
+
+// journalFile writes the transactions and transfers of the range as a
+// ledger-cli journal.
+func (h *Handler) journalFile(ctx context.Context, rangeArgs []string) (*domain.File, *errors.AppError) {
+	req, err := detailedStatementRange(rangeArgs, h.now())
+	if err != nil {
+		return nil, err
+	}
+	res, err := h.client.GetDetailedStatement(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if user, ok := domain.UserFromContext(ctx); ok {
+		res = visibleStatement(res, user.Role)
+	}
+	return &domain.File{
+		Name:        exportFileName(rangeArgs, "journal"),
+		ContentType: journalContentType,
+		Content:     ledger.Write(res, h.ledger, h.location),
+	}, nil
+}
+
+// detailedStatementRange returns the range given by the command's
+// arguments: none or "m" (this month so far), "a" (this year so far), two
+// dates or a period such as "last month".
+func detailedStatementRange(rangeArgs []string, now time.Time) (*domain.GetOverviewStatementRequest, *errors.AppError) {
+	switch {
+	case len(rangeArgs) == 0:
+		return detailedStatementRange([]string{"m"}, now)
+	case len(rangeArgs) == 1 && rangeArgs[0] == "m":
+		from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		return &domain.GetOverviewStatementRequest{From: from.UTC(), To: now.UTC()}, nil
+	case len(rangeArgs) == 1 && rangeArgs[0] == "a":
+		from := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+		return &domain.GetOverviewStatementRequest{From: from.UTC(), To: now.UTC()}, nil
+	case isSelectedRange(rangeArgs):
+		return parseSelectedRange(rangeArgs[0], rangeArgs[1], now.Location())
+	default:
+		return daterange.Parse(rangeArgs, now)
+	}
+}
+
+// visibleStatement drops the transactions of the accounts the role doesn't
+// grant, and the transfers unless it grants both accounts.
+func visibleStatement(res *domain.GetDetailedStatementResponse, role domain.Role) *domain.GetDetailedStatementResponse {
+	visibleSection := func(s *domain.GetDetailedStatementSection) *domain.GetDetailedStatementSection {
+		if s == nil {
+			return nil
+		}
+		entries := make([]domain.Entry, 0, len(s.Entries))
+		for _, v := range s.Entries {
+			if role.CanAccess(v.Account) {
+				entries = append(entries, v)
+			}
+		}
+		return &domain.GetDetailedStatementSection{Total: s.Total, Entries: entries}
+	}
+
+	transfers := make([]domain.TransferEntry, 0, len(res.Transfers))
+	for _, v := range res.Transfers {
+		if role.CanAccess(v.FromAccount) && role.CanAccess(v.ToAccount) {
+			transfers = append(transfers, v)
+		}
+	}
+	return &domain.GetDetailedStatementResponse{
+		Revenue:   visibleSection(res.Revenue),
+		Expense:   visibleSection(res.Expense),
+		Transfers: transfers,
+		Profit:    res.Profit,
+	}
+}
@@ -0,0 +1,93 @@
+package finance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/daterange"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetailedStatementRange(t *testing.T) {
+	now := time.Date(2025, 3, 15, 18, 30, 0, 0, time.UTC)
+	testcases := []struct {
+		it          string
+		rangeArgs   []string
+		expected    *domain.GetOverviewStatementRequest
+		expectedErr *errors.AppError
+	}{
+		{
+			it:        "returns this month so far if no argument is provided",
+			rangeArgs: nil,
+			expected:  &domain.GetOverviewStatementRequest{From: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), To: now},
+		},
+		{
+			it:        "returns this year so far for 'a'",
+			rangeArgs: []string{"a"},
+			expected:  &domain.GetOverviewStatementRequest{From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), To: now},
+		},
+		{
+			it:        "returns the selected range",
+			rangeArgs: []string{"2025-01-01", "2025-01-31"},
+			expected: &domain.GetOverviewStatementRequest{
+				From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			it:        "returns a relative period",
+			rangeArgs: []string{"last-month"},
+			expected: &domain.GetOverviewStatementRequest{
+				From: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			it:          "returns error for an unknown range",
+			rangeArgs:   []string{"w"},
+			expectedErr: errors.BadRequestError("Unknown period 'w'.\nPlease use " + daterange.Syntax),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			res, err := detailedStatementRange(tc.rangeArgs, now)
+
+			assert.Equal(t, tc.expected, res)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestVisibleStatement(t *testing.T) {
+	role := domain.Role{Name: "member", Accounts: []string{"shared-*"}}
+	statement := &domain.GetDetailedStatementResponse{
+		Revenue: &domain.GetDetailedStatementSection{
+			Total:   100,
+			Entries: []domain.Entry{{Account: "debit1", Amount: 100}},
+		},
+		Expense: &domain.GetDetailedStatementSection{
+			Total:   50,
+			Entries: []domain.Entry{{Account: "shared-kbank", Amount: 50}},
+		},
+		Transfers: []domain.TransferEntry{
+			{FromAccount: "shared-kbank", ToAccount: "shared-cash", Amount: 10},
+			{FromAccount: "debit1", ToAccount: "shared-kbank", Amount: 20},
+		},
+		Profit: 50,
+	}
+
+	res := visibleStatement(statement, role)
+
+	assert.Equal(t, &domain.GetDetailedStatementResponse{
+		Revenue: &domain.GetDetailedStatementSection{Total: 100, Entries: []domain.Entry{}},
+		Expense: &domain.GetDetailedStatementSection{
+			Total:   50,
+			Entries: []domain.Entry{{Account: "shared-kbank", Amount: 50}},
+		},
+		Transfers: []domain.TransferEntry{{FromAccount: "shared-kbank", ToAccount: "shared-cash", Amount: 10}},
+		Profit:    50,
+	}, res)
+}
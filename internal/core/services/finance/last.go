@@ -0,0 +1,102 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+)
+
+const (
+	invalidLastMsg = "Invalid command's arguments.\nPlease recheck the syntax (last [count])"
+
+	defaultLastCount = 10
+	maxLastCount     = 50
+
+	lastTimeLayout = "2006-01-02 15:04"
+)
+
+// last lists the caller's most recent withdrawals, deposits and transfers,
+// e.g. "last 20", newest first. Each line starts with the transaction's
+// short reference, which the follow-up commands take.
+func (h *Handler) last(ctx context.Context, tokenizedMsg []string) (string, *errors.AppError) {
+	n := defaultLastCount
+	switch len(tokenizedMsg) {
+	case 1:
+	case 2:
+		var err error
+		if n, err = strconv.Atoi(tokenizedMsg[1]); err != nil || n < 1 || n > maxLastCount {
+			return "", errors.BadRequestError(fmt.Sprintf("Invalid count '%s', it must be between 1 and %d", tokenizedMsg[1], maxLastCount))
+		}
+	default:
+		return "", errors.BadRequestError(invalidLastMsg)
+	}
+
+	transactions, err := h.listTransactions(ctx, n)
+	if err != nil {
+		return "", err
+	}
+	if len(transactions) == 0 {
+		return "You have no transactions yet", nil
+	}
+	refs, err := h.rememberRefs(ctx, transactions)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Last %d transactions\n================", len(transactions)))
+	for _, t := range transactions {
+		sb.WriteString(fmt.Sprintf("\n[%s] %s", refs[t.ID], h.printTransaction(t)))
+	}
+	return sb.String(), nil
+}
+
+// listTransactions pages through the transactions until it has the last n
+// the caller's role grants.
+func (h *Handler) listTransactions(ctx context.Context, n int) ([]domain.Transaction, *errors.AppError) {
+	user, hasUser := domain.UserFromContext(ctx)
+	var transactions []domain.Transaction
+	req := &domain.ListTransactionsRequest{}
+	for len(transactions) < n {
+		req.PageSize = n - len(transactions)
+		res, err := h.client.ListTransactions(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range res.Transactions {
+			if hasUser && !user.Role.CanAccess(t.Account) && (t.ToAccount == "" || !user.Role.CanAccess(t.ToAccount)) {
+				continue
+			}
+			transactions = append(transactions, t)
+		}
+		if res.NextPageToken == "" || len(res.Transactions) == 0 {
+			break
+		}
+		req.PageToken = res.NextPageToken
+	}
+	return transactions[:min(len(transactions), n)], nil
+}
+
+// printTransaction shows a transaction on one line, e.g.
+//
+//	2025-03-15 19:04 withdraw ฿120 (fd) from debit1: lunch
+func (h *Handler) printTransaction(t domain.Transaction) string {
+	var sb strings.Builder
+	sb.WriteString(t.Timestamp.In(h.location).Format(lastTimeLayout) + " ")
+	switch t.Type {
+	case domain.TransactionWithdrawal:
+		sb.WriteString(fmt.Sprintf("withdraw ฿%v (%s) from %s", t.Amount, t.Category, t.Account))
+	case domain.TransactionDeposit:
+		sb.WriteString(fmt.Sprintf("deposit ฿%v (%s) to %s", t.Amount, t.Category, t.Account))
+	case domain.TransactionTransfer:
+		sb.WriteString(fmt.Sprintf("transfer ฿%v from %s to %s", t.Amount, t.Account, t.ToAccount))
+	}
+	if t.Description != "" {
+		sb.WriteString(": " + t.Description)
+	}
+	return sb.String()
+}
@@ -0,0 +1,171 @@
+package finance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	lunch = domain.Transaction{
+		ID:          "tx-3",
+		Type:        domain.TransactionWithdrawal,
+		Timestamp:   time.Date(2025, 3, 15, 12, 4, 0, 0, time.UTC),
+		Account:     "debit1",
+		Category:    "fd",
+		Amount:      120,
+		Description: "lunch",
+	}
+	salary = domain.Transaction{
+		ID:        "tx-2",
+		Type:      domain.TransactionDeposit,
+		Timestamp: time.Date(2025, 3, 15, 2, 0, 0, 0, time.UTC),
+		Account:   "debit1",
+		Category:  "salary",
+		Amount:    30000,
+	}
+	saving = domain.Transaction{
+		ID:        "tx-1",
+		Type:      domain.TransactionTransfer,
+		Timestamp: time.Date(2025, 3, 14, 23, 30, 0, 0, time.UTC),
+		Account:   "debit1",
+		ToAccount: "shared-savings",
+		Amount:    5000,
+	}
+)
+
+func newLastHandler(t *testing.T, client *mocks.MockFinanceServiceClient) *Handler {
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	return newTestHandler(t, client, usingLocation(bangkok))
+}
+
+func TestLast(t *testing.T) {
+	testcases := []struct {
+		it               string
+		user             domain.User
+		tokenizedMsg     []string
+		mock             func(client *mocks.MockFinanceServiceClient)
+		expectedReplyMsg string
+		expectedErr      *errors.AppError
+	}{
+		{
+			it:           "lists the last transactions, newest first",
+			user:         serviceOwner,
+			tokenizedMsg: []string{"last"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().ListTransactions(mock.Anything, &domain.ListTransactionsRequest{PageSize: 10}).
+					Return(&domain.ListTransactionsResponse{Transactions: []domain.Transaction{lunch, salary, saving}}, nil).Once()
+			},
+			expectedReplyMsg: "Last 3 transactions\n================\n" +
+				"[eea1a] 2025-03-15 19:04 withdraw ฿120 (fd) from debit1: lunch\n" +
+				"[0ab25] 2025-03-15 09:00 deposit ฿30000 (salary) to debit1\n" +
+				"[045ef] 2025-03-15 06:30 transfer ฿5000 from debit1 to shared-savings",
+		},
+		{
+			it:           "pages through the transactions up to the count",
+			user:         serviceOwner,
+			tokenizedMsg: []string{"last", "2"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().ListTransactions(mock.Anything, &domain.ListTransactionsRequest{PageSize: 2}).
+					Return(&domain.ListTransactionsResponse{Transactions: []domain.Transaction{lunch}, NextPageToken: "page-2"}, nil).Once()
+				client.EXPECT().ListTransactions(mock.Anything, &domain.ListTransactionsRequest{PageSize: 1, PageToken: "page-2"}).
+					Return(&domain.ListTransactionsResponse{Transactions: []domain.Transaction{salary}, NextPageToken: "page-3"}, nil).Once()
+			},
+			expectedReplyMsg: "Last 2 transactions\n================\n" +
+				"[eea1a] 2025-03-15 19:04 withdraw ฿120 (fd) from debit1: lunch\n" +
+				"[0ab25] 2025-03-15 09:00 deposit ฿30000 (salary) to debit1",
+		},
+		{
+			it:           "only lists the transactions of the accounts the caller's role grants",
+			user:         serviceMember,
+			tokenizedMsg: []string{"last", "1"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().ListTransactions(mock.Anything, &domain.ListTransactionsRequest{PageSize: 1}).
+					Return(&domain.ListTransactionsResponse{Transactions: []domain.Transaction{lunch}, NextPageToken: "page-2"}, nil).Once()
+				client.EXPECT().ListTransactions(mock.Anything, &domain.ListTransactionsRequest{PageSize: 1, PageToken: "page-2"}).
+					Return(&domain.ListTransactionsResponse{Transactions: []domain.Transaction{saving}}, nil).Once()
+			},
+			expectedReplyMsg: "Last 1 transactions\n================\n" +
+				"[045ef] 2025-03-15 06:30 transfer ฿5000 from debit1 to shared-savings",
+		},
+		{
+			it:           "tells when there is no transaction",
+			user:         serviceOwner,
+			tokenizedMsg: []string{"last"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().ListTransactions(mock.Anything, mock.Anything).Return(&domain.ListTransactionsResponse{}, nil).Once()
+			},
+			expectedReplyMsg: "You have no transactions yet",
+		},
+		{
+			it:           "rejects a count out of range",
+			user:         serviceOwner,
+			tokenizedMsg: []string{"last", "0"},
+			expectedErr:  errors.BadRequestError("Invalid count '0', it must be between 1 and 50"),
+		},
+		{
+			it:           "rejects extra arguments",
+			user:         serviceOwner,
+			tokenizedMsg: []string{"last", "10", "fd"},
+			expectedErr:  errors.BadRequestError(invalidLastMsg),
+		},
+		{
+			it:           "returns the error of the finance service",
+			user:         serviceOwner,
+			tokenizedMsg: []string{"last"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().ListTransactions(mock.Anything, mock.Anything).Return(nil, errors.BadGatewayError("cannot list transactions")).Once()
+			},
+			expectedErr: errors.BadGatewayError("cannot list transactions"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			client := mocks.NewMockFinanceServiceClient(t)
+			if tc.mock != nil {
+				tc.mock(client)
+			}
+			handler := newLastHandler(t, client)
+
+			res, err := handler.Handle(domain.ContextWithUser(context.Background(), tc.user), tc.tokenizedMsg)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedReplyMsg, res)
+		})
+	}
+}
+
+func TestLast_KeepsReferences(t *testing.T) {
+	client := mocks.NewMockFinanceServiceClient(t)
+	client.EXPECT().ListTransactions(mock.Anything, mock.Anything).
+		Return(&domain.ListTransactionsResponse{Transactions: []domain.Transaction{salary}}, nil).Once()
+	client.EXPECT().ListTransactions(mock.Anything, mock.Anything).
+		Return(&domain.ListTransactionsResponse{Transactions: []domain.Transaction{lunch, salary}}, nil).Once()
+	handler := newLastHandler(t, client)
+	ctx := domain.ContextWithUser(context.Background(), serviceOwner)
+
+	first, err := handler.Handle(ctx, []string{"last"})
+	require.Nil(t, err)
+	second, err := handler.Handle(ctx, []string{"last"})
+	require.Nil(t, err)
+
+	assert.Contains(t, first, "[0ab25] 2025-03-15 09:00 deposit")
+	assert.Contains(t, second, "[0ab25] 2025-03-15 09:00 deposit")
+	assert.Contains(t, second, "[eea1a] 2025-03-15 19:04 withdraw")
+}
+
+func TestRefOf(t *testing.T) {
+	taken := []transactionRef{{Ref: "eea1a", ID: "other"}}
+
+	assert.Equal(t, "eea1a", refOf("tx-3", nil))
+	assert.Equal(t, "eea1a", refOf("tx-3", []transactionRef{{Ref: "eea1a", ID: "tx-3"}}))
+	assert.Len(t, refOf("tx-3", taken), refLength+1)
+}
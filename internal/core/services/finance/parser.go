@@ -1,35 +1,31 @@
 package finance
 
 import (
+	"context"
 	"fmt"
-	"regexp"
-	"strconv"
+	"slices"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/account"
 	"github.com/sMARCHz/secretaria-bot/internal/logger"
 )
 
-var transactionCommandPattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)?([a-zA-Z]+)$`)
-
 // TODO: Rename variable
-func parseTransactionRequest(tokenizedMsg []string) (*domain.TransactionRequest, *errors.AppError) {
-	if err := validateLength(tokenizedMsg, 3, "!p/!e <account_name> <amount><category> <description>"); err != nil {
+func parseTransactionRequest(ctx context.Context, tokenizedMsg []string, accounts *account.Service) (*domain.TransactionRequest, *errors.AppError) {
+	tokenizedMsg, err := withAccounts(ctx, tokenizedMsg, 1, isCategory, accounts)
+	if err != nil {
 		return nil, err
 	}
-
-	// 200.12sh -> [200.12sh, 200.12, sh]
-	submatch := transactionCommandPattern.FindStringSubmatch(tokenizedMsg[2])
-	if len(submatch) != 3 {
-		logger.Error("invalid amount and category combination['%v']", tokenizedMsg[2])
-		return nil, errors.BadRequestError("Invalid amount and category combination")
+	if err := validateLength(ctx, tokenizedMsg, 3, "!p/!e <account_name> <amount><category> <description>"); err != nil {
+		return nil, err
 	}
 
-	amount, err := strconv.ParseFloat(submatch[1], 64)
+	amount, category, err := parseCategorizedAmount(ctx, tokenizedMsg[2])
 	if err != nil {
-		logger.Error("cannot parse amount to float64: ", err)
-		return nil, errors.BadRequestError("Invalid command's arguments.\nPlease recheck syntax and amount of transaction in the command")
+		return nil, err
 	}
 
 	var description string
@@ -40,20 +36,41 @@ func parseTransactionRequest(tokenizedMsg []string) (*domain.TransactionRequest,
 	return &domain.TransactionRequest{
 		Account:     tokenizedMsg[1],
 		Amount:      amount,
-		Category:    submatch[2],
+		Category:    category,
 		Description: description,
 	}, nil
 }
 
-func parseTransferRequest(tokenizedMsg []string) (*domain.TransferRequest, *errors.AppError) {
-	if err := validateLength(tokenizedMsg, 4, "!t <transfer_from> <transfer_to> <amount> <description>"); err != nil {
+// parseCategorizedAmount splits the token into its amount and category,
+// e.g. 120+45.5sh into 165.5 and sh.
+func parseCategorizedAmount(ctx context.Context, token string) (float64, string, *errors.AppError) {
+	amount, rest, err := parseAmount(token)
+	if err == nil {
+		err = unexpectedRest(token, rest, isCategory)
+	}
+	if err != nil {
+		logger.Ctx(ctx).Errorw("invalid amount", "input", token, "error", err)
+		return 0, "", errors.BadRequestError(fmt.Sprintf("Invalid amount '%s': %v", token, err))
+	}
+	if rest == "" {
+		logger.Ctx(ctx).Errorw("invalid amount and category combination", "input", token)
+		return 0, "", errors.BadRequestError("Invalid amount and category combination")
+	}
+	return amount, rest, nil
+}
+
+func parseTransferRequest(ctx context.Context, tokenizedMsg []string, accounts *account.Service) (*domain.TransferRequest, *errors.AppError) {
+	tokenizedMsg, err := withAccounts(ctx, tokenizedMsg, 2, isPlain, accounts)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateLength(ctx, tokenizedMsg, 4, "!t <transfer_from> <transfer_to> <amount> <description>"); err != nil {
 		return nil, err
 	}
 
-	amount, err := strconv.ParseFloat(tokenizedMsg[3], 64)
+	amount, err := parsePlainAmount(ctx, tokenizedMsg[3])
 	if err != nil {
-		logger.Error("cannot parse amount to float64: ", err)
-		return nil, errors.BadRequestError("Invalid command's arguments.\nPlease recheck syntax and amount of transaction in the command")
+		return nil, err
 	}
 
 	var description string
@@ -69,9 +86,123 @@ func parseTransferRequest(tokenizedMsg []string) (*domain.TransferRequest, *erro
 	}, nil
 }
 
-func validateLength(tokenizedMsg []string, minLength int, commandSyntax string) *errors.AppError {
+// parsePlainAmount parses the token as an amount with nothing after it,
+// e.g. 120+45.5.
+func parsePlainAmount(ctx context.Context, token string) (float64, *errors.AppError) {
+	amount, rest, err := parseAmount(token)
+	if err == nil {
+		err = unexpectedRest(token, rest, isPlain)
+	}
+	if err != nil {
+		logger.Ctx(ctx).Errorw("invalid amount", "input", token, "error", err)
+		return 0, errors.BadRequestError(fmt.Sprintf("Invalid amount '%s': %v", token, err))
+	}
+	return amount, nil
+}
+
+// withAccounts returns a copy of the command whose n accounts, which
+// follow the command name, are resolved from their aliases. The first one
+// is the caller's default account when it is left out, i.e. when an amount
+// or a person takes its place, e.g. "!p 120sh lunch", "!t savings 500" or
+// "settle @alice 300". The amounts are only followed by the characters
+// allowed says, e.g. the category of "!p".
+func withAccounts(ctx context.Context, tokenizedMsg []string, n int, allowed func(rune) bool, accounts *account.Service) ([]string, *errors.AppError) {
+	tokenizedMsg = slices.Clone(tokenizedMsg)
+	leftOut, err := accountLeftOut(ctx, tokenizedMsg, n, allowed, accounts)
+	if err != nil {
+		return nil, err
+	}
+	if leftOut {
+		defaultAccount, err := accounts.Default(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if defaultAccount == "" {
+			return nil, errors.BadRequestError("You have no default account.\nName the account or set one with 'account default <account>'")
+		}
+		tokenizedMsg = slices.Insert(tokenizedMsg, 1, defaultAccount)
+	}
+	for i := 1; i <= n && i < len(tokenizedMsg); i++ {
+		account, err := accounts.Resolve(ctx, tokenizedMsg[i])
+		if err != nil {
+			return nil, err
+		}
+		tokenizedMsg[i] = account
+	}
+	return tokenizedMsg, nil
+}
+
+// accountLeftOut reports whether the nth token takes the place of the
+// first account. Accounts starting with digits, e.g. 7eleven, are told
+// from the amounts by their aliases and by what follows their digits.
+// When both the token and the next one read as categorized amounts, as in
+// "!p 7eleven 100fd" and "!p 120sh 2pcs", the accounts are looked up.
+func accountLeftOut(ctx context.Context, tokenizedMsg []string, n int, allowed func(rune) bool, accounts *account.Service) (bool, *errors.AppError) {
+	if len(tokenizedMsg) <= n {
+		return false, nil
+	}
+	token := tokenizedMsg[n]
+	if strings.HasPrefix(token, "@") {
+		return true, nil
+	}
+	if !isAmount(token, allowed) {
+		return false, nil
+	}
+	resolved, err := accounts.Resolve(ctx, token)
+	if err != nil {
+		return false, err
+	}
+	if resolved != token {
+		return false, nil
+	}
+	if !hasCategory(token) || len(tokenizedMsg) <= n+1 {
+		return true, nil
+	}
+	if next := tokenizedMsg[n+1]; !hasCategory(next) || !isAmount(next, allowed) {
+		return true, nil
+	}
+	exist, err := accounts.Exists(ctx, token)
+	return !exist, err
+}
+
+// hasCategory reports whether characters follow the amount of the token.
+func hasCategory(token string) bool {
+	_, rest, err := parseAmount(token)
+	return err == nil && rest != ""
+}
+
+// isAmount reports whether the whole token is an amount followed by the
+// characters allowed says.
+func isAmount(token string, allowed func(rune) bool) bool {
+	_, rest, err := parseAmount(token)
+	return err == nil && unexpectedRest(token, rest, allowed) == nil
+}
+
+// isPlain allows nothing after the amount.
+func isPlain(rune) bool {
+	return false
+}
+
+// unexpectedRest reports the first character of rest, the part of token
+// after the amount, which isn't allowed.
+func unexpectedRest(token, rest string, allowed func(rune) bool) *amountError {
+	i := strings.IndexFunc(rest, func(r rune) bool { return !allowed(r) })
+	if i < 0 {
+		return nil
+	}
+	offset := len(token) - len(rest) + i
+	r, _ := utf8.DecodeRuneInString(token[offset:])
+	return &amountError{msg: fmt.Sprintf("unexpected '%c'", r), pos: utf8.RuneCountInString(token[:offset]) + 1}
+}
+
+func isCategory(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func validateLength(ctx context.Context, tokenizedMsg []string, minLength int, commandSyntax string) *errors.AppError {
 	if len(tokenizedMsg) < minLength {
-		logger.Error("invalid command length")
+		msg, _ := domain.MessageFromContext(ctx)
+		logger.Ctx(ctx).Errorw("invalid command length", "message", msg)
 		return errors.BadRequestError(fmt.Sprintf("Invalid command's arguments.\nPlease recheck the syntax (%s)", commandSyntax))
 	}
 	return nil
@@ -1,26 +1,55 @@
 package finance
 
 import (
+	"context"
 	"testing"
 
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
 	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/account"
+	"github.com/sMARCHz/secretaria-bot/test/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestParseTransactionRequest(t *testing.T) {
-	tokenizedMsg := []string{"!p", "debit1", "200sh", "steam purchase"}
+	testcases := []struct {
+		it               string
+		amount           string
+		expectedAmount   float64
+		expectedCategory string
+	}{
+		{it: "parses a plain amount", amount: "200sh", expectedAmount: 200, expectedCategory: "sh"},
+		{it: "parses a decimal amount", amount: "200.12sh", expectedAmount: 200.12, expectedCategory: "sh"},
+		{it: "parses a sum", amount: "120+45.5sh", expectedAmount: 165.5, expectedCategory: "sh"},
+		{it: "parses a difference", amount: "500-120fd", expectedAmount: 380, expectedCategory: "fd"},
+		{it: "parses the k suffix as thousands", amount: "(1.2k)sh", expectedAmount: 1200, expectedCategory: "sh"},
+		{it: "parses x as multiplication", amount: "3x40fd", expectedAmount: 120, expectedCategory: "fd"},
+		{it: "parses * as multiplication", amount: "3*40fd", expectedAmount: 120, expectedCategory: "fd"},
+		{it: "parses thousands separators", amount: "1,200sh", expectedAmount: 1200, expectedCategory: "sh"},
+		{it: "applies precedence", amount: "100+2x50fd", expectedAmount: 200, expectedCategory: "fd"},
+		{it: "splits a bill with parentheses", amount: "(120+45)/3fd", expectedAmount: 55, expectedCategory: "fd"},
+		{it: "rounds the result to satang", amount: "100/3fd", expectedAmount: 33.33, expectedCategory: "fd"},
+		{it: "keeps a category starting with x", amount: "20xmas", expectedAmount: 20, expectedCategory: "xmas"},
+	}
 
-	res, err := parseTransactionRequest(tokenizedMsg)
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			tokenizedMsg := []string{"!p", "debit1", tc.amount, "steam", "purchase"}
+
+			res, err := parseTransactionRequest(context.Background(), tokenizedMsg, account.NewService(nil, domain.AccountConfig{}, memory.NewStore()))
 
-	expected := &domain.TransactionRequest{
-		Account:     "debit1",
-		Amount:      200,
-		Category:    "sh",
-		Description: "steam purchase",
+			expected := &domain.TransactionRequest{
+				Account:     "debit1",
+				Amount:      tc.expectedAmount,
+				Category:    tc.expectedCategory,
+				Description: "steam purchase",
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, expected, res)
+		})
 	}
-	assert.Nil(t, err)
-	assert.Equal(t, expected, res)
 }
 
 func TestParseTransactionRequest_Error(t *testing.T) {
@@ -40,15 +69,46 @@ func TestParseTransactionRequest_Error(t *testing.T) {
 			expectedErr:  errors.BadRequestError("Invalid amount and category combination"),
 		},
 		{
-			it:           "return error when amount cannot be parsed to float64",
+			// It used to record ฿50,000 of fc
+			it:           "return error when a category follows the k suffix",
+			tokenizedMsg: []string{"!p", "debit1", "50kfc"},
+			expectedErr:  errors.BadRequestError("Invalid amount '50kfc': ambiguous amount, write (50k)fc for thousands"),
+		},
+		{
+			it:           "return error when the amount is missing",
 			tokenizedMsg: []string{"!p", "debit1", "sh"},
-			expectedErr:  errors.BadRequestError("Invalid command's arguments.\nPlease recheck syntax and amount of transaction in the command"),
+			expectedErr:  errors.BadRequestError("Invalid amount 'sh': expected a number, got 's' at position 1"),
+		},
+		{
+			it:           "return error with the position of an operator without operand",
+			tokenizedMsg: []string{"!p", "debit1", "120+*5sh"},
+			expectedErr:  errors.BadRequestError("Invalid amount '120+*5sh': expected a number, got '*' at position 5"),
+		},
+		{
+			it:           "return error with the position of an unclosed parenthesis",
+			tokenizedMsg: []string{"!p", "debit1", "(120+45fd"},
+			expectedErr:  errors.BadRequestError("Invalid amount '(120+45fd': missing ')', got 'f' at position 8"),
+		},
+		{
+			it:           "return error when a division by zero",
+			tokenizedMsg: []string{"!p", "debit1", "120/0fd"},
+			expectedErr:  errors.BadRequestError("Invalid amount '120/0fd': division by zero at position 4"),
+		},
+		{
+			it:           "return error when the category isn't only letters",
+			tokenizedMsg: []string{"!p", "debit1", "120sh2"},
+			expectedErr:  errors.BadRequestError("Invalid amount '120sh2': unexpected '2' at position 6"),
+		},
+		{
+			it:           "return error when the amount isn't positive",
+			tokenizedMsg: []string{"!p", "debit1", "100-120fd"},
+			expectedErr:  errors.BadRequestError("Invalid amount '100-120fd': the amount must be greater than 0"),
 		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.it, func(t *testing.T) {
-			res, err := parseTransactionRequest(tc.tokenizedMsg)
+			res, err := parseTransactionRequest(context.Background(), tc.tokenizedMsg, account.NewService(nil, domain.AccountConfig{}, memory.NewStore()))
 			assert.Nil(t, res)
 			assert.Equal(t, tc.expectedErr, err)
 			assert.Equal(t, tc.expectedErr.StatusCode, err.StatusCode)
@@ -56,10 +116,80 @@ func TestParseTransactionRequest_Error(t *testing.T) {
 	}
 }
 
+func TestParseRequest_Accounts(t *testing.T) {
+	accounts := account.NewService(nil, domain.AccountConfig{
+		Aliases:  map[string]string{"k": "kbank-savings", "d": "debit1"},
+		Defaults: map[string]string{"owner": "debit1"},
+	}, memory.NewStore())
+	ctx := domain.ContextWithUser(context.Background(), domain.User{ID: "owner"})
+
+	transaction, err := parseTransactionRequest(ctx, []string{"!p", "k", "120sh", "lunch"}, accounts)
+	assert.Nil(t, err)
+	assert.Equal(t, &domain.TransactionRequest{Account: "kbank-savings", Amount: 120, Category: "sh", Description: "lunch"}, transaction)
+
+	transaction, err = parseTransactionRequest(ctx, []string{"!p", "120sh", "lunch"}, accounts)
+	assert.Nil(t, err)
+	assert.Equal(t, &domain.TransactionRequest{Account: "debit1", Amount: 120, Category: "sh", Description: "lunch"}, transaction)
+
+	transfer, err := parseTransferRequest(ctx, []string{"!t", "k", "500"}, accounts)
+	assert.Nil(t, err)
+	assert.Equal(t, &domain.TransferRequest{FromAccount: "debit1", ToAccount: "kbank-savings", Amount: 500}, transfer)
+
+	transfer, err = parseTransferRequest(ctx, []string{"!t", "k", "d", "500"}, accounts)
+	assert.Nil(t, err)
+	assert.Equal(t, &domain.TransferRequest{FromAccount: "kbank-savings", ToAccount: "debit1", Amount: 500}, transfer)
+
+	transfer, err = parseTransferRequest(ctx, []string{"!t", "debit1", "2ndacct", "500"}, accounts)
+	assert.Nil(t, err)
+	assert.Equal(t, &domain.TransferRequest{FromAccount: "debit1", ToAccount: "2ndacct", Amount: 500}, transfer)
+}
+
+func TestParseRequest_DigitAccounts(t *testing.T) {
+	client := mocks.NewMockFinanceServiceClient(t)
+	client.EXPECT().GetBalance(mock.Anything).Return(&domain.GetBalanceResponse{
+		Accounts: []domain.AccountBalance{{Account: "debit1"}, {Account: "7eleven"}},
+	}, nil).Times(2)
+	accounts := account.NewService(client, domain.AccountConfig{
+		Defaults: map[string]string{"owner": "debit1"},
+	}, memory.NewStore())
+	ctx := domain.ContextWithUser(context.Background(), domain.User{ID: "owner"})
+
+	transaction, err := parseTransactionRequest(ctx, []string{"!p", "7eleven", "100fd"}, accounts)
+	assert.Nil(t, err)
+	assert.Equal(t, &domain.TransactionRequest{Account: "7eleven", Amount: 100, Category: "fd"}, transaction)
+
+	transaction, err = parseTransactionRequest(ctx, []string{"!p", "120sh", "2pcs"}, accounts)
+	assert.Nil(t, err)
+	assert.Equal(t, &domain.TransactionRequest{Account: "debit1", Amount: 120, Category: "sh", Description: "2pcs"}, transaction)
+	client.AssertExpectations(t)
+}
+
+func TestParseRequest_DigitAlias(t *testing.T) {
+	accounts := account.NewService(nil, domain.AccountConfig{
+		Aliases:  map[string]string{"7k": "kbank-savings"},
+		Defaults: map[string]string{"owner": "debit1"},
+	}, memory.NewStore())
+	ctx := domain.ContextWithUser(context.Background(), domain.User{ID: "owner"})
+
+	transfer, err := parseTransferRequest(ctx, []string{"!t", "7k", "500"}, accounts)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &domain.TransferRequest{FromAccount: "debit1", ToAccount: "kbank-savings", Amount: 500}, transfer)
+}
+
+func TestParseRequest_NoDefaultAccount(t *testing.T) {
+	ctx := domain.ContextWithUser(context.Background(), domain.User{ID: "partner"})
+
+	res, err := parseTransactionRequest(ctx, []string{"!p", "120sh", "lunch"}, account.NewService(nil, domain.AccountConfig{}, memory.NewStore()))
+
+	assert.Nil(t, res)
+	assert.Equal(t, errors.BadRequestError("You have no default account.\nName the account or set one with 'account default <account>'"), err)
+}
+
 func TestParseTransferRequest(t *testing.T) {
-	tokenizedMsg := []string{"!t", "debit2", "debit1", "20000", "salary"}
+	tokenizedMsg := []string{"!t", "debit2", "debit1", "15k+5,000", "salary"}
 
-	res, err := parseTransferRequest(tokenizedMsg)
+	res, err := parseTransferRequest(context.Background(), tokenizedMsg, account.NewService(nil, domain.AccountConfig{}, memory.NewStore()))
 
 	expected := &domain.TransferRequest{
 		FromAccount: "debit2",
@@ -83,15 +213,20 @@ func TestParseTransferRequest_Error(t *testing.T) {
 			expectedErr:  errors.BadRequestError("Invalid command's arguments.\nPlease recheck the syntax (!t <transfer_from> <transfer_to> <amount> <description>)"),
 		},
 		{
-			it:           "return error when amount cannot be parsed to float64",
+			it:           "return error when the amount is missing",
 			tokenizedMsg: []string{"!t", "debit2", "debit1", "invalid"},
-			expectedErr:  errors.BadRequestError("Invalid command's arguments.\nPlease recheck syntax and amount of transaction in the command"),
+			expectedErr:  errors.BadRequestError("Invalid amount 'invalid': expected a number, got 'i' at position 1"),
+		},
+		{
+			it:           "return error when the amount is followed by a category",
+			tokenizedMsg: []string{"!t", "debit2", "debit1", "200sh"},
+			expectedErr:  errors.BadRequestError("Invalid amount '200sh': unexpected 's' at position 4"),
 		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.it, func(t *testing.T) {
-			res, err := parseTransferRequest(tc.tokenizedMsg)
+			res, err := parseTransferRequest(context.Background(), tc.tokenizedMsg, account.NewService(nil, domain.AccountConfig{}, memory.NewStore()))
 			assert.Nil(t, res)
 			assert.Equal(t, tc.expectedErr, err)
 		})
@@ -123,7 +258,7 @@ func TestValidateLength(t *testing.T) {
 
 	for _, tc := range testcases {
 		t.Run(tc.it, func(t *testing.T) {
-			err := validateLength(tc.tokenizedMsg, tc.minLength, tc.commandSyntax)
+			err := validateLength(context.Background(), tc.tokenizedMsg, tc.minLength, tc.commandSyntax)
 			assert.Equal(t, tc.expectedErr, err)
 		})
 	}
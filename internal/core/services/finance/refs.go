@@ -0,0 +1,113 @@
+package finance
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+)
+
+const (
+	refLength = 5
+	// maxRefs is how many references are kept per user, the most recently
+	// listed first.
+	maxRefs = 200
+)
+
+var refCollection = storage.NewCollection[[]transactionRef]("transaction_refs") // by user ID
+
+// transactionRef is the short reference shown for a transaction, which the
+// follow-up commands take instead of its ID. The type, accounts and
+// category, as last listed, let amount edits be confirmed like new
+// transactions.
+type transactionRef struct {
+	Ref       string `json:"ref"`
+	ID        string `json:"id"`
+	Type      string `json:"type,omitempty"`
+	Account   string `json:"account,omitempty"`
+	ToAccount string `json:"to_account,omitempty"`
+	Category  string `json:"category,omitempty"`
+}
+
+// rememberRefs gives the transactions their short references and keeps
+// them for the caller. A transaction keeps its reference across listings.
+func (h *Handler) rememberRefs(ctx context.Context, transactions []domain.Transaction) (map[string]string, *errors.AppError) {
+	user, _ := domain.UserFromContext(ctx)
+	refs := make(map[string]string, len(transactions)) // by ID
+	err := h.store.Update(ctx, func(tx storage.Tx) error {
+		known, _, err := refCollection.Get(tx, user.ID)
+		if err != nil {
+			return err
+		}
+		listed := make([]transactionRef, 0, len(transactions))
+		for _, t := range transactions {
+			if _, done := refs[t.ID]; done {
+				continue
+			}
+			ref := refOf(t.ID, slices.Concat(listed, known))
+			refs[t.ID] = ref
+			listed = append(listed, transactionRef{Ref: ref, ID: t.ID, Type: t.Type, Account: t.Account, ToAccount: t.ToAccount, Category: t.Category})
+		}
+		known = slices.DeleteFunc(known, func(r transactionRef) bool {
+			_, relisted := refs[r.ID]
+			return relisted
+		})
+		known = slices.Concat(listed, known)
+		return refCollection.Put(tx, user.ID, known[:min(len(known), maxRefs)])
+	})
+	if err != nil {
+		logger.Ctx(ctx).Errorw("failed to save transaction references", "error", err)
+		return nil, errors.InternalServerError("cannot save the transaction references")
+	}
+	return refs, nil
+}
+
+// lookupRef returns the transaction the caller's reference was given to.
+func (h *Handler) lookupRef(ctx context.Context, ref string) (transactionRef, *errors.AppError) {
+	user, _ := domain.UserFromContext(ctx)
+	var found transactionRef
+	err := h.store.View(ctx, func(tx storage.Tx) error {
+		known, _, err := refCollection.Get(tx, user.ID)
+		if err != nil {
+			return err
+		}
+		if i := slices.IndexFunc(known, func(r transactionRef) bool { return r.Ref == ref }); i >= 0 {
+			found = known[i]
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Ctx(ctx).Errorw("failed to read transaction references", "error", err)
+		return transactionRef{}, errors.InternalServerError("cannot read the transaction references")
+	}
+	if found.ID == "" {
+		return transactionRef{}, errors.NotFoundError(fmt.Sprintf("Unknown reference '%s'.\nList the transactions with 'last' first", ref))
+	}
+	return found, nil
+}
+
+// refOf derives the reference from the ID, so that it stays the same
+// across listings, lengthening it when its prefix is taken by another
+// transaction.
+func refOf(id string, known []transactionRef) string {
+	for _, r := range known {
+		if r.ID == id {
+			return r.Ref
+		}
+	}
+	sum := sha256.Sum256([]byte(id))
+	digest := hex.EncodeToString(sum[:])
+	for n := refLength; n < len(digest); n++ {
+		ref := digest[:n]
+		if !slices.ContainsFunc(known, func(r transactionRef) bool { return r.Ref == ref }) {
+			return ref
+		}
+	}
+	return digest
+}
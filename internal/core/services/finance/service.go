@@ -0,0 +1,187 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/category"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/ledger"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/permission"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/client"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/inbound"
+)
+
+// Service implements the finance operations for the adapters which don't
+// speak in chat commands. Each operation is subject to the same role
+// permissions as its command, and names are lowercased like the chat
+// messages are, so both refer to the same accounts and categories.
+type Service struct {
+	client     client.FinanceServiceClient
+	categories *category.Service
+	ledger     domain.LedgerConfig
+	location   *time.Location
+}
+
+// NewService constructs the typed finance service. Categories are resolved
+// through the registry, and journals name their accounts after the ledger
+// settings and date their entries in location.
+func NewService(client client.FinanceServiceClient, categories *category.Service, ledger domain.LedgerConfig, location *time.Location) inbound.FinanceService {
+	return &Service{client: client, categories: categories, ledger: ledger, location: location}
+}
+
+func (s *Service) Withdraw(ctx context.Context, user domain.User, req *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError) {
+	ctx = withCaller(ctx, user)
+	req = normalizeTransactionRequest(req)
+	if err := permission.Check(ctx, user, "!p", req.Account); err != nil {
+		return nil, err
+	}
+	if err := validateTransactionRequest(req); err != nil {
+		return nil, err
+	}
+	var err *errors.AppError
+	if req.Category, err = s.categories.Resolve(ctx, req.Category); err != nil {
+		return nil, err
+	}
+	return s.client.Withdraw(ctx, req)
+}
+
+func (s *Service) Deposit(ctx context.Context, user domain.User, req *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError) {
+	ctx = withCaller(ctx, user)
+	req = normalizeTransactionRequest(req)
+	if err := permission.Check(ctx, user, "!e", req.Account); err != nil {
+		return nil, err
+	}
+	if err := validateTransactionRequest(req); err != nil {
+		return nil, err
+	}
+	var err *errors.AppError
+	if req.Category, err = s.categories.Resolve(ctx, req.Category); err != nil {
+		return nil, err
+	}
+	return s.client.Deposit(ctx, req)
+}
+
+func (s *Service) Transfer(ctx context.Context, user domain.User, req *domain.TransferRequest) (*domain.TransferResponse, *errors.AppError) {
+	ctx = withCaller(ctx, user)
+	req = &domain.TransferRequest{
+		FromAccount: strings.ToLower(req.FromAccount),
+		ToAccount:   strings.ToLower(req.ToAccount),
+		Amount:      req.Amount,
+		Description: req.Description,
+	}
+	if err := permission.Check(ctx, user, "!t", req.FromAccount, req.ToAccount); err != nil {
+		return nil, err
+	}
+	if err := validateTransferRequest(req); err != nil {
+		return nil, err
+	}
+	return s.client.Transfer(ctx, req)
+}
+
+// GetBalance only returns the accounts the user's role grants.
+func (s *Service) GetBalance(ctx context.Context, user domain.User) (*domain.GetBalanceResponse, *errors.AppError) {
+	ctx = withCaller(ctx, user)
+	if err := permission.Check(ctx, user, "balance"); err != nil {
+		return nil, err
+	}
+	res, err := s.client.GetBalance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]domain.AccountBalance, 0, len(res.Accounts))
+	for _, v := range res.Accounts {
+		if user.Role.CanAccess(v.Account) {
+			accounts = append(accounts, v)
+		}
+	}
+	return &domain.GetBalanceResponse{Accounts: accounts}, nil
+}
+
+// GetOverviewStatement totals all the accounts, so it is only available to
+// the roles granting them all.
+func (s *Service) GetOverviewStatement(ctx context.Context, user domain.User, req *domain.GetOverviewStatementRequest) (*domain.GetOverviewStatementResponse, *errors.AppError) {
+	ctx = withCaller(ctx, user)
+	if err := permission.CheckAllAccounts(ctx, user, "statement"); err != nil {
+		return nil, err
+	}
+	if err := validateStatementRequest(req); err != nil {
+		return nil, err
+	}
+	return s.client.GetOverviewStatement(ctx, req)
+}
+
+// GetJournal returns the transactions and transfers of the range as a
+// ledger-cli journal, like the export command. Only the accounts the
+// user's role grants are included.
+func (s *Service) GetJournal(ctx context.Context, user domain.User, req *domain.GetOverviewStatementRequest) ([]byte, *errors.AppError) {
+	ctx = withCaller(ctx, user)
+	if err := permission.Check(ctx, user, "export"); err != nil {
+		return nil, err
+	}
+	if err := validateStatementRequest(req); err != nil {
+		return nil, err
+	}
+	res, err := s.client.GetDetailedStatement(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return ledger.Write(visibleStatement(res, user.Role), s.ledger, s.location), nil
+}
+
+// withCaller attaches the user to ctx, as the bot service does for commands.
+func withCaller(ctx context.Context, user domain.User) context.Context {
+	ctx = domain.ContextWithUser(ctx, user)
+	return logger.WithFields(ctx, "user_id", user.ID)
+}
+
+func normalizeTransactionRequest(req *domain.TransactionRequest) *domain.TransactionRequest {
+	return &domain.TransactionRequest{
+		Account:     strings.ToLower(req.Account),
+		Amount:      req.Amount,
+		Category:    strings.ToLower(req.Category),
+		Description: req.Description,
+	}
+}
+
+func validateTransactionRequest(req *domain.TransactionRequest) *errors.AppError {
+	switch {
+	case req.Account == "":
+		return errors.BadRequestError("account is required")
+	case req.Category == "":
+		return errors.BadRequestError("category is required")
+	case req.Amount <= 0:
+		return errors.BadRequestError(fmt.Sprintf("amount must be greater than 0, got %v", req.Amount))
+	}
+	return nil
+}
+
+func validateTransferRequest(req *domain.TransferRequest) *errors.AppError {
+	switch {
+	case req.FromAccount == "":
+		return errors.BadRequestError("from_account is required")
+	case req.ToAccount == "":
+		return errors.BadRequestError("to_account is required")
+	case req.FromAccount == req.ToAccount:
+		return errors.BadRequestError("from_account and to_account must be different")
+	case req.Amount <= 0:
+		return errors.BadRequestError(fmt.Sprintf("amount must be greater than 0, got %v", req.Amount))
+	}
+	return nil
+}
+
+func validateStatementRequest(req *domain.GetOverviewStatementRequest) *errors.AppError {
+	switch {
+	case req.From.IsZero():
+		return errors.BadRequestError("from is required")
+	case req.To.IsZero():
+		return errors.BadRequestError("to is required")
+	case req.To.Before(req.From):
+		return errors.BadRequestError("to must not be before from")
+	}
+	return nil
+}
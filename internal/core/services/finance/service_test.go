@@ -0,0 +1,337 @@
+package finance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/category"
+	"github.com/sMARCHz/secretaria-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	serviceOwner = domain.User{
+		ID:   "owner",
+		Role: domain.Role{Name: "owner", Commands: []string{"*"}, Accounts: []string{"*"}},
+	}
+	serviceMember = domain.User{
+		ID:   "partner",
+		Role: domain.Role{Name: "member", Commands: []string{"!p", "balance"}, Accounts: []string{"shared-*"}},
+	}
+	restrictedViewer = domain.User{
+		ID:   "viewer",
+		Role: domain.Role{Name: "viewer", Commands: []string{"*"}, Accounts: []string{"shared-*"}},
+	}
+)
+
+// ownerContext is the context of a chat message sent by the owner.
+func ownerContext() context.Context {
+	return domain.ContextWithUser(context.Background(), serviceOwner)
+}
+
+// callerIs matches a context carrying the given user.
+func callerIs(user domain.User) interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		caller, ok := domain.UserFromContext(ctx)
+		return ok && caller.ID == user.ID
+	})
+}
+
+func TestNewService(t *testing.T) {
+	client := mocks.NewMockFinanceServiceClient(t)
+	categories := category.NewService(domain.CategoryConfig{}, memory.NewStore())
+	ledger := domain.LedgerConfig{AssetsPrefix: "assets"}
+
+	res := NewService(client, categories, ledger, time.UTC)
+
+	assert.Equal(t, &Service{client: client, categories: categories, ledger: ledger, location: time.UTC}, res)
+}
+
+func TestServiceWithdraw(t *testing.T) {
+	testcases := []struct {
+		it          string
+		user        domain.User
+		req         *domain.TransactionRequest
+		mock        func(client *mocks.MockFinanceServiceClient)
+		expected    *domain.TransactionResponse
+		expectedErr *errors.AppError
+	}{
+		{
+			it:   "withdraws on behalf of the user",
+			user: serviceOwner,
+			req:  &domain.TransactionRequest{Account: "debit1", Amount: 500, Category: "sh"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().Withdraw(callerIs(serviceOwner), &domain.TransactionRequest{Account: "debit1", Amount: 500, Category: "sh"}).
+					Return(&domain.TransactionResponse{Account: "debit1", Balance: 1000}, nil)
+			},
+			expected: &domain.TransactionResponse{Account: "debit1", Balance: 1000},
+		},
+		{
+			it:   "lowercases the account and category",
+			user: serviceOwner,
+			req:  &domain.TransactionRequest{Account: "Debit1", Amount: 500, Category: "SH", Description: "YouTube"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().Withdraw(mock.Anything, &domain.TransactionRequest{Account: "debit1", Amount: 500, Category: "sh", Description: "YouTube"}).
+					Return(&domain.TransactionResponse{Account: "debit1", Balance: 1000}, nil)
+			},
+			expected: &domain.TransactionResponse{Account: "debit1", Balance: 1000},
+		},
+		{
+			it:          "returns forbidden error when the account isn't granted",
+			user:        serviceMember,
+			req:         &domain.TransactionRequest{Account: "debit1", Amount: 500, Category: "sh"},
+			expectedErr: errors.ForbiddenError("You are not permitted to use the account 'debit1'"),
+		},
+		{
+			it:          "returns error when the amount isn't positive",
+			user:        serviceOwner,
+			req:         &domain.TransactionRequest{Account: "debit1", Amount: -1, Category: "sh"},
+			expectedErr: errors.BadRequestError("amount must be greater than 0, got -1"),
+		},
+		{
+			it:          "returns error when the category is missing",
+			user:        serviceOwner,
+			req:         &domain.TransactionRequest{Account: "debit1", Amount: 1},
+			expectedErr: errors.BadRequestError("category is required"),
+		},
+		{
+			it:   "returns error when withdraw fails",
+			user: serviceOwner,
+			req:  &domain.TransactionRequest{Account: "debit1", Amount: 500, Category: "sh"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().Withdraw(mock.Anything, mock.Anything).Return(nil, errors.BadGatewayError("failed to withdraw"))
+			},
+			expectedErr: errors.BadGatewayError("failed to withdraw"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			client := mocks.NewMockFinanceServiceClient(t)
+			if tc.mock != nil {
+				tc.mock(client)
+			}
+			service := NewService(client, category.NewService(domain.CategoryConfig{}, memory.NewStore()), domain.LedgerConfig{}, time.UTC)
+
+			res, err := service.Withdraw(context.Background(), tc.user, tc.req)
+
+			assert.Equal(t, tc.expected, res)
+			assert.Equal(t, tc.expectedErr, err)
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestServiceDeposit(t *testing.T) {
+	client := mocks.NewMockFinanceServiceClient(t)
+	req := &domain.TransactionRequest{Account: "debit1", Amount: 30000, Category: "salary"}
+	client.EXPECT().Deposit(callerIs(serviceOwner), req).Return(&domain.TransactionResponse{Account: "debit1", Balance: 31000}, nil)
+	service := NewService(client, category.NewService(domain.CategoryConfig{}, memory.NewStore()), domain.LedgerConfig{}, time.UTC)
+
+	res, err := service.Deposit(context.Background(), serviceOwner, req)
+	assert.Nil(t, err)
+	assert.Equal(t, &domain.TransactionResponse{Account: "debit1", Balance: 31000}, res)
+
+	res, err = service.Deposit(context.Background(), serviceMember, &domain.TransactionRequest{Account: "shared-kbank", Amount: 1, Category: "salary"})
+	assert.Nil(t, res)
+	assert.Equal(t, errors.ForbiddenError("You are not permitted to run '!e'"), err)
+	client.AssertExpectations(t)
+}
+
+func TestServiceTransfer(t *testing.T) {
+	testcases := []struct {
+		it          string
+		req         *domain.TransferRequest
+		mock        func(client *mocks.MockFinanceServiceClient)
+		expected    *domain.TransferResponse
+		expectedErr *errors.AppError
+	}{
+		{
+			it:  "transfers on behalf of the user",
+			req: &domain.TransferRequest{FromAccount: "debit1", ToAccount: "credit1", Amount: 200},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().Transfer(callerIs(serviceOwner), &domain.TransferRequest{FromAccount: "debit1", ToAccount: "credit1", Amount: 200}).
+					Return(&domain.TransferResponse{FromAccount: "debit1", Balance: 800}, nil)
+			},
+			expected: &domain.TransferResponse{FromAccount: "debit1", Balance: 800},
+		},
+		{
+			it:          "returns error when the accounts are the same",
+			req:         &domain.TransferRequest{FromAccount: "debit1", ToAccount: "debit1", Amount: 200},
+			expectedErr: errors.BadRequestError("from_account and to_account must be different"),
+		},
+		{
+			it:          "returns error when to_account is missing",
+			req:         &domain.TransferRequest{FromAccount: "debit1", Amount: 200},
+			expectedErr: errors.BadRequestError("to_account is required"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			client := mocks.NewMockFinanceServiceClient(t)
+			if tc.mock != nil {
+				tc.mock(client)
+			}
+			service := NewService(client, category.NewService(domain.CategoryConfig{}, memory.NewStore()), domain.LedgerConfig{}, time.UTC)
+
+			res, err := service.Transfer(context.Background(), serviceOwner, tc.req)
+
+			assert.Equal(t, tc.expected, res)
+			assert.Equal(t, tc.expectedErr, err)
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestServiceGetBalance(t *testing.T) {
+	client := mocks.NewMockFinanceServiceClient(t)
+	client.EXPECT().GetBalance(callerIs(serviceMember)).Return(&domain.GetBalanceResponse{
+		Accounts: []domain.AccountBalance{
+			{Account: "debit1", Balance: 1000},
+			{Account: "shared-kbank", Balance: 500},
+		},
+	}, nil)
+	service := NewService(client, category.NewService(domain.CategoryConfig{}, memory.NewStore()), domain.LedgerConfig{}, time.UTC)
+
+	res, err := service.GetBalance(context.Background(), serviceMember)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &domain.GetBalanceResponse{
+		Accounts: []domain.AccountBalance{{Account: "shared-kbank", Balance: 500}},
+	}, res)
+	client.AssertExpectations(t)
+}
+
+func TestServiceGetOverviewStatement(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	testcases := []struct {
+		it          string
+		user        domain.User
+		req         *domain.GetOverviewStatementRequest
+		mock        func(client *mocks.MockFinanceServiceClient)
+		expected    *domain.GetOverviewStatementResponse
+		expectedErr *errors.AppError
+	}{
+		{
+			it:   "returns the statement of the range",
+			user: serviceOwner,
+			req:  &domain.GetOverviewStatementRequest{From: from, To: to},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().GetOverviewStatement(callerIs(serviceOwner), &domain.GetOverviewStatementRequest{From: from, To: to}).
+					Return(&domain.GetOverviewStatementResponse{Profit: 100}, nil)
+			},
+			expected: &domain.GetOverviewStatementResponse{Profit: 100},
+		},
+		{
+			it:          "returns error when the range is reversed",
+			user:        serviceOwner,
+			req:         &domain.GetOverviewStatementRequest{From: to, To: from},
+			expectedErr: errors.BadRequestError("to must not be before from"),
+		},
+		{
+			it:          "returns forbidden error when the command isn't granted",
+			user:        serviceMember,
+			req:         &domain.GetOverviewStatementRequest{From: from, To: to},
+			expectedErr: errors.ForbiddenError("You are not permitted to run 'statement'"),
+		},
+		{
+			it:          "returns forbidden error when the role only grants some accounts",
+			user:        restrictedViewer,
+			req:         &domain.GetOverviewStatementRequest{From: from, To: to},
+			expectedErr: errors.ForbiddenError("You are not permitted to run 'statement', which totals all the accounts.\nList your accounts' transactions with 'last' instead"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			client := mocks.NewMockFinanceServiceClient(t)
+			if tc.mock != nil {
+				tc.mock(client)
+			}
+			service := NewService(client, category.NewService(domain.CategoryConfig{}, memory.NewStore()), domain.LedgerConfig{}, time.UTC)
+
+			res, err := service.GetOverviewStatement(context.Background(), tc.user, tc.req)
+
+			assert.Equal(t, tc.expected, res)
+			assert.Equal(t, tc.expectedErr, err)
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestServiceGetJournal(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	exporter := domain.User{
+		ID:   "partner",
+		Role: domain.Role{Name: "exporter", Commands: []string{"export"}, Accounts: []string{"shared-*"}},
+	}
+	statement := &domain.GetDetailedStatementResponse{
+		Expense: &domain.GetDetailedStatementSection{
+			Total: 700,
+			Entries: []domain.Entry{
+				{Timestamp: from, Account: "shared-kbank", Category: "fd", Amount: 200, Description: "dinner"},
+				{Timestamp: from, Account: "debit1", Category: "sh", Amount: 500},
+			},
+		},
+		Transfers: []domain.TransferEntry{
+			{Timestamp: to, FromAccount: "debit1", ToAccount: "shared-kbank", Amount: 1000},
+		},
+	}
+	testcases := []struct {
+		it          string
+		user        domain.User
+		req         *domain.GetOverviewStatementRequest
+		mock        func(client *mocks.MockFinanceServiceClient)
+		expected    string
+		expectedErr *errors.AppError
+	}{
+		{
+			it:   "returns the journal of the accounts the role grants",
+			user: exporter,
+			req:  &domain.GetOverviewStatementRequest{From: from, To: to},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().GetDetailedStatement(callerIs(exporter), &domain.GetOverviewStatementRequest{From: from, To: to}).
+					Return(statement, nil)
+			},
+			expected: "2025-01-01 dinner\n" +
+				"    expenses:fd                           200.00\n" +
+				"    assets:shared-kbank                   -200.00\n",
+		},
+		{
+			it:          "returns error when the range is missing",
+			user:        serviceOwner,
+			req:         &domain.GetOverviewStatementRequest{From: from},
+			expectedErr: errors.BadRequestError("to is required"),
+		},
+		{
+			it:          "returns forbidden error when the command isn't granted",
+			user:        serviceMember,
+			req:         &domain.GetOverviewStatementRequest{From: from, To: to},
+			expectedErr: errors.ForbiddenError("You are not permitted to run 'export'"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			client := mocks.NewMockFinanceServiceClient(t)
+			if tc.mock != nil {
+				tc.mock(client)
+			}
+			service := NewService(client, category.NewService(domain.CategoryConfig{}, memory.NewStore()), domain.LedgerConfig{AssetsPrefix: "assets", ExpensesPrefix: "expenses", IncomePrefix: "income"}, time.UTC)
+
+			res, err := service.GetJournal(context.Background(), tc.user, tc.req)
+
+			assert.Equal(t, tc.expected, string(res))
+			assert.Equal(t, tc.expectedErr, err)
+			client.AssertExpectations(t)
+		})
+	}
+}
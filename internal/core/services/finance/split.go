@@ -0,0 +1,234 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/daterange"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+)
+
+const (
+	invalidSplitMsg  = "Invalid command's arguments.\nPlease recheck the syntax (split [account] <amount><category> @person... <description>)"
+	invalidSettleMsg = "Invalid command's arguments.\nPlease recheck the syntax (settle [account] @person <amount>)"
+	invalidOweMsg    = "Invalid command's arguments.\nPlease recheck the syntax (owe [@person])"
+)
+
+// split records an expense paid for the caller and others, e.g.
+// "split 900fd @alice @bob dinner". The caller's share is withdrawn under
+// the category, and the others' under the debt category, which their
+// settlements are deposited under, so that the account's balance follows
+// the money. What each person owes goes to the debt ledger. The whole
+// amount is confirmed like a withdrawal of the category.
+func (h *Handler) split(ctx context.Context, tokenizedMsg []string) (string, *errors.AppError) {
+	tokenizedMsg, err := withAccounts(ctx, tokenizedMsg, 1, isCategory, h.accounts)
+	if err != nil {
+		return "", err
+	}
+	if len(tokenizedMsg) < 4 {
+		return "", errors.BadRequestError(invalidSplitMsg)
+	}
+	amount, category, err := parseCategorizedAmount(ctx, tokenizedMsg[2])
+	if err != nil {
+		return "", err
+	}
+	people, description, err := parsePeople(tokenizedMsg[3:])
+	if err != nil {
+		return "", err
+	}
+	if category, err = h.categories.Resolve(ctx, category); err != nil {
+		return "", err
+	}
+
+	if share, othersShare, _ := splitShares(amount, len(people)); share <= 0 || othersShare <= 0 {
+		return "", errors.BadRequestError(fmt.Sprintf("฿%v is too little to split between %d people", amount, len(people)+1))
+	}
+
+	account := tokenizedMsg[1]
+
+	t := domain.Transaction{Type: domain.TransactionWithdrawal, Account: account, Category: category, Amount: amount}
+	return h.confirmations.Submit(ctx, t, func(ctx context.Context) (string, *errors.AppError) {
+		return h.recordSplit(ctx, t, people, description)
+	})
+}
+
+// splitShares returns the caller's share of the amount split with n
+// others, the share of each of them and what they owe together. Everyone
+// pays the same, the caller takes the rounding difference.
+func splitShares(amount float64, n int) (share, othersShare, lent float64) {
+	othersShare = math.Round(amount/float64(n+1)*100) / 100
+	lent = math.Round(othersShare*float64(n)*100) / 100
+	share = math.Round((amount-lent)*100) / 100
+	return share, othersShare, lent
+}
+
+// recordSplit withdraws the caller's share of the transaction and the part
+// the people owe, and records what each of them owes.
+func (h *Handler) recordSplit(ctx context.Context, t domain.Transaction, people []string, description string) (string, *errors.AppError) {
+	account, amount, category := t.Account, t.Amount, t.Category
+	share, othersShare, lent := splitShares(amount, len(people))
+
+	res, err := h.client.Withdraw(ctx, &domain.TransactionRequest{
+		Account:     account,
+		Amount:      share,
+		Category:    category,
+		Description: description,
+	})
+	if err != nil {
+		return "", err
+	}
+	mentions := "@" + strings.Join(people, ", @")
+	lentDescription := mentions
+	if description != "" {
+		lentDescription = fmt.Sprintf("%s (%s)", description, mentions)
+	}
+	res, err = h.client.Withdraw(ctx, &domain.TransactionRequest{
+		Account:     account,
+		Amount:      lent,
+		Category:    h.debts.Category(),
+		Description: lentDescription,
+	})
+	if err != nil {
+		logger.Ctx(ctx).Errorw("failed to withdraw the lent part of a split", "account", account, "amount", lent)
+		return "", &errors.AppError{
+			StatusCode: err.StatusCode,
+			Message:    fmt.Sprintf("Your share of ฿%v is recorded, but not the ฿%v others owe: %s", share, lent, err.Message),
+		}
+	}
+
+	user, _ := domain.UserFromContext(ctx)
+	now := h.now()
+	if description == "" {
+		description = "split"
+	}
+	entries := make([]domain.DebtEntry, 0, len(people))
+	for _, person := range people {
+		entries = append(entries, domain.DebtEntry{Person: person, Amount: othersShare, Description: description, Date: now})
+	}
+	if err := h.debts.Add(ctx, user.ID, entries...); err != nil {
+		logger.Ctx(ctx).Errorw("failed to record the debts of a split", "account", account, "amount", lent)
+		return "", &errors.AppError{
+			StatusCode: err.StatusCode,
+			Message:    fmt.Sprintf("The ฿%v split is recorded, but not who owes you what: %s", amount, err.Message),
+		}
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Split ฿%v\n================\nYour share: ฿%v\n", amount, share))
+	for _, person := range people {
+		sb.WriteString(fmt.Sprintf("@%s owes you ฿%v\n", person, othersShare))
+	}
+	sb.WriteString(fmt.Sprintf("\nAccount: %v\nBalance: ฿%v", res.Account, res.Balance))
+	return sb.String(), nil
+}
+
+// parsePeople returns the people mentioned at the start of the arguments,
+// e.g. "@alice @bob dinner", and the description which follows them.
+func parsePeople(args []string) ([]string, string, *errors.AppError) {
+	var people []string
+	i := 0
+	for ; i < len(args) && strings.HasPrefix(args[i], "@"); i++ {
+		person := strings.TrimPrefix(args[i], "@")
+		if person == "" {
+			return nil, "", errors.BadRequestError(invalidSplitMsg)
+		}
+		if slices.Contains(people, person) {
+			return nil, "", errors.BadRequestError(fmt.Sprintf("@%s is mentioned twice", person))
+		}
+		people = append(people, person)
+	}
+	if len(people) == 0 {
+		return nil, "", errors.BadRequestError(invalidSplitMsg)
+	}
+	return people, strings.Join(args[i:], " "), nil
+}
+
+// settle deposits what a person paid back, e.g. "settle @alice 300", and
+// takes it off what they owe.
+func (h *Handler) settle(ctx context.Context, tokenizedMsg []string) (string, *errors.AppError) {
+	tokenizedMsg, err := withAccounts(ctx, tokenizedMsg, 1, isPlain, h.accounts)
+	if err != nil {
+		return "", err
+	}
+	if len(tokenizedMsg) != 4 || !strings.HasPrefix(tokenizedMsg[2], "@") || tokenizedMsg[2] == "@" {
+		return "", errors.BadRequestError(invalidSettleMsg)
+	}
+	person := strings.TrimPrefix(tokenizedMsg[2], "@")
+	amount, rest, amountErr := parseAmount(tokenizedMsg[3])
+	if amountErr == nil {
+		amountErr = unexpectedRest(tokenizedMsg[3], rest, isPlain)
+	}
+	if amountErr != nil {
+		logger.Ctx(ctx).Errorw("invalid amount", "input", tokenizedMsg[3], "error", amountErr)
+		return "", errors.BadRequestError(fmt.Sprintf("Invalid amount '%s': %v", tokenizedMsg[3], amountErr))
+	}
+
+	user, _ := domain.UserFromContext(ctx)
+	if err := h.debts.CheckSettlement(ctx, user.ID, person, amount); err != nil {
+		return "", err
+	}
+	res, err := h.client.Deposit(ctx, &domain.TransactionRequest{
+		Account:     tokenizedMsg[1],
+		Amount:      amount,
+		Category:    h.debts.Category(),
+		Description: "settle @" + person,
+	})
+	if err != nil {
+		return "", err
+	}
+	owed, err := h.debts.Settle(ctx, user.ID, domain.DebtEntry{Person: person, Amount: amount, Description: "settled", Date: h.now()})
+	if err != nil {
+		// Another settlement came first, or the ledger failed; the deposit
+		// stays as it's money received
+		logger.Ctx(ctx).Warnw("deposited a settlement the ledger didn't record", "person", person, "amount", amount)
+		return "", err
+	}
+	return fmt.Sprintf("@%s paid back ฿%v\n================\n@%s still owes you ฿%v\n\nAccount: %v\nBalance: ฿%v", person, amount, person, owed, res.Account, res.Balance), nil
+}
+
+// owe prints what everyone owes the caller, or what one person owes them
+// entry by entry, e.g. "owe @alice".
+func (h *Handler) owe(ctx context.Context, tokenizedMsg []string) (string, *errors.AppError) {
+	user, _ := domain.UserFromContext(ctx)
+	switch {
+	case len(tokenizedMsg) == 1:
+		balances, err := h.debts.Balances(ctx, user.ID)
+		if err != nil {
+			return "", err
+		}
+		if len(balances) == 0 {
+			return "Nobody owes you anything", nil
+		}
+		var sb strings.Builder
+		var total float64
+		sb.WriteString("Owed to you\n================\n")
+		for _, b := range balances {
+			total += b.Amount
+			sb.WriteString(fmt.Sprintf("@%s: ฿%v\n", b.Person, b.Amount))
+		}
+		sb.WriteString(fmt.Sprintf("\nTotal: ฿%v", math.Round(total*100)/100))
+		return sb.String(), nil
+	case len(tokenizedMsg) == 2 && strings.HasPrefix(tokenizedMsg[1], "@") && tokenizedMsg[1] != "@":
+		person := strings.TrimPrefix(tokenizedMsg[1], "@")
+		entries, err := h.debts.Entries(ctx, user.ID, person)
+		if err != nil {
+			return "", err
+		}
+		if len(entries) == 0 {
+			return "", errors.NotFoundError(fmt.Sprintf("@%s has never owed you anything", person))
+		}
+		var sb strings.Builder
+		var total float64
+		for _, e := range entries {
+			total += e.Amount
+			sb.WriteString(fmt.Sprintf("\n%s %s ฿%v", e.Date.In(h.location).Format(daterange.DateLayout), e.Description, e.Amount))
+		}
+		return fmt.Sprintf("@%s owes you ฿%v\n================", person, math.Round(total*100)/100) + sb.String(), nil
+	default:
+		return "", errors.BadRequestError(invalidOweMsg)
+	}
+}
@@ -0,0 +1,257 @@
+package finance
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/account"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/confirmation"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/debt"
+	"github.com/sMARCHz/secretaria-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var splitDate = time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+
+func newSplitHandler(t *testing.T) (*Handler, *mocks.MockFinanceServiceClient, *debt.Ledger, context.Context) {
+	originalTimeNow := timeNow
+	timeNow = func() time.Time { return splitDate }
+	t.Cleanup(func() { timeNow = originalTimeNow })
+
+	client := mocks.NewMockFinanceServiceClient(t)
+	accounts := account.NewService(client, domain.AccountConfig{Defaults: map[string]string{"owner": "debit1"}}, memory.NewStore())
+	debts := debt.NewLedger(domain.DebtConfig{Category: "lent"}, memory.NewStore())
+	handler := newTestHandler(t, client, usingAccounts(accounts), usingDebts(debts))
+	ctx := domain.ContextWithUser(context.Background(), domain.User{ID: "owner"})
+	return handler, client, debts, ctx
+}
+
+func TestSplit(t *testing.T) {
+	testcases := []struct {
+		it               string
+		tokenizedMsg     []string
+		mock             func(client *mocks.MockFinanceServiceClient)
+		expected         string
+		expectedBalances []domain.DebtBalance
+	}{
+		{
+			it:           "withdraws the caller's share and the others' from the default account",
+			tokenizedMsg: []string{"split", "900fd", "@alice", "@bob", "dinner", "at", "siam"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().Withdraw(mock.Anything, &domain.TransactionRequest{
+					Account: "debit1", Amount: 300, Category: "fd", Description: "dinner at siam",
+				}).Return(&domain.TransactionResponse{Account: "debit1", Balance: 1700}, nil)
+				client.EXPECT().Withdraw(mock.Anything, &domain.TransactionRequest{
+					Account: "debit1", Amount: 600, Category: "lent", Description: "dinner at siam (@alice, @bob)",
+				}).Return(&domain.TransactionResponse{Account: "debit1", Balance: 1100}, nil)
+			},
+			expected:         "Split ฿900\n================\nYour share: ฿300\n@alice owes you ฿300\n@bob owes you ฿300\n\nAccount: debit1\nBalance: ฿1100",
+			expectedBalances: []domain.DebtBalance{{Person: "alice", Amount: 300}, {Person: "bob", Amount: 300}},
+		},
+		{
+			it:           "gives the rounding difference to the caller",
+			tokenizedMsg: []string{"split", "credit1", "100fd", "@alice", "@bob"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().Withdraw(mock.Anything, &domain.TransactionRequest{
+					Account: "credit1", Amount: 33.34, Category: "fd",
+				}).Return(&domain.TransactionResponse{Account: "credit1", Balance: -33.34}, nil)
+				client.EXPECT().Withdraw(mock.Anything, &domain.TransactionRequest{
+					Account: "credit1", Amount: 66.66, Category: "lent", Description: "@alice, @bob",
+				}).Return(&domain.TransactionResponse{Account: "credit1", Balance: -100}, nil)
+			},
+			expected:         "Split ฿100\n================\nYour share: ฿33.34\n@alice owes you ฿33.33\n@bob owes you ฿33.33\n\nAccount: credit1\nBalance: ฿-100",
+			expectedBalances: []domain.DebtBalance{{Person: "alice", Amount: 33.33}, {Person: "bob", Amount: 33.33}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			handler, client, debts, ctx := newSplitHandler(t)
+			tc.mock(client)
+
+			res, err := handler.Handle(ctx, tc.tokenizedMsg)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, res)
+			balances, err := debts.Balances(ctx, "owner")
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedBalances, balances)
+		})
+	}
+}
+
+func TestSplit_Confirmation(t *testing.T) {
+	client := mocks.NewMockFinanceServiceClient(t)
+	confirmations := confirmation.NewService(client, domain.ConfirmationConfig{Threshold: 10000, Timeout: time.Minute})
+	debts := debt.NewLedger(domain.DebtConfig{Category: "lent"}, memory.NewStore())
+	handler := newTestHandler(t, client, usingDebts(debts), usingConfirmations(confirmations))
+	ctx := domain.ContextWithUser(context.Background(), domain.User{ID: "owner"})
+
+	res, err := handler.split(ctx, []string{"split", "debit1", "12000fd", "@alice", "party"})
+	assert.Nil(t, err)
+	assert.Equal(t, "฿12,000 is over the ฿10,000 threshold.\nConfirm ฿12,000 to fd? yes/no", res, "the whole amount leaves the account")
+	balances, err := debts.Balances(ctx, "owner")
+	require.Nil(t, err)
+	assert.Empty(t, balances, "nobody owes anything until it is confirmed")
+
+	client.EXPECT().Withdraw(mock.Anything, &domain.TransactionRequest{Account: "debit1", Amount: 6000, Category: "fd", Description: "party"}).
+		Return(&domain.TransactionResponse{Account: "debit1", Balance: 14000}, nil).Once()
+	client.EXPECT().Withdraw(mock.Anything, &domain.TransactionRequest{Account: "debit1", Amount: 6000, Category: "lent", Description: "party (@alice)"}).
+		Return(&domain.TransactionResponse{Account: "debit1", Balance: 8000}, nil).Once()
+	res, err = confirmations.Handle(ctx, []string{"yes"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Split ฿12000\n================\nYour share: ฿6000\n@alice owes you ฿6000\n\nAccount: debit1\nBalance: ฿8000", res)
+	balances, err = debts.Balances(ctx, "owner")
+	require.Nil(t, err)
+	assert.Equal(t, []domain.DebtBalance{{Person: "alice", Amount: 6000}}, balances)
+}
+
+func TestSplit_Error(t *testing.T) {
+	testcases := []struct {
+		it           string
+		tokenizedMsg []string
+		mock         func(client *mocks.MockFinanceServiceClient)
+		expectedErr  *errors.AppError
+	}{
+		{
+			it:           "returns error when nobody is mentioned",
+			tokenizedMsg: []string{"split", "900fd", "dinner"},
+			expectedErr:  errors.BadRequestError(invalidSplitMsg),
+		},
+		{
+			it:           "returns error when someone is mentioned twice",
+			tokenizedMsg: []string{"split", "900fd", "@alice", "@alice"},
+			expectedErr:  errors.BadRequestError("@alice is mentioned twice"),
+		},
+		{
+			it:           "returns error when the category is missing",
+			tokenizedMsg: []string{"split", "900", "@alice"},
+			expectedErr:  errors.BadRequestError("Invalid amount and category combination"),
+		},
+		{
+			it:           "returns error when a share rounds to nothing",
+			tokenizedMsg: []string{"split", "0.02fd", "@a", "@b", "@c"},
+			expectedErr:  errors.BadRequestError("฿0.02 is too little to split between 4 people"),
+		},
+		{
+			it:           "returns error when the share can't be withdrawn",
+			tokenizedMsg: []string{"split", "900fd", "@alice"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().Withdraw(mock.Anything, mock.Anything).Return(nil, errors.InternalServerError("failed to withdraw")).Once()
+			},
+			expectedErr: errors.InternalServerError("failed to withdraw"),
+		},
+		{
+			it:           "tells that the share is recorded when the lent part can't be withdrawn",
+			tokenizedMsg: []string{"split", "900fd", "@alice"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().Withdraw(mock.Anything, mock.Anything).Return(&domain.TransactionResponse{Account: "debit1"}, nil).Once()
+				client.EXPECT().Withdraw(mock.Anything, mock.Anything).Return(nil, errors.InternalServerError("failed to withdraw")).Once()
+			},
+			expectedErr: &errors.AppError{StatusCode: http.StatusInternalServerError, Message: "Your share of ฿450 is recorded, but not the ฿450 others owe: failed to withdraw"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			handler, client, debts, ctx := newSplitHandler(t)
+			if tc.mock != nil {
+				tc.mock(client)
+			}
+
+			res, err := handler.Handle(ctx, tc.tokenizedMsg)
+
+			assert.Empty(t, res)
+			assert.Equal(t, tc.expectedErr, err)
+			balances, err := debts.Balances(ctx, "owner")
+			assert.Nil(t, err)
+			assert.Empty(t, balances)
+		})
+	}
+}
+
+func TestSettle(t *testing.T) {
+	handler, client, debts, ctx := newSplitHandler(t)
+	require.Nil(t, debts.Add(ctx, "owner", domain.DebtEntry{Person: "alice", Amount: 300, Description: "dinner", Date: splitDate}))
+	client.EXPECT().Deposit(mock.Anything, &domain.TransactionRequest{
+		Account: "debit1", Amount: 100, Category: "lent", Description: "settle @alice",
+	}).Return(&domain.TransactionResponse{Account: "debit1", Balance: 1200}, nil)
+
+	res, err := handler.Handle(ctx, []string{"settle", "@alice", "100"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "@alice paid back ฿100\n================\n@alice still owes you ฿200\n\nAccount: debit1\nBalance: ฿1200", res)
+	balances, err := debts.Balances(ctx, "owner")
+	assert.Nil(t, err)
+	assert.Equal(t, []domain.DebtBalance{{Person: "alice", Amount: 200}}, balances)
+}
+
+func TestSettle_Error(t *testing.T) {
+	testcases := []struct {
+		it           string
+		tokenizedMsg []string
+		expectedErr  *errors.AppError
+	}{
+		{
+			it:           "returns error when nobody is mentioned",
+			tokenizedMsg: []string{"settle", "debit1", "300"},
+			expectedErr:  errors.BadRequestError(invalidSettleMsg),
+		},
+		{
+			it:           "returns error when the amount is invalid",
+			tokenizedMsg: []string{"settle", "@alice", "300fd"},
+			expectedErr:  errors.BadRequestError("Invalid amount '300fd': unexpected 'f' at position 4"),
+		},
+		{
+			it:           "returns error before depositing when the amount is more than owed",
+			tokenizedMsg: []string{"settle", "@alice", "500"},
+			expectedErr:  errors.BadRequestError("@alice only owes you ฿300"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			handler, _, debts, ctx := newSplitHandler(t)
+			require.Nil(t, debts.Add(ctx, "owner", domain.DebtEntry{Person: "alice", Amount: 300, Description: "dinner", Date: splitDate}))
+
+			res, err := handler.Handle(ctx, tc.tokenizedMsg)
+
+			assert.Empty(t, res)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestOwe(t *testing.T) {
+	handler, _, debts, ctx := newSplitHandler(t)
+
+	res, err := handler.Handle(ctx, []string{"owe"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Nobody owes you anything", res)
+
+	require.Nil(t, debts.Add(ctx, "owner",
+		domain.DebtEntry{Person: "bob", Amount: 300, Description: "dinner", Date: splitDate},
+		domain.DebtEntry{Person: "alice", Amount: 300, Description: "dinner", Date: splitDate},
+		domain.DebtEntry{Person: "alice", Amount: -100, Description: "settled", Date: splitDate.AddDate(0, 0, 1)},
+	))
+
+	res, err = handler.Handle(ctx, []string{"owe"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Owed to you\n================\n@alice: ฿200\n@bob: ฿300\n\nTotal: ฿500", res)
+
+	res, err = handler.Handle(ctx, []string{"owe", "@alice"})
+	assert.Nil(t, err)
+	assert.Equal(t, "@alice owes you ฿200\n================\n2025-03-15 dinner ฿300\n2025-03-16 settled ฿-100", res)
+
+	_, err = handler.Handle(ctx, []string{"owe", "@carol"})
+	assert.Equal(t, errors.NotFoundError("@carol has never owed you anything"), err)
+	_, err = handler.Handle(ctx, []string{"owe", "alice"})
+	assert.Equal(t, errors.BadRequestError(invalidOweMsg), err)
+}
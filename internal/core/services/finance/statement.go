@@ -1,68 +1,107 @@
 package finance
 
 import (
+	"context"
 	"fmt"
+	"math"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/category"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/daterange"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/permission"
 )
 
+var datePattern = regexp.MustCompile(`^\d{4}-\d{1,2}-\d{1,2}$`)
+
 // TODO: Refactor
-func (h *Handler) getStatement(tokenizedMsg []string) (string, *errors.AppError) {
-	var res *domain.GetOverviewStatementResponse
-	var err *errors.AppError
-	statementType := "Income"
-	switch len(tokenizedMsg) {
-	case 1:
-		res, statementType, err = h.callMonthlyOrAnnualStatement("m")
-	case 2:
-		res, statementType, err = h.callMonthlyOrAnnualStatement(tokenizedMsg[1])
-	case 3:
-		res, err = h.callSelectedRangeStatement(tokenizedMsg[1], tokenizedMsg[2])
-	default:
-		err = errors.BadRequestError("Invalid command")
+func (h *Handler) getStatement(ctx context.Context, tokenizedMsg []string) (string, *errors.AppError) {
+	if err := permission.CheckCallerAllAccounts(ctx, "statement"); err != nil {
+		return "", err
 	}
-
+	if len(tokenizedMsg) > 1 && tokenizedMsg[1] == "compare" {
+		return h.compareStatements(ctx, tokenizedMsg[2:])
+	}
+	res, statementType, err := h.fetchStatement(ctx, tokenizedMsg[1:])
+	if err != nil {
+		return "", err
+	}
+	categories, err := h.categories.Registry(ctx)
 	if err != nil {
 		return "", err
 	}
-	return printStatement(res, statementType), nil
+	h.attachCharts(ctx, tokenizedMsg[1:], res)
+	return printStatement(res, statementType, categories), nil
+}
+
+// fetchStatement returns the statement of the range given by the command's
+// arguments: none (monthly), "m", "a", two dates or a period such as
+// "last month", and its type.
+func (h *Handler) fetchStatement(ctx context.Context, rangeArgs []string) (*domain.GetOverviewStatementResponse, string, *errors.AppError) {
+	switch {
+	case len(rangeArgs) == 0:
+		return h.callMonthlyOrAnnualStatement(ctx, "m")
+	case len(rangeArgs) == 1 && (rangeArgs[0] == "m" || rangeArgs[0] == "a"):
+		return h.callMonthlyOrAnnualStatement(ctx, rangeArgs[0])
+	case isSelectedRange(rangeArgs):
+		res, err := h.callSelectedRangeStatement(ctx, rangeArgs[0], rangeArgs[1])
+		return res, "Income", err
+	default:
+		req, err := daterange.Parse(rangeArgs, h.now())
+		if err != nil {
+			return nil, "", err
+		}
+		res, err := h.client.GetOverviewStatement(ctx, req)
+		return res, "Income", err
+	}
+}
+
+// isSelectedRange reports whether the arguments are two dates, as opposed
+// to a period such as "last month" or "มีนาคม 2568".
+func isSelectedRange(rangeArgs []string) bool {
+	return len(rangeArgs) == 2 && datePattern.MatchString(rangeArgs[0])
 }
 
 // TODO: Refactor
-func (h *Handler) callMonthlyOrAnnualStatement(statmentType string) (*domain.GetOverviewStatementResponse, string, *errors.AppError) {
+func (h *Handler) callMonthlyOrAnnualStatement(ctx context.Context, statmentType string) (*domain.GetOverviewStatementResponse, string, *errors.AppError) {
 	switch statmentType {
 	case "m":
-		res, err := h.client.GetOverviewMonthlyStatement()
+		res, err := h.client.GetOverviewMonthlyStatement(ctx)
 		return res, "Monthly", err
 	case "a":
-		res, err := h.client.GetOverviewAnnualStatement()
+		res, err := h.client.GetOverviewAnnualStatement(ctx)
 		return res, "Annual", err
 	default:
 		return nil, "", errors.BadRequestError(invalidCommandMsg)
 	}
 }
 
-// TODO: Refactor time in the database to be in UTC
-func (h *Handler) callSelectedRangeStatement(from, to string) (*domain.GetOverviewStatementResponse, *errors.AppError) {
-	fromAsTime, err := time.Parse("2006-01-02", from)
+func (h *Handler) callSelectedRangeStatement(ctx context.Context, from, to string) (*domain.GetOverviewStatementResponse, *errors.AppError) {
+	req, err := parseSelectedRange(from, to, h.location)
+	if err != nil {
+		return nil, err
+	}
+	return h.client.GetOverviewStatement(ctx, req)
+}
+
+// parseSelectedRange returns the whole days from from to to, both included,
+// read as dates in loc.
+func parseSelectedRange(from, to string, loc *time.Location) (*domain.GetOverviewStatementRequest, *errors.AppError) {
+	fromAsTime, err := time.ParseInLocation(daterange.DateLayout, from, loc)
 	if err != nil {
 		return nil, errors.BadRequestError("Invalid command's arguments.\nPlease recheck the from_date, <statement> <from_date: 2022-01-01> <to_date: 2022-01-01>")
 	}
-	toAsTime, err := time.Parse("2006-01-02", to)
+	toAsTime, err := time.ParseInLocation(daterange.DateLayout, to, loc)
 	if err != nil {
 		return nil, errors.BadRequestError("Invalid command's arguments.\nPlease recheck the to_date, <statement> <from_date: 2022-01-01> <to_date: 2022-01-01>")
 	}
-	req := &domain.GetOverviewStatementRequest{
-		From: fromAsTime,
-		To:   toAsTime,
-	}
-	return h.client.GetOverviewStatement(req)
+	return daterange.Days(fromAsTime, toAsTime)
 }
 
-func printStatement(res *domain.GetOverviewStatementResponse, statementType string) string {
+func printStatement(res *domain.GetOverviewStatementResponse, statementType string, categories *category.Registry) string {
 	if res.Revenue == nil {
 		res.Revenue = &domain.GetOverviewStatementSection{}
 	}
@@ -73,15 +112,58 @@ func printStatement(res *domain.GetOverviewStatementResponse, statementType stri
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("%v Statement\n================\n", statementType))
 	sb.WriteString(fmt.Sprintf("Revenue: ฿%v\n", res.Revenue.Total))
-	for _, v := range res.Revenue.Entries {
-		sb.WriteString(fmt.Sprintf("%v = ฿%v\n", v.Category, v.Amount))
-	}
+	writeEntries(&sb, res.Revenue.Entries, categories)
 	sb.WriteString("\n")
 	sb.WriteString(fmt.Sprintf("Expense: ฿%v\n", res.Expense.Total))
-	for _, v := range res.Expense.Entries {
-		sb.WriteString(fmt.Sprintf("%v = ฿%v\n", v.Category, v.Amount))
-	}
+	writeEntries(&sb, res.Expense.Entries, categories)
 	sb.WriteString("\n")
 	sb.WriteString(fmt.Sprintf("Profit: ฿%v", res.Profit))
 	return sb.String()
 }
+
+// entryGroup is a top-level category of a statement with the entries
+// which roll up into it.
+type entryGroup struct {
+	code    string
+	total   float64
+	entries []domain.CategorizedEntry
+}
+
+// groupEntries rolls the entries up into their groups, in the order the
+// groups first appear.
+func groupEntries(entries []domain.CategorizedEntry, categories *category.Registry) []*entryGroup {
+	var groups []*entryGroup
+	byCode := make(map[string]*entryGroup)
+	for _, v := range entries {
+		code := categories.Group(v.Category)
+		g, exist := byCode[code]
+		if !exist {
+			g = &entryGroup{code: code}
+			byCode[code] = g
+			groups = append(groups, g)
+		}
+		g.total += v.Amount
+		g.entries = append(g.entries, v)
+	}
+	return groups
+}
+
+// rolledUp reports whether the group holds other categories than its own.
+func (g *entryGroup) rolledUp() bool {
+	return len(g.entries) != 1 || g.entries[0].Category != g.code
+}
+
+// writeEntries writes the entries by their category names. The categories
+// of a group are totalled under the group, and listed below it.
+func writeEntries(sb *strings.Builder, entries []domain.CategorizedEntry, categories *category.Registry) {
+	for _, g := range groupEntries(entries, categories) {
+		if !g.rolledUp() {
+			sb.WriteString(fmt.Sprintf("%v = ฿%v\n", categories.DisplayName(g.code), g.entries[0].Amount))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%v = ฿%v\n", categories.DisplayName(g.code), math.Round(g.total*100)/100))
+		for _, v := range g.entries {
+			sb.WriteString(fmt.Sprintf("- %v = ฿%v\n", categories.DisplayName(v.Category), v.Amount))
+		}
+	}
+}
@@ -1,14 +1,21 @@
 package finance
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"testing"
 	"time"
 
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
 	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/category"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/daterange"
 	"github.com/sMARCHz/secretaria-bot/test/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetStatement(t *testing.T) {
@@ -22,7 +29,7 @@ func TestGetStatement(t *testing.T) {
 			it:           "return reply message for monthly statement if no argument is provided",
 			tokenizedMsg: []string{"statement"},
 			mock: func(client *mocks.MockFinanceServiceClient) {
-				client.EXPECT().GetOverviewMonthlyStatement().Return(&domain.GetOverviewStatementResponse{
+				client.EXPECT().GetOverviewMonthlyStatement(mock.Anything).Return(&domain.GetOverviewStatementResponse{
 					Revenue: &domain.GetOverviewStatementSection{
 						Total: 20000,
 					},
@@ -38,7 +45,7 @@ func TestGetStatement(t *testing.T) {
 			it:           "return reply message for annual statement if 'a' argument is provided",
 			tokenizedMsg: []string{"statement", "a"},
 			mock: func(client *mocks.MockFinanceServiceClient) {
-				client.EXPECT().GetOverviewAnnualStatement().Return(&domain.GetOverviewStatementResponse{
+				client.EXPECT().GetOverviewAnnualStatement(mock.Anything).Return(&domain.GetOverviewStatementResponse{
 					Revenue: &domain.GetOverviewStatementSection{
 						Total: 240000,
 					},
@@ -54,9 +61,9 @@ func TestGetStatement(t *testing.T) {
 			it:           "return reply message for selected range statement if two date arguments are provided",
 			tokenizedMsg: []string{"statement", "2025-01-01", "2025-03-31"},
 			mock: func(client *mocks.MockFinanceServiceClient) {
-				client.EXPECT().GetOverviewStatement(&domain.GetOverviewStatementRequest{
+				client.EXPECT().GetOverviewStatement(mock.Anything, &domain.GetOverviewStatementRequest{
 					From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
-					To:   time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+					To:   time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
 				}).Return(&domain.GetOverviewStatementResponse{
 					Revenue: &domain.GetOverviewStatementSection{
 						Total: 60000,
@@ -75,9 +82,9 @@ func TestGetStatement(t *testing.T) {
 		t.Run(tc.it, func(t *testing.T) {
 			client := mocks.NewMockFinanceServiceClient(t)
 			tc.mock(client)
-			handler := NewHandler(client)
+			handler := newTestHandler(t, client)
 
-			res, err := handler.getStatement(tc.tokenizedMsg)
+			res, err := handler.getStatement(ownerContext(), tc.tokenizedMsg)
 
 			assert.Nil(t, err)
 			assert.Equal(t, tc.expectedReplyMsg, res)
@@ -86,6 +93,62 @@ func TestGetStatement(t *testing.T) {
 	}
 }
 
+func TestGetStatement_Period(t *testing.T) {
+	bangkok, err := time.LoadLocation("Asia/Bangkok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2025-04-01 01:30 in Bangkok, still March in UTC
+	originalTimeNow := timeNow
+	timeNow = func() time.Time { return time.Date(2025, 3, 31, 18, 30, 0, 0, time.UTC) }
+	defer func() { timeNow = originalTimeNow }()
+
+	testcases := []struct {
+		it           string
+		tokenizedMsg []string
+		expectedReq  *domain.GetOverviewStatementRequest
+	}{
+		{
+			it:           "resolves last month in the handler's time zone",
+			tokenizedMsg: []string{"statement", "last", "month"},
+			expectedReq: &domain.GetOverviewStatementRequest{
+				From: time.Date(2025, 2, 28, 17, 0, 0, 0, time.UTC),
+				To:   time.Date(2025, 3, 31, 17, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			it:           "resolves a Thai month name with a Buddhist Era year",
+			tokenizedMsg: []string{"statement", "มีนาคม", "2568"},
+			expectedReq: &domain.GetOverviewStatementRequest{
+				From: time.Date(2025, 2, 28, 17, 0, 0, 0, time.UTC),
+				To:   time.Date(2025, 3, 31, 17, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			it:           "resolves today in the handler's time zone",
+			tokenizedMsg: []string{"statement", "today"},
+			expectedReq: &domain.GetOverviewStatementRequest{
+				From: time.Date(2025, 3, 31, 17, 0, 0, 0, time.UTC),
+				To:   time.Date(2025, 4, 1, 17, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			client := mocks.NewMockFinanceServiceClient(t)
+			client.EXPECT().GetOverviewStatement(mock.Anything, tc.expectedReq).Return(&domain.GetOverviewStatementResponse{Profit: 100}, nil)
+			handler := newTestHandler(t, client, usingLocation(bangkok))
+
+			res, err := handler.getStatement(ownerContext(), tc.tokenizedMsg)
+
+			assert.Nil(t, err)
+			assert.Equal(t, "Income Statement\n================\nRevenue: ฿0\n\nExpense: ฿0\n\nProfit: ฿100", res)
+			client.AssertExpectations(t)
+		})
+	}
+}
+
 func TestGetStatement_Error(t *testing.T) {
 	testcases := []struct {
 		it           string
@@ -95,14 +158,14 @@ func TestGetStatement_Error(t *testing.T) {
 	}{
 		{
 			it:           "return error when invalid command is provided",
-			tokenizedMsg: []string{"this", "is", "invalid", "command"},
-			expectedErr:  errors.BadRequestError("Invalid command"),
+			tokenizedMsg: []string{"statement", "is", "invalid", "command"},
+			expectedErr:  errors.BadRequestError("Unknown period 'is invalid command'.\nPlease use " + daterange.Syntax),
 		},
 		{
 			it:           "return error when fail to get statement",
 			tokenizedMsg: []string{"statement"},
 			mock: func(client *mocks.MockFinanceServiceClient) {
-				client.EXPECT().GetOverviewMonthlyStatement().Return(nil, errors.InternalServerError("failed to get statement"))
+				client.EXPECT().GetOverviewMonthlyStatement(mock.Anything).Return(nil, errors.InternalServerError("failed to get statement"))
 			},
 			expectedErr: errors.InternalServerError("failed to get statement"),
 		},
@@ -114,9 +177,9 @@ func TestGetStatement_Error(t *testing.T) {
 			if tc.mock != nil {
 				tc.mock(client)
 			}
-			handler := NewHandler(client)
+			handler := newTestHandler(t, client)
 
-			res, err := handler.getStatement(tc.tokenizedMsg)
+			res, err := handler.getStatement(ownerContext(), tc.tokenizedMsg)
 
 			assert.Empty(t, res)
 			assert.EqualError(t, err, tc.expectedErr.Message)
@@ -137,7 +200,7 @@ func TestCallMonthlyOrAnnualStatement(t *testing.T) {
 			it:            "return monthly statement when statementType is m",
 			statementType: "m",
 			mock: func(client *mocks.MockFinanceServiceClient) {
-				client.EXPECT().GetOverviewMonthlyStatement().Return(&domain.GetOverviewStatementResponse{
+				client.EXPECT().GetOverviewMonthlyStatement(mock.Anything).Return(&domain.GetOverviewStatementResponse{
 					Revenue: &domain.GetOverviewStatementSection{
 						Total: 20000,
 						Entries: []domain.CategorizedEntry{
@@ -172,7 +235,7 @@ func TestCallMonthlyOrAnnualStatement(t *testing.T) {
 			it:            "return annual statement when statementType is a",
 			statementType: "a",
 			mock: func(client *mocks.MockFinanceServiceClient) {
-				client.EXPECT().GetOverviewAnnualStatement().Return(&domain.GetOverviewStatementResponse{
+				client.EXPECT().GetOverviewAnnualStatement(mock.Anything).Return(&domain.GetOverviewStatementResponse{
 					Revenue: &domain.GetOverviewStatementSection{
 						Total: 240000,
 						Entries: []domain.CategorizedEntry{
@@ -213,9 +276,9 @@ func TestCallMonthlyOrAnnualStatement(t *testing.T) {
 			if tc.mock != nil {
 				tc.mock(client)
 			}
-			handler := NewHandler(client)
+			handler := newTestHandler(t, client)
 
-			res, statementType, err := handler.callMonthlyOrAnnualStatement(tc.statementType)
+			res, statementType, err := handler.callMonthlyOrAnnualStatement(context.Background(), tc.statementType)
 
 			assert.Nil(t, err)
 			assert.Equal(t, tc.expectedRes, res)
@@ -226,9 +289,9 @@ func TestCallMonthlyOrAnnualStatement(t *testing.T) {
 
 func TestCallMonthlyOrAnnualStatement_Error(t *testing.T) {
 	client := mocks.NewMockFinanceServiceClient(t)
-	handler := NewHandler(client)
+	handler := newTestHandler(t, client)
 
-	res, statementType, err := handler.callMonthlyOrAnnualStatement("invalid_type")
+	res, statementType, err := handler.callMonthlyOrAnnualStatement(context.Background(), "invalid_type")
 
 	assert.Nil(t, res)
 	assert.Empty(t, statementType)
@@ -247,18 +310,35 @@ func TestCallSelectedRangeStatement(t *testing.T) {
 		Profit: 60000,
 	}
 	client := mocks.NewMockFinanceServiceClient(t)
-	client.EXPECT().GetOverviewStatement(&domain.GetOverviewStatementRequest{
+	client.EXPECT().GetOverviewStatement(mock.Anything, &domain.GetOverviewStatementRequest{
 		From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
-		To:   time.Date(2025, 11, 23, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 11, 24, 0, 0, 0, 0, time.UTC),
 	}).Return(financeRes, nil)
-	handler := NewHandler(client)
+	handler := newTestHandler(t, client)
 
-	res, err := handler.callSelectedRangeStatement("2025-01-01", "2025-11-23")
+	res, err := handler.callSelectedRangeStatement(context.Background(), "2025-01-01", "2025-11-23")
 
 	assert.Nil(t, err)
 	assert.Equal(t, financeRes, res)
 }
 
+func TestCallSelectedRangeStatement_TimeZone(t *testing.T) {
+	bangkok, err := time.LoadLocation("Asia/Bangkok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := mocks.NewMockFinanceServiceClient(t)
+	client.EXPECT().GetOverviewStatement(mock.Anything, &domain.GetOverviewStatementRequest{
+		From: time.Date(2024, 12, 31, 17, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 1, 31, 17, 0, 0, 0, time.UTC),
+	}).Return(&domain.GetOverviewStatementResponse{}, nil)
+	handler := newTestHandler(t, client, usingLocation(bangkok))
+
+	_, appErr := handler.callSelectedRangeStatement(context.Background(), "2025-01-01", "2025-01-31")
+
+	assert.Nil(t, appErr)
+}
+
 func TestCallSelectedRangeStatement_Error(t *testing.T) {
 	testcases := []struct {
 		it          string
@@ -279,14 +359,20 @@ func TestCallSelectedRangeStatement_Error(t *testing.T) {
 			to:          "invalid-date",
 			expectedErr: errors.BadRequestError("Invalid command's arguments.\nPlease recheck the to_date, <statement> <from_date: 2022-01-01> <to_date: 2022-01-01>"),
 		},
+		{
+			it:          "return error when the to date is before the from date",
+			from:        "2025-02-01",
+			to:          "2025-01-31",
+			expectedErr: errors.BadRequestError("The end date 2025-01-31 is before the start date 2025-02-01"),
+		},
 		{
 			it:   "return error when fail to get statement from finance service",
 			from: "2025-01-01",
 			to:   "2025-12-31",
 			mock: func(client *mocks.MockFinanceServiceClient) {
-				client.EXPECT().GetOverviewStatement(&domain.GetOverviewStatementRequest{
+				client.EXPECT().GetOverviewStatement(mock.Anything, &domain.GetOverviewStatementRequest{
 					From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
-					To:   time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+					To:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
 				}).Return(nil, errors.InternalServerError("failed to get statement"))
 			},
 			expectedErr: errors.InternalServerError("failed to get statement"),
@@ -299,9 +385,9 @@ func TestCallSelectedRangeStatement_Error(t *testing.T) {
 			if tc.mock != nil {
 				tc.mock(client)
 			}
-			handler := NewHandler(client)
+			handler := newTestHandler(t, client)
 
-			res, err := handler.callSelectedRangeStatement(tc.from, tc.to)
+			res, err := handler.callSelectedRangeStatement(context.Background(), tc.from, tc.to)
 
 			assert.Nil(t, res)
 			assert.EqualError(t, err, tc.expectedErr.Message)
@@ -367,8 +453,95 @@ func TestPrintStatement(t *testing.T) {
 
 	for _, tc := range testcases {
 		t.Run(tc.it, func(t *testing.T) {
-			msg := printStatement(tc.statementRes, "Income")
+			categories, err := category.NewService(domain.CategoryConfig{}, memory.NewStore()).Registry(context.Background())
+			require.Nil(t, err)
+
+			msg := printStatement(tc.statementRes, "Income", categories)
 			assert.Equal(t, tc.expected, msg)
 		})
 	}
 }
+
+func TestPrintStatement_Categories(t *testing.T) {
+	categories, err := category.NewService(domain.CategoryConfig{
+		Categories: []domain.Category{
+			{Code: "fd", Name: "Food"},
+			{Code: "gr", Name: "Groceries", Parent: "fd"},
+			{Code: "rs", Name: "Restaurants", Parent: "fd"},
+			{Code: "sh", Name: "Shopping"},
+			{Code: "salary", Name: "Salary"},
+		},
+	}, memory.NewStore()).Registry(context.Background())
+	require.Nil(t, err)
+	res := &domain.GetOverviewStatementResponse{
+		Revenue: &domain.GetOverviewStatementSection{
+			Total:   30000,
+			Entries: []domain.CategorizedEntry{{Category: "salary", Amount: 30000}},
+		},
+		Expense: &domain.GetOverviewStatementSection{
+			Total: 20700.3,
+			Entries: []domain.CategorizedEntry{
+				{Category: "gr", Amount: 5000.1},
+				{Category: "sh", Amount: 12000},
+				{Category: "rs", Amount: 3000.2},
+				{Category: "tx", Amount: 700},
+			},
+		},
+		Profit: 9299.7,
+	}
+
+	msg := printStatement(res, "Income", categories)
+
+	assert.Equal(t, "Income Statement\n================\nRevenue: ฿30000\nSalary = ฿30000\n\n"+
+		"Expense: ฿20700.3\nFood = ฿8000.3\n- Groceries = ฿5000.1\n- Restaurants = ฿3000.2\nShopping = ฿12000\ntx = ฿700\n\n"+
+		"Profit: ฿9299.7", msg)
+}
+
+func TestStatement_RestrictedRole(t *testing.T) {
+	testcases := []struct {
+		it           string
+		tokenizedMsg []string
+		command      string
+	}{
+		{
+			it:           "refuses the statement",
+			tokenizedMsg: []string{"statement", "m"},
+			command:      "statement",
+		},
+		{
+			it:           "refuses the comparison",
+			tokenizedMsg: []string{"statement", "compare", "last-month", "this-month"},
+			command:      "statement",
+		},
+		{
+			it:           "refuses the csv export",
+			tokenizedMsg: []string{"export", "m", "csv"},
+			command:      "export",
+		},
+		{
+			it:           "refuses the excel export",
+			tokenizedMsg: []string{"export", "m", "excel"},
+			command:      "export",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			handler := newTestHandler(t, mocks.NewMockFinanceServiceClient(t))
+
+			res, err := handler.Handle(domain.ContextWithUser(context.Background(), restrictedViewer), tc.tokenizedMsg)
+
+			assert.Empty(t, res)
+			assert.Equal(t, errors.ForbiddenError(fmt.Sprintf("You are not permitted to run '%s', which totals all the accounts.\nList your accounts' transactions with 'last' instead", tc.command)), err)
+		})
+	}
+}
+
+func TestStatement_NoCaller(t *testing.T) {
+	handler := newTestHandler(t, mocks.NewMockFinanceServiceClient(t))
+
+	res, err := handler.Handle(context.Background(), []string{"statement", "m"})
+
+	assert.Empty(t, res)
+	assert.Equal(t, errors.ForbiddenError("You are not permitted to run this command"), err)
+}
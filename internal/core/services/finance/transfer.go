@@ -1,19 +1,24 @@
 package finance
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
 )
 
-func (h *Handler) transfer(tokenizedMsg []string) (string, *errors.AppError) {
-	req, err := parseTransferRequest(tokenizedMsg)
+func (h *Handler) transfer(ctx context.Context, tokenizedMsg []string) (string, *errors.AppError) {
+	req, err := parseTransferRequest(ctx, tokenizedMsg, h.accounts)
 	if err != nil {
 		return "", err
 	}
-	res, err := h.client.Transfer(req)
-	if err != nil {
-		return "", err
-	}
-	return fmt.Sprintf("Succesfully transfer\n================\nResult\nAccount: %v\nBalance: ฿%v", res.FromAccount, res.Balance), nil
+	t := domain.Transaction{Type: domain.TransactionTransfer, Account: req.FromAccount, ToAccount: req.ToAccount, Amount: req.Amount}
+	return h.confirmations.Submit(ctx, t, func(ctx context.Context) (string, *errors.AppError) {
+		res, err := h.client.Transfer(ctx, req)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Succesfully transfer\n================\nResult\nAccount: %v\nBalance: ฿%v", res.FromAccount, res.Balance), nil
+	})
 }
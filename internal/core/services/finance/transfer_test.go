@@ -1,18 +1,20 @@
 package finance
 
 import (
+	"context"
 	"testing"
 
 	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
 	"github.com/sMARCHz/secretaria-bot/test/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestTransfer(t *testing.T) {
 	tokenizedMsg := []string{"!t", "debit2", "debit1", "20000"}
 	client := mocks.NewMockFinanceServiceClient(t)
-	client.EXPECT().Transfer(&domain.TransferRequest{
+	client.EXPECT().Transfer(mock.Anything, &domain.TransferRequest{
 		FromAccount: "debit2",
 		ToAccount:   "debit1",
 		Amount:      20000,
@@ -21,9 +23,9 @@ func TestTransfer(t *testing.T) {
 		FromAccount: "debit2",
 		Balance:     500,
 	}, nil)
-	handler := NewHandler(client)
+	handler := newTestHandler(t, client)
 
-	res, err := handler.transfer(tokenizedMsg)
+	res, err := handler.transfer(context.Background(), tokenizedMsg)
 
 	assert.Nil(t, err)
 	assert.Equal(t, "Succesfully transfer\n================\nResult\nAccount: debit2\nBalance: ฿500", res)
@@ -46,7 +48,7 @@ func TestTransfer_Error(t *testing.T) {
 			it:           "return error when transfer fails",
 			tokenizedMsg: []string{"!t", "debit2", "debit1", "20000"},
 			mock: func(client *mocks.MockFinanceServiceClient) {
-				client.EXPECT().Transfer(&domain.TransferRequest{
+				client.EXPECT().Transfer(mock.Anything, &domain.TransferRequest{
 					FromAccount: "debit2",
 					ToAccount:   "debit1",
 					Amount:      20000,
@@ -63,9 +65,9 @@ func TestTransfer_Error(t *testing.T) {
 			if tc.mock != nil {
 				tc.mock(client)
 			}
-			handler := NewHandler(client)
+			handler := newTestHandler(t, client)
 
-			res, err := handler.transfer(tc.tokenizedMsg)
+			res, err := handler.transfer(context.Background(), tc.tokenizedMsg)
 
 			assert.Empty(t, res)
 			assert.EqualError(t, err, tc.expectedErr.Message)
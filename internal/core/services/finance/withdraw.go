@@ -1,19 +1,27 @@
 package finance
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
 )
 
-func (h *Handler) withdraw(tokenizedMsg []string) (string, *errors.AppError) {
-	req, err := parseTransactionRequest(tokenizedMsg)
+func (h *Handler) withdraw(ctx context.Context, tokenizedMsg []string) (string, *errors.AppError) {
+	req, err := parseTransactionRequest(ctx, tokenizedMsg, h.accounts)
 	if err != nil {
 		return "", err
 	}
-	res, err := h.client.Withdraw(req)
-	if err != nil {
+	if req.Category, err = h.categories.Resolve(ctx, req.Category); err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("Succesfully withdraw\n================\nResult\nAccount: %v\nBalance: ฿%v", res.Account, res.Balance), nil
+	t := domain.Transaction{Type: domain.TransactionWithdrawal, Account: req.Account, Category: req.Category, Amount: req.Amount}
+	return h.confirmations.Submit(ctx, t, func(ctx context.Context) (string, *errors.AppError) {
+		res, err := h.client.Withdraw(ctx, req)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Succesfully withdraw\n================\nResult\nAccount: %v\nBalance: ฿%v", res.Account, res.Balance), nil
+	})
 }
@@ -1,18 +1,24 @@
 package finance
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
 	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/category"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/confirmation"
 	"github.com/sMARCHz/secretaria-bot/test/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestWithdraw(t *testing.T) {
 	tokenizedMsg := []string{"!p", "debit1", "500sh", "youtube membership"}
 	client := mocks.NewMockFinanceServiceClient(t)
-	client.EXPECT().Withdraw(&domain.TransactionRequest{
+	client.EXPECT().Withdraw(mock.Anything, &domain.TransactionRequest{
 		Account:     "debit1",
 		Amount:      500,
 		Category:    "sh",
@@ -21,15 +27,53 @@ func TestWithdraw(t *testing.T) {
 		Account: "debit1",
 		Balance: 1000,
 	}, nil)
-	handler := NewHandler(client)
+	handler := newTestHandler(t, client)
 
-	res, err := handler.withdraw(tokenizedMsg)
+	res, err := handler.withdraw(context.Background(), tokenizedMsg)
 
 	assert.Nil(t, err)
 	assert.Equal(t, "Succesfully withdraw\n================\nResult\nAccount: debit1\nBalance: ฿1000", res)
 	client.AssertExpectations(t)
 }
 
+func TestWithdraw_Category(t *testing.T) {
+	categories := category.NewService(domain.CategoryConfig{
+		Categories: []domain.Category{{Code: "sh", Name: "Shopping", Aliases: []string{"shop"}}},
+	}, memory.NewStore())
+	client := mocks.NewMockFinanceServiceClient(t)
+	client.EXPECT().Withdraw(mock.Anything, &domain.TransactionRequest{
+		Account:  "debit1",
+		Amount:   500,
+		Category: "sh",
+	}).Return(&domain.TransactionResponse{Account: "debit1", Balance: 1000}, nil)
+	handler := newTestHandler(t, client, usingCategories(categories))
+
+	_, err := handler.withdraw(context.Background(), []string{"!p", "debit1", "500shop"})
+	assert.Nil(t, err)
+
+	res, err := handler.withdraw(context.Background(), []string{"!p", "debit1", "500tx"})
+	assert.Empty(t, res)
+	assert.Equal(t, errors.BadRequestError("Unknown category 'tx'.\nAdd it with 'category add tx <name>' or use one of: sh"), err)
+	client.AssertExpectations(t)
+}
+
+func TestWithdraw_Confirmation(t *testing.T) {
+	client := mocks.NewMockFinanceServiceClient(t)
+	confirmations := confirmation.NewService(client, domain.ConfirmationConfig{Threshold: 10000, Timeout: time.Minute})
+	handler := newTestHandler(t, client, usingConfirmations(confirmations))
+	ctx := domain.ContextWithUser(context.Background(), serviceOwner)
+
+	res, err := handler.withdraw(ctx, []string{"!p", "debit1", "20000sh"})
+	assert.Nil(t, err)
+	assert.Equal(t, "฿20,000 is over the ฿10,000 threshold.\nConfirm ฿20,000 to sh? yes/no", res)
+
+	client.EXPECT().Withdraw(mock.Anything, &domain.TransactionRequest{Account: "debit1", Amount: 20000, Category: "sh"}).
+		Return(&domain.TransactionResponse{Account: "debit1", Balance: 1000}, nil).Once()
+	res, err = confirmations.Handle(ctx, []string{"yes"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Succesfully withdraw\n================\nResult\nAccount: debit1\nBalance: ฿1000", res)
+}
+
 func TestWithdraw_Error(t *testing.T) {
 	testcases := []struct {
 		it           string
@@ -46,7 +90,7 @@ func TestWithdraw_Error(t *testing.T) {
 			it:           "return error when withdraw fails",
 			tokenizedMsg: []string{"!p", "debit1", "500sh", "youtube membership"},
 			mock: func(client *mocks.MockFinanceServiceClient) {
-				client.EXPECT().Withdraw(&domain.TransactionRequest{
+				client.EXPECT().Withdraw(mock.Anything, &domain.TransactionRequest{
 					Account:     "debit1",
 					Amount:      500,
 					Category:    "sh",
@@ -63,9 +107,9 @@ func TestWithdraw_Error(t *testing.T) {
 			if tc.mock != nil {
 				tc.mock(client)
 			}
-			handler := NewHandler(client)
+			handler := newTestHandler(t, client)
 
-			res, err := handler.withdraw(tc.tokenizedMsg)
+			res, err := handler.withdraw(context.Background(), tc.tokenizedMsg)
 
 			assert.Empty(t, res)
 			assert.EqualError(t, err, tc.expectedErr.Message)
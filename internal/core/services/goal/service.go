@@ -0,0 +1,333 @@
+package goal
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/account"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/daterange"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/permission"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/client"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+)
+
+const (
+	invalidGoalMsg = "Invalid command's arguments.\nPlease recheck the syntax (goal [list], goal add <name> <amount> by <month> [on <account>] or goal remove <name>)"
+
+	// barWidth is the number of blocks of a progress bar.
+	barWidth = 10
+)
+
+var (
+	namePattern    = regexp.MustCompile(`^[\p{L}\d_-]+$`)
+	goalCollection = storage.NewCollection[[]domain.Goal]("goals") // by user ID
+)
+
+// Service keeps the users' savings goals and reports their progress from
+// the live balances of their accounts, through the "goal" command. A user's
+// goals on the same account split its balance by target.
+type Service struct {
+	client   client.FinanceServiceClient
+	accounts *account.Service
+	store    storage.Store
+	location *time.Location
+
+	now func() time.Time
+}
+
+// NewService constructs the goal service. Goal accounts are resolved
+// through the account registry, and months are counted in location.
+func NewService(client client.FinanceServiceClient, accounts *account.Service, store storage.Store, location *time.Location) *Service {
+	return &Service{
+		client:   client,
+		accounts: accounts,
+		store:    store,
+		location: location,
+		now:      time.Now,
+	}
+}
+
+func (s *Service) Match(cmd string) bool {
+	return cmd == "goal"
+}
+
+// Handle serves "goal [list]", "goal add <name> <amount> by <month>
+// [on <account>]" and "goal remove <name>".
+func (s *Service) Handle(ctx context.Context, tokenizedMsg []string) (string, *errors.AppError) {
+	switch {
+	case len(tokenizedMsg) < 2 || (tokenizedMsg[1] == "list" && len(tokenizedMsg) == 2):
+		return s.list(ctx)
+	case tokenizedMsg[1] == "add" && len(tokenizedMsg) >= 6:
+		return s.add(ctx, tokenizedMsg[2:])
+	case tokenizedMsg[1] == "remove" && len(tokenizedMsg) == 3:
+		return s.remove(ctx, tokenizedMsg[2])
+	default:
+		return "", errors.BadRequestError(invalidGoalMsg)
+	}
+}
+
+// add sets a goal from "<name> <amount> by <month> [on <account>]", where
+// the month is any period daterange understands, e.g. 2026-12 or
+// "dec 2026", and the account defaults to the user's default account.
+func (s *Service) add(ctx context.Context, args []string) (string, *errors.AppError) {
+	name := args[0]
+	if !namePattern.MatchString(name) {
+		return "", errors.BadRequestError(fmt.Sprintf("Invalid goal name '%s', it must be a single word", name))
+	}
+	target, err := strconv.ParseFloat(strings.ReplaceAll(args[1], ",", ""), 64)
+	if err != nil || target <= 0 || math.IsInf(target, 0) {
+		return "", errors.BadRequestError(fmt.Sprintf("Invalid amount '%s', it must be a number greater than 0", args[1]))
+	}
+	if args[2] != "by" {
+		return "", errors.BadRequestError(invalidGoalMsg)
+	}
+	periodArgs := args[3:]
+	var accountName string
+	if i := slices.Index(periodArgs, "on"); i >= 0 {
+		if i != len(periodArgs)-2 {
+			return "", errors.BadRequestError(invalidGoalMsg)
+		}
+		accountName = periodArgs[i+1]
+		periodArgs = periodArgs[:i]
+	}
+
+	now := s.now().In(s.location)
+	period, appErr := daterange.Parse(periodArgs, now)
+	if appErr != nil {
+		return "", appErr
+	}
+	if !period.To.After(now) {
+		return "", errors.BadRequestError("The goal's deadline has already passed")
+	}
+	account, appErr := s.account(ctx, accountName)
+	if appErr != nil {
+		return "", appErr
+	}
+	balances, appErr := s.balances(ctx)
+	if appErr != nil {
+		return "", appErr
+	}
+	balance, exist := balances[account]
+	if !exist {
+		return "", errors.NotFoundError(fmt.Sprintf("Unknown account '%s'", account))
+	}
+
+	user, _ := domain.UserFromContext(ctx)
+	var goals []domain.Goal
+	goal := domain.Goal{
+		Name:         name,
+		Account:      account,
+		Target:       target,
+		StartBalance: balance,
+		Created:      now,
+		Deadline:     period.To,
+	}
+	err = s.store.Update(ctx, func(tx storage.Tx) error {
+		var err error
+		if goals, _, err = goalCollection.Get(tx, user.ID); err != nil {
+			return err
+		}
+		if slices.ContainsFunc(goals, func(g domain.Goal) bool { return g.Name == name }) {
+			appErr = errors.BadRequestError(fmt.Sprintf("You already have a goal named '%s'", name))
+			return appErr
+		}
+		goals = append(goals, goal)
+		return goalCollection.Put(tx, user.ID, goals)
+	})
+	if appErr != nil {
+		return "", appErr
+	}
+	if err != nil {
+		logger.Ctx(ctx).Errorw("failed to save goal", "goal", name, "error", err)
+		return "", errors.InternalServerError("cannot save the goal")
+	}
+
+	logger.Ctx(ctx).Infow("added goal", "goal", name, "account", account)
+	allocations := allocate(goals, balances)
+	return "Goal added\n================\n" + s.printProgress(goal, allocations[len(goals)-1], now), nil
+}
+
+func (s *Service) remove(ctx context.Context, name string) (string, *errors.AppError) {
+	user, _ := domain.UserFromContext(ctx)
+	var appErr *errors.AppError
+	err := s.store.Update(ctx, func(tx storage.Tx) error {
+		goals, _, err := goalCollection.Get(tx, user.ID)
+		if err != nil {
+			return err
+		}
+		i := slices.IndexFunc(goals, func(g domain.Goal) bool { return g.Name == name })
+		if i < 0 {
+			appErr = errors.NotFoundError(fmt.Sprintf("You have no goal named '%s'", name))
+			return appErr
+		}
+		if goals = slices.Delete(goals, i, i+1); len(goals) == 0 {
+			return goalCollection.Delete(tx, user.ID)
+		}
+		return goalCollection.Put(tx, user.ID, goals)
+	})
+	if appErr != nil {
+		return "", appErr
+	}
+	if err != nil {
+		logger.Ctx(ctx).Errorw("failed to remove goal", "goal", name, "error", err)
+		return "", errors.InternalServerError("cannot remove the goal")
+	}
+	logger.Ctx(ctx).Infow("removed goal", "goal", name)
+	return fmt.Sprintf("The goal '%s' is removed", name), nil
+}
+
+// list prints the progress of the user's goals.
+func (s *Service) list(ctx context.Context) (string, *errors.AppError) {
+	goals, err := s.goals(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(goals) == 0 {
+		return "You have no goal.\nSet one with 'goal add <name> <amount> by <month>'", nil
+	}
+
+	balances, err := s.balances(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := s.now().In(s.location)
+	allocations := allocate(goals, balances)
+	parts := make([]string, 0, len(goals))
+	for i, g := range goals {
+		parts = append(parts, s.printProgress(g, allocations[i], now))
+	}
+	return "Your goals\n================\n" + strings.Join(parts, "\n\n"), nil
+}
+
+// goals returns the goals of the caller.
+func (s *Service) goals(ctx context.Context) ([]domain.Goal, *errors.AppError) {
+	user, _ := domain.UserFromContext(ctx)
+	var goals []domain.Goal
+	if err := s.store.View(ctx, func(tx storage.Tx) error {
+		var err error
+		goals, _, err = goalCollection.Get(tx, user.ID)
+		return err
+	}); err != nil {
+		logger.Ctx(ctx).Errorw("failed to read goals", "error", err)
+		return nil, errors.InternalServerError("cannot read the goals")
+	}
+	return goals, nil
+}
+
+// allocation is the part of its account's balance which counts for a goal.
+type allocation struct {
+	balance      float64
+	startBalance float64
+	// sharedWith is the number of the user's other goals on the account.
+	sharedWith int
+}
+
+// allocate splits the balance of each account, and the start balances,
+// among the goals on the account in proportion to their targets, so that
+// the same money doesn't count twice.
+func allocate(goals []domain.Goal, balances map[string]float64) []allocation {
+	targets := make(map[string]float64)
+	counts := make(map[string]int)
+	for _, g := range goals {
+		targets[g.Account] += g.Target
+		counts[g.Account]++
+	}
+	allocations := make([]allocation, len(goals))
+	for i, g := range goals {
+		share := g.Target / targets[g.Account]
+		allocations[i] = allocation{
+			balance:      round(balances[g.Account] * share),
+			startBalance: g.StartBalance * share,
+			sharedWith:   counts[g.Account] - 1,
+		}
+	}
+	return allocations
+}
+
+// printProgress shows how far the goal is, the monthly contribution it
+// still needs, and a nudge when the savings are behind the schedule.
+func (s *Service) printProgress(g domain.Goal, a allocation, now time.Time) string {
+	var sb strings.Builder
+	balance := a.balance
+	lastMonth := g.Deadline.In(s.location).AddDate(0, 0, -1)
+	sb.WriteString(fmt.Sprintf("%s (%s)\n%s\n฿%v of ฿%v by %s", g.Name, g.Account, progressBar(balance/g.Target), balance, g.Target, lastMonth.Format("2006-01")))
+	switch a.sharedWith {
+	case 0:
+	case 1:
+		sb.WriteString(fmt.Sprintf("\nShares %s with 1 other goal, split by target", g.Account))
+	default:
+		sb.WriteString(fmt.Sprintf("\nShares %s with %d other goals, split by target", g.Account, a.sharedWith))
+	}
+
+	remaining := round(g.Target - balance)
+	switch {
+	case remaining <= 0:
+		sb.WriteString("\nReached")
+	case !now.Before(g.Deadline):
+		sb.WriteString(fmt.Sprintf("\nThe deadline has passed, ฿%v short", remaining))
+	default:
+		months := (lastMonth.Year()-now.Year())*12 + int(lastMonth.Month()-now.Month()) + 1
+		sb.WriteString(fmt.Sprintf("\nSave ฿%v/month for %d months", round(remaining/float64(months)), months))
+		// The savings are expected to grow linearly from the start balance
+		elapsed := now.Sub(g.Created).Seconds() / g.Deadline.Sub(g.Created).Seconds()
+		expected := a.startBalance + (g.Target-a.startBalance)*elapsed
+		if behind := round(expected - balance); behind > 0 {
+			sb.WriteString(fmt.Sprintf("\nBehind schedule by ฿%v", behind))
+		}
+	}
+	return sb.String()
+}
+
+// progressBar draws the ratio, capped to [0, 1], e.g. ▓▓▓░░░░░░░ 30%.
+func progressBar(ratio float64) string {
+	ratio = max(0, min(1, ratio))
+	filled := int(math.Round(ratio * barWidth))
+	return fmt.Sprintf("%s%s %d%%", strings.Repeat("▓", filled), strings.Repeat("░", barWidth-filled), int(math.Floor(ratio*100)))
+}
+
+// account resolves the account of a new goal, which the user's role must
+// grant.
+func (s *Service) account(ctx context.Context, name string) (string, *errors.AppError) {
+	var account string
+	var err *errors.AppError
+	if name == "" {
+		if account, err = s.accounts.Default(ctx); err == nil && account == "" {
+			return "", errors.BadRequestError("You have no default account.\nName the goal's account (goal add <name> <amount> by <month> on <account>) or set one with 'account default <account>'")
+		}
+	} else {
+		account, err = s.accounts.Resolve(ctx, name)
+	}
+	if err != nil {
+		return "", err
+	}
+	if err := permission.CheckCaller(ctx, "goal", account); err != nil {
+		return "", err
+	}
+	return account, nil
+}
+
+// balances returns the balance of each account.
+func (s *Service) balances(ctx context.Context) (map[string]float64, *errors.AppError) {
+	res, err := s.client.GetBalance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	balances := make(map[string]float64, len(res.Accounts))
+	for _, v := range res.Accounts {
+		balances[v.Account] = v.Balance
+	}
+	return balances, nil
+}
+
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,241 @@
+package goal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/account"
+	"github.com/sMARCHz/secretaria-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	bangkok, _ = time.LoadLocation("Asia/Bangkok")
+	owner      = domain.User{
+		ID:   "owner",
+		Role: domain.Role{Name: "owner", Commands: []string{"*"}, Accounts: []string{"*"}},
+	}
+	member = domain.User{
+		ID:   "partner",
+		Role: domain.Role{Name: "member", Commands: []string{"goal"}, Accounts: []string{"shared-*"}},
+	}
+)
+
+func balance(savings float64) *domain.GetBalanceResponse {
+	return &domain.GetBalanceResponse{
+		Accounts: []domain.AccountBalance{
+			{Account: "debit1", Balance: 1000},
+			{Account: "kbank-savings", Balance: savings},
+		},
+	}
+}
+
+func newTestService(t *testing.T, now time.Time) (*Service, *mocks.MockFinanceServiceClient) {
+	client := mocks.NewMockFinanceServiceClient(t)
+	accounts := account.NewService(client, domain.AccountConfig{
+		Aliases:  map[string]string{"k": "kbank-savings"},
+		Defaults: map[string]string{"owner": "kbank-savings"},
+	}, memory.NewStore())
+	service := NewService(client, accounts, memory.NewStore(), bangkok)
+	service.now = func() time.Time { return now }
+	return service, client
+}
+
+func storedGoals(t *testing.T, service *Service, user domain.User) []domain.Goal {
+	goals, err := service.goals(domain.ContextWithUser(context.Background(), user))
+	require.Nil(t, err)
+	return goals
+}
+
+func handle(service *Service, user domain.User, msg ...string) (string, *errors.AppError) {
+	return service.Handle(domain.ContextWithUser(context.Background(), user), append([]string{"goal"}, msg...))
+}
+
+func TestAdd(t *testing.T) {
+	testcases := []struct {
+		it           string
+		msg          []string
+		expected     string
+		expectedGoal domain.Goal
+	}{
+		{
+			it:       "adds a goal on the default account",
+			msg:      []string{"add", "japan", "60,000", "by", "2026-12"},
+			expected: "Goal added\n================\njapan (kbank-savings)\n▓▓░░░░░░░░ 20%\n฿12000 of ฿60000 by 2026-12\nSave ฿2181.82/month for 22 months",
+			expectedGoal: domain.Goal{
+				Name:         "japan",
+				Account:      "kbank-savings",
+				Target:       60000,
+				StartBalance: 12000,
+				Created:      time.Date(2025, 3, 15, 12, 0, 0, 0, bangkok),
+				Deadline:     time.Date(2026, 12, 31, 17, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			it:       "adds a goal on an account by alias, by a month name",
+			msg:      []string{"add", "bike", "20000", "by", "dec", "2025", "on", "k"},
+			expected: "Goal added\n================\nbike (kbank-savings)\n▓▓▓▓▓▓░░░░ 60%\n฿12000 of ฿20000 by 2025-12\nSave ฿800/month for 10 months",
+			expectedGoal: domain.Goal{
+				Name:         "bike",
+				Account:      "kbank-savings",
+				Target:       20000,
+				StartBalance: 12000,
+				Created:      time.Date(2025, 3, 15, 12, 0, 0, 0, bangkok),
+				Deadline:     time.Date(2025, 12, 31, 17, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			service, client := newTestService(t, time.Date(2025, 3, 15, 12, 0, 0, 0, bangkok))
+			client.EXPECT().GetBalance(mock.Anything).Return(balance(12000), nil)
+
+			res, err := handle(service, owner, tc.msg...)
+
+			require.Nil(t, err)
+			assert.Equal(t, tc.expected, res)
+			goals := storedGoals(t, service, owner)
+			require.Len(t, goals, 1)
+			goal := goals[0]
+			assert.True(t, tc.expectedGoal.Created.Equal(goal.Created))
+			assert.True(t, tc.expectedGoal.Deadline.Equal(goal.Deadline))
+			goal.Created, goal.Deadline = tc.expectedGoal.Created, tc.expectedGoal.Deadline
+			assert.Equal(t, tc.expectedGoal, goal)
+		})
+	}
+}
+
+func TestAdd_SharedAccount(t *testing.T) {
+	service, client := newTestService(t, time.Date(2025, 3, 15, 12, 0, 0, 0, bangkok))
+	client.EXPECT().GetBalance(mock.Anything).Return(balance(12000), nil)
+	_, err := handle(service, owner, "add", "japan", "60000", "by", "2026-12")
+	require.Nil(t, err)
+	_, err = handle(service, owner, "add", "phone", "500", "by", "2025-12", "on", "debit1")
+	require.Nil(t, err)
+
+	res, err := handle(service, owner, "add", "bike", "30000", "by", "2025-12")
+
+	require.Nil(t, err)
+	assert.Equal(t, "Goal added\n================\nbike (kbank-savings)\n▓░░░░░░░░░ 13%\n฿4000 of ฿30000 by 2025-12\n"+
+		"Shares kbank-savings with 1 other goal, split by target\nSave ฿2600/month for 10 months", res, "only the goals on the same account share it")
+}
+
+func TestAdd_Error(t *testing.T) {
+	testcases := []struct {
+		it          string
+		user        domain.User
+		msg         []string
+		mock        func(client *mocks.MockFinanceServiceClient)
+		expectedErr *errors.AppError
+	}{
+		{
+			it:          "returns error when by is missing",
+			user:        owner,
+			msg:         []string{"add", "japan", "60000", "2026-12", "k"},
+			expectedErr: errors.BadRequestError(invalidGoalMsg),
+		},
+		{
+			it:          "returns error when the amount isn't a number",
+			user:        owner,
+			msg:         []string{"add", "japan", "60kbaht", "by", "2026-12"},
+			expectedErr: errors.BadRequestError("Invalid amount '60kbaht', it must be a number greater than 0"),
+		},
+		{
+			it:          "returns error when the deadline has passed",
+			user:        owner,
+			msg:         []string{"add", "japan", "60000", "by", "2025-02"},
+			expectedErr: errors.BadRequestError("The goal's deadline has already passed"),
+		},
+		{
+			it:          "returns error without account nor default account",
+			user:        member,
+			msg:         []string{"add", "japan", "60000", "by", "2026-12"},
+			expectedErr: errors.BadRequestError("You have no default account.\nName the goal's account (goal add <name> <amount> by <month> on <account>) or set one with 'account default <account>'"),
+		},
+		{
+			it:          "returns error when the role doesn't grant the account",
+			user:        member,
+			msg:         []string{"add", "japan", "60000", "by", "2026-12", "on", "k"},
+			expectedErr: errors.ForbiddenError("You are not permitted to use the account 'kbank-savings'"),
+		},
+		{
+			it:   "returns error when the account is unknown",
+			user: owner,
+			msg:  []string{"add", "japan", "60000", "by", "2026-12", "on", "credit1"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().GetBalance(mock.Anything).Return(balance(12000), nil)
+			},
+			expectedErr: errors.NotFoundError("Unknown account 'credit1'"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			service, client := newTestService(t, time.Date(2025, 3, 15, 12, 0, 0, 0, bangkok))
+			if tc.mock != nil {
+				tc.mock(client)
+			}
+
+			res, err := handle(service, tc.user, tc.msg...)
+
+			assert.Empty(t, res)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Empty(t, storedGoals(t, service, tc.user))
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	service, client := newTestService(t, time.Date(2025, 3, 15, 12, 0, 0, 0, bangkok))
+	client.EXPECT().GetBalance(mock.Anything).Return(balance(12000), nil).Once()
+	_, err := handle(service, owner, "add", "japan", "60000", "by", "2026-12")
+	require.Nil(t, err)
+	client.EXPECT().GetBalance(mock.Anything).Return(balance(12000), nil).Once()
+	_, err = handle(service, owner, "add", "laptop", "10000", "by", "2025-06")
+	require.Nil(t, err)
+
+	// Half way to the japan goal's deadline, without saving anything more
+	service.now = func() time.Time { return time.Date(2026, 2, 6, 12, 0, 0, 0, bangkok) }
+	client.EXPECT().GetBalance(mock.Anything).Return(balance(12000), nil).Once()
+
+	res, err := handle(service, owner)
+
+	assert.Nil(t, err)
+	// The goals split the account's balance by target, 6:1
+	assert.Equal(t, "Your goals\n================\n"+
+		"japan (kbank-savings)\n▓▓░░░░░░░░ 17%\n฿10285.71 of ฿60000 by 2026-12\nShares kbank-savings with 1 other goal, split by target\nSave ฿4519.48/month for 11 months\nBehind schedule by ฿24838.22\n\n"+
+		"laptop (kbank-savings)\n▓▓░░░░░░░░ 17%\n฿1714.29 of ฿10000 by 2025-06\nShares kbank-savings with 1 other goal, split by target\nThe deadline has passed, ฿8285.71 short", res)
+
+	res, err = handle(service, member, "list")
+	assert.Nil(t, err)
+	assert.Equal(t, "You have no goal.\nSet one with 'goal add <name> <amount> by <month>'", res)
+}
+
+func TestRemove(t *testing.T) {
+	service, client := newTestService(t, time.Date(2025, 3, 15, 12, 0, 0, 0, bangkok))
+	client.EXPECT().GetBalance(mock.Anything).Return(balance(12000), nil)
+	_, err := handle(service, owner, "add", "japan", "60000", "by", "2026-12")
+	require.Nil(t, err)
+
+	res, err := handle(service, owner, "remove", "japan")
+	assert.Nil(t, err)
+	assert.Equal(t, "The goal 'japan' is removed", res)
+	assert.Empty(t, storedGoals(t, service, owner))
+
+	_, err = handle(service, owner, "remove", "japan")
+	assert.Equal(t, errors.NotFoundError("You have no goal named 'japan'"), err)
+}
+
+func TestProgressBar(t *testing.T) {
+	assert.Equal(t, "░░░░░░░░░░ 0%", progressBar(-0.5))
+	assert.Equal(t, "▓▓▓░░░░░░░ 33%", progressBar(1.0/3))
+	assert.Equal(t, "▓▓▓▓▓▓▓▓▓▓ 99%", progressBar(0.999))
+	assert.Equal(t, "▓▓▓▓▓▓▓▓▓▓ 100%", progressBar(2))
+}
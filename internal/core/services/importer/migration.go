@@ -0,0 +1,47 @@
+package importer
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+)
+
+// KeyByAccount returns the migration which moves the fingerprints of the
+// imported rows, kept by user ID, to the accounts of the rows in the users'
+// namespaces, see importedKey. findUser finds the users by ID; the rows of
+// a user who is gone are kept under the default namespace.
+func KeyByAccount(findUser func(id string) (domain.User, bool)) func(tx storage.Tx) error {
+	return func(tx storage.Tx) error {
+		byAccount := make(map[string][]string)
+		var keys []string
+		for _, userID := range tx.Keys(importedCollection.Name()) {
+			fingerprints, _, err := importedCollection.Get(tx, userID)
+			if err != nil {
+				return err
+			}
+			if err := importedCollection.Delete(tx, userID); err != nil {
+				return err
+			}
+			user, _ := findUser(userID)
+			for _, fingerprint := range fingerprints {
+				// The fingerprints start with the account, see nextFingerprint
+				account, _, _ := strings.Cut(fingerprint, "|")
+				key := importedKey(user, account)
+				if _, exist := byAccount[key]; !exist {
+					keys = append(keys, key)
+				}
+				if !slices.Contains(byAccount[key], fingerprint) {
+					byAccount[key] = append(byAccount[key], fingerprint)
+				}
+			}
+		}
+		for _, key := range keys {
+			if err := importedCollection.Put(tx, key, byAccount[key]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
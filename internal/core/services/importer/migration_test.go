@@ -0,0 +1,49 @@
+package importer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyByAccount(t *testing.T) {
+	store := memory.NewStore()
+	require.NoError(t, store.Update(context.Background(), func(tx storage.Tx) error {
+		for userID, fingerprints := range map[string][]string{
+			"owner":   {"credit1|2025-01-03|withdraw|150|STARBUCKS|1", "shared|2025-01-04|withdraw|90|LAZADA|1"},
+			"partner": {"shared|2025-01-04|withdraw|90|LAZADA|1"},
+			"former":  {"debit1|2025-01-05|deposit|500|REFUND|1"},
+		} {
+			if err := importedCollection.Put(tx, userID, fingerprints); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	findUser := func(id string) (domain.User, bool) {
+		user, exist := map[string]domain.User{
+			"owner":   {ID: "owner", AccountNamespace: "home"},
+			"partner": {ID: "partner", AccountNamespace: "home"},
+		}[id]
+		return user, exist
+	}
+
+	err := store.Update(context.Background(), KeyByAccount(findUser))
+
+	require.NoError(t, err)
+	require.NoError(t, store.View(context.Background(), func(tx storage.Tx) error {
+		all, err := importedCollection.All(tx)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]string{
+			"home/credit1": {"credit1|2025-01-03|withdraw|150|STARBUCKS|1"},
+			"home/shared":  {"shared|2025-01-04|withdraw|90|LAZADA|1"},
+			"/debit1":      {"debit1|2025-01-05|deposit|500|REFUND|1"},
+		}, all)
+		return nil
+	}))
+}
@@ -0,0 +1,155 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+)
+
+// columns maps the header names of a file to their index.
+type columns map[string]int
+
+func (c columns) has(name string) bool {
+	if name == "" {
+		return true
+	}
+	_, exist := c[strings.ToLower(name)]
+	return exist
+}
+
+func (c columns) value(record []string, name string) string {
+	i, exist := c[strings.ToLower(name)]
+	if !exist || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// parseFile reads the rows of a CSV file with the first layout matching its
+// header. The dates are read in loc.
+func parseFile(content []byte, layouts []domain.ImportLayout, loc *time.Location) (domain.ImportLayout, []domain.ImportRow, *errors.AppError) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\ufeff"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return domain.ImportLayout{}, nil, errors.BadRequestError("The file isn't a CSV file")
+	}
+	cols := make(columns, len(header))
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	i := slices.IndexFunc(layouts, func(l domain.ImportLayout) bool { return matchLayout(l, cols) })
+	if i < 0 {
+		return domain.ImportLayout{}, nil, errors.BadRequestError("The columns of the file don't match any import layout")
+	}
+	layout := layouts[i]
+
+	var rows []domain.ImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return layout, nil, errors.BadRequestError(fmt.Sprintf("The file isn't a valid CSV file: %v", err))
+		}
+		line, _ := reader.FieldPos(0)
+		if isBlank(record) {
+			continue
+		}
+		row, err := parseRow(layout, cols, record, loc)
+		if err != nil {
+			return layout, nil, errors.BadRequestError(fmt.Sprintf("Line %d: %v", line, err))
+		}
+		row.Line = line
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return layout, nil, errors.BadRequestError("The file has no transaction")
+	}
+	return layout, rows, nil
+}
+
+func matchLayout(layout domain.ImportLayout, cols columns) bool {
+	return cols.has(layout.DateColumn) &&
+		cols.has(layout.DescriptionColumn) &&
+		cols.has(layout.AmountColumn) &&
+		cols.has(layout.DebitColumn) &&
+		cols.has(layout.CreditColumn)
+}
+
+func parseRow(layout domain.ImportLayout, cols columns, record []string, loc *time.Location) (domain.ImportRow, error) {
+	date, err := time.ParseInLocation(layout.DateFormat, cols.value(record, layout.DateColumn), loc)
+	if err != nil {
+		return domain.ImportRow{}, fmt.Errorf("invalid date '%s'", cols.value(record, layout.DateColumn))
+	}
+
+	var amount float64
+	if layout.AmountColumn != "" {
+		amount, err = parseAmount(cols.value(record, layout.AmountColumn))
+	} else {
+		var debit, credit float64
+		debit, err = parseAmount(cols.value(record, layout.DebitColumn))
+		if err == nil {
+			credit, err = parseAmount(cols.value(record, layout.CreditColumn))
+		}
+		amount = credit - debit
+	}
+	if err != nil {
+		return domain.ImportRow{}, err
+	}
+	if amount == 0 {
+		return domain.ImportRow{}, fmt.Errorf("the amount is missing")
+	}
+
+	row := domain.ImportRow{
+		Date:        date,
+		Description: cols.value(record, layout.DescriptionColumn),
+		Type:        domain.TransactionTypeDeposit,
+		Account:     layout.Account,
+		Amount:      amount,
+	}
+	if amount < 0 {
+		row.Type = domain.TransactionTypeWithdraw
+		row.Amount = -amount
+	}
+	return row, nil
+}
+
+// parseAmount reads amounts such as "1,200.50", "-45", "(45.00)" or "฿45".
+// Empty cells are zero.
+func parseAmount(s string) (float64, error) {
+	cleaned := strings.NewReplacer(",", "", "฿", "", " ", "").Replace(s)
+	negative := strings.HasPrefix(cleaned, "(") && strings.HasSuffix(cleaned, ")")
+	cleaned = strings.Trim(cleaned, "()")
+	if cleaned == "" {
+		return 0, nil
+	}
+	amount, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount '%s'", s)
+	}
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+func isBlank(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+package importer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	signedLayout = domain.ImportLayout{
+		Name:              "card",
+		Account:           "credit1",
+		DateColumn:        "Posting Date",
+		DateFormat:        "2006-01-02",
+		DescriptionColumn: "Merchant",
+		AmountColumn:      "Amount",
+	}
+	debitCreditLayout = domain.ImportLayout{
+		Name:              "kbank",
+		Account:           "debit1",
+		DateColumn:        "Date",
+		DateFormat:        "02/01/2006",
+		DescriptionColumn: "Details",
+		DebitColumn:       "Withdrawal",
+		CreditColumn:      "Deposit",
+	}
+	testLayouts = []domain.ImportLayout{signedLayout, debitCreditLayout}
+)
+
+func TestParseFile(t *testing.T) {
+	testcases := []struct {
+		it             string
+		content        string
+		expectedLayout string
+		expectedRows   []domain.ImportRow
+	}{
+		{
+			it:             "parses a file with signed amounts",
+			content:        "Posting Date,Merchant,Amount\n2025-01-03,STARBUCKS,-150.00\n2025-01-04,REFUND,\"1,200.50\"\n",
+			expectedLayout: "card",
+			expectedRows: []domain.ImportRow{
+				{Line: 2, Date: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), Description: "STARBUCKS", Type: domain.TransactionTypeWithdraw, Account: "credit1", Amount: 150},
+				{Line: 3, Date: time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC), Description: "REFUND", Type: domain.TransactionTypeDeposit, Account: "credit1", Amount: 1200.5},
+			},
+		},
+		{
+			it:             "parses a file with debit and credit columns, in any order and case",
+			content:        "\ufeffdeposit, Withdrawal ,details,DATE\n,฿45,7-ELEVEN,05/01/2025\n\n30000,,SALARY,25/01/2025\n",
+			expectedLayout: "kbank",
+			expectedRows: []domain.ImportRow{
+				{Line: 2, Date: time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC), Description: "7-ELEVEN", Type: domain.TransactionTypeWithdraw, Account: "debit1", Amount: 45},
+				{Line: 4, Date: time.Date(2025, 1, 25, 0, 0, 0, 0, time.UTC), Description: "SALARY", Type: domain.TransactionTypeDeposit, Account: "debit1", Amount: 30000},
+			},
+		},
+		{
+			it:             "reads amounts in parentheses as negative",
+			content:        "Posting Date,Merchant,Amount\n2025-01-03,GRAB,(99)\n",
+			expectedLayout: "card",
+			expectedRows: []domain.ImportRow{
+				{Line: 2, Date: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), Description: "GRAB", Type: domain.TransactionTypeWithdraw, Account: "credit1", Amount: 99},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			layout, rows, err := parseFile([]byte(tc.content), testLayouts, time.UTC)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedLayout, layout.Name)
+			assert.Equal(t, tc.expectedRows, rows)
+		})
+	}
+}
+
+func TestParseFile_Error(t *testing.T) {
+	testcases := []struct {
+		it          string
+		content     string
+		expectedErr *errors.AppError
+	}{
+		{
+			it:          "returns error when the file is empty",
+			content:     "",
+			expectedErr: errors.BadRequestError("The file isn't a CSV file"),
+		},
+		{
+			it:          "returns error when no layout matches the header",
+			content:     "When,What,How much\n2025-01-03,STARBUCKS,-150\n",
+			expectedErr: errors.BadRequestError("The columns of the file don't match any import layout"),
+		},
+		{
+			it:          "returns error when the file has no row",
+			content:     "Posting Date,Merchant,Amount\n",
+			expectedErr: errors.BadRequestError("The file has no transaction"),
+		},
+		{
+			it:          "returns error with the line of an invalid date",
+			content:     "Posting Date,Merchant,Amount\n2025-01-03,STARBUCKS,-150\n03/01/2025,GRAB,-99\n",
+			expectedErr: errors.BadRequestError("Line 3: invalid date '03/01/2025'"),
+		},
+		{
+			it:          "returns error with the line of an invalid amount",
+			content:     "Posting Date,Merchant,Amount\n2025-01-03,STARBUCKS,abc\n",
+			expectedErr: errors.BadRequestError("Line 2: invalid amount 'abc'"),
+		},
+		{
+			it:          "returns error with the line of a missing amount",
+			content:     "Date,Details,Withdrawal,Deposit\n05/01/2025,7-ELEVEN,,\n",
+			expectedErr: errors.BadRequestError("Line 2: the amount is missing"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			_, rows, err := parseFile([]byte(tc.content), testLayouts, time.UTC)
+
+			assert.Nil(t, rows)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
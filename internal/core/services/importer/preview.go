@@ -0,0 +1,58 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+)
+
+const (
+	invalidImportMsg = "Invalid command's arguments.\nPlease recheck the syntax (import <confirm|cancel>)"
+
+	// maxPreviewRows keeps the preview short enough for a chat message.
+	maxPreviewRows = 10
+)
+
+func printPreview(preview *domain.ImportPreview) string {
+	var withdrawn, deposited float64
+	var newRows, duplicates, uncategorized int
+	for _, row := range preview.Rows {
+		if row.Duplicate {
+			duplicates++
+			continue
+		}
+		newRows++
+		if !row.Categorized {
+			uncategorized++
+		}
+		if row.Type == domain.TransactionTypeWithdraw {
+			withdrawn += row.Amount
+		} else {
+			deposited += row.Amount
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Import preview (%v)\n================\n", preview.Layout))
+	sb.WriteString(fmt.Sprintf("New: %v\nDuplicates: %v\nUncategorized: %v\n", newRows, duplicates, uncategorized))
+	sb.WriteString(fmt.Sprintf("Withdraw: ฿%v\nDeposit: ฿%v\n\n", withdrawn, deposited))
+	shown := 0
+	for _, row := range preview.Rows {
+		if row.Duplicate {
+			continue
+		}
+		if shown == maxPreviewRows {
+			sb.WriteString(fmt.Sprintf("...and %v more\n", newRows-shown))
+			break
+		}
+		command := "!e"
+		if row.Type == domain.TransactionTypeWithdraw {
+			command = "!p"
+		}
+		sb.WriteString(fmt.Sprintf("%v %v %v %v%v %v\n", row.Date.Format("2006-01-02"), command, row.Account, row.Amount, row.Category, row.Description))
+		shown++
+	}
+	sb.WriteString("\nReply 'import confirm' to import or 'import cancel' to discard.")
+	return sb.String()
+}
@@ -0,0 +1,297 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/category"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/permission"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/client"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+)
+
+// previewTTL is how long a preview waits for the user's confirmation.
+const previewTTL = 30 * time.Minute
+
+// row fingerprints, by account, see importedKey
+var importedCollection = storage.NewCollection[[]string]("imported_rows")
+
+type pendingImport struct {
+	preview   *domain.ImportPreview
+	expiresAt time.Time
+}
+
+// Service previews bank CSV files and, once the user confirms through the
+// "import" command, submits their rows to the finance service.
+//
+// The fingerprints of the imported rows are kept in storage by account, so
+// that a file is only imported once, even by the users sharing the account. Previews are kept in memory, so a restart
+// forgets them.
+type Service struct {
+	client     client.FinanceServiceClient
+	categories *category.Service
+	cfg        domain.ImportConfig
+	store      storage.Store
+
+	mu      sync.Mutex
+	pending map[string]pendingImport // by user ID
+	now     func() time.Time
+}
+
+// NewService constructs the import service. The categories of the rules
+// are resolved through categories.
+func NewService(client client.FinanceServiceClient, categories *category.Service, cfg domain.ImportConfig, store storage.Store) *Service {
+	return &Service{
+		client:     client,
+		categories: categories,
+		cfg:        cfg,
+		store:      store,
+		pending:    make(map[string]pendingImport),
+		now:        time.Now,
+	}
+}
+
+// Preview parses the file and keeps the result until the user confirms or
+// cancels it, replacing the user's previous preview.
+func (s *Service) Preview(ctx context.Context, user domain.User, file *domain.File) (*domain.ImportPreview, *errors.AppError) {
+	ctx = domain.ContextWithUser(ctx, user)
+	ctx = logger.WithFields(ctx, "user_id", user.ID)
+	if len(s.cfg.Layouts) == 0 {
+		return nil, errors.BadRequestError("No import layout is configured")
+	}
+
+	layout, rows, err := parseFile(file.Content, s.cfg.Layouts, s.cfg.Location)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.categorize(ctx, rows); err != nil {
+		return nil, err
+	}
+	if err := permission.Check(ctx, user, "import", accounts(rows)...); err != nil {
+		return nil, err
+	}
+
+	imported, err := s.imported(ctx, user, accounts(rows))
+	if err != nil {
+		return nil, err
+	}
+	markDuplicates(rows, imported)
+	preview := &domain.ImportPreview{Layout: layout.Name, Rows: rows}
+	preview.Summary = printPreview(preview)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune()
+	s.pending[user.ID] = pendingImport{preview: preview, expiresAt: s.now().Add(previewTTL)}
+
+	logger.Ctx(ctx).Infow("previewed import", "layout", layout.Name, "rows", len(rows))
+	return preview, nil
+}
+
+func (s *Service) Match(cmd string) bool {
+	return cmd == "import"
+}
+
+// Handle serves "import" (show the pending preview), "import confirm" and "import cancel".
+func (s *Service) Handle(ctx context.Context, tokenizedMsg []string) (string, *errors.AppError) {
+	user, _ := domain.UserFromContext(ctx)
+	if len(tokenizedMsg) > 2 {
+		return "", errors.BadRequestError(invalidImportMsg)
+	}
+
+	var action string
+	if len(tokenizedMsg) == 2 {
+		action = tokenizedMsg[1]
+		if action != "confirm" && action != "cancel" {
+			return "", errors.BadRequestError(invalidImportMsg)
+		}
+	}
+
+	// Confirming or cancelling takes the preview, so that it's only submitted once
+	s.mu.Lock()
+	s.prune()
+	pending, exist := s.pending[user.ID]
+	if action != "" {
+		delete(s.pending, user.ID)
+	}
+	s.mu.Unlock()
+	if !exist {
+		return "", errors.NotFoundError("There is no import to confirm.\nSend a bank CSV file first")
+	}
+
+	switch action {
+	case "confirm":
+		return s.submit(ctx, user, pending.preview)
+	case "cancel":
+		return "The import is cancelled", nil
+	default:
+		return pending.preview.Summary, nil
+	}
+}
+
+// submit sends the rows which weren't imported yet. Failed rows aren't
+// remembered, so importing the file again retries them.
+func (s *Service) submit(ctx context.Context, user domain.User, preview *domain.ImportPreview) (string, *errors.AppError) {
+	alreadyImported, appErr := s.imported(ctx, user, accounts(preview.Rows))
+	if appErr != nil {
+		return "", appErr
+	}
+	var imported, duplicates, failed int
+	occurrences := make(map[string]int)
+	for _, row := range preview.Rows {
+		fingerprint := nextFingerprint(row, occurrences)
+		if _, duplicate := alreadyImported[fingerprint]; duplicate {
+			duplicates++
+			continue
+		}
+
+		req := &domain.TransactionRequest{
+			Account:     row.Account,
+			Amount:      row.Amount,
+			Category:    row.Category,
+			Description: row.Description,
+			Timestamp:   row.Date,
+		}
+		var err *errors.AppError
+		if row.Type == domain.TransactionTypeWithdraw {
+			_, err = s.client.Withdraw(ctx, req)
+		} else {
+			_, err = s.client.Deposit(ctx, req)
+		}
+		if err != nil {
+			logger.Ctx(ctx).Errorw("cannot import row", "line", row.Line, "error", err)
+			failed++
+			continue
+		}
+		s.remember(ctx, importedKey(user, row.Account), fingerprint)
+		imported++
+	}
+
+	logger.Ctx(ctx).Infow("imported rows", "imported", imported, "duplicates", duplicates, "failed", failed)
+	reply := fmt.Sprintf("Imported %d transactions\nDuplicates skipped: %d", imported, duplicates)
+	if failed > 0 {
+		reply += fmt.Sprintf("\nFailed: %d, send the file again to retry them", failed)
+	}
+	return reply, nil
+}
+
+// imported returns the fingerprints of the rows imported to the user's
+// accounts, by any user.
+func (s *Service) imported(ctx context.Context, user domain.User, accounts []string) (map[string]struct{}, *errors.AppError) {
+	imported := make(map[string]struct{})
+	err := s.store.View(ctx, func(tx storage.Tx) error {
+		for _, account := range accounts {
+			fingerprints, _, err := importedCollection.Get(tx, importedKey(user, account))
+			if err != nil {
+				return err
+			}
+			for _, fingerprint := range fingerprints {
+				imported[fingerprint] = struct{}{}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Ctx(ctx).Errorw("failed to load the imported rows", "error", err)
+		return nil, errors.InternalServerError("cannot load the imported rows")
+	}
+	return imported, nil
+}
+
+// remember records the imported row. The row is already in the finance
+// service, so failing to record it is only logged.
+func (s *Service) remember(ctx context.Context, key, fingerprint string) {
+	err := s.store.Update(ctx, func(tx storage.Tx) error {
+		fingerprints, _, err := importedCollection.Get(tx, key)
+		if err != nil {
+			return err
+		}
+		return importedCollection.Put(tx, key, append(fingerprints, fingerprint))
+	})
+	if err != nil {
+		logger.Ctx(ctx).Errorw("failed to save the imported row, importing the file again duplicates it", "error", err)
+	}
+}
+
+// categorize applies the first matching rule to each row, and resolves
+// the categories through the registry, so that an unknown category is
+// reported before anything is imported.
+func (s *Service) categorize(ctx context.Context, rows []domain.ImportRow) *errors.AppError {
+	resolved := make(map[string]string)
+	for i := range rows {
+		rows[i].Category = s.cfg.DefaultCategory
+		for _, rule := range s.cfg.Rules {
+			if !rule.Pattern.MatchString(rows[i].Description) {
+				continue
+			}
+			if rule.Category != "" {
+				rows[i].Category = rule.Category
+				rows[i].Categorized = true
+			}
+			if rule.Account != "" {
+				rows[i].Account = rule.Account
+			}
+			break
+		}
+
+		code, exist := resolved[rows[i].Category]
+		if !exist {
+			var err *errors.AppError
+			if code, err = s.categories.Resolve(ctx, rows[i].Category); err != nil {
+				return err
+			}
+			resolved[rows[i].Category] = code
+		}
+		rows[i].Category = code
+	}
+	return nil
+}
+
+// markDuplicates flags the rows which were already imported.
+func markDuplicates(rows []domain.ImportRow, imported map[string]struct{}) {
+	occurrences := make(map[string]int)
+	for i := range rows {
+		_, rows[i].Duplicate = imported[nextFingerprint(rows[i], occurrences)]
+	}
+}
+
+// prune forgets the expired previews. The caller must hold mu.
+func (s *Service) prune() {
+	now := s.now()
+	for userID, pending := range s.pending {
+		if !now.Before(pending.expiresAt) {
+			delete(s.pending, userID)
+		}
+	}
+}
+
+// importedKey identifies the account in the finance service, which scopes
+// the accounts by the user's namespace.
+func importedKey(user domain.User, account string) string {
+	return user.AccountNamespace + "/" + account
+}
+
+// nextFingerprint identifies a row by its content and its occurrence in the
+// file, so that identical purchases on the same day are all imported once.
+func nextFingerprint(row domain.ImportRow, occurrences map[string]int) string {
+	key := fmt.Sprintf("%s|%s|%s|%v|%s", row.Account, row.Date.Format("2006-01-02"), row.Type, row.Amount, row.Description)
+	occurrences[key]++
+	return fmt.Sprintf("%s|%d", key, occurrences[key])
+}
+
+func accounts(rows []domain.ImportRow) []string {
+	seen := make(map[string]struct{})
+	var accounts []string
+	for _, row := range rows {
+		if _, exist := seen[row.Account]; !exist {
+			seen[row.Account] = struct{}{}
+			accounts = append(accounts, row.Account)
+		}
+	}
+	return accounts
+}
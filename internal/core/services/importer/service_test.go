@@ -0,0 +1,265 @@
+package importer
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/category"
+	"github.com/sMARCHz/secretaria-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	owner = domain.User{
+		ID:   "owner",
+		Role: domain.Role{Name: "owner", Commands: []string{"*"}, Accounts: []string{"*"}},
+	}
+	bangkok          = time.FixedZone("ICT", 7*60*60)
+	testImportConfig = domain.ImportConfig{
+		DefaultCategory: "other",
+		Layouts:         testLayouts,
+		Location:        bangkok,
+		Rules: []domain.ImportRule{
+			{Pattern: regexp.MustCompile(`(?i)starbucks|grab`), Category: "fd"},
+			{Pattern: regexp.MustCompile(`(?i)^salary`), Category: "salary", Account: "debit1"},
+		},
+	}
+	cardFile = &domain.File{
+		Name: "card.csv",
+		Content: []byte("Posting Date,Merchant,Amount\n" +
+			"2025-01-03,STARBUCKS,-150\n" +
+			"2025-01-03,STARBUCKS,-150\n" +
+			"2025-01-04,SALARY JAN,30000\n" +
+			"2025-01-05,LAZADA,-990\n"),
+	}
+)
+
+func newTestService(t *testing.T) (*Service, *mocks.MockFinanceServiceClient) {
+	client := mocks.NewMockFinanceServiceClient(t)
+	return NewService(client, newTestCategories(), testImportConfig, memory.NewStore()), client
+}
+
+func newTestCategories() *category.Service {
+	return category.NewService(domain.CategoryConfig{
+		Categories: []domain.Category{
+			{Code: "fd", Name: "Food"},
+			{Code: "other", Name: "Other"},
+			{Code: "salary", Name: "Salary", Aliases: []string{"income"}},
+		},
+	}, memory.NewStore())
+}
+
+func confirm(service *Service, user domain.User, action ...string) (string, *errors.AppError) {
+	return service.Handle(domain.ContextWithUser(context.Background(), user), append([]string{"import"}, action...))
+}
+
+func TestPreview(t *testing.T) {
+	service, _ := newTestService(t)
+
+	res, err := service.Preview(context.Background(), owner, cardFile)
+
+	require.Nil(t, err)
+	assert.Equal(t, "card", res.Layout)
+	assert.Equal(t, []domain.ImportRow{
+		{Line: 2, Date: time.Date(2025, 1, 3, 0, 0, 0, 0, bangkok), Description: "STARBUCKS", Type: domain.TransactionTypeWithdraw, Account: "credit1", Category: "fd", Amount: 150, Categorized: true},
+		{Line: 3, Date: time.Date(2025, 1, 3, 0, 0, 0, 0, bangkok), Description: "STARBUCKS", Type: domain.TransactionTypeWithdraw, Account: "credit1", Category: "fd", Amount: 150, Categorized: true},
+		{Line: 4, Date: time.Date(2025, 1, 4, 0, 0, 0, 0, bangkok), Description: "SALARY JAN", Type: domain.TransactionTypeDeposit, Account: "debit1", Category: "salary", Amount: 30000, Categorized: true},
+		{Line: 5, Date: time.Date(2025, 1, 5, 0, 0, 0, 0, bangkok), Description: "LAZADA", Type: domain.TransactionTypeWithdraw, Account: "credit1", Category: "other", Amount: 990},
+	}, res.Rows)
+	assert.Equal(t, "Import preview (card)\n================\n"+
+		"New: 4\nDuplicates: 0\nUncategorized: 1\n"+
+		"Withdraw: ฿1290\nDeposit: ฿30000\n\n"+
+		"2025-01-03 !p credit1 150fd STARBUCKS\n"+
+		"2025-01-03 !p credit1 150fd STARBUCKS\n"+
+		"2025-01-04 !e debit1 30000salary SALARY JAN\n"+
+		"2025-01-05 !p credit1 990other LAZADA\n"+
+		"\nReply 'import confirm' to import or 'import cancel' to discard.", res.Summary)
+
+	reply, err := confirm(service, owner)
+	assert.Nil(t, err)
+	assert.Equal(t, res.Summary, reply, "the pending preview can be shown again")
+}
+
+func TestPreview_Error(t *testing.T) {
+	member := domain.User{
+		ID:   "partner",
+		Role: domain.Role{Name: "member", Commands: []string{"import"}, Accounts: []string{"credit1"}},
+	}
+	service, _ := newTestService(t)
+
+	res, err := service.Preview(context.Background(), member, cardFile)
+	assert.Nil(t, res)
+	assert.Equal(t, errors.ForbiddenError("You are not permitted to use the account 'debit1'"), err)
+
+	res, err = NewService(nil, newTestCategories(), domain.ImportConfig{}, memory.NewStore()).Preview(context.Background(), owner, cardFile)
+	assert.Nil(t, res)
+	assert.Equal(t, errors.BadRequestError("No import layout is configured"), err)
+
+	_, err = confirm(service, member, "confirm")
+	assert.Equal(t, errors.NotFoundError("There is no import to confirm.\nSend a bank CSV file first"), err, "rejected previews aren't kept")
+}
+
+func TestPreview_Categories(t *testing.T) {
+	testcases := []struct {
+		it               string
+		rule             domain.ImportRule
+		expectedCategory string
+		expectedErr      *errors.AppError
+	}{
+		{
+			it:               "resolves the alias of a rule",
+			rule:             domain.ImportRule{Pattern: regexp.MustCompile(`(?i)^salary`), Category: "income"},
+			expectedCategory: "salary",
+		},
+		{
+			it:          "reports the unknown category of a rule",
+			rule:        domain.ImportRule{Pattern: regexp.MustCompile(`(?i)^salary`), Category: "tx"},
+			expectedErr: errors.BadRequestError("Unknown category 'tx'.\nAdd it with 'category add tx <name>' or use one of: fd, other, salary"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			cfg := testImportConfig
+			cfg.Rules = []domain.ImportRule{tc.rule}
+			service := NewService(mocks.NewMockFinanceServiceClient(t), newTestCategories(), cfg, memory.NewStore())
+
+			res, err := service.Preview(context.Background(), owner, cardFile)
+
+			assert.Equal(t, tc.expectedErr, err)
+			if tc.expectedErr != nil {
+				assert.Nil(t, res)
+				return
+			}
+			require.NotNil(t, res)
+			assert.Equal(t, tc.expectedCategory, res.Rows[2].Category)
+		})
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	service, client := newTestService(t)
+	client.EXPECT().Withdraw(mock.Anything, &domain.TransactionRequest{Account: "credit1", Amount: 150, Category: "fd", Description: "STARBUCKS", Timestamp: time.Date(2025, 1, 3, 0, 0, 0, 0, bangkok)}).
+		Return(&domain.TransactionResponse{}, nil).Times(2)
+	client.EXPECT().Deposit(mock.Anything, &domain.TransactionRequest{Account: "debit1", Amount: 30000, Category: "salary", Description: "SALARY JAN", Timestamp: time.Date(2025, 1, 4, 0, 0, 0, 0, bangkok)}).
+		Return(&domain.TransactionResponse{}, nil).Once()
+	client.EXPECT().Withdraw(mock.Anything, &domain.TransactionRequest{Account: "credit1", Amount: 990, Category: "other", Description: "LAZADA", Timestamp: time.Date(2025, 1, 5, 0, 0, 0, 0, bangkok)}).
+		Return(nil, errors.BadGatewayError("cannot withdraw money")).Once()
+	_, err := service.Preview(context.Background(), owner, cardFile)
+	require.Nil(t, err)
+
+	reply, err := confirm(service, owner, "confirm")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Imported 3 transactions\nDuplicates skipped: 0\nFailed: 1, send the file again to retry them", reply)
+	_, err = confirm(service, owner, "confirm")
+	assert.Equal(t, errors.NotFoundError("There is no import to confirm.\nSend a bank CSV file first"), err, "a preview is only submitted once")
+
+	// Importing the same file again only submits the failed row
+	client.EXPECT().Withdraw(mock.Anything, &domain.TransactionRequest{Account: "credit1", Amount: 990, Category: "other", Description: "LAZADA", Timestamp: time.Date(2025, 1, 5, 0, 0, 0, 0, bangkok)}).
+		Return(&domain.TransactionResponse{}, nil).Once()
+	res, err := service.Preview(context.Background(), owner, cardFile)
+	require.Nil(t, err)
+	assert.Contains(t, res.Summary, "New: 1\nDuplicates: 3\n")
+
+	reply, err = confirm(service, owner, "confirm")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Imported 1 transactions\nDuplicates skipped: 3", reply)
+	client.AssertExpectations(t)
+}
+
+func TestConfirm_RememberedAcrossRestarts(t *testing.T) {
+	client := mocks.NewMockFinanceServiceClient(t)
+	store := memory.NewStore()
+	client.EXPECT().Withdraw(mock.Anything, mock.Anything).Return(&domain.TransactionResponse{}, nil).Times(3)
+	client.EXPECT().Deposit(mock.Anything, mock.Anything).Return(&domain.TransactionResponse{}, nil).Once()
+	service := NewService(client, newTestCategories(), testImportConfig, store)
+	_, err := service.Preview(context.Background(), owner, cardFile)
+	require.Nil(t, err)
+	_, err = confirm(service, owner, "confirm")
+	require.Nil(t, err)
+
+	res, err := NewService(client, newTestCategories(), testImportConfig, store).Preview(context.Background(), owner, cardFile)
+
+	require.Nil(t, err)
+	assert.Contains(t, res.Summary, "New: 0\nDuplicates: 4\n")
+}
+
+func TestConfirm_SharedAccount(t *testing.T) {
+	client := mocks.NewMockFinanceServiceClient(t)
+	store := memory.NewStore()
+	client.EXPECT().Withdraw(mock.Anything, mock.Anything).Return(&domain.TransactionResponse{}, nil).Times(3)
+	client.EXPECT().Deposit(mock.Anything, mock.Anything).Return(&domain.TransactionResponse{}, nil).Once()
+	service := NewService(client, newTestCategories(), testImportConfig, store)
+	household := owner
+	household.AccountNamespace = "home"
+	partner := household
+	partner.ID = "partner"
+	stranger := owner
+	stranger.ID = "stranger"
+	stranger.AccountNamespace = "elsewhere"
+	_, err := service.Preview(context.Background(), household, cardFile)
+	require.Nil(t, err)
+	_, err = confirm(service, household, "confirm")
+	require.Nil(t, err)
+
+	res, err := service.Preview(context.Background(), partner, cardFile)
+	require.Nil(t, err)
+	assert.Contains(t, res.Summary, "New: 0\nDuplicates: 4\n", "the rows of the shared accounts were imported")
+
+	res, err = service.Preview(context.Background(), stranger, cardFile)
+	require.Nil(t, err)
+	assert.Contains(t, res.Summary, "New: 4\nDuplicates: 0\n", "the accounts of another namespace are other accounts")
+}
+
+func TestConfirm_Cancel(t *testing.T) {
+	service, _ := newTestService(t)
+	_, err := service.Preview(context.Background(), owner, cardFile)
+	require.Nil(t, err)
+
+	reply, err := confirm(service, owner, "cancel")
+	assert.Nil(t, err)
+	assert.Equal(t, "The import is cancelled", reply)
+
+	_, err = confirm(service, owner, "confirm")
+	assert.Equal(t, errors.NotFoundError("There is no import to confirm.\nSend a bank CSV file first"), err)
+}
+
+func TestConfirm_Expired(t *testing.T) {
+	service, _ := newTestService(t)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	_, err := service.Preview(context.Background(), owner, cardFile)
+	require.Nil(t, err)
+
+	now = now.Add(previewTTL)
+	_, err = confirm(service, owner, "confirm")
+
+	assert.Equal(t, errors.NotFoundError("There is no import to confirm.\nSend a bank CSV file first"), err)
+	assert.Empty(t, service.pending)
+}
+
+func TestConfirm_InvalidArguments(t *testing.T) {
+	service, _ := newTestService(t)
+
+	_, err := confirm(service, owner, "yes")
+	assert.Equal(t, errors.BadRequestError(invalidImportMsg), err)
+
+	_, err = confirm(service, owner, "confirm", "now")
+	assert.Equal(t, errors.BadRequestError(invalidImportMsg), err)
+}
+
+func TestMatch(t *testing.T) {
+	service, _ := newTestService(t)
+
+	assert.True(t, service.Match("import"))
+	assert.False(t, service.Match("export"))
+}
@@ -0,0 +1,126 @@
+// Package ledger renders statements as ledger-cli journals, which hledger
+// reads as well.
+package ledger
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	// accountWidth aligns the amounts. Longer accounts are still followed
+	// by the two spaces which end an account name.
+	accountWidth = 36
+
+	uncategorized = "uncategorized"
+	transferPayee = "transfer"
+)
+
+// entry is a journal entry of two postings: amount into account and out of
+// balancingAccount.
+type entry struct {
+	timestamp        time.Time
+	payee            string
+	account          string
+	balancingAccount string
+	amount           float64
+}
+
+// Write renders the transactions and transfers of the statement as journal
+// entries in chronological order, each with two balanced postings, dated
+// in location. Descriptions become payees, or the category when there is
+// none.
+func Write(res *domain.GetDetailedStatementResponse, cfg domain.LedgerConfig, location *time.Location) []byte {
+	var entries []entry
+	if res.Revenue != nil {
+		for _, v := range res.Revenue.Entries {
+			entries = append(entries, entry{
+				timestamp:        v.Timestamp,
+				payee:            payee(v.Description, category(v.Category)),
+				account:          assetAccount(cfg, v.Account),
+				balancingAccount: join(cfg.IncomePrefix, category(v.Category)),
+				amount:           v.Amount,
+			})
+		}
+	}
+	if res.Expense != nil {
+		for _, v := range res.Expense.Entries {
+			entries = append(entries, entry{
+				timestamp:        v.Timestamp,
+				payee:            payee(v.Description, category(v.Category)),
+				account:          join(cfg.ExpensesPrefix, category(v.Category)),
+				balancingAccount: assetAccount(cfg, v.Account),
+				amount:           v.Amount,
+			})
+		}
+	}
+	for _, v := range res.Transfers {
+		entries = append(entries, entry{
+			timestamp:        v.Timestamp,
+			payee:            payee(v.Description, transferPayee),
+			account:          assetAccount(cfg, v.ToAccount),
+			balancingAccount: assetAccount(cfg, v.FromAccount),
+			amount:           v.Amount,
+		})
+	}
+	slices.SortStableFunc(entries, func(a, b entry) int {
+		return cmp.Compare(a.timestamp.Unix(), b.timestamp.Unix())
+	})
+
+	var buf bytes.Buffer
+	for i, e := range entries {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "%s %s\n", e.timestamp.In(location).Format(dateLayout), e.payee)
+		fmt.Fprintf(&buf, "    %-*s  %s\n", accountWidth, e.account, formatAmount(e.amount))
+		fmt.Fprintf(&buf, "    %-*s  %s\n", accountWidth, e.balancingAccount, formatAmount(-e.amount))
+	}
+	return buf.Bytes()
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+func assetAccount(cfg domain.LedgerConfig, account string) string {
+	if mapped, ok := cfg.Accounts[account]; ok {
+		return mapped
+	}
+	return join(cfg.AssetsPrefix, sanitize(account))
+}
+
+func category(c string) string {
+	if c = sanitize(c); c == "" {
+		return uncategorized
+	}
+	return c
+}
+
+func payee(description, fallback string) string {
+	if p := sanitize(description); p != "" {
+		return p
+	}
+	return sanitize(fallback)
+}
+
+func join(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + ":" + name
+}
+
+// sanitize collapses whitespace, since two spaces end an account name, and
+// drops semicolons, which start a comment.
+func sanitize(s string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(s, ";", "")), " ")
+}
@@ -0,0 +1,183 @@
+package ledger
+
+import (
+	"bufio"
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var ledgerConfig = domain.LedgerConfig{
+	Accounts:       map[string]string{"debit1": "assets:bank:debit1", "credit1": "liabilities:credit card"},
+	AssetsPrefix:   "assets",
+	ExpensesPrefix: "expenses",
+	IncomePrefix:   "income",
+}
+
+func at(day, hour int) time.Time {
+	return time.Date(2025, 1, day, hour, 0, 0, 0, time.UTC)
+}
+
+var detailedStatement = &domain.GetDetailedStatementResponse{
+	Revenue: &domain.GetDetailedStatementSection{
+		Total: 30000,
+		Entries: []domain.Entry{
+			{Timestamp: at(25, 9), Account: "debit1", Category: "salary", Amount: 30000, Description: "january salary"},
+		},
+	},
+	Expense: &domain.GetDetailedStatementSection{
+		Total: 700.5,
+		Entries: []domain.Entry{
+			{Timestamp: at(6, 8), Account: "cash", Category: "fd", Amount: 200.5},
+			{Timestamp: at(5, 12), Account: "credit1", Category: "sh", Amount: 500, Description: "youtube  membership; yearly"},
+		},
+	},
+	Transfers: []domain.TransferEntry{
+		{Timestamp: at(26, 10), FromAccount: "debit1", ToAccount: "credit1", Amount: 500},
+	},
+	Profit: 29299.5,
+}
+
+func TestWrite(t *testing.T) {
+	testcases := []struct {
+		it        string
+		statement *domain.GetDetailedStatementResponse
+		cfg       domain.LedgerConfig
+		expected  string
+	}{
+		{
+			it:        "writes the transactions and transfers in chronological order",
+			statement: detailedStatement,
+			cfg:       ledgerConfig,
+			expected: "2025-01-05 youtube membership yearly\n" +
+				"    expenses:sh                           500.00\n" +
+				"    liabilities:credit card               -500.00\n" +
+				"\n" +
+				"2025-01-06 fd\n" +
+				"    expenses:fd                           200.50\n" +
+				"    assets:cash                           -200.50\n" +
+				"\n" +
+				"2025-01-25 january salary\n" +
+				"    assets:bank:debit1                    30000.00\n" +
+				"    income:salary                         -30000.00\n" +
+				"\n" +
+				"2025-01-26 transfer\n" +
+				"    liabilities:credit card               500.00\n" +
+				"    assets:bank:debit1                    -500.00\n",
+		},
+		{
+			it: "uses the bare names without prefixes",
+			statement: &domain.GetDetailedStatementResponse{
+				Expense: &domain.GetDetailedStatementSection{
+					Entries: []domain.Entry{{Timestamp: at(1, 0), Account: "debit1", Amount: 1}},
+				},
+			},
+			expected: "2025-01-01 uncategorized\n" +
+				"    uncategorized                         1.00\n" +
+				"    debit1                                -1.00\n",
+		},
+		{
+			it:        "writes nothing when the statement is empty",
+			statement: &domain.GetDetailedStatementResponse{},
+			cfg:       ledgerConfig,
+			expected:  "",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			res := Write(tc.statement, tc.cfg, time.UTC)
+
+			assert.Equal(t, tc.expected, string(res))
+		})
+	}
+}
+
+func TestWrite_Location(t *testing.T) {
+	bangkok := time.FixedZone("ICT", 7*60*60)
+	statement := &domain.GetDetailedStatementResponse{
+		Expense: &domain.GetDetailedStatementSection{
+			Entries: []domain.Entry{
+				// 2025-01-01 00:30 in Bangkok
+				{Timestamp: time.Date(2024, 12, 31, 17, 30, 0, 0, time.UTC), Account: "cash", Category: "fd", Amount: 80, Description: "new year noodles"},
+			},
+		},
+	}
+
+	res := Write(statement, ledgerConfig, bangkok)
+
+	assert.Equal(t, "2025-01-01 new year noodles\n"+
+		"    expenses:fd                           80.00\n"+
+		"    assets:cash                           -80.00\n", string(res))
+}
+
+// TestWrite_RoundTrip reads the journal back like ledger-cli does and
+// checks that it balances and agrees with the statement.
+func TestWrite_RoundTrip(t *testing.T) {
+	journal := Write(detailedStatement, ledgerConfig, time.UTC)
+
+	transactions := parseJournal(t, journal)
+
+	require.Len(t, transactions, 4)
+	totals := make(map[string]float64)
+	for _, tx := range transactions {
+		var sum float64
+		for _, p := range tx.postings {
+			sum += p.amount
+			top, _, _ := strings.Cut(p.account, ":")
+			totals[top] += p.amount
+		}
+		assert.InDelta(t, 0, sum, 1e-9, "%s %s balances", tx.date, tx.payee)
+	}
+	assert.InDelta(t, detailedStatement.Expense.Total, totals["expenses"], 1e-9)
+	assert.InDelta(t, -detailedStatement.Revenue.Total, totals["income"], 1e-9)
+	assert.InDelta(t, detailedStatement.Profit, totals["assets"]+totals["liabilities"], 1e-9)
+}
+
+type transaction struct {
+	date     string
+	payee    string
+	postings []posting
+}
+
+type posting struct {
+	account string
+	amount  float64
+}
+
+func parseJournal(t *testing.T, journal []byte) []transaction {
+	t.Helper()
+	var transactions []transaction
+	scanner := bufio.NewScanner(bytes.NewReader(journal))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			continue
+		case !strings.HasPrefix(line, " "):
+			date, payee, _ := strings.Cut(line, " ")
+			_, err := time.Parse(dateLayout, date)
+			require.NoError(t, err, "line %q starts with a date", line)
+			transactions = append(transactions, transaction{date: date, payee: payee})
+		default:
+			require.NotEmpty(t, transactions, "posting %q belongs to a transaction", line)
+			// Two spaces end the account name
+			account, amount, found := strings.Cut(strings.TrimSpace(line), "  ")
+			require.True(t, found, "posting %q has an amount", line)
+			value, err := strconv.ParseFloat(strings.TrimSpace(amount), 64)
+			require.NoError(t, err)
+			require.False(t, math.IsNaN(value))
+			tx := &transactions[len(transactions)-1]
+			tx.postings = append(tx.postings, posting{account: account, amount: value})
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return transactions
+}
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/account"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/audit"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/importer"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+)
+
+// Migrations upgrade the data the services keep in storage, see
+// storage.Migrate, moving what goes to the journal there, and keying what
+// is shared by account by the users' namespaces, found by findUser, or
+// among namespaces. Append
+// a migration whenever the stored values change shape; never edit the
+// released ones.
+func Migrations(journal storage.Journal, findUser func(id string) (domain.User, bool), namespaces []string) []storage.Migration {
+	return []storage.Migration{
+		{Version: 1, Description: "account aliases, default accounts, categories, debts and goals"},
+		{Version: 2, Description: "fingerprints of the imported bank CSV rows"},
+		{Version: 3, Description: "audit log entries moved to the journal", Migrate: audit.MoveToJournal(journal)},
+		{Version: 4, Description: "fingerprints of the imported rows kept by account", Migrate: importer.KeyByAccount(findUser)},
+		{Version: 5, Description: "account aliases kept by namespace", Migrate: account.KeyByNamespace(namespaces)},
+	}
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/permission"
+)
+
+// AccountScopedHandler is implemented by command handlers whose commands act on accounts.
+type AccountScopedHandler interface {
+	// Accounts returns the accounts the command refers to.
+	Accounts(ctx context.Context, msgArgs []string) []string
+}
+
+// permissionMiddleware sits in front of a CommandHandler and only lets the
+// command through when the caller's role grants it, and grants every
+// account it refers to.
+type permissionMiddleware struct {
+	next CommandHandler
+}
+
+func withPermissions(next CommandHandler) CommandHandler {
+	return &permissionMiddleware{next: next}
+}
+
+func (p *permissionMiddleware) Match(cmd string) bool {
+	return p.next.Match(cmd)
+}
+
+func (p *permissionMiddleware) Handle(ctx context.Context, msgArgs []string) (string, *errors.AppError) {
+	if len(msgArgs) == 0 {
+		return p.next.Handle(ctx, msgArgs)
+	}
+	var accounts []string
+	if scoped, ok := p.next.(AccountScopedHandler); ok {
+		accounts = scoped.Accounts(ctx, msgArgs)
+	}
+	if err := permission.CheckCaller(ctx, msgArgs[0], accounts...); err != nil {
+		return "", err
+	}
+	return p.next.Handle(ctx, msgArgs)
+}
@@ -0,0 +1,90 @@
+package permission
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+)
+
+// Recorder keeps the denials, e.g. in the audit log.
+type Recorder interface {
+	Record(context.Context, domain.AuditEntry) *errors.AppError
+}
+
+// recorder is set once at startup, before any check runs.
+var recorder Recorder
+
+// RecordDenials makes the checks record their denials with r.
+func RecordDenials(r Recorder) {
+	recorder = r
+}
+
+// Check returns a forbidden error, and records the denial in the audit log,
+// unless the user's role grants the command on every given account.
+func Check(ctx context.Context, user domain.User, command string, accounts ...string) *errors.AppError {
+	if !user.Role.CanRun(command) {
+		return deny(ctx, user, command, "", fmt.Sprintf("You are not permitted to run '%s'", command))
+	}
+	for _, account := range accounts {
+		if !user.Role.CanAccess(account) {
+			return deny(ctx, user, command, account, fmt.Sprintf("You are not permitted to use the account '%s'", account))
+		}
+	}
+	return nil
+}
+
+// CheckAllAccounts is Check for the commands which total all the accounts,
+// such as the overview statements, and so can't be narrowed down to the
+// accounts the user's role grants.
+func CheckAllAccounts(ctx context.Context, user domain.User, command string) *errors.AppError {
+	if err := Check(ctx, user, command); err != nil {
+		return err
+	}
+	if !user.Role.CanAccessAll() {
+		return deny(ctx, user, command, domain.Wildcard, fmt.Sprintf("You are not permitted to run '%s', which totals all the accounts.\nList your accounts' transactions with 'last' instead", command))
+	}
+	return nil
+}
+
+// CheckCaller is Check for the user attached to ctx. Requests without a user are denied.
+func CheckCaller(ctx context.Context, command string, accounts ...string) *errors.AppError {
+	user, ok := domain.UserFromContext(ctx)
+	if !ok {
+		return deny(ctx, user, command, "", "You are not permitted to run this command")
+	}
+	return Check(ctx, user, command, accounts...)
+}
+
+// CheckCallerAllAccounts is CheckAllAccounts for the user attached to ctx.
+// Requests without a user are denied.
+func CheckCallerAllAccounts(ctx context.Context, command string) *errors.AppError {
+	user, ok := domain.UserFromContext(ctx)
+	if !ok {
+		return deny(ctx, user, command, "", "You are not permitted to run this command")
+	}
+	return CheckAllAccounts(ctx, user, command)
+}
+
+func deny(ctx context.Context, user domain.User, command, account, replyMsg string) *errors.AppError {
+	logger.Ctx(ctx).Warnw("permission denied",
+		"audit", "permission_denied",
+		"role", user.Role.Name,
+		"command", command,
+		"account", account,
+	)
+	err := errors.ForbiddenError(replyMsg)
+	if recorder != nil {
+		// Record logs its failures
+		recorder.Record(ctx, domain.AuditEntry{
+			Action:     domain.AuditDenied,
+			Command:    command,
+			Account:    account,
+			StatusCode: err.StatusCode,
+			Error:      err.Message,
+		})
+	}
+	return err
+}
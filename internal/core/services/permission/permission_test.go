@@ -0,0 +1,129 @@
+package permission
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var member = domain.User{
+	ID: "partner",
+	Role: domain.Role{
+		Name:     "member",
+		Commands: []string{"!p", "balance"},
+		Accounts: []string{"shared-*"},
+	},
+}
+
+func TestCheck(t *testing.T) {
+	testcases := []struct {
+		it          string
+		command     string
+		accounts    []string
+		expectedMsg string
+	}{
+		{
+			it:       "returns nil for a granted command on granted accounts",
+			command:  "!p",
+			accounts: []string{"shared-kbank"},
+		},
+		{
+			it:      "returns nil for a granted command without account",
+			command: "balance",
+		},
+		{
+			it:          "returns forbidden error for a command which isn't granted",
+			command:     "!t",
+			accounts:    []string{"shared-kbank", "shared-scb"},
+			expectedMsg: "You are not permitted to run '!t'",
+		},
+		{
+			it:          "returns forbidden error for an account which isn't granted",
+			command:     "!p",
+			accounts:    []string{"shared-kbank", "debit1"},
+			expectedMsg: "You are not permitted to use the account 'debit1'",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			err := Check(context.Background(), member, tc.command, tc.accounts...)
+
+			if tc.expectedMsg == "" {
+				assert.Nil(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.expectedMsg)
+			assert.Equal(t, http.StatusForbidden, err.StatusCode)
+		})
+	}
+}
+
+func TestCheckAllAccounts(t *testing.T) {
+	owner := domain.User{ID: "owner", Role: domain.Role{Commands: []string{"statement"}, Accounts: []string{"*"}}}
+	assert.Nil(t, CheckAllAccounts(context.Background(), owner, "statement"))
+
+	err := CheckAllAccounts(context.Background(), member, "balance")
+	assert.EqualError(t, err, "You are not permitted to run 'balance', which totals all the accounts.\nList your accounts' transactions with 'last' instead")
+	assert.Equal(t, http.StatusForbidden, err.StatusCode)
+
+	err = CheckAllAccounts(context.Background(), member, "statement")
+	assert.EqualError(t, err, "You are not permitted to run 'statement'")
+}
+
+func TestCheckCaller(t *testing.T) {
+	err := CheckCaller(domain.ContextWithUser(context.Background(), member), "!p", "shared-kbank")
+	assert.Nil(t, err)
+
+	err = CheckCaller(context.Background(), "balance")
+	assert.EqualError(t, err, "You are not permitted to run this command")
+	assert.Equal(t, http.StatusForbidden, err.StatusCode)
+}
+
+func TestCheckCallerAllAccounts(t *testing.T) {
+	err := CheckCallerAllAccounts(domain.ContextWithUser(context.Background(), member), "balance")
+	assert.EqualError(t, err, "You are not permitted to run 'balance', which totals all the accounts.\nList your accounts' transactions with 'last' instead")
+
+	err = CheckCallerAllAccounts(context.Background(), "balance")
+	assert.EqualError(t, err, "You are not permitted to run this command")
+	assert.Equal(t, http.StatusForbidden, err.StatusCode)
+}
+
+// entries keeps the recorded denials.
+type entries []domain.AuditEntry
+
+func (e *entries) Record(_ context.Context, entry domain.AuditEntry) *errors.AppError {
+	*e = append(*e, entry)
+	return nil
+}
+
+func TestRecordDenials(t *testing.T) {
+	recorded := &entries{}
+	RecordDenials(recorded)
+	t.Cleanup(func() { RecordDenials(nil) })
+	ctx := domain.ContextWithUser(context.Background(), member)
+
+	assert.Nil(t, Check(ctx, member, "!p", "shared-kbank"))
+	assert.NotNil(t, Check(ctx, member, "!p", "debit1"))
+	assert.NotNil(t, CheckCaller(context.Background(), "statement"))
+
+	assert.Equal(t, &entries{
+		{
+			Action:     domain.AuditDenied,
+			Command:    "!p",
+			Account:    "debit1",
+			StatusCode: http.StatusForbidden,
+			Error:      "You are not permitted to use the account 'debit1'",
+		},
+		{
+			Action:     domain.AuditDenied,
+			Command:    "statement",
+			StatusCode: http.StatusForbidden,
+			Error:      "You are not permitted to run this command",
+		},
+	}, recorded, "only the denials are recorded")
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/account"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/category"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/confirmation"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/debt"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/finance"
+	"github.com/sMARCHz/secretaria-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var member = domain.User{
+	ID: "partner",
+	Role: domain.Role{
+		Name:     "member",
+		Commands: []string{"!p", "balance"},
+		Accounts: []string{"shared-*"},
+	},
+}
+
+func TestPermissionMiddleware(t *testing.T) {
+	testcases := []struct {
+		it               string
+		user             domain.User
+		tokenizedMsg     []string
+		mock             func(client *mocks.MockFinanceServiceClient)
+		expectedReplyMsg string
+	}{
+		{
+			it:           "lets through any command when the role has the wildcard",
+			user:         owner,
+			tokenizedMsg: []string{"!t", "debit1", "debit2", "100"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().Transfer(mock.Anything, mock.Anything).Return(&domain.TransferResponse{
+					FromAccount: "debit1",
+					Balance:     900,
+				}, nil)
+			},
+			expectedReplyMsg: "Succesfully transfer\n================\nResult\nAccount: debit1\nBalance: ฿900",
+		},
+		{
+			it:           "lets through a granted command on a granted account",
+			user:         member,
+			tokenizedMsg: []string{"!p", "shared-kbank", "100sh"},
+			mock: func(client *mocks.MockFinanceServiceClient) {
+				client.EXPECT().Withdraw(mock.Anything, mock.Anything).Return(&domain.TransactionResponse{
+					Account: "shared-kbank",
+					Balance: 900,
+				}, nil)
+			},
+			expectedReplyMsg: "Succesfully withdraw\n================\nResult\nAccount: shared-kbank\nBalance: ฿900",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			client := mocks.NewMockFinanceServiceClient(t)
+			tc.mock(client)
+			handler := withPermissions(newFinanceHandler(client))
+			ctx := domain.ContextWithUser(context.Background(), tc.user)
+
+			res, err := handler.Handle(ctx, tc.tokenizedMsg)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedReplyMsg, res)
+		})
+	}
+}
+
+// newFinanceHandler constructs a finance handler with empty registries.
+func newFinanceHandler(client *mocks.MockFinanceServiceClient) *finance.Handler {
+	return finance.NewHandler(finance.Deps{
+		Client:        client,
+		Accounts:      account.NewService(client, domain.AccountConfig{}, memory.NewStore()),
+		Categories:    category.NewService(domain.CategoryConfig{}, memory.NewStore()),
+		Debts:         debt.NewLedger(domain.DebtConfig{Category: "lent"}, memory.NewStore()),
+		Confirmations: confirmation.NewService(client, domain.ConfirmationConfig{}),
+		Store:         memory.NewStore(),
+		Location:      time.UTC,
+	})
+}
+
+func TestPermissionMiddleware_Denied(t *testing.T) {
+	testcases := []struct {
+		it           string
+		ctx          context.Context
+		tokenizedMsg []string
+		expectedMsg  string
+	}{
+		{
+			it:           "denies a command which isn't granted",
+			ctx:          domain.ContextWithUser(context.Background(), member),
+			tokenizedMsg: []string{"!t", "shared-kbank", "shared-scb", "100"},
+			expectedMsg:  "You are not permitted to run '!t'",
+		},
+		{
+			it:           "denies a granted command on an account which isn't granted",
+			ctx:          domain.ContextWithUser(context.Background(), member),
+			tokenizedMsg: []string{"!p", "debit1", "100sh"},
+			expectedMsg:  "You are not permitted to use the account 'debit1'",
+		},
+		{
+			it:           "denies every command when the caller is unknown",
+			ctx:          context.Background(),
+			tokenizedMsg: []string{"balance"},
+			expectedMsg:  "You are not permitted to run this command",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			client := mocks.NewMockFinanceServiceClient(t)
+			handler := withPermissions(newFinanceHandler(client))
+
+			res, err := handler.Handle(tc.ctx, tc.tokenizedMsg)
+
+			assert.Empty(t, res)
+			assert.EqualError(t, err, tc.expectedMsg)
+			assert.Equal(t, http.StatusForbidden, err.StatusCode)
+			client.AssertExpectations(t)
+		})
+	}
+}
@@ -1,12 +1,16 @@
 package services
 
 import (
+	"context"
 	"strings"
 
 	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/audit"
 	"github.com/sMARCHz/secretaria-bot/internal/core/services/finance"
-	"github.com/sMARCHz/secretaria-bot/internal/ports/client"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/goal"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/importer"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
 	"github.com/sMARCHz/secretaria-bot/internal/ports/inbound"
 )
 
@@ -14,14 +18,36 @@ type botServiceImpl struct {
 	commandHandlers []CommandHandler
 }
 
-func NewBotService(financeClient client.FinanceServiceClient) inbound.BotService {
-	financeHandler := finance.NewHandler(financeClient)
+// Deps are what the finance commands work with, and the other command
+// handlers.
+type Deps struct {
+	finance.Deps
+	Imports *importer.Service
+	Goals   *goal.Service
+	History *audit.Log
+}
+
+func NewBotService(deps Deps) inbound.BotService {
 	return &botServiceImpl{
-		commandHandlers: []CommandHandler{financeHandler},
+		commandHandlers: []CommandHandler{
+			withPermissions(finance.NewHandler(deps.Deps)),
+			withPermissions(deps.Imports),
+			withPermissions(deps.Accounts),
+			withPermissions(deps.Categories),
+			withPermissions(deps.Goals),
+			withPermissions(deps.History),
+			// yes and no only answer for the caller's held transaction, whose
+			// command was checked when it was held
+			deps.Confirmations,
+		},
 	}
 }
 
-func (b *botServiceImpl) HandleTextMessage(msg string) (*domain.TextMessageResponse, *errors.AppError) {
+func (b *botServiceImpl) HandleTextMessage(ctx context.Context, user domain.User, msg string) (*domain.TextMessageResponse, *errors.AppError) {
+	ctx = domain.ContextWithUser(ctx, user)
+	ctx = logger.WithFields(ctx, "user_id", user.ID)
+	ctx = domain.ContextWithMessage(ctx, msg)
+
 	msg = strings.TrimSpace(msg)
 	msg = strings.ToLower(msg)
 	tokenizedMsg := strings.Fields(msg)
@@ -34,7 +60,8 @@ func (b *botServiceImpl) HandleTextMessage(msg string) (*domain.TextMessageRespo
 	var replyMsg string
 	for _, h := range b.commandHandlers {
 		if h.Match(tokenizedMsg[0]) {
-			replyMsg, err = h.Handle(tokenizedMsg)
+			logger.Ctx(ctx).Infow("handling command", "command", tokenizedMsg[0])
+			replyMsg, err = h.Handle(ctx, tokenizedMsg)
 			handled = true
 			break
 		}
@@ -43,10 +70,18 @@ func (b *botServiceImpl) HandleTextMessage(msg string) (*domain.TextMessageRespo
 		return nil, err
 	}
 	if !handled {
+		logger.Ctx(ctx).Infow("command not found", "command", tokenizedMsg[0])
 		replyMsg = "Command not found"
 	}
 
-	return &domain.TextMessageResponse{
+	res := &domain.TextMessageResponse{
 		ReplyMessage: replyMsg,
-	}, nil
+	}
+	if images, ok := domain.ReplyImagesFromContext(ctx); ok {
+		res.Images = images.Images()
+	}
+	if replies, ok := domain.QuickRepliesFromContext(ctx); ok {
+		res.QuickReplies = replies.Answers()
+	}
+	return res, nil
 }
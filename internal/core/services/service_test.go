@@ -1,29 +1,90 @@
 package services
 
 import (
+	"context"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
 	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/account"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/audit"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/category"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/confirmation"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/debt"
 	"github.com/sMARCHz/secretaria-bot/internal/core/services/finance"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/goal"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/importer"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/client"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/inbound"
 	"github.com/sMARCHz/secretaria-bot/test/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestNewBotService(t *testing.T) {
 	client := mocks.NewMockFinanceServiceClient(t)
+	publisher := mocks.NewMockFilePublisher(t)
+	accounts := account.NewService(client, domain.AccountConfig{}, memory.NewStore())
+	categories := category.NewService(domain.CategoryConfig{}, memory.NewStore())
+	imports := importer.NewService(client, categories, domain.ImportConfig{}, memory.NewStore())
+	debts := debt.NewLedger(domain.DebtConfig{Category: "lent"}, memory.NewStore())
+	goals := goal.NewService(client, accounts, memory.NewStore(), time.UTC)
+	history := audit.NewLog(memory.NewStore(), memory.NewJournal(), time.UTC)
+	confirmations := confirmation.NewService(client, domain.ConfirmationConfig{})
+	store := memory.NewStore()
+	ledger := domain.LedgerConfig{AssetsPrefix: "assets"}
 
-	res := NewBotService(client)
+	financeDeps := finance.Deps{
+		Client:        client,
+		Publisher:     publisher,
+		Accounts:      accounts,
+		Categories:    categories,
+		Debts:         debts,
+		Confirmations: confirmations,
+		Store:         store,
+		Ledger:        ledger,
+		Location:      time.UTC,
+	}
+
+	res := NewBotService(Deps{Deps: financeDeps, Imports: imports, Goals: goals, History: history})
 
 	expected := &botServiceImpl{
 		commandHandlers: []CommandHandler{
-			finance.NewHandler(client),
+			&permissionMiddleware{next: finance.NewHandler(financeDeps)},
+			&permissionMiddleware{next: imports},
+			&permissionMiddleware{next: accounts},
+			&permissionMiddleware{next: categories},
+			&permissionMiddleware{next: goals},
+			&permissionMiddleware{next: history},
+			confirmations,
 		},
 	}
 	assert.Equal(t, expected, res)
 }
 
+// newTestBotService constructs a bot service with empty registries.
+func newTestBotService(client *mocks.MockFinanceServiceClient, publisher client.FilePublisher) inbound.BotService {
+	accounts := account.NewService(client, domain.AccountConfig{}, memory.NewStore())
+	return NewBotService(Deps{
+		Deps: finance.Deps{
+			Client:        client,
+			Publisher:     publisher,
+			Accounts:      accounts,
+			Categories:    category.NewService(domain.CategoryConfig{}, memory.NewStore()),
+			Debts:         debt.NewLedger(domain.DebtConfig{Category: "lent"}, memory.NewStore()),
+			Confirmations: confirmation.NewService(client, domain.ConfirmationConfig{}),
+			Store:         memory.NewStore(),
+			Location:      time.UTC,
+		},
+		Imports: importer.NewService(client, category.NewService(domain.CategoryConfig{}, memory.NewStore()), domain.ImportConfig{}, memory.NewStore()),
+		Goals:   goal.NewService(client, accounts, memory.NewStore(), time.UTC),
+		History: audit.NewLog(memory.NewStore(), memory.NewJournal(), time.UTC),
+	})
+}
+
 func TestHandleTextMessage(t *testing.T) {
 	testcases := []struct {
 		it               string
@@ -50,7 +111,7 @@ func TestHandleTextMessage(t *testing.T) {
 	for _, tc := range testcases {
 		t.Run(tc.it, func(t *testing.T) {
 			client := mocks.NewMockFinanceServiceClient(t)
-			client.EXPECT().GetBalance().Return(&domain.GetBalanceResponse{
+			client.EXPECT().GetBalance(mock.Anything).Return(&domain.GetBalanceResponse{
 				Accounts: []domain.AccountBalance{
 					{
 						Account: "debit1",
@@ -58,9 +119,9 @@ func TestHandleTextMessage(t *testing.T) {
 					},
 				},
 			}, nil).Maybe()
-			service := NewBotService(client)
+			service := newTestBotService(client, nil)
 
-			res, err := service.HandleTextMessage(tc.inputMsg)
+			res, err := service.HandleTextMessage(context.Background(), owner, tc.inputMsg)
 
 			assert.Nil(t, err)
 			assert.Equal(t, &domain.TextMessageResponse{
@@ -71,15 +132,70 @@ func TestHandleTextMessage(t *testing.T) {
 	}
 }
 
+func TestHandleTextMessage_PassesUserDownstream(t *testing.T) {
+	user := domain.User{ID: "partner", AccountNamespace: "partner", Role: owner.Role}
+	client := mocks.NewMockFinanceServiceClient(t)
+	client.EXPECT().GetBalance(mock.MatchedBy(func(ctx context.Context) bool {
+		caller, ok := domain.UserFromContext(ctx)
+		return ok && caller.ID == user.ID && caller.AccountNamespace == user.AccountNamespace
+	})).Return(&domain.GetBalanceResponse{}, nil).Once()
+	service := newTestBotService(client, nil)
+
+	res, err := service.HandleTextMessage(context.Background(), user, "balance")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Your balance\n\n", res.ReplyMessage)
+	client.AssertExpectations(t)
+}
+
+func TestHandleTextMessage_PassesMessageDownstream(t *testing.T) {
+	client := mocks.NewMockFinanceServiceClient(t)
+	client.EXPECT().GetBalance(mock.MatchedBy(func(ctx context.Context) bool {
+		msg, ok := domain.MessageFromContext(ctx)
+		return ok && msg == " Balance "
+	})).Return(&domain.GetBalanceResponse{}, nil).Once()
+	service := newTestBotService(client, nil)
+
+	_, err := service.HandleTextMessage(context.Background(), owner, " Balance ")
+
+	assert.Nil(t, err)
+	client.AssertExpectations(t)
+}
+
+func TestHandleTextMessage_Images(t *testing.T) {
+	client := mocks.NewMockFinanceServiceClient(t)
+	client.EXPECT().GetOverviewMonthlyStatement(mock.Anything).Return(&domain.GetOverviewStatementResponse{
+		Expense: &domain.GetOverviewStatementSection{Total: 100, Entries: []domain.CategorizedEntry{{Category: "fd", Amount: 100}}},
+	}, nil)
+	publisher := mocks.NewMockFilePublisher(t)
+	publisher.EXPECT().PublishImage(mock.Anything, mock.Anything).Return(&domain.PublishedFile{URL: "https://bot.example.com/downloads/1"}, nil)
+	service := newTestBotService(client, publisher)
+	ctx, _ := domain.ContextWithReplyImages(context.Background())
+
+	res, err := service.HandleTextMessage(ctx, owner, "statement")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []domain.Image{{URL: "https://bot.example.com/downloads/1", PreviewURL: "https://bot.example.com/downloads/1"}}, res.Images)
+}
+
 func TestHandleTextMessage_Error(t *testing.T) {
 	client := mocks.NewMockFinanceServiceClient(t)
-	client.EXPECT().GetBalance().Return(nil, errors.InternalServerError("something went wrong")).Once()
-	service := NewBotService(client)
+	client.EXPECT().GetBalance(mock.Anything).Return(nil, errors.InternalServerError("something went wrong")).Once()
+	service := newTestBotService(client, nil)
 
-	res, err := service.HandleTextMessage("balance")
+	res, err := service.HandleTextMessage(context.Background(), owner, "balance")
 
 	assert.Nil(t, res)
 	assert.Equal(t, "something went wrong", err.Message)
 	assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
 	client.AssertExpectations(t)
 }
+
+var owner = domain.User{
+	ID: "owner",
+	Role: domain.Role{
+		Name:     "owner",
+		Commands: []string{domain.Wildcard},
+		Accounts: []string{domain.Wildcard},
+	},
+}
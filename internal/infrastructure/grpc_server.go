@@ -0,0 +1,49 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	grpcapi "github.com/sMARCHz/secretaria-bot/internal/adapters/inbound/grpc"
+	"github.com/sMARCHz/secretaria-bot/internal/config"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/inbound"
+	"google.golang.org/grpc"
+)
+
+// startGRPCServer serves the gRPC BotService on app.grpc_port. It returns
+// nil when no port is configured.
+func startGRPCServer(cfg config.Configuration, bot inbound.BotService, finance inbound.FinanceService) *grpc.Server {
+	if cfg.App.GRPCPort == "" {
+		return nil
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%v", cfg.App.GRPCPort))
+	if err != nil {
+		logger.Fatal("Cannot listen for gRPC: ", err)
+	}
+	server := grpcapi.NewServer(bot, finance, cfg.FindUserByAPIKey)
+	go func() {
+		logger.Infof("Listening and serving gRPC on :%v", cfg.App.GRPCPort)
+		if err := server.Serve(listener); err != nil {
+			logger.Fatal("Cannot start a gRPC server: ", err)
+		}
+	}()
+	return server
+}
+
+// stopGRPCServer waits for the in-flight calls to finish, or stops the
+// server forcefully once ctx is done.
+func stopGRPCServer(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		logger.Warn("Forcefully stopping the gRPC server")
+		server.Stop()
+	}
+}
@@ -1,24 +1,20 @@
 package infrastructure
 
 import (
-	"context"
 	"fmt"
 	"net/http"
-	"os/signal"
-	"syscall"
-	"time"
 
 	httpapi "github.com/sMARCHz/secretaria-bot/internal/adapters/inbound/http"
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/inbound/http/download"
 	"github.com/sMARCHz/secretaria-bot/internal/config"
 	"github.com/sMARCHz/secretaria-bot/internal/logger"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/inbound"
 )
 
-func StartHTTPServer() {
-	// Start server
-	cfg := config.Get()
+func startHTTPServer(cfg config.Configuration, bot inbound.BotService, finance inbound.FinanceService, imports inbound.ImportService, audit inbound.AuditService, downloads *download.Store) *http.Server {
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%v", cfg.App.Port),
-		Handler: httpapi.NewRouter(),
+		Handler: httpapi.NewRouter(bot, finance, imports, audit, downloads),
 	}
 	go func() {
 		logger.Infof("Listening and serving HTTP on :%v", cfg.App.Port)
@@ -26,16 +22,5 @@ func StartHTTPServer() {
 			logger.Fatal("Cannot start a server: ", err)
 		}
 	}()
-
-	// Shutdown: listen for interrupt/terminate signals (SIGKILL cannot be caught)
-	sigCtx, sigCancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
-	<-sigCtx.Done()
-	sigCancel()
-
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer shutdownCancel()
-	if err := server.Shutdown(shutdownCtx); err != nil {
-		logger.Fatal("Forcefully shutting down: ", err)
-	}
-	logger.Info("Gracefully shutting down...")
+	return server
 }
@@ -0,0 +1,83 @@
+package infrastructure
+
+import (
+	"context"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/client/finance"
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/inbound/http/download"
+	"github.com/sMARCHz/secretaria-bot/internal/config"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/account"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/audit"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/category"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/confirmation"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/debt"
+	financeservice "github.com/sMARCHz/secretaria-bot/internal/core/services/finance"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/goal"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/importer"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services/permission"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+)
+
+const shutdownTimeout = 5 * time.Second
+
+// Start serves the HTTP server, and the gRPC server when configured, over
+// the same services until the process is interrupted or terminated.
+func Start() {
+	cfg := config.Get()
+	store, journal := openStore(cfg)
+	auditLog := audit.NewLog(store, journal, cfg.Location())
+	if err := auditLog.Reconcile(context.Background()); err != nil {
+		logger.Fatal("Cannot reconcile the audit log: ", err.Message)
+	}
+	permission.RecordDenials(auditLog)
+	// Every withdrawal, deposit and transfer goes through the audit log
+	financeClient := audit.NewClient(finance.NewFinanceServiceClient(), auditLog)
+	downloads := download.NewStore(cfg.App.PublicURL, cfg.Downloads.TTL)
+	accounts := account.NewService(financeClient, cfg.AccountConfig(), store)
+	categories := category.NewService(cfg.CategoryConfig(), store)
+	imports := importer.NewService(financeClient, categories, cfg.ImportConfig(), store)
+	debts := debt.NewLedger(cfg.DebtConfig(), store)
+	goals := goal.NewService(financeClient, accounts, store, cfg.Location())
+	confirmations := confirmation.NewService(financeClient, cfg.ConfirmationConfig())
+	ledger := cfg.LedgerConfig()
+	bot := services.NewBotService(services.Deps{
+		Deps: financeservice.Deps{
+			Client:        financeClient,
+			Publisher:     downloads,
+			Accounts:      accounts,
+			Categories:    categories,
+			Debts:         debts,
+			Confirmations: confirmations,
+			Store:         store,
+			Ledger:        ledger,
+			Location:      cfg.Location(),
+		},
+		Imports: imports,
+		Goals:   goals,
+		History: auditLog,
+	})
+	financeService := financeservice.NewService(financeClient, categories, ledger, cfg.Location())
+
+	httpServer := startHTTPServer(cfg, bot, financeService, imports, auditLog, downloads)
+	grpcServer := startGRPCServer(cfg, bot, financeService)
+
+	// Shutdown: listen for interrupt/terminate signals (SIGKILL cannot be caught)
+	sigCtx, sigCancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	<-sigCtx.Done()
+	sigCancel()
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer shutdownCancel()
+	if grpcServer != nil {
+		stopGRPCServer(shutdownCtx, grpcServer)
+	}
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		logger.Fatal("Forcefully shutting down: ", err)
+	}
+	closeStore(store, journal)
+	logger.Info("Gracefully shutting down...")
+}
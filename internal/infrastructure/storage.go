@@ -0,0 +1,52 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/file"
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
+	"github.com/sMARCHz/secretaria-bot/internal/config"
+	"github.com/sMARCHz/secretaria-bot/internal/core/services"
+	"github.com/sMARCHz/secretaria-bot/internal/logger"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+)
+
+// openStore opens the configured store and journal, and migrates their
+// data to the schema of this build.
+func openStore(cfg config.Configuration) (storage.Store, storage.Journal) {
+	var store storage.Store
+	var journal storage.Journal
+	switch cfg.Storage.Driver {
+	case config.StorageDriverMemory:
+		logger.Warn("The state is kept in memory, a restart forgets it")
+		store = memory.NewStore()
+		journal = memory.NewJournal()
+	default:
+		s, err := file.Open(cfg.Storage.Path)
+		if err != nil {
+			logger.Fatal("Cannot open the storage: ", err)
+		}
+		j, err := file.OpenJournal(cfg.Storage.AuditPath)
+		if err != nil {
+			logger.Fatal("Cannot open the audit journal: ", err)
+		}
+		store, journal = s, j
+	}
+
+	version, err := storage.Migrate(context.Background(), store, services.Migrations(journal, cfg.FindUserByID, cfg.AccountNamespaces()))
+	if err != nil {
+		logger.Fatal("Cannot migrate the storage: ", err)
+	}
+	logger.Infof("Opened the %s storage at schema version %d", cfg.Storage.Driver, version)
+	return store, journal
+}
+
+// closeStore closes the store and journal once the servers are stopped.
+func closeStore(store storage.Store, journal storage.Journal) {
+	if err := store.Close(); err != nil {
+		logger.Error("Cannot close the storage: ", err)
+	}
+	if err := journal.Close(); err != nil {
+		logger.Error("Cannot close the audit journal: ", err)
+	}
+}
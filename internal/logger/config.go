@@ -0,0 +1,39 @@
+package logger
+
+// Config describes where and how log lines are written.
+type Config struct {
+	// Level is the minimum enabled level (debug, info, warn, error).
+	Level string `mapstructure:"level"`
+	// Format is either "json" or "console".
+	Format string `mapstructure:"format"`
+	// OutputPaths are "stdout", "stderr" or file paths. Files are rotated.
+	OutputPaths []string        `mapstructure:"output_paths"`
+	Rotation    RotationConfig  `mapstructure:"rotation"`
+	Redaction   RedactionConfig `mapstructure:"redaction"`
+}
+
+// RotationConfig bounds the size and age of file outputs.
+type RotationConfig struct {
+	MaxSizeMB  int  `mapstructure:"max_size_mb"`
+	MaxAgeDays int  `mapstructure:"max_age_days"`
+	MaxBackups int  `mapstructure:"max_backups"`
+	Compress   bool `mapstructure:"compress"`
+}
+
+// DefaultConfig logs JSON lines at info level to stdout, with sensitive data redacted.
+func DefaultConfig() Config {
+	return Config{
+		Level:       "info",
+		Format:      "json",
+		OutputPaths: []string{"stdout"},
+		Rotation: RotationConfig{
+			MaxSizeMB:  100,
+			MaxAgeDays: 30,
+			MaxBackups: 10,
+			Compress:   true,
+		},
+		Redaction: RedactionConfig{
+			Enabled: true,
+		},
+	}
+}
@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"go.uber.org/zap"
+)
+
+const (
+	requestIDField     = "request_id"
+	maxRequestIDLength = 64
+)
+
+type contextKey struct{}
+
+// contextFields is the set of structured fields carried by a context.
+// The request ID is kept apart so that it is always logged first and
+// can be read back by adapters (e.g. to echo it in a response header).
+type contextFields struct {
+	requestID string
+	fields    []interface{}
+}
+
+func fieldsFromContext(ctx context.Context) contextFields {
+	if ctx == nil {
+		return contextFields{}
+	}
+	if f, ok := ctx.Value(contextKey{}).(contextFields); ok {
+		return f
+	}
+	return contextFields{}
+}
+
+// WithRequestID returns a copy of ctx whose log lines are tagged with the given request ID.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	f := fieldsFromContext(ctx)
+	f.requestID = requestID
+	return context.WithValue(ctx, contextKey{}, f)
+}
+
+// WithFields returns a copy of ctx whose log lines carry the given key-value pairs.
+func WithFields(ctx context.Context, keysAndValues ...interface{}) context.Context {
+	f := fieldsFromContext(ctx)
+	fields := make([]interface{}, 0, len(f.fields)+len(keysAndValues))
+	fields = append(fields, f.fields...)
+	f.fields = append(fields, keysAndValues...)
+	return context.WithValue(ctx, contextKey{}, f)
+}
+
+// RequestIDFromContext returns the request ID attached to ctx, or an empty string.
+func RequestIDFromContext(ctx context.Context) string {
+	return fieldsFromContext(ctx).requestID
+}
+
+// IsValidRequestID reports whether a request ID provided by a caller is
+// safe to log: 1 to 64 alphanumeric characters, dashes or underscores.
+func IsValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range requestID {
+		isAlphanumeric := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+		if !isAlphanumeric && r != '-' && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// NewRequestID generates a random request ID.
+func NewRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// Ctx returns the global logger enriched with the fields attached to ctx.
+func Ctx(ctx context.Context) Logger {
+	f := fieldsFromContext(ctx)
+	fields := make([]interface{}, 0, len(f.fields)+2)
+	if f.requestID != "" {
+		fields = append(fields, requestIDField, f.requestID)
+	}
+	fields = append(fields, f.fields...)
+	if len(fields) == 0 {
+		return logger
+	}
+
+	switch l := logger.(type) {
+	case *zap.SugaredLogger:
+		// The global logger skips one frame for the package-level helpers,
+		// which aren't on the stack when the caller logs through Ctx.
+		return l.Desugar().WithOptions(zap.AddCallerSkip(-1)).Sugar().With(fields...)
+	case interface{ With(...interface{}) Logger }:
+		return l.With(fields...)
+	default:
+		return logger
+	}
+}
@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestCtx(t *testing.T) {
+	testcases := []struct {
+		it             string
+		ctx            context.Context
+		expectedFields map[string]interface{}
+	}{
+		{
+			it:             "logs without extra fields when the context carries none",
+			ctx:            context.Background(),
+			expectedFields: map[string]interface{}{},
+		},
+		{
+			it:             "logs the request id attached to the context",
+			ctx:            WithRequestID(context.Background(), "req-1"),
+			expectedFields: map[string]interface{}{"request_id": "req-1"},
+		},
+		{
+			it:  "logs every field attached to the context",
+			ctx: WithFields(WithRequestID(context.Background(), "req-1"), "webhook_event_id", "evt-1"),
+			expectedFields: map[string]interface{}{
+				"request_id":       "req-1",
+				"webhook_event_id": "evt-1",
+			},
+		},
+		{
+			it:             "replaces the request id instead of logging it twice",
+			ctx:            WithRequestID(WithRequestID(context.Background(), "req-1"), "req-2"),
+			expectedFields: map[string]interface{}{"request_id": "req-2"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+			originalLogger := logger
+			SetLogger(zap.New(core).Sugar())
+			defer SetLogger(originalLogger)
+
+			Ctx(tc.ctx).Infof("hello %s", "world")
+
+			entries := logs.AllUntimed()
+			assert.Len(t, entries, 1)
+			assert.Equal(t, "hello world", entries[0].Message)
+			assert.Equal(t, tc.expectedFields, entries[0].ContextMap())
+		})
+	}
+}
+
+func TestCtx_NoopLogger(t *testing.T) {
+	res := Ctx(WithRequestID(context.Background(), "req-1"))
+
+	assert.Equal(t, &noopLogger{}, res)
+}
+
+func TestRequestIDFromContext(t *testing.T) {
+	ctx := WithFields(WithRequestID(context.Background(), "req-1"), "user", "me")
+
+	assert.Equal(t, "req-1", RequestIDFromContext(ctx))
+	assert.Empty(t, RequestIDFromContext(context.Background()))
+}
+
+func TestIsValidRequestID(t *testing.T) {
+	assert.True(t, IsValidRequestID("abc-123_XYZ"))
+	assert.False(t, IsValidRequestID(""))
+	assert.False(t, IsValidRequestID("abc 123\n"))
+	assert.False(t, IsValidRequestID(strings.Repeat("a", 65)))
+}
+
+func TestNewRequestID(t *testing.T) {
+	id := NewRequestID()
+
+	assert.Len(t, id, 32)
+	assert.NotEqual(t, id, NewRequestID())
+}
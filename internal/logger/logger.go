@@ -1,30 +1,98 @@
 package logger
 
 import (
+	"fmt"
+	"net/http"
+	"os"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-func InitLogger() func() error {
-	l := newZapLogger()
+// level is shared by every logger built by InitLogger so that
+// it can be changed at runtime.
+var level = zap.NewAtomicLevel()
+
+func InitLogger(cfg Config) func() error {
+	l, err := newZapLogger(cfg)
+	if err != nil {
+		panic(err)
+	}
 	logger = l.Sugar()
 	return l.Sync
 }
 
-// TODO: Make log directory to env
-func newZapLogger() *zap.Logger {
+// SetLevel changes the minimum enabled level of the running logger.
+func SetLevel(lvl string) error {
+	return level.UnmarshalText([]byte(lvl))
+}
+
+// GetLevel returns the minimum enabled level of the running logger.
+func GetLevel() string {
+	return level.String()
+}
+
+// LevelHandler serves GET and PUT requests to read or change the level
+// as JSON ({"level":"debug"}).
+func LevelHandler() http.Handler {
+	return level
+}
+
+func newZapLogger(cfg Config) (*zap.Logger, error) {
+	if err := SetLevel(cfg.Level); err != nil {
+		return nil, fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
+	}
+
 	encoderConfig := zap.NewProductionEncoderConfig()
 	encoderConfig.TimeKey = "timestamp"
 	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-	config := zap.NewProductionConfig()
-	config.EncoderConfig = encoderConfig
-	config.OutputPaths = []string{"logs/secretaria.log", "stdout"}
-	config.ErrorOutputPaths = []string{"stderr"}
+	var encoder zapcore.Encoder
+	switch cfg.Format {
+	case "json", "":
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+	case "console":
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	default:
+		return nil, fmt.Errorf("invalid log format %q", cfg.Format)
+	}
 
-	logger, err := config.Build()
-	if err != nil {
-		panic(err)
+	if len(cfg.OutputPaths) == 0 {
+		return nil, fmt.Errorf("no log output path")
+	}
+	writers := make([]zapcore.WriteSyncer, len(cfg.OutputPaths))
+	for i, path := range cfg.OutputPaths {
+		writers[i] = newWriteSyncer(path, cfg.Rotation)
 	}
 
-	return logger.WithOptions(zap.AddCaller(), zap.AddCallerSkip(1))
+	core := zapcore.NewCore(encoder, zapcore.NewMultiWriteSyncer(writers...), level)
+	if cfg.Redaction.Enabled {
+		var err error
+		if core, err = newRedactingCore(core, cfg.Redaction); err != nil {
+			return nil, err
+		}
+	}
+	return zap.New(core,
+		zap.ErrorOutput(zapcore.Lock(os.Stderr)),
+		zap.AddCaller(),
+		zap.AddCallerSkip(1),
+		zap.AddStacktrace(zapcore.ErrorLevel),
+	), nil
+}
+
+func newWriteSyncer(path string, rotation RotationConfig) zapcore.WriteSyncer {
+	switch path {
+	case "stdout":
+		return zapcore.Lock(os.Stdout)
+	case "stderr":
+		return zapcore.Lock(os.Stderr)
+	default:
+		return zapcore.AddSync(&lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    rotation.MaxSizeMB,
+			MaxAge:     rotation.MaxAgeDays,
+			MaxBackups: rotation.MaxBackups,
+			Compress:   rotation.Compress,
+		})
+	}
 }
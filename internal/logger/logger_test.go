@@ -0,0 +1,120 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewZapLogger(t *testing.T) {
+	testcases := []struct {
+		it     string
+		format string
+		assert func(t *testing.T, line string)
+	}{
+		{
+			it:     "writes json lines to the file output",
+			format: "json",
+			assert: func(t *testing.T, line string) {
+				var entry map[string]interface{}
+				require.NoError(t, json.Unmarshal([]byte(line), &entry))
+				assert.Equal(t, "hello", entry["msg"])
+				assert.Equal(t, "info", entry["level"])
+				assert.Contains(t, entry, "timestamp")
+			},
+		},
+		{
+			it:     "writes console lines to the file output",
+			format: "console",
+			assert: func(t *testing.T, line string) {
+				assert.Contains(t, line, "\tinfo\t")
+				assert.Contains(t, line, "\thello")
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "logs", "secretaria.log")
+			cfg := DefaultConfig()
+			cfg.Format = tc.format
+			cfg.OutputPaths = []string{path}
+
+			l, err := newZapLogger(cfg)
+			require.NoError(t, err)
+			l.Info("hello")
+			l.Debug("not enabled")
+			require.NoError(t, l.Sync())
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+			assert.Len(t, lines, 1)
+			tc.assert(t, lines[0])
+		})
+	}
+}
+
+func TestNewZapLogger_Error(t *testing.T) {
+	testcases := []struct {
+		it          string
+		modify      func(cfg *Config)
+		expectedErr string
+	}{
+		{
+			it:          "returns error when the level is unknown",
+			modify:      func(cfg *Config) { cfg.Level = "verbose" },
+			expectedErr: `invalid log level "verbose"`,
+		},
+		{
+			it:          "returns error when the format is unknown",
+			modify:      func(cfg *Config) { cfg.Format = "xml" },
+			expectedErr: `invalid log format "xml"`,
+		},
+		{
+			it:          "returns error when there is no output path",
+			modify:      func(cfg *Config) { cfg.OutputPaths = nil },
+			expectedErr: "no log output path",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tc.modify(&cfg)
+
+			l, err := newZapLogger(cfg)
+
+			assert.Nil(t, l)
+			assert.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestSetLevel(t *testing.T) {
+	defer SetLevel("info")
+
+	assert.NoError(t, SetLevel("debug"))
+	assert.Equal(t, "debug", GetLevel())
+	assert.Error(t, SetLevel("verbose"))
+	assert.Equal(t, "debug", GetLevel())
+}
+
+func TestLevelHandler(t *testing.T) {
+	defer SetLevel("info")
+	handler := LevelHandler()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"level":"warn"}`)))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"level":"warn"}`, w.Body.String())
+	assert.Equal(t, "warn", GetLevel())
+}
@@ -17,6 +17,11 @@ func (n *noopLogger) Warnf(format string, args ...interface{})  {}
 func (n *noopLogger) Errorf(format string, args ...interface{}) {}
 func (n *noopLogger) Fatalf(format string, args ...interface{}) {}
 
+func (n *noopLogger) Debugw(msg string, keysAndValues ...interface{}) {}
+func (n *noopLogger) Infow(msg string, keysAndValues ...interface{})  {}
+func (n *noopLogger) Warnw(msg string, keysAndValues ...interface{})  {}
+func (n *noopLogger) Errorw(msg string, keysAndValues ...interface{}) {}
+
 // SetLogger allows replacing the global logger (useful in tests).
 func SetLogger(l Logger) {
 	if l == nil {
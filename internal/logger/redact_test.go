@@ -0,0 +1,180 @@
+package logger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+const (
+	testLineUserID   = "Ub005e82b5b457efc7c18e1961a36ae4d"
+	testChannelToken = "channel-token-value"
+)
+
+func TestRedactingCore(t *testing.T) {
+	testcases := []struct {
+		it              string
+		log             func(l *zap.SugaredLogger)
+		expectedMessage string
+		expectedFields  map[string]interface{}
+	}{
+		{
+			it: "masks sensitive fields by key",
+			log: func(l *zap.SugaredLogger) {
+				l.Infow("withdraw", "amount", 200.5, "description", "rent", "Token", "abc", "command", "!p")
+			},
+			expectedMessage: "withdraw",
+			expectedFields: map[string]interface{}{
+				"amount":      redactedValue,
+				"description": redactedValue,
+				"Token":       redactedValue,
+				"command":     "!p",
+			},
+		},
+		{
+			it: "masks baht amounts and line user ids in messages",
+			log: func(l *zap.SugaredLogger) {
+				l.Errorf("invalid input from %s, balance ฿1,200.50", testLineUserID)
+			},
+			expectedMessage: "invalid input from [REDACTED], balance [REDACTED]",
+			expectedFields:  map[string]interface{}{},
+		},
+		{
+			it: "masks the messages sent to the bot",
+			log: func(l *zap.SugaredLogger) {
+				l.Errorw("invalid amount", "input", "500x", "message", "!t savings 500x", "error", "unexpected 'x' at position 4")
+			},
+			expectedMessage: "invalid amount",
+			expectedFields: map[string]interface{}{
+				"input":   redactedValue,
+				"message": redactedValue,
+				"error":   "unexpected 'x' at position 4",
+			},
+		},
+		{
+			it: "keeps timestamps, versions and ids in messages",
+			log: func(l *zap.SugaredLogger) {
+				l.Errorf("at 2025-03-15T10:00:00Z commit 3d17681a: x509: certificate signed by unknown authority on v1.2.3beta")
+			},
+			expectedMessage: "at 2025-03-15T10:00:00Z commit 3d17681a: x509: certificate signed by unknown authority on v1.2.3beta",
+			expectedFields:  map[string]interface{}{},
+		},
+		{
+			it: "masks configured secrets and bearer tokens in errors",
+			log: func(l *zap.SugaredLogger) {
+				l.Errorw("cannot reply message", "error", errors.New("invalid token "+testChannelToken), "header", "Bearer abc.def")
+			},
+			expectedMessage: "cannot reply message",
+			expectedFields: map[string]interface{}{
+				"error":  "invalid token [REDACTED]",
+				"header": "[REDACTED]",
+			},
+		},
+		{
+			it: "masks fields attached with With",
+			log: func(l *zap.SugaredLogger) {
+				l.With("line_user_id", testLineUserID, "request_id", "req-1").Info("hello")
+			},
+			expectedMessage: "hello",
+			expectedFields: map[string]interface{}{
+				"line_user_id": redactedValue,
+				"request_id":   "req-1",
+			},
+		},
+		{
+			it: "masks configured fields and patterns",
+			log: func(l *zap.SugaredLogger) {
+				l.Infow("account acc-1234 updated", "balance", 1000)
+			},
+			expectedMessage: "account [REDACTED] updated",
+			expectedFields:  map[string]interface{}{"balance": redactedValue},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			encoderCore, logs := observer.New(zapcore.DebugLevel)
+			core, err := newRedactingCore(encoderCore, RedactionConfig{
+				Enabled:  true,
+				Fields:   []string{"balance"},
+				Patterns: []RedactionPattern{{Name: "account", Regex: `acc-\d+`}},
+				Secrets:  []string{testChannelToken, ""},
+			})
+			require.NoError(t, err)
+
+			tc.log(zap.New(core).Sugar())
+
+			entries := logs.AllUntimed()
+			require.Len(t, entries, 1)
+			assert.Equal(t, tc.expectedMessage, entries[0].Message)
+			assert.Equal(t, tc.expectedFields, entries[0].ContextMap())
+		})
+	}
+}
+
+func TestNewRedactingCore_Error(t *testing.T) {
+	encoderCore, _ := observer.New(zapcore.DebugLevel)
+
+	core, err := newRedactingCore(encoderCore, RedactionConfig{
+		Patterns: []RedactionPattern{{Name: "broken", Regex: `(`}},
+	})
+
+	assert.Nil(t, core)
+	assert.ErrorContains(t, err, `invalid redaction pattern "broken"`)
+}
+
+func TestNewZapLogger_Redaction(t *testing.T) {
+	testcases := []struct {
+		it               string
+		enabled          bool
+		expectedContains []string
+		expectedExcludes []string
+	}{
+		{
+			it:               "never writes sensitive values to the output when redaction is enabled",
+			enabled:          true,
+			expectedContains: []string{redactedValue},
+			expectedExcludes: []string{testLineUserID, testChannelToken, "500sh", "youtube membership"},
+		},
+		{
+			it:               "writes raw values when redaction is disabled",
+			enabled:          false,
+			expectedContains: []string{testLineUserID, testChannelToken, "500sh", "youtube membership"},
+			expectedExcludes: []string{redactedValue},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "secretaria.log")
+			cfg := DefaultConfig()
+			cfg.OutputPaths = []string{path}
+			cfg.Redaction.Enabled = tc.enabled
+			cfg.Redaction.Secrets = []string{testChannelToken}
+
+			l, err := newZapLogger(cfg)
+			require.NoError(t, err)
+			sugar := l.Sugar().With("line_user_id", testLineUserID)
+			sugar.Errorw("invalid amount", "input", "500sh", "message", "!p debit1 500sh")
+			sugar.Infow("transaction", "description", "youtube membership", "token", testChannelToken)
+			sugar.Error(errors.New("linebot: token " + testChannelToken + " for " + testLineUserID))
+			require.NoError(t, l.Sync())
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			for _, s := range tc.expectedContains {
+				assert.Contains(t, string(content), s)
+			}
+			for _, s := range tc.expectedExcludes {
+				assert.NotContains(t, string(content), s)
+			}
+		})
+	}
+}
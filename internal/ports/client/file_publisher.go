@@ -0,0 +1,16 @@
+package client
+
+import (
+	"context"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+)
+
+// FilePublisher makes files downloadable through short-lived, single-use links.
+type FilePublisher interface {
+	Publish(context.Context, *domain.File) (*domain.PublishedFile, *errors.AppError)
+	// PublishImage makes an image shown in chat available through a
+	// short-lived link, which works until it expires.
+	PublishImage(context.Context, *domain.File) (*domain.PublishedFile, *errors.AppError)
+}
@@ -1,16 +1,21 @@
 package client
 
 import (
+	"context"
+
 	domain "github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
 )
 
 type FinanceServiceClient interface {
-	Withdraw(*domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError)
-	Deposit(*domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError)
-	Transfer(*domain.TransferRequest) (*domain.TransferResponse, *errors.AppError)
-	GetBalance() (*domain.GetBalanceResponse, *errors.AppError)
-	GetOverviewStatement(*domain.GetOverviewStatementRequest) (*domain.GetOverviewStatementResponse, *errors.AppError)
-	GetOverviewMonthlyStatement() (*domain.GetOverviewStatementResponse, *errors.AppError)
-	GetOverviewAnnualStatement() (*domain.GetOverviewStatementResponse, *errors.AppError)
+	Withdraw(context.Context, *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError)
+	Deposit(context.Context, *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError)
+	Transfer(context.Context, *domain.TransferRequest) (*domain.TransferResponse, *errors.AppError)
+	GetBalance(context.Context) (*domain.GetBalanceResponse, *errors.AppError)
+	GetOverviewStatement(context.Context, *domain.GetOverviewStatementRequest) (*domain.GetOverviewStatementResponse, *errors.AppError)
+	GetOverviewMonthlyStatement(context.Context) (*domain.GetOverviewStatementResponse, *errors.AppError)
+	GetOverviewAnnualStatement(context.Context) (*domain.GetOverviewStatementResponse, *errors.AppError)
+	GetDetailedStatement(context.Context, *domain.GetOverviewStatementRequest) (*domain.GetDetailedStatementResponse, *errors.AppError)
+	ListTransactions(context.Context, *domain.ListTransactionsRequest) (*domain.ListTransactionsResponse, *errors.AppError)
+	UpdateTransaction(context.Context, *domain.UpdateTransactionRequest) (*domain.UpdateTransactionResponse, *errors.AppError)
 }
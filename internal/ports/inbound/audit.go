@@ -0,0 +1,14 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+)
+
+// AuditService exports the audit log of the calls which changed the
+// finance service's data.
+type AuditService interface {
+	Export(context.Context) (*domain.AuditExport, *errors.AppError)
+}
@@ -1,10 +1,12 @@
 package inbound
 
 import (
+	"context"
+
 	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
 )
 
 type BotService interface {
-	HandleTextMessage(string) (*domain.TextMessageResponse, *errors.AppError)
+	HandleTextMessage(context.Context, domain.User, string) (*domain.TextMessageResponse, *errors.AppError)
 }
@@ -0,0 +1,20 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+)
+
+// FinanceService exposes the finance operations behind the chat commands
+// as typed calls, made on behalf of the given user.
+type FinanceService interface {
+	Withdraw(context.Context, domain.User, *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError)
+	Deposit(context.Context, domain.User, *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError)
+	Transfer(context.Context, domain.User, *domain.TransferRequest) (*domain.TransferResponse, *errors.AppError)
+	GetBalance(context.Context, domain.User) (*domain.GetBalanceResponse, *errors.AppError)
+	GetOverviewStatement(context.Context, domain.User, *domain.GetOverviewStatementRequest) (*domain.GetOverviewStatementResponse, *errors.AppError)
+	// GetJournal returns the transactions and transfers of the range as a ledger-cli journal.
+	GetJournal(context.Context, domain.User, *domain.GetOverviewStatementRequest) ([]byte, *errors.AppError)
+}
@@ -0,0 +1,14 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+)
+
+// ImportService parses bank CSV files into transactions. The preview is
+// kept until the user confirms it with the "import confirm" command.
+type ImportService interface {
+	Preview(context.Context, domain.User, *domain.File) (*domain.ImportPreview, *errors.AppError)
+}
@@ -0,0 +1,12 @@
+package storage
+
+// Journal keeps an append-only sequence of records, for the data which only
+// grows, e.g. the audit log, so that adding a record doesn't rewrite the
+// others. Records must not contain newlines.
+type Journal interface {
+	// Append adds the record at the end of the journal.
+	Append(record []byte) error
+	// Records returns the records, oldest first.
+	Records() ([][]byte, error)
+	Close() error
+}
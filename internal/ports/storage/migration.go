@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+)
+
+// schema records the version of the stored data.
+var schema = NewCollection[int]("schema")
+
+const schemaVersionKey = "version"
+
+// Migration upgrades the stored data from the previous schema version to
+// Version.
+type Migration struct {
+	Version     int
+	Description string
+	// Migrate may be nil when the version only introduces new collections.
+	Migrate func(tx Tx) error
+}
+
+// SchemaVersion returns the version of the stored data, which is 0 for a
+// new store.
+func SchemaVersion(ctx context.Context, store Store) (int, error) {
+	var version int
+	err := store.View(ctx, func(tx Tx) error {
+		var err error
+		version, _, err = schema.Get(tx, schemaVersionKey)
+		return err
+	})
+	return version, err
+}
+
+// Migrate runs the migrations newer than the stored data, ordered by
+// version, in a single transaction. It refuses data written by a newer
+// schema than the last migration, which this build doesn't understand.
+// It returns the resulting version.
+func Migrate(ctx context.Context, store Store, migrations []Migration) (int, error) {
+	var version int
+	err := store.Update(ctx, func(tx Tx) error {
+		var err error
+		if version, _, err = schema.Get(tx, schemaVersionKey); err != nil {
+			return err
+		}
+		latest := 0
+		for _, m := range migrations {
+			if m.Version <= latest {
+				return fmt.Errorf("migration %d must come after migration %d", m.Version, latest)
+			}
+			latest = m.Version
+		}
+		if version > latest {
+			return fmt.Errorf("the stored data has schema version %d, which is newer than the supported version %d", version, latest)
+		}
+		for _, m := range migrations {
+			if m.Version <= version {
+				continue
+			}
+			if m.Migrate != nil {
+				if err := m.Migrate(tx); err != nil {
+					return fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Description, err)
+				}
+			}
+			version = m.Version
+		}
+		return schema.Put(tx, schemaVersionKey, version)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return version, nil
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrClosed is returned by the transactions of a closed store.
+	ErrClosed = errors.New("storage: store is closed")
+	// ErrReadOnly is returned by the writes of a View transaction.
+	ErrReadOnly = errors.New("storage: read-only transaction")
+)
+
+// Store keeps the state of the bot, e.g. the account aliases, as values
+// grouped by collection and identified by key.
+//
+// The transactions are serializable: an Update sees no other write until
+// it returns.
+type Store interface {
+	// View runs fn in a read-only transaction.
+	View(ctx context.Context, fn func(tx Tx) error) error
+	// Update runs fn in a read-write transaction, which is committed when
+	// fn returns nil and rolled back otherwise.
+	Update(ctx context.Context, fn func(tx Tx) error) error
+	Close() error
+}
+
+// Tx reads and writes the values of a transaction. The returned values
+// must not be modified.
+type Tx interface {
+	Get(collection, key string) ([]byte, bool)
+	Put(collection, key string, value []byte) error
+	Delete(collection, key string) error
+	// Keys returns the keys of the collection, sorted.
+	Keys(collection string) []string
+}
+
+// Collection stores values of type T, encoded in JSON, in the named
+// collection.
+type Collection[T any] struct {
+	name string
+}
+
+// NewCollection returns the collection of the given name.
+func NewCollection[T any](name string) Collection[T] {
+	return Collection[T]{name: name}
+}
+
+// Name returns the name of the collection.
+func (c Collection[T]) Name() string {
+	return c.name
+}
+
+// Get returns the value of the key, and whether it exists.
+func (c Collection[T]) Get(tx Tx, key string) (T, bool, error) {
+	var value T
+	data, exist := tx.Get(c.name, key)
+	if !exist {
+		return value, false, nil
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return value, false, fmt.Errorf("failed to decode %s/%s: %w", c.name, key, err)
+	}
+	return value, true, nil
+}
+
+// Put sets the value of the key.
+func (c Collection[T]) Put(tx Tx, key string, value T) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s/%s: %w", c.name, key, err)
+	}
+	return tx.Put(c.name, key, data)
+}
+
+// Delete removes the key, if it exists.
+func (c Collection[T]) Delete(tx Tx, key string) error {
+	return tx.Delete(c.name, key)
+}
+
+// All returns the values of the collection by key.
+func (c Collection[T]) All(tx Tx) (map[string]T, error) {
+	keys := tx.Keys(c.name)
+	values := make(map[string]T, len(keys))
+	for _, key := range keys {
+		value, _, err := c.Get(tx, key)
+		if err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,142 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sMARCHz/secretaria-bot/internal/adapters/storage/memory"
+	"github.com/sMARCHz/secretaria-bot/internal/ports/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type goal struct {
+	Name   string
+	Target float64
+}
+
+var ctx = context.Background()
+
+func TestCollection(t *testing.T) {
+	goals := storage.NewCollection[[]goal]("goals")
+	store := memory.NewStore()
+
+	err := store.Update(ctx, func(tx storage.Tx) error {
+		require.NoError(t, goals.Put(tx, "owner", []goal{{Name: "japan", Target: 60000}}))
+		require.NoError(t, goals.Put(tx, "partner", []goal{{Name: "bike", Target: 20000}}))
+		return goals.Delete(tx, "partner")
+	})
+	require.NoError(t, err)
+
+	err = store.View(ctx, func(tx storage.Tx) error {
+		value, exist, err := goals.Get(tx, "owner")
+		assert.NoError(t, err)
+		assert.True(t, exist)
+		assert.Equal(t, []goal{{Name: "japan", Target: 60000}}, value)
+
+		_, exist, err = goals.Get(tx, "partner")
+		assert.NoError(t, err)
+		assert.False(t, exist)
+
+		all, err := goals.All(tx)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]goal{"owner": {{Name: "japan", Target: 60000}}}, all)
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestCollection_DecodeError(t *testing.T) {
+	store := memory.NewPersistentStore(memory.Data{"goals": {"owner": []byte("{")}}, nil)
+
+	err := store.View(ctx, func(tx storage.Tx) error {
+		_, _, err := storage.NewCollection[[]goal]("goals").Get(tx, "owner")
+		return err
+	})
+
+	assert.ErrorContains(t, err, "failed to decode goals/owner")
+}
+
+func TestMigrate(t *testing.T) {
+	var ran []int
+	migrations := []storage.Migration{
+		{Version: 1, Description: "initial"},
+		{Version: 2, Description: "rename", Migrate: func(tx storage.Tx) error {
+			ran = append(ran, 2)
+			return nil
+		}},
+		{Version: 3, Description: "split", Migrate: func(tx storage.Tx) error {
+			ran = append(ran, 3)
+			return tx.Put("goals", "owner", []byte("[]"))
+		}},
+	}
+	store := memory.NewStore()
+
+	version, err := storage.Migrate(ctx, store, migrations[:2])
+	assert.NoError(t, err)
+	assert.Equal(t, 2, version)
+
+	version, err = storage.Migrate(ctx, store, migrations)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, version)
+	assert.Equal(t, []int{2, 3}, ran)
+
+	version, err = storage.SchemaVersion(ctx, store)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, version)
+}
+
+func TestMigrate_Error(t *testing.T) {
+	testcases := []struct {
+		it          string
+		stored      int
+		migrations  []storage.Migration
+		expectedErr string
+	}{
+		{
+			it:     "rolls back when a migration fails",
+			stored: 1,
+			migrations: []storage.Migration{
+				{Version: 1},
+				{Version: 2, Migrate: func(tx storage.Tx) error { return tx.Put("goals", "owner", []byte("[]")) }},
+				{Version: 3, Description: "split", Migrate: func(storage.Tx) error { return errors.New("bad goal") }},
+			},
+			expectedErr: "migration 3 (split) failed: bad goal",
+		},
+		{
+			it:          "refuses data of a newer schema",
+			stored:      3,
+			migrations:  []storage.Migration{{Version: 1}, {Version: 2}},
+			expectedErr: "the stored data has schema version 3, which is newer than the supported version 2",
+		},
+		{
+			it:          "refuses unordered migrations",
+			migrations:  []storage.Migration{{Version: 2}, {Version: 1}},
+			expectedErr: "migration 1 must come after migration 2",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.it, func(t *testing.T) {
+			store := memory.NewStore()
+			var stored []storage.Migration
+			for v := 1; v <= tc.stored; v++ {
+				stored = append(stored, storage.Migration{Version: v})
+			}
+			_, err := storage.Migrate(ctx, store, stored)
+			require.NoError(t, err)
+
+			_, err = storage.Migrate(ctx, store, tc.migrations)
+
+			assert.EqualError(t, err, tc.expectedErr)
+			version, err := storage.SchemaVersion(ctx, store)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.stored, version)
+			assert.NoError(t, store.View(ctx, func(tx storage.Tx) error {
+				assert.Empty(t, tx.Keys("goals"))
+				return nil
+			}))
+		})
+	}
+}
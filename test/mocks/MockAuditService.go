@@ -0,0 +1,104 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockAuditService creates a new instance of MockAuditService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditService {
+	mock := &MockAuditService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAuditService is an autogenerated mock type for the AuditService type
+type MockAuditService struct {
+	mock.Mock
+}
+
+type MockAuditService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuditService) EXPECT() *MockAuditService_Expecter {
+	return &MockAuditService_Expecter{mock: &_m.Mock}
+}
+
+// Export provides a mock function for the type MockAuditService
+func (_mock *MockAuditService) Export(context1 context.Context) (*domain.AuditExport, *errors.AppError) {
+	ret := _mock.Called(context1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 *domain.AuditExport
+	var r1 *errors.AppError
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*domain.AuditExport, *errors.AppError)); ok {
+		return returnFunc(context1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *domain.AuditExport); ok {
+		r0 = returnFunc(context1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AuditExport)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) *errors.AppError); ok {
+		r1 = returnFunc(context1)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errors.AppError)
+		}
+	}
+	return r0, r1
+}
+
+// MockAuditService_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type MockAuditService_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - context1 context.Context
+func (_e *MockAuditService_Expecter) Export(context1 interface{}) *MockAuditService_Export_Call {
+	return &MockAuditService_Export_Call{Call: _e.mock.On("Export", context1)}
+}
+
+func (_c *MockAuditService_Export_Call) Run(run func(context1 context.Context)) *MockAuditService_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditService_Export_Call) Return(auditExport *domain.AuditExport, appError *errors.AppError) *MockAuditService_Export_Call {
+	_c.Call.Return(auditExport, appError)
+	return _c
+}
+
+func (_c *MockAuditService_Export_Call) RunAndReturn(run func(context1 context.Context) (*domain.AuditExport, *errors.AppError)) *MockAuditService_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
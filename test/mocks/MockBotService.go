@@ -5,6 +5,8 @@
 package mocks
 
 import (
+	"context"
+
 	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
 	mock "github.com/stretchr/testify/mock"
@@ -38,8 +40,8 @@ func (_m *MockBotService) EXPECT() *MockBotService_Expecter {
 }
 
 // HandleTextMessage provides a mock function for the type MockBotService
-func (_mock *MockBotService) HandleTextMessage(s string) (*domain.TextMessageResponse, *errors.AppError) {
-	ret := _mock.Called(s)
+func (_mock *MockBotService) HandleTextMessage(context1 context.Context, user domain.User, s string) (*domain.TextMessageResponse, *errors.AppError) {
+	ret := _mock.Called(context1, user, s)
 
 	if len(ret) == 0 {
 		panic("no return value specified for HandleTextMessage")
@@ -47,18 +49,18 @@ func (_mock *MockBotService) HandleTextMessage(s string) (*domain.TextMessageRes
 
 	var r0 *domain.TextMessageResponse
 	var r1 *errors.AppError
-	if returnFunc, ok := ret.Get(0).(func(string) (*domain.TextMessageResponse, *errors.AppError)); ok {
-		return returnFunc(s)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.User, string) (*domain.TextMessageResponse, *errors.AppError)); ok {
+		return returnFunc(context1, user, s)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) *domain.TextMessageResponse); ok {
-		r0 = returnFunc(s)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.User, string) *domain.TextMessageResponse); ok {
+		r0 = returnFunc(context1, user, s)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.TextMessageResponse)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) *errors.AppError); ok {
-		r1 = returnFunc(s)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.User, string) *errors.AppError); ok {
+		r1 = returnFunc(context1, user, s)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*errors.AppError)
@@ -73,19 +75,31 @@ type MockBotService_HandleTextMessage_Call struct {
 }
 
 // HandleTextMessage is a helper method to define mock.On call
+//   - context1 context.Context
+//   - user domain.User
 //   - s string
-func (_e *MockBotService_Expecter) HandleTextMessage(s interface{}) *MockBotService_HandleTextMessage_Call {
-	return &MockBotService_HandleTextMessage_Call{Call: _e.mock.On("HandleTextMessage", s)}
+func (_e *MockBotService_Expecter) HandleTextMessage(context1 interface{}, user interface{}, s interface{}) *MockBotService_HandleTextMessage_Call {
+	return &MockBotService_HandleTextMessage_Call{Call: _e.mock.On("HandleTextMessage", context1, user, s)}
 }
 
-func (_c *MockBotService_HandleTextMessage_Call) Run(run func(s string)) *MockBotService_HandleTextMessage_Call {
+func (_c *MockBotService_HandleTextMessage_Call) Run(run func(context1 context.Context, user domain.User, s string)) *MockBotService_HandleTextMessage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.User
+		if args[1] != nil {
+			arg1 = args[1].(domain.User)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -96,7 +110,7 @@ func (_c *MockBotService_HandleTextMessage_Call) Return(textMessageResponse *dom
 	return _c
 }
 
-func (_c *MockBotService_HandleTextMessage_Call) RunAndReturn(run func(s string) (*domain.TextMessageResponse, *errors.AppError)) *MockBotService_HandleTextMessage_Call {
+func (_c *MockBotService_HandleTextMessage_Call) RunAndReturn(run func(context1 context.Context, user domain.User, s string) (*domain.TextMessageResponse, *errors.AppError)) *MockBotService_HandleTextMessage_Call {
 	_c.Call.Return(run)
 	return _c
 }
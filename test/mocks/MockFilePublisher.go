@@ -0,0 +1,180 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockFilePublisher creates a new instance of MockFilePublisher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFilePublisher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFilePublisher {
+	mock := &MockFilePublisher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockFilePublisher is an autogenerated mock type for the FilePublisher type
+type MockFilePublisher struct {
+	mock.Mock
+}
+
+type MockFilePublisher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFilePublisher) EXPECT() *MockFilePublisher_Expecter {
+	return &MockFilePublisher_Expecter{mock: &_m.Mock}
+}
+
+// Publish provides a mock function for the type MockFilePublisher
+func (_mock *MockFilePublisher) Publish(context1 context.Context, file *domain.File) (*domain.PublishedFile, *errors.AppError) {
+	ret := _mock.Called(context1, file)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 *domain.PublishedFile
+	var r1 *errors.AppError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.File) (*domain.PublishedFile, *errors.AppError)); ok {
+		return returnFunc(context1, file)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.File) *domain.PublishedFile); ok {
+		r0 = returnFunc(context1, file)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PublishedFile)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.File) *errors.AppError); ok {
+		r1 = returnFunc(context1, file)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errors.AppError)
+		}
+	}
+	return r0, r1
+}
+
+// MockFilePublisher_Publish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Publish'
+type MockFilePublisher_Publish_Call struct {
+	*mock.Call
+}
+
+// Publish is a helper method to define mock.On call
+//   - context1 context.Context
+//   - file *domain.File
+func (_e *MockFilePublisher_Expecter) Publish(context1 interface{}, file interface{}) *MockFilePublisher_Publish_Call {
+	return &MockFilePublisher_Publish_Call{Call: _e.mock.On("Publish", context1, file)}
+}
+
+func (_c *MockFilePublisher_Publish_Call) Run(run func(context1 context.Context, file *domain.File)) *MockFilePublisher_Publish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.File
+		if args[1] != nil {
+			arg1 = args[1].(*domain.File)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFilePublisher_Publish_Call) Return(publishedFile *domain.PublishedFile, appError *errors.AppError) *MockFilePublisher_Publish_Call {
+	_c.Call.Return(publishedFile, appError)
+	return _c
+}
+
+func (_c *MockFilePublisher_Publish_Call) RunAndReturn(run func(context1 context.Context, file *domain.File) (*domain.PublishedFile, *errors.AppError)) *MockFilePublisher_Publish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PublishImage provides a mock function for the type MockFilePublisher
+func (_mock *MockFilePublisher) PublishImage(context1 context.Context, file *domain.File) (*domain.PublishedFile, *errors.AppError) {
+	ret := _mock.Called(context1, file)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishImage")
+	}
+
+	var r0 *domain.PublishedFile
+	var r1 *errors.AppError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.File) (*domain.PublishedFile, *errors.AppError)); ok {
+		return returnFunc(context1, file)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.File) *domain.PublishedFile); ok {
+		r0 = returnFunc(context1, file)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PublishedFile)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.File) *errors.AppError); ok {
+		r1 = returnFunc(context1, file)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errors.AppError)
+		}
+	}
+	return r0, r1
+}
+
+// MockFilePublisher_PublishImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishImage'
+type MockFilePublisher_PublishImage_Call struct {
+	*mock.Call
+}
+
+// PublishImage is a helper method to define mock.On call
+//   - context1 context.Context
+//   - file *domain.File
+func (_e *MockFilePublisher_Expecter) PublishImage(context1 interface{}, file interface{}) *MockFilePublisher_PublishImage_Call {
+	return &MockFilePublisher_PublishImage_Call{Call: _e.mock.On("PublishImage", context1, file)}
+}
+
+func (_c *MockFilePublisher_PublishImage_Call) Run(run func(context1 context.Context, file *domain.File)) *MockFilePublisher_PublishImage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.File
+		if args[1] != nil {
+			arg1 = args[1].(*domain.File)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFilePublisher_PublishImage_Call) Return(publishedFile *domain.PublishedFile, appError *errors.AppError) *MockFilePublisher_PublishImage_Call {
+	_c.Call.Return(publishedFile, appError)
+	return _c
+}
+
+func (_c *MockFilePublisher_PublishImage_Call) RunAndReturn(run func(context1 context.Context, file *domain.File) (*domain.PublishedFile, *errors.AppError)) *MockFilePublisher_PublishImage_Call {
+	_c.Call.Return(run)
+	return _c
+}
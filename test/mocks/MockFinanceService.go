@@ -0,0 +1,490 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockFinanceService creates a new instance of MockFinanceService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFinanceService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFinanceService {
+	mock := &MockFinanceService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockFinanceService is an autogenerated mock type for the FinanceService type
+type MockFinanceService struct {
+	mock.Mock
+}
+
+type MockFinanceService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFinanceService) EXPECT() *MockFinanceService_Expecter {
+	return &MockFinanceService_Expecter{mock: &_m.Mock}
+}
+
+// Deposit provides a mock function for the type MockFinanceService
+func (_mock *MockFinanceService) Deposit(context1 context.Context, user domain.User, transactionRequest *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError) {
+	ret := _mock.Called(context1, user, transactionRequest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Deposit")
+	}
+
+	var r0 *domain.TransactionResponse
+	var r1 *errors.AppError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.User, *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError)); ok {
+		return returnFunc(context1, user, transactionRequest)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.User, *domain.TransactionRequest) *domain.TransactionResponse); ok {
+		r0 = returnFunc(context1, user, transactionRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TransactionResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.User, *domain.TransactionRequest) *errors.AppError); ok {
+		r1 = returnFunc(context1, user, transactionRequest)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errors.AppError)
+		}
+	}
+	return r0, r1
+}
+
+// MockFinanceService_Deposit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Deposit'
+type MockFinanceService_Deposit_Call struct {
+	*mock.Call
+}
+
+// Deposit is a helper method to define mock.On call
+//   - context1 context.Context
+//   - user domain.User
+//   - transactionRequest *domain.TransactionRequest
+func (_e *MockFinanceService_Expecter) Deposit(context1 interface{}, user interface{}, transactionRequest interface{}) *MockFinanceService_Deposit_Call {
+	return &MockFinanceService_Deposit_Call{Call: _e.mock.On("Deposit", context1, user, transactionRequest)}
+}
+
+func (_c *MockFinanceService_Deposit_Call) Run(run func(context1 context.Context, user domain.User, transactionRequest *domain.TransactionRequest)) *MockFinanceService_Deposit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.User
+		if args[1] != nil {
+			arg1 = args[1].(domain.User)
+		}
+		var arg2 *domain.TransactionRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.TransactionRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFinanceService_Deposit_Call) Return(transactionResponse *domain.TransactionResponse, appError *errors.AppError) *MockFinanceService_Deposit_Call {
+	_c.Call.Return(transactionResponse, appError)
+	return _c
+}
+
+func (_c *MockFinanceService_Deposit_Call) RunAndReturn(run func(context1 context.Context, user domain.User, transactionRequest *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError)) *MockFinanceService_Deposit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBalance provides a mock function for the type MockFinanceService
+func (_mock *MockFinanceService) GetBalance(context1 context.Context, user domain.User) (*domain.GetBalanceResponse, *errors.AppError) {
+	ret := _mock.Called(context1, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalance")
+	}
+
+	var r0 *domain.GetBalanceResponse
+	var r1 *errors.AppError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.User) (*domain.GetBalanceResponse, *errors.AppError)); ok {
+		return returnFunc(context1, user)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.User) *domain.GetBalanceResponse); ok {
+		r0 = returnFunc(context1, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.GetBalanceResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.User) *errors.AppError); ok {
+		r1 = returnFunc(context1, user)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errors.AppError)
+		}
+	}
+	return r0, r1
+}
+
+// MockFinanceService_GetBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalance'
+type MockFinanceService_GetBalance_Call struct {
+	*mock.Call
+}
+
+// GetBalance is a helper method to define mock.On call
+//   - context1 context.Context
+//   - user domain.User
+func (_e *MockFinanceService_Expecter) GetBalance(context1 interface{}, user interface{}) *MockFinanceService_GetBalance_Call {
+	return &MockFinanceService_GetBalance_Call{Call: _e.mock.On("GetBalance", context1, user)}
+}
+
+func (_c *MockFinanceService_GetBalance_Call) Run(run func(context1 context.Context, user domain.User)) *MockFinanceService_GetBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.User
+		if args[1] != nil {
+			arg1 = args[1].(domain.User)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFinanceService_GetBalance_Call) Return(getBalanceResponse *domain.GetBalanceResponse, appError *errors.AppError) *MockFinanceService_GetBalance_Call {
+	_c.Call.Return(getBalanceResponse, appError)
+	return _c
+}
+
+func (_c *MockFinanceService_GetBalance_Call) RunAndReturn(run func(context1 context.Context, user domain.User) (*domain.GetBalanceResponse, *errors.AppError)) *MockFinanceService_GetBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetJournal provides a mock function for the type MockFinanceService
+func (_mock *MockFinanceService) GetJournal(context1 context.Context, user domain.User, getOverviewStatementRequest *domain.GetOverviewStatementRequest) ([]byte, *errors.AppError) {
+	ret := _mock.Called(context1, user, getOverviewStatementRequest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJournal")
+	}
+
+	var r0 []byte
+	var r1 *errors.AppError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.User, *domain.GetOverviewStatementRequest) ([]byte, *errors.AppError)); ok {
+		return returnFunc(context1, user, getOverviewStatementRequest)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.User, *domain.GetOverviewStatementRequest) []byte); ok {
+		r0 = returnFunc(context1, user, getOverviewStatementRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.User, *domain.GetOverviewStatementRequest) *errors.AppError); ok {
+		r1 = returnFunc(context1, user, getOverviewStatementRequest)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errors.AppError)
+		}
+	}
+	return r0, r1
+}
+
+// MockFinanceService_GetJournal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJournal'
+type MockFinanceService_GetJournal_Call struct {
+	*mock.Call
+}
+
+// GetJournal is a helper method to define mock.On call
+//   - context1 context.Context
+//   - user domain.User
+//   - getOverviewStatementRequest *domain.GetOverviewStatementRequest
+func (_e *MockFinanceService_Expecter) GetJournal(context1 interface{}, user interface{}, getOverviewStatementRequest interface{}) *MockFinanceService_GetJournal_Call {
+	return &MockFinanceService_GetJournal_Call{Call: _e.mock.On("GetJournal", context1, user, getOverviewStatementRequest)}
+}
+
+func (_c *MockFinanceService_GetJournal_Call) Run(run func(context1 context.Context, user domain.User, getOverviewStatementRequest *domain.GetOverviewStatementRequest)) *MockFinanceService_GetJournal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.User
+		if args[1] != nil {
+			arg1 = args[1].(domain.User)
+		}
+		var arg2 *domain.GetOverviewStatementRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.GetOverviewStatementRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFinanceService_GetJournal_Call) Return(bytes []byte, appError *errors.AppError) *MockFinanceService_GetJournal_Call {
+	_c.Call.Return(bytes, appError)
+	return _c
+}
+
+func (_c *MockFinanceService_GetJournal_Call) RunAndReturn(run func(context1 context.Context, user domain.User, getOverviewStatementRequest *domain.GetOverviewStatementRequest) ([]byte, *errors.AppError)) *MockFinanceService_GetJournal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOverviewStatement provides a mock function for the type MockFinanceService
+func (_mock *MockFinanceService) GetOverviewStatement(context1 context.Context, user domain.User, getOverviewStatementRequest *domain.GetOverviewStatementRequest) (*domain.GetOverviewStatementResponse, *errors.AppError) {
+	ret := _mock.Called(context1, user, getOverviewStatementRequest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOverviewStatement")
+	}
+
+	var r0 *domain.GetOverviewStatementResponse
+	var r1 *errors.AppError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.User, *domain.GetOverviewStatementRequest) (*domain.GetOverviewStatementResponse, *errors.AppError)); ok {
+		return returnFunc(context1, user, getOverviewStatementRequest)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.User, *domain.GetOverviewStatementRequest) *domain.GetOverviewStatementResponse); ok {
+		r0 = returnFunc(context1, user, getOverviewStatementRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.GetOverviewStatementResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.User, *domain.GetOverviewStatementRequest) *errors.AppError); ok {
+		r1 = returnFunc(context1, user, getOverviewStatementRequest)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errors.AppError)
+		}
+	}
+	return r0, r1
+}
+
+// MockFinanceService_GetOverviewStatement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOverviewStatement'
+type MockFinanceService_GetOverviewStatement_Call struct {
+	*mock.Call
+}
+
+// GetOverviewStatement is a helper method to define mock.On call
+//   - context1 context.Context
+//   - user domain.User
+//   - getOverviewStatementRequest *domain.GetOverviewStatementRequest
+func (_e *MockFinanceService_Expecter) GetOverviewStatement(context1 interface{}, user interface{}, getOverviewStatementRequest interface{}) *MockFinanceService_GetOverviewStatement_Call {
+	return &MockFinanceService_GetOverviewStatement_Call{Call: _e.mock.On("GetOverviewStatement", context1, user, getOverviewStatementRequest)}
+}
+
+func (_c *MockFinanceService_GetOverviewStatement_Call) Run(run func(context1 context.Context, user domain.User, getOverviewStatementRequest *domain.GetOverviewStatementRequest)) *MockFinanceService_GetOverviewStatement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.User
+		if args[1] != nil {
+			arg1 = args[1].(domain.User)
+		}
+		var arg2 *domain.GetOverviewStatementRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.GetOverviewStatementRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFinanceService_GetOverviewStatement_Call) Return(getOverviewStatementResponse *domain.GetOverviewStatementResponse, appError *errors.AppError) *MockFinanceService_GetOverviewStatement_Call {
+	_c.Call.Return(getOverviewStatementResponse, appError)
+	return _c
+}
+
+func (_c *MockFinanceService_GetOverviewStatement_Call) RunAndReturn(run func(context1 context.Context, user domain.User, getOverviewStatementRequest *domain.GetOverviewStatementRequest) (*domain.GetOverviewStatementResponse, *errors.AppError)) *MockFinanceService_GetOverviewStatement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Transfer provides a mock function for the type MockFinanceService
+func (_mock *MockFinanceService) Transfer(context1 context.Context, user domain.User, transferRequest *domain.TransferRequest) (*domain.TransferResponse, *errors.AppError) {
+	ret := _mock.Called(context1, user, transferRequest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transfer")
+	}
+
+	var r0 *domain.TransferResponse
+	var r1 *errors.AppError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.User, *domain.TransferRequest) (*domain.TransferResponse, *errors.AppError)); ok {
+		return returnFunc(context1, user, transferRequest)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.User, *domain.TransferRequest) *domain.TransferResponse); ok {
+		r0 = returnFunc(context1, user, transferRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TransferResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.User, *domain.TransferRequest) *errors.AppError); ok {
+		r1 = returnFunc(context1, user, transferRequest)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errors.AppError)
+		}
+	}
+	return r0, r1
+}
+
+// MockFinanceService_Transfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Transfer'
+type MockFinanceService_Transfer_Call struct {
+	*mock.Call
+}
+
+// Transfer is a helper method to define mock.On call
+//   - context1 context.Context
+//   - user domain.User
+//   - transferRequest *domain.TransferRequest
+func (_e *MockFinanceService_Expecter) Transfer(context1 interface{}, user interface{}, transferRequest interface{}) *MockFinanceService_Transfer_Call {
+	return &MockFinanceService_Transfer_Call{Call: _e.mock.On("Transfer", context1, user, transferRequest)}
+}
+
+func (_c *MockFinanceService_Transfer_Call) Run(run func(context1 context.Context, user domain.User, transferRequest *domain.TransferRequest)) *MockFinanceService_Transfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.User
+		if args[1] != nil {
+			arg1 = args[1].(domain.User)
+		}
+		var arg2 *domain.TransferRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.TransferRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFinanceService_Transfer_Call) Return(transferResponse *domain.TransferResponse, appError *errors.AppError) *MockFinanceService_Transfer_Call {
+	_c.Call.Return(transferResponse, appError)
+	return _c
+}
+
+func (_c *MockFinanceService_Transfer_Call) RunAndReturn(run func(context1 context.Context, user domain.User, transferRequest *domain.TransferRequest) (*domain.TransferResponse, *errors.AppError)) *MockFinanceService_Transfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Withdraw provides a mock function for the type MockFinanceService
+func (_mock *MockFinanceService) Withdraw(context1 context.Context, user domain.User, transactionRequest *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError) {
+	ret := _mock.Called(context1, user, transactionRequest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Withdraw")
+	}
+
+	var r0 *domain.TransactionResponse
+	var r1 *errors.AppError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.User, *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError)); ok {
+		return returnFunc(context1, user, transactionRequest)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.User, *domain.TransactionRequest) *domain.TransactionResponse); ok {
+		r0 = returnFunc(context1, user, transactionRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TransactionResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.User, *domain.TransactionRequest) *errors.AppError); ok {
+		r1 = returnFunc(context1, user, transactionRequest)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errors.AppError)
+		}
+	}
+	return r0, r1
+}
+
+// MockFinanceService_Withdraw_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Withdraw'
+type MockFinanceService_Withdraw_Call struct {
+	*mock.Call
+}
+
+// Withdraw is a helper method to define mock.On call
+//   - context1 context.Context
+//   - user domain.User
+//   - transactionRequest *domain.TransactionRequest
+func (_e *MockFinanceService_Expecter) Withdraw(context1 interface{}, user interface{}, transactionRequest interface{}) *MockFinanceService_Withdraw_Call {
+	return &MockFinanceService_Withdraw_Call{Call: _e.mock.On("Withdraw", context1, user, transactionRequest)}
+}
+
+func (_c *MockFinanceService_Withdraw_Call) Run(run func(context1 context.Context, user domain.User, transactionRequest *domain.TransactionRequest)) *MockFinanceService_Withdraw_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.User
+		if args[1] != nil {
+			arg1 = args[1].(domain.User)
+		}
+		var arg2 *domain.TransactionRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.TransactionRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFinanceService_Withdraw_Call) Return(transactionResponse *domain.TransactionResponse, appError *errors.AppError) *MockFinanceService_Withdraw_Call {
+	_c.Call.Return(transactionResponse, appError)
+	return _c
+}
+
+func (_c *MockFinanceService_Withdraw_Call) RunAndReturn(run func(context1 context.Context, user domain.User, transactionRequest *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError)) *MockFinanceService_Withdraw_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -5,6 +5,8 @@
 package mocks
 
 import (
+	"context"
+
 	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
 	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
 	mock "github.com/stretchr/testify/mock"
@@ -38,8 +40,8 @@ func (_m *MockFinanceServiceClient) EXPECT() *MockFinanceServiceClient_Expecter
 }
 
 // Deposit provides a mock function for the type MockFinanceServiceClient
-func (_mock *MockFinanceServiceClient) Deposit(transactionRequest *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError) {
-	ret := _mock.Called(transactionRequest)
+func (_mock *MockFinanceServiceClient) Deposit(context1 context.Context, transactionRequest *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError) {
+	ret := _mock.Called(context1, transactionRequest)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Deposit")
@@ -47,18 +49,18 @@ func (_mock *MockFinanceServiceClient) Deposit(transactionRequest *domain.Transa
 
 	var r0 *domain.TransactionResponse
 	var r1 *errors.AppError
-	if returnFunc, ok := ret.Get(0).(func(*domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError)); ok {
-		return returnFunc(transactionRequest)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError)); ok {
+		return returnFunc(context1, transactionRequest)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*domain.TransactionRequest) *domain.TransactionResponse); ok {
-		r0 = returnFunc(transactionRequest)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.TransactionRequest) *domain.TransactionResponse); ok {
+		r0 = returnFunc(context1, transactionRequest)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.TransactionResponse)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*domain.TransactionRequest) *errors.AppError); ok {
-		r1 = returnFunc(transactionRequest)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.TransactionRequest) *errors.AppError); ok {
+		r1 = returnFunc(context1, transactionRequest)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*errors.AppError)
@@ -73,19 +75,25 @@ type MockFinanceServiceClient_Deposit_Call struct {
 }
 
 // Deposit is a helper method to define mock.On call
+//   - context1 context.Context
 //   - transactionRequest *domain.TransactionRequest
-func (_e *MockFinanceServiceClient_Expecter) Deposit(transactionRequest interface{}) *MockFinanceServiceClient_Deposit_Call {
-	return &MockFinanceServiceClient_Deposit_Call{Call: _e.mock.On("Deposit", transactionRequest)}
+func (_e *MockFinanceServiceClient_Expecter) Deposit(context1 interface{}, transactionRequest interface{}) *MockFinanceServiceClient_Deposit_Call {
+	return &MockFinanceServiceClient_Deposit_Call{Call: _e.mock.On("Deposit", context1, transactionRequest)}
 }
 
-func (_c *MockFinanceServiceClient_Deposit_Call) Run(run func(transactionRequest *domain.TransactionRequest)) *MockFinanceServiceClient_Deposit_Call {
+func (_c *MockFinanceServiceClient_Deposit_Call) Run(run func(context1 context.Context, transactionRequest *domain.TransactionRequest)) *MockFinanceServiceClient_Deposit_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *domain.TransactionRequest
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*domain.TransactionRequest)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.TransactionRequest
+		if args[1] != nil {
+			arg1 = args[1].(*domain.TransactionRequest)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -96,14 +104,14 @@ func (_c *MockFinanceServiceClient_Deposit_Call) Return(transactionResponse *dom
 	return _c
 }
 
-func (_c *MockFinanceServiceClient_Deposit_Call) RunAndReturn(run func(transactionRequest *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError)) *MockFinanceServiceClient_Deposit_Call {
+func (_c *MockFinanceServiceClient_Deposit_Call) RunAndReturn(run func(context1 context.Context, transactionRequest *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError)) *MockFinanceServiceClient_Deposit_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetBalance provides a mock function for the type MockFinanceServiceClient
-func (_mock *MockFinanceServiceClient) GetBalance() (*domain.GetBalanceResponse, *errors.AppError) {
-	ret := _mock.Called()
+func (_mock *MockFinanceServiceClient) GetBalance(context1 context.Context) (*domain.GetBalanceResponse, *errors.AppError) {
+	ret := _mock.Called(context1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetBalance")
@@ -111,18 +119,18 @@ func (_mock *MockFinanceServiceClient) GetBalance() (*domain.GetBalanceResponse,
 
 	var r0 *domain.GetBalanceResponse
 	var r1 *errors.AppError
-	if returnFunc, ok := ret.Get(0).(func() (*domain.GetBalanceResponse, *errors.AppError)); ok {
-		return returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*domain.GetBalanceResponse, *errors.AppError)); ok {
+		return returnFunc(context1)
 	}
-	if returnFunc, ok := ret.Get(0).(func() *domain.GetBalanceResponse); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *domain.GetBalanceResponse); ok {
+		r0 = returnFunc(context1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.GetBalanceResponse)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func() *errors.AppError); ok {
-		r1 = returnFunc()
+	if returnFunc, ok := ret.Get(1).(func(context.Context) *errors.AppError); ok {
+		r1 = returnFunc(context1)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*errors.AppError)
@@ -137,13 +145,20 @@ type MockFinanceServiceClient_GetBalance_Call struct {
 }
 
 // GetBalance is a helper method to define mock.On call
-func (_e *MockFinanceServiceClient_Expecter) GetBalance() *MockFinanceServiceClient_GetBalance_Call {
-	return &MockFinanceServiceClient_GetBalance_Call{Call: _e.mock.On("GetBalance")}
+//   - context1 context.Context
+func (_e *MockFinanceServiceClient_Expecter) GetBalance(context1 interface{}) *MockFinanceServiceClient_GetBalance_Call {
+	return &MockFinanceServiceClient_GetBalance_Call{Call: _e.mock.On("GetBalance", context1)}
 }
 
-func (_c *MockFinanceServiceClient_GetBalance_Call) Run(run func()) *MockFinanceServiceClient_GetBalance_Call {
+func (_c *MockFinanceServiceClient_GetBalance_Call) Run(run func(context1 context.Context)) *MockFinanceServiceClient_GetBalance_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
 	})
 	return _c
 }
@@ -153,14 +168,84 @@ func (_c *MockFinanceServiceClient_GetBalance_Call) Return(getBalanceResponse *d
 	return _c
 }
 
-func (_c *MockFinanceServiceClient_GetBalance_Call) RunAndReturn(run func() (*domain.GetBalanceResponse, *errors.AppError)) *MockFinanceServiceClient_GetBalance_Call {
+func (_c *MockFinanceServiceClient_GetBalance_Call) RunAndReturn(run func(context1 context.Context) (*domain.GetBalanceResponse, *errors.AppError)) *MockFinanceServiceClient_GetBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDetailedStatement provides a mock function for the type MockFinanceServiceClient
+func (_mock *MockFinanceServiceClient) GetDetailedStatement(context1 context.Context, getOverviewStatementRequest *domain.GetOverviewStatementRequest) (*domain.GetDetailedStatementResponse, *errors.AppError) {
+	ret := _mock.Called(context1, getOverviewStatementRequest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDetailedStatement")
+	}
+
+	var r0 *domain.GetDetailedStatementResponse
+	var r1 *errors.AppError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.GetOverviewStatementRequest) (*domain.GetDetailedStatementResponse, *errors.AppError)); ok {
+		return returnFunc(context1, getOverviewStatementRequest)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.GetOverviewStatementRequest) *domain.GetDetailedStatementResponse); ok {
+		r0 = returnFunc(context1, getOverviewStatementRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.GetDetailedStatementResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.GetOverviewStatementRequest) *errors.AppError); ok {
+		r1 = returnFunc(context1, getOverviewStatementRequest)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errors.AppError)
+		}
+	}
+	return r0, r1
+}
+
+// MockFinanceServiceClient_GetDetailedStatement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDetailedStatement'
+type MockFinanceServiceClient_GetDetailedStatement_Call struct {
+	*mock.Call
+}
+
+// GetDetailedStatement is a helper method to define mock.On call
+//   - context1 context.Context
+//   - getOverviewStatementRequest *domain.GetOverviewStatementRequest
+func (_e *MockFinanceServiceClient_Expecter) GetDetailedStatement(context1 interface{}, getOverviewStatementRequest interface{}) *MockFinanceServiceClient_GetDetailedStatement_Call {
+	return &MockFinanceServiceClient_GetDetailedStatement_Call{Call: _e.mock.On("GetDetailedStatement", context1, getOverviewStatementRequest)}
+}
+
+func (_c *MockFinanceServiceClient_GetDetailedStatement_Call) Run(run func(context1 context.Context, getOverviewStatementRequest *domain.GetOverviewStatementRequest)) *MockFinanceServiceClient_GetDetailedStatement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.GetOverviewStatementRequest
+		if args[1] != nil {
+			arg1 = args[1].(*domain.GetOverviewStatementRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFinanceServiceClient_GetDetailedStatement_Call) Return(getDetailedStatementResponse *domain.GetDetailedStatementResponse, appError *errors.AppError) *MockFinanceServiceClient_GetDetailedStatement_Call {
+	_c.Call.Return(getDetailedStatementResponse, appError)
+	return _c
+}
+
+func (_c *MockFinanceServiceClient_GetDetailedStatement_Call) RunAndReturn(run func(context1 context.Context, getOverviewStatementRequest *domain.GetOverviewStatementRequest) (*domain.GetDetailedStatementResponse, *errors.AppError)) *MockFinanceServiceClient_GetDetailedStatement_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetOverviewAnnualStatement provides a mock function for the type MockFinanceServiceClient
-func (_mock *MockFinanceServiceClient) GetOverviewAnnualStatement() (*domain.GetOverviewStatementResponse, *errors.AppError) {
-	ret := _mock.Called()
+func (_mock *MockFinanceServiceClient) GetOverviewAnnualStatement(context1 context.Context) (*domain.GetOverviewStatementResponse, *errors.AppError) {
+	ret := _mock.Called(context1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetOverviewAnnualStatement")
@@ -168,18 +253,18 @@ func (_mock *MockFinanceServiceClient) GetOverviewAnnualStatement() (*domain.Get
 
 	var r0 *domain.GetOverviewStatementResponse
 	var r1 *errors.AppError
-	if returnFunc, ok := ret.Get(0).(func() (*domain.GetOverviewStatementResponse, *errors.AppError)); ok {
-		return returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*domain.GetOverviewStatementResponse, *errors.AppError)); ok {
+		return returnFunc(context1)
 	}
-	if returnFunc, ok := ret.Get(0).(func() *domain.GetOverviewStatementResponse); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *domain.GetOverviewStatementResponse); ok {
+		r0 = returnFunc(context1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.GetOverviewStatementResponse)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func() *errors.AppError); ok {
-		r1 = returnFunc()
+	if returnFunc, ok := ret.Get(1).(func(context.Context) *errors.AppError); ok {
+		r1 = returnFunc(context1)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*errors.AppError)
@@ -194,13 +279,20 @@ type MockFinanceServiceClient_GetOverviewAnnualStatement_Call struct {
 }
 
 // GetOverviewAnnualStatement is a helper method to define mock.On call
-func (_e *MockFinanceServiceClient_Expecter) GetOverviewAnnualStatement() *MockFinanceServiceClient_GetOverviewAnnualStatement_Call {
-	return &MockFinanceServiceClient_GetOverviewAnnualStatement_Call{Call: _e.mock.On("GetOverviewAnnualStatement")}
+//   - context1 context.Context
+func (_e *MockFinanceServiceClient_Expecter) GetOverviewAnnualStatement(context1 interface{}) *MockFinanceServiceClient_GetOverviewAnnualStatement_Call {
+	return &MockFinanceServiceClient_GetOverviewAnnualStatement_Call{Call: _e.mock.On("GetOverviewAnnualStatement", context1)}
 }
 
-func (_c *MockFinanceServiceClient_GetOverviewAnnualStatement_Call) Run(run func()) *MockFinanceServiceClient_GetOverviewAnnualStatement_Call {
+func (_c *MockFinanceServiceClient_GetOverviewAnnualStatement_Call) Run(run func(context1 context.Context)) *MockFinanceServiceClient_GetOverviewAnnualStatement_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
 	})
 	return _c
 }
@@ -210,14 +302,14 @@ func (_c *MockFinanceServiceClient_GetOverviewAnnualStatement_Call) Return(getOv
 	return _c
 }
 
-func (_c *MockFinanceServiceClient_GetOverviewAnnualStatement_Call) RunAndReturn(run func() (*domain.GetOverviewStatementResponse, *errors.AppError)) *MockFinanceServiceClient_GetOverviewAnnualStatement_Call {
+func (_c *MockFinanceServiceClient_GetOverviewAnnualStatement_Call) RunAndReturn(run func(context1 context.Context) (*domain.GetOverviewStatementResponse, *errors.AppError)) *MockFinanceServiceClient_GetOverviewAnnualStatement_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetOverviewMonthlyStatement provides a mock function for the type MockFinanceServiceClient
-func (_mock *MockFinanceServiceClient) GetOverviewMonthlyStatement() (*domain.GetOverviewStatementResponse, *errors.AppError) {
-	ret := _mock.Called()
+func (_mock *MockFinanceServiceClient) GetOverviewMonthlyStatement(context1 context.Context) (*domain.GetOverviewStatementResponse, *errors.AppError) {
+	ret := _mock.Called(context1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetOverviewMonthlyStatement")
@@ -225,18 +317,18 @@ func (_mock *MockFinanceServiceClient) GetOverviewMonthlyStatement() (*domain.Ge
 
 	var r0 *domain.GetOverviewStatementResponse
 	var r1 *errors.AppError
-	if returnFunc, ok := ret.Get(0).(func() (*domain.GetOverviewStatementResponse, *errors.AppError)); ok {
-		return returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*domain.GetOverviewStatementResponse, *errors.AppError)); ok {
+		return returnFunc(context1)
 	}
-	if returnFunc, ok := ret.Get(0).(func() *domain.GetOverviewStatementResponse); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *domain.GetOverviewStatementResponse); ok {
+		r0 = returnFunc(context1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.GetOverviewStatementResponse)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func() *errors.AppError); ok {
-		r1 = returnFunc()
+	if returnFunc, ok := ret.Get(1).(func(context.Context) *errors.AppError); ok {
+		r1 = returnFunc(context1)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*errors.AppError)
@@ -251,13 +343,20 @@ type MockFinanceServiceClient_GetOverviewMonthlyStatement_Call struct {
 }
 
 // GetOverviewMonthlyStatement is a helper method to define mock.On call
-func (_e *MockFinanceServiceClient_Expecter) GetOverviewMonthlyStatement() *MockFinanceServiceClient_GetOverviewMonthlyStatement_Call {
-	return &MockFinanceServiceClient_GetOverviewMonthlyStatement_Call{Call: _e.mock.On("GetOverviewMonthlyStatement")}
+//   - context1 context.Context
+func (_e *MockFinanceServiceClient_Expecter) GetOverviewMonthlyStatement(context1 interface{}) *MockFinanceServiceClient_GetOverviewMonthlyStatement_Call {
+	return &MockFinanceServiceClient_GetOverviewMonthlyStatement_Call{Call: _e.mock.On("GetOverviewMonthlyStatement", context1)}
 }
 
-func (_c *MockFinanceServiceClient_GetOverviewMonthlyStatement_Call) Run(run func()) *MockFinanceServiceClient_GetOverviewMonthlyStatement_Call {
+func (_c *MockFinanceServiceClient_GetOverviewMonthlyStatement_Call) Run(run func(context1 context.Context)) *MockFinanceServiceClient_GetOverviewMonthlyStatement_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
 	})
 	return _c
 }
@@ -267,14 +366,14 @@ func (_c *MockFinanceServiceClient_GetOverviewMonthlyStatement_Call) Return(getO
 	return _c
 }
 
-func (_c *MockFinanceServiceClient_GetOverviewMonthlyStatement_Call) RunAndReturn(run func() (*domain.GetOverviewStatementResponse, *errors.AppError)) *MockFinanceServiceClient_GetOverviewMonthlyStatement_Call {
+func (_c *MockFinanceServiceClient_GetOverviewMonthlyStatement_Call) RunAndReturn(run func(context1 context.Context) (*domain.GetOverviewStatementResponse, *errors.AppError)) *MockFinanceServiceClient_GetOverviewMonthlyStatement_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetOverviewStatement provides a mock function for the type MockFinanceServiceClient
-func (_mock *MockFinanceServiceClient) GetOverviewStatement(getOverviewStatementRequest *domain.GetOverviewStatementRequest) (*domain.GetOverviewStatementResponse, *errors.AppError) {
-	ret := _mock.Called(getOverviewStatementRequest)
+func (_mock *MockFinanceServiceClient) GetOverviewStatement(context1 context.Context, getOverviewStatementRequest *domain.GetOverviewStatementRequest) (*domain.GetOverviewStatementResponse, *errors.AppError) {
+	ret := _mock.Called(context1, getOverviewStatementRequest)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetOverviewStatement")
@@ -282,18 +381,18 @@ func (_mock *MockFinanceServiceClient) GetOverviewStatement(getOverviewStatement
 
 	var r0 *domain.GetOverviewStatementResponse
 	var r1 *errors.AppError
-	if returnFunc, ok := ret.Get(0).(func(*domain.GetOverviewStatementRequest) (*domain.GetOverviewStatementResponse, *errors.AppError)); ok {
-		return returnFunc(getOverviewStatementRequest)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.GetOverviewStatementRequest) (*domain.GetOverviewStatementResponse, *errors.AppError)); ok {
+		return returnFunc(context1, getOverviewStatementRequest)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*domain.GetOverviewStatementRequest) *domain.GetOverviewStatementResponse); ok {
-		r0 = returnFunc(getOverviewStatementRequest)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.GetOverviewStatementRequest) *domain.GetOverviewStatementResponse); ok {
+		r0 = returnFunc(context1, getOverviewStatementRequest)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.GetOverviewStatementResponse)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*domain.GetOverviewStatementRequest) *errors.AppError); ok {
-		r1 = returnFunc(getOverviewStatementRequest)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.GetOverviewStatementRequest) *errors.AppError); ok {
+		r1 = returnFunc(context1, getOverviewStatementRequest)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*errors.AppError)
@@ -308,19 +407,25 @@ type MockFinanceServiceClient_GetOverviewStatement_Call struct {
 }
 
 // GetOverviewStatement is a helper method to define mock.On call
+//   - context1 context.Context
 //   - getOverviewStatementRequest *domain.GetOverviewStatementRequest
-func (_e *MockFinanceServiceClient_Expecter) GetOverviewStatement(getOverviewStatementRequest interface{}) *MockFinanceServiceClient_GetOverviewStatement_Call {
-	return &MockFinanceServiceClient_GetOverviewStatement_Call{Call: _e.mock.On("GetOverviewStatement", getOverviewStatementRequest)}
+func (_e *MockFinanceServiceClient_Expecter) GetOverviewStatement(context1 interface{}, getOverviewStatementRequest interface{}) *MockFinanceServiceClient_GetOverviewStatement_Call {
+	return &MockFinanceServiceClient_GetOverviewStatement_Call{Call: _e.mock.On("GetOverviewStatement", context1, getOverviewStatementRequest)}
 }
 
-func (_c *MockFinanceServiceClient_GetOverviewStatement_Call) Run(run func(getOverviewStatementRequest *domain.GetOverviewStatementRequest)) *MockFinanceServiceClient_GetOverviewStatement_Call {
+func (_c *MockFinanceServiceClient_GetOverviewStatement_Call) Run(run func(context1 context.Context, getOverviewStatementRequest *domain.GetOverviewStatementRequest)) *MockFinanceServiceClient_GetOverviewStatement_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *domain.GetOverviewStatementRequest
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*domain.GetOverviewStatementRequest)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.GetOverviewStatementRequest
+		if args[1] != nil {
+			arg1 = args[1].(*domain.GetOverviewStatementRequest)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -331,14 +436,84 @@ func (_c *MockFinanceServiceClient_GetOverviewStatement_Call) Return(getOverview
 	return _c
 }
 
-func (_c *MockFinanceServiceClient_GetOverviewStatement_Call) RunAndReturn(run func(getOverviewStatementRequest *domain.GetOverviewStatementRequest) (*domain.GetOverviewStatementResponse, *errors.AppError)) *MockFinanceServiceClient_GetOverviewStatement_Call {
+func (_c *MockFinanceServiceClient_GetOverviewStatement_Call) RunAndReturn(run func(context1 context.Context, getOverviewStatementRequest *domain.GetOverviewStatementRequest) (*domain.GetOverviewStatementResponse, *errors.AppError)) *MockFinanceServiceClient_GetOverviewStatement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTransactions provides a mock function for the type MockFinanceServiceClient
+func (_mock *MockFinanceServiceClient) ListTransactions(context1 context.Context, listTransactionsRequest *domain.ListTransactionsRequest) (*domain.ListTransactionsResponse, *errors.AppError) {
+	ret := _mock.Called(context1, listTransactionsRequest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTransactions")
+	}
+
+	var r0 *domain.ListTransactionsResponse
+	var r1 *errors.AppError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.ListTransactionsRequest) (*domain.ListTransactionsResponse, *errors.AppError)); ok {
+		return returnFunc(context1, listTransactionsRequest)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.ListTransactionsRequest) *domain.ListTransactionsResponse); ok {
+		r0 = returnFunc(context1, listTransactionsRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ListTransactionsResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.ListTransactionsRequest) *errors.AppError); ok {
+		r1 = returnFunc(context1, listTransactionsRequest)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errors.AppError)
+		}
+	}
+	return r0, r1
+}
+
+// MockFinanceServiceClient_ListTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTransactions'
+type MockFinanceServiceClient_ListTransactions_Call struct {
+	*mock.Call
+}
+
+// ListTransactions is a helper method to define mock.On call
+//   - context1 context.Context
+//   - listTransactionsRequest *domain.ListTransactionsRequest
+func (_e *MockFinanceServiceClient_Expecter) ListTransactions(context1 interface{}, listTransactionsRequest interface{}) *MockFinanceServiceClient_ListTransactions_Call {
+	return &MockFinanceServiceClient_ListTransactions_Call{Call: _e.mock.On("ListTransactions", context1, listTransactionsRequest)}
+}
+
+func (_c *MockFinanceServiceClient_ListTransactions_Call) Run(run func(context1 context.Context, listTransactionsRequest *domain.ListTransactionsRequest)) *MockFinanceServiceClient_ListTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.ListTransactionsRequest
+		if args[1] != nil {
+			arg1 = args[1].(*domain.ListTransactionsRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFinanceServiceClient_ListTransactions_Call) Return(listTransactionsResponse *domain.ListTransactionsResponse, appError *errors.AppError) *MockFinanceServiceClient_ListTransactions_Call {
+	_c.Call.Return(listTransactionsResponse, appError)
+	return _c
+}
+
+func (_c *MockFinanceServiceClient_ListTransactions_Call) RunAndReturn(run func(context1 context.Context, listTransactionsRequest *domain.ListTransactionsRequest) (*domain.ListTransactionsResponse, *errors.AppError)) *MockFinanceServiceClient_ListTransactions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Transfer provides a mock function for the type MockFinanceServiceClient
-func (_mock *MockFinanceServiceClient) Transfer(transferRequest *domain.TransferRequest) (*domain.TransferResponse, *errors.AppError) {
-	ret := _mock.Called(transferRequest)
+func (_mock *MockFinanceServiceClient) Transfer(context1 context.Context, transferRequest *domain.TransferRequest) (*domain.TransferResponse, *errors.AppError) {
+	ret := _mock.Called(context1, transferRequest)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Transfer")
@@ -346,18 +521,18 @@ func (_mock *MockFinanceServiceClient) Transfer(transferRequest *domain.Transfer
 
 	var r0 *domain.TransferResponse
 	var r1 *errors.AppError
-	if returnFunc, ok := ret.Get(0).(func(*domain.TransferRequest) (*domain.TransferResponse, *errors.AppError)); ok {
-		return returnFunc(transferRequest)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.TransferRequest) (*domain.TransferResponse, *errors.AppError)); ok {
+		return returnFunc(context1, transferRequest)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*domain.TransferRequest) *domain.TransferResponse); ok {
-		r0 = returnFunc(transferRequest)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.TransferRequest) *domain.TransferResponse); ok {
+		r0 = returnFunc(context1, transferRequest)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.TransferResponse)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*domain.TransferRequest) *errors.AppError); ok {
-		r1 = returnFunc(transferRequest)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.TransferRequest) *errors.AppError); ok {
+		r1 = returnFunc(context1, transferRequest)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*errors.AppError)
@@ -372,19 +547,25 @@ type MockFinanceServiceClient_Transfer_Call struct {
 }
 
 // Transfer is a helper method to define mock.On call
+//   - context1 context.Context
 //   - transferRequest *domain.TransferRequest
-func (_e *MockFinanceServiceClient_Expecter) Transfer(transferRequest interface{}) *MockFinanceServiceClient_Transfer_Call {
-	return &MockFinanceServiceClient_Transfer_Call{Call: _e.mock.On("Transfer", transferRequest)}
+func (_e *MockFinanceServiceClient_Expecter) Transfer(context1 interface{}, transferRequest interface{}) *MockFinanceServiceClient_Transfer_Call {
+	return &MockFinanceServiceClient_Transfer_Call{Call: _e.mock.On("Transfer", context1, transferRequest)}
 }
 
-func (_c *MockFinanceServiceClient_Transfer_Call) Run(run func(transferRequest *domain.TransferRequest)) *MockFinanceServiceClient_Transfer_Call {
+func (_c *MockFinanceServiceClient_Transfer_Call) Run(run func(context1 context.Context, transferRequest *domain.TransferRequest)) *MockFinanceServiceClient_Transfer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *domain.TransferRequest
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*domain.TransferRequest)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.TransferRequest
+		if args[1] != nil {
+			arg1 = args[1].(*domain.TransferRequest)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -395,14 +576,84 @@ func (_c *MockFinanceServiceClient_Transfer_Call) Return(transferResponse *domai
 	return _c
 }
 
-func (_c *MockFinanceServiceClient_Transfer_Call) RunAndReturn(run func(transferRequest *domain.TransferRequest) (*domain.TransferResponse, *errors.AppError)) *MockFinanceServiceClient_Transfer_Call {
+func (_c *MockFinanceServiceClient_Transfer_Call) RunAndReturn(run func(context1 context.Context, transferRequest *domain.TransferRequest) (*domain.TransferResponse, *errors.AppError)) *MockFinanceServiceClient_Transfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTransaction provides a mock function for the type MockFinanceServiceClient
+func (_mock *MockFinanceServiceClient) UpdateTransaction(context1 context.Context, updateTransactionRequest *domain.UpdateTransactionRequest) (*domain.UpdateTransactionResponse, *errors.AppError) {
+	ret := _mock.Called(context1, updateTransactionRequest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTransaction")
+	}
+
+	var r0 *domain.UpdateTransactionResponse
+	var r1 *errors.AppError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UpdateTransactionRequest) (*domain.UpdateTransactionResponse, *errors.AppError)); ok {
+		return returnFunc(context1, updateTransactionRequest)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UpdateTransactionRequest) *domain.UpdateTransactionResponse); ok {
+		r0 = returnFunc(context1, updateTransactionRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UpdateTransactionResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.UpdateTransactionRequest) *errors.AppError); ok {
+		r1 = returnFunc(context1, updateTransactionRequest)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errors.AppError)
+		}
+	}
+	return r0, r1
+}
+
+// MockFinanceServiceClient_UpdateTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTransaction'
+type MockFinanceServiceClient_UpdateTransaction_Call struct {
+	*mock.Call
+}
+
+// UpdateTransaction is a helper method to define mock.On call
+//   - context1 context.Context
+//   - updateTransactionRequest *domain.UpdateTransactionRequest
+func (_e *MockFinanceServiceClient_Expecter) UpdateTransaction(context1 interface{}, updateTransactionRequest interface{}) *MockFinanceServiceClient_UpdateTransaction_Call {
+	return &MockFinanceServiceClient_UpdateTransaction_Call{Call: _e.mock.On("UpdateTransaction", context1, updateTransactionRequest)}
+}
+
+func (_c *MockFinanceServiceClient_UpdateTransaction_Call) Run(run func(context1 context.Context, updateTransactionRequest *domain.UpdateTransactionRequest)) *MockFinanceServiceClient_UpdateTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.UpdateTransactionRequest
+		if args[1] != nil {
+			arg1 = args[1].(*domain.UpdateTransactionRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFinanceServiceClient_UpdateTransaction_Call) Return(updateTransactionResponse *domain.UpdateTransactionResponse, appError *errors.AppError) *MockFinanceServiceClient_UpdateTransaction_Call {
+	_c.Call.Return(updateTransactionResponse, appError)
+	return _c
+}
+
+func (_c *MockFinanceServiceClient_UpdateTransaction_Call) RunAndReturn(run func(context1 context.Context, updateTransactionRequest *domain.UpdateTransactionRequest) (*domain.UpdateTransactionResponse, *errors.AppError)) *MockFinanceServiceClient_UpdateTransaction_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Withdraw provides a mock function for the type MockFinanceServiceClient
-func (_mock *MockFinanceServiceClient) Withdraw(transactionRequest *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError) {
-	ret := _mock.Called(transactionRequest)
+func (_mock *MockFinanceServiceClient) Withdraw(context1 context.Context, transactionRequest *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError) {
+	ret := _mock.Called(context1, transactionRequest)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Withdraw")
@@ -410,18 +661,18 @@ func (_mock *MockFinanceServiceClient) Withdraw(transactionRequest *domain.Trans
 
 	var r0 *domain.TransactionResponse
 	var r1 *errors.AppError
-	if returnFunc, ok := ret.Get(0).(func(*domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError)); ok {
-		return returnFunc(transactionRequest)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError)); ok {
+		return returnFunc(context1, transactionRequest)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*domain.TransactionRequest) *domain.TransactionResponse); ok {
-		r0 = returnFunc(transactionRequest)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.TransactionRequest) *domain.TransactionResponse); ok {
+		r0 = returnFunc(context1, transactionRequest)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.TransactionResponse)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*domain.TransactionRequest) *errors.AppError); ok {
-		r1 = returnFunc(transactionRequest)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.TransactionRequest) *errors.AppError); ok {
+		r1 = returnFunc(context1, transactionRequest)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*errors.AppError)
@@ -436,19 +687,25 @@ type MockFinanceServiceClient_Withdraw_Call struct {
 }
 
 // Withdraw is a helper method to define mock.On call
+//   - context1 context.Context
 //   - transactionRequest *domain.TransactionRequest
-func (_e *MockFinanceServiceClient_Expecter) Withdraw(transactionRequest interface{}) *MockFinanceServiceClient_Withdraw_Call {
-	return &MockFinanceServiceClient_Withdraw_Call{Call: _e.mock.On("Withdraw", transactionRequest)}
+func (_e *MockFinanceServiceClient_Expecter) Withdraw(context1 interface{}, transactionRequest interface{}) *MockFinanceServiceClient_Withdraw_Call {
+	return &MockFinanceServiceClient_Withdraw_Call{Call: _e.mock.On("Withdraw", context1, transactionRequest)}
 }
 
-func (_c *MockFinanceServiceClient_Withdraw_Call) Run(run func(transactionRequest *domain.TransactionRequest)) *MockFinanceServiceClient_Withdraw_Call {
+func (_c *MockFinanceServiceClient_Withdraw_Call) Run(run func(context1 context.Context, transactionRequest *domain.TransactionRequest)) *MockFinanceServiceClient_Withdraw_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *domain.TransactionRequest
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*domain.TransactionRequest)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.TransactionRequest
+		if args[1] != nil {
+			arg1 = args[1].(*domain.TransactionRequest)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -459,7 +716,7 @@ func (_c *MockFinanceServiceClient_Withdraw_Call) Return(transactionResponse *do
 	return _c
 }
 
-func (_c *MockFinanceServiceClient_Withdraw_Call) RunAndReturn(run func(transactionRequest *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError)) *MockFinanceServiceClient_Withdraw_Call {
+func (_c *MockFinanceServiceClient_Withdraw_Call) RunAndReturn(run func(context1 context.Context, transactionRequest *domain.TransactionRequest) (*domain.TransactionResponse, *errors.AppError)) *MockFinanceServiceClient_Withdraw_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -206,6 +206,89 @@ func (_c *MockGRPCFinanceServiceClient_GetBalance_Call) RunAndReturn(run func(ct
 	return _c
 }
 
+// GetDetailedStatement provides a mock function for the type MockGRPCFinanceServiceClient
+func (_mock *MockGRPCFinanceServiceClient) GetDetailedStatement(ctx context.Context, in *pb.OverviewStatementRequest, opts ...grpc.CallOption) (*pb.DetailedStatementResponse, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, in, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, in)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDetailedStatement")
+	}
+
+	var r0 *pb.DetailedStatementResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *pb.OverviewStatementRequest, ...grpc.CallOption) (*pb.DetailedStatementResponse, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *pb.OverviewStatementRequest, ...grpc.CallOption) *pb.DetailedStatementResponse); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.DetailedStatementResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *pb.OverviewStatementRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGRPCFinanceServiceClient_GetDetailedStatement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDetailedStatement'
+type MockGRPCFinanceServiceClient_GetDetailedStatement_Call struct {
+	*mock.Call
+}
+
+// GetDetailedStatement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *pb.OverviewStatementRequest
+//   - opts ...grpc.CallOption
+func (_e *MockGRPCFinanceServiceClient_Expecter) GetDetailedStatement(ctx interface{}, in interface{}, opts ...interface{}) *MockGRPCFinanceServiceClient_GetDetailedStatement_Call {
+	return &MockGRPCFinanceServiceClient_GetDetailedStatement_Call{Call: _e.mock.On("GetDetailedStatement",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockGRPCFinanceServiceClient_GetDetailedStatement_Call) Run(run func(ctx context.Context, in *pb.OverviewStatementRequest, opts ...grpc.CallOption)) *MockGRPCFinanceServiceClient_GetDetailedStatement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *pb.OverviewStatementRequest
+		if args[1] != nil {
+			arg1 = args[1].(*pb.OverviewStatementRequest)
+		}
+		var arg2 []grpc.CallOption
+		var variadicArgs []grpc.CallOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]grpc.CallOption)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGRPCFinanceServiceClient_GetDetailedStatement_Call) Return(detailedStatementResponse *pb.DetailedStatementResponse, err error) *MockGRPCFinanceServiceClient_GetDetailedStatement_Call {
+	_c.Call.Return(detailedStatementResponse, err)
+	return _c
+}
+
+func (_c *MockGRPCFinanceServiceClient_GetDetailedStatement_Call) RunAndReturn(run func(ctx context.Context, in *pb.OverviewStatementRequest, opts ...grpc.CallOption) (*pb.DetailedStatementResponse, error)) *MockGRPCFinanceServiceClient_GetDetailedStatement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetOverviewAnnualStatement provides a mock function for the type MockGRPCFinanceServiceClient
 func (_mock *MockGRPCFinanceServiceClient) GetOverviewAnnualStatement(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*pb.OverviewStatementResponse, error) {
 	var tmpRet mock.Arguments
@@ -455,6 +538,89 @@ func (_c *MockGRPCFinanceServiceClient_GetOverviewStatement_Call) RunAndReturn(r
 	return _c
 }
 
+// ListTransactions provides a mock function for the type MockGRPCFinanceServiceClient
+func (_mock *MockGRPCFinanceServiceClient) ListTransactions(ctx context.Context, in *pb.ListTransactionsRequest, opts ...grpc.CallOption) (*pb.ListTransactionsResponse, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, in, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, in)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTransactions")
+	}
+
+	var r0 *pb.ListTransactionsResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *pb.ListTransactionsRequest, ...grpc.CallOption) (*pb.ListTransactionsResponse, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *pb.ListTransactionsRequest, ...grpc.CallOption) *pb.ListTransactionsResponse); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.ListTransactionsResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *pb.ListTransactionsRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGRPCFinanceServiceClient_ListTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTransactions'
+type MockGRPCFinanceServiceClient_ListTransactions_Call struct {
+	*mock.Call
+}
+
+// ListTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *pb.ListTransactionsRequest
+//   - opts ...grpc.CallOption
+func (_e *MockGRPCFinanceServiceClient_Expecter) ListTransactions(ctx interface{}, in interface{}, opts ...interface{}) *MockGRPCFinanceServiceClient_ListTransactions_Call {
+	return &MockGRPCFinanceServiceClient_ListTransactions_Call{Call: _e.mock.On("ListTransactions",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockGRPCFinanceServiceClient_ListTransactions_Call) Run(run func(ctx context.Context, in *pb.ListTransactionsRequest, opts ...grpc.CallOption)) *MockGRPCFinanceServiceClient_ListTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *pb.ListTransactionsRequest
+		if args[1] != nil {
+			arg1 = args[1].(*pb.ListTransactionsRequest)
+		}
+		var arg2 []grpc.CallOption
+		var variadicArgs []grpc.CallOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]grpc.CallOption)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGRPCFinanceServiceClient_ListTransactions_Call) Return(listTransactionsResponse *pb.ListTransactionsResponse, err error) *MockGRPCFinanceServiceClient_ListTransactions_Call {
+	_c.Call.Return(listTransactionsResponse, err)
+	return _c
+}
+
+func (_c *MockGRPCFinanceServiceClient_ListTransactions_Call) RunAndReturn(run func(ctx context.Context, in *pb.ListTransactionsRequest, opts ...grpc.CallOption) (*pb.ListTransactionsResponse, error)) *MockGRPCFinanceServiceClient_ListTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Transfer provides a mock function for the type MockGRPCFinanceServiceClient
 func (_mock *MockGRPCFinanceServiceClient) Transfer(ctx context.Context, in *pb.TransferRequest, opts ...grpc.CallOption) (*pb.TransferResponse, error) {
 	var tmpRet mock.Arguments
@@ -538,6 +704,89 @@ func (_c *MockGRPCFinanceServiceClient_Transfer_Call) RunAndReturn(run func(ctx
 	return _c
 }
 
+// UpdateTransaction provides a mock function for the type MockGRPCFinanceServiceClient
+func (_mock *MockGRPCFinanceServiceClient) UpdateTransaction(ctx context.Context, in *pb.UpdateTransactionRequest, opts ...grpc.CallOption) (*pb.UpdateTransactionResponse, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, in, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, in)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTransaction")
+	}
+
+	var r0 *pb.UpdateTransactionResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *pb.UpdateTransactionRequest, ...grpc.CallOption) (*pb.UpdateTransactionResponse, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *pb.UpdateTransactionRequest, ...grpc.CallOption) *pb.UpdateTransactionResponse); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.UpdateTransactionResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *pb.UpdateTransactionRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGRPCFinanceServiceClient_UpdateTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTransaction'
+type MockGRPCFinanceServiceClient_UpdateTransaction_Call struct {
+	*mock.Call
+}
+
+// UpdateTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *pb.UpdateTransactionRequest
+//   - opts ...grpc.CallOption
+func (_e *MockGRPCFinanceServiceClient_Expecter) UpdateTransaction(ctx interface{}, in interface{}, opts ...interface{}) *MockGRPCFinanceServiceClient_UpdateTransaction_Call {
+	return &MockGRPCFinanceServiceClient_UpdateTransaction_Call{Call: _e.mock.On("UpdateTransaction",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockGRPCFinanceServiceClient_UpdateTransaction_Call) Run(run func(ctx context.Context, in *pb.UpdateTransactionRequest, opts ...grpc.CallOption)) *MockGRPCFinanceServiceClient_UpdateTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *pb.UpdateTransactionRequest
+		if args[1] != nil {
+			arg1 = args[1].(*pb.UpdateTransactionRequest)
+		}
+		var arg2 []grpc.CallOption
+		var variadicArgs []grpc.CallOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]grpc.CallOption)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGRPCFinanceServiceClient_UpdateTransaction_Call) Return(updateTransactionResponse *pb.UpdateTransactionResponse, err error) *MockGRPCFinanceServiceClient_UpdateTransaction_Call {
+	_c.Call.Return(updateTransactionResponse, err)
+	return _c
+}
+
+func (_c *MockGRPCFinanceServiceClient_UpdateTransaction_Call) RunAndReturn(run func(ctx context.Context, in *pb.UpdateTransactionRequest, opts ...grpc.CallOption) (*pb.UpdateTransactionResponse, error)) *MockGRPCFinanceServiceClient_UpdateTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Withdraw provides a mock function for the type MockGRPCFinanceServiceClient
 func (_mock *MockGRPCFinanceServiceClient) Withdraw(ctx context.Context, in *pb.TransactionRequest, opts ...grpc.CallOption) (*pb.TransactionResponse, error) {
 	var tmpRet mock.Arguments
@@ -0,0 +1,116 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/sMARCHz/secretaria-bot/internal/core/domain"
+	"github.com/sMARCHz/secretaria-bot/internal/core/errors"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockImportService creates a new instance of MockImportService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockImportService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockImportService {
+	mock := &MockImportService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockImportService is an autogenerated mock type for the ImportService type
+type MockImportService struct {
+	mock.Mock
+}
+
+type MockImportService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockImportService) EXPECT() *MockImportService_Expecter {
+	return &MockImportService_Expecter{mock: &_m.Mock}
+}
+
+// Preview provides a mock function for the type MockImportService
+func (_mock *MockImportService) Preview(context1 context.Context, user domain.User, file *domain.File) (*domain.ImportPreview, *errors.AppError) {
+	ret := _mock.Called(context1, user, file)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Preview")
+	}
+
+	var r0 *domain.ImportPreview
+	var r1 *errors.AppError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.User, *domain.File) (*domain.ImportPreview, *errors.AppError)); ok {
+		return returnFunc(context1, user, file)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.User, *domain.File) *domain.ImportPreview); ok {
+		r0 = returnFunc(context1, user, file)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ImportPreview)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.User, *domain.File) *errors.AppError); ok {
+		r1 = returnFunc(context1, user, file)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errors.AppError)
+		}
+	}
+	return r0, r1
+}
+
+// MockImportService_Preview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Preview'
+type MockImportService_Preview_Call struct {
+	*mock.Call
+}
+
+// Preview is a helper method to define mock.On call
+//   - context1 context.Context
+//   - user domain.User
+//   - file *domain.File
+func (_e *MockImportService_Expecter) Preview(context1 interface{}, user interface{}, file interface{}) *MockImportService_Preview_Call {
+	return &MockImportService_Preview_Call{Call: _e.mock.On("Preview", context1, user, file)}
+}
+
+func (_c *MockImportService_Preview_Call) Run(run func(context1 context.Context, user domain.User, file *domain.File)) *MockImportService_Preview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.User
+		if args[1] != nil {
+			arg1 = args[1].(domain.User)
+		}
+		var arg2 *domain.File
+		if args[2] != nil {
+			arg2 = args[2].(*domain.File)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockImportService_Preview_Call) Return(importPreview *domain.ImportPreview, appError *errors.AppError) *MockImportService_Preview_Call {
+	_c.Call.Return(importPreview, appError)
+	return _c
+}
+
+func (_c *MockImportService_Preview_Call) RunAndReturn(run func(context1 context.Context, user domain.User, file *domain.File) (*domain.ImportPreview, *errors.AppError)) *MockImportService_Preview_Call {
+	_c.Call.Return(run)
+	return _c
+}